@@ -7,6 +7,7 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -28,6 +29,7 @@ type API interface {
 	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
 	GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error)
 	GetTransactionResult(ctx context.Context, id flow.Identifier) (*TransactionResult, error)
+	SubscribeTransactionStatus(ctx context.Context, id flow.Identifier, handler func(*TransactionResult) error) error
 
 	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
@@ -37,8 +39,13 @@ type API interface {
 	ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error)
 
+	DryRunTransaction(ctx context.Context, tx *flow.TransactionBody, blockID flow.Identifier, opts DryRunOptions) (*DryRunResult, error)
+
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error)
+	SubscribeEvents(ctx context.Context, startHeight uint64, filter EventFilter, handler func(flow.BlockEvents) error) error
+
+	GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error)
 }
 
 // TODO: Combine this with flow.TransactionResult?
@@ -47,6 +54,8 @@ type TransactionResult struct {
 	StatusCode   uint
 	Events       []flow.Event
 	ErrorMessage string
+	BlockID      flow.Identifier // the block including the transaction, if any
+	BlockHeight  uint64
 }
 
 func TransactionResultToMessage(result *TransactionResult) *access.TransactionResultResponse {
@@ -68,6 +77,47 @@ func MessageToTransactionResult(message *access.TransactionResultResponse) *Tran
 	}
 }
 
+// DryRunOptions defines which steps of the transaction processing are skipped
+// when a transaction is dry-run.
+type DryRunOptions struct {
+	SkipSignatures     bool
+	SkipSequenceNumber bool
+	SkipFees           bool
+}
+
+// DryRunResult is the outcome of a transaction which was run without being
+// committed.
+type DryRunResult struct {
+	StatusCode      uint
+	ErrorMessage    string
+	Events          []flow.Event
+	Logs            []string
+	ComputationUsed uint64
+	RegisterUpdates flow.RegisterEntries // the changes the transaction would apply
+}
+
+func DryRunResultToMessage(result *DryRunResult) *dryrunproto.DryRunTransactionResponse {
+	return &dryrunproto.DryRunTransactionResponse{
+		Events:          convert.EventsToMessages(result.Events),
+		Logs:            result.Logs,
+		StatusCode:      uint32(result.StatusCode),
+		ErrorMessage:    result.ErrorMessage,
+		ComputationUsed: result.ComputationUsed,
+		RegisterUpdates: convert.RegisterEntriesToMessages(result.RegisterUpdates),
+	}
+}
+
+func MessageToDryRunResult(message *dryrunproto.DryRunTransactionResponse) *DryRunResult {
+	return &DryRunResult{
+		StatusCode:      uint(message.GetStatusCode()),
+		ErrorMessage:    message.GetErrorMessage(),
+		Events:          convert.MessagesToEvents(message.GetEvents()),
+		Logs:            message.GetLogs(),
+		ComputationUsed: message.GetComputationUsed(),
+		RegisterUpdates: convert.MessagesToRegisterEntries(message.GetRegisterUpdates()),
+	}
+}
+
 // NetworkParameters contains the network-wide parameters for the Flow blockchain.
 type NetworkParameters struct {
 	ChainID flow.ChainID
@@ -0,0 +1,68 @@
+package access
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DryRunHandler implements the dry-run API of the access node.
+type DryRunHandler struct {
+	api   API
+	chain flow.Chain
+}
+
+func NewDryRunHandler(api API, chain flow.Chain) *DryRunHandler {
+	return &DryRunHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// DryRunTransaction runs a transaction at the requested block, or at the
+// latest sealed block if no block is requested, without committing it.
+func (h *DryRunHandler) DryRunTransaction(
+	ctx context.Context,
+	req *dryrunproto.DryRunTransactionRequest,
+) (*dryrunproto.DryRunTransactionResponse, error) {
+
+	txMsg := req.GetTransaction()
+	if txMsg == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction not specified")
+	}
+	tx, err := convert.MessageToTransaction(txMsg, h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	blockID := flow.ZeroID
+	if len(req.GetBlockId()) > 0 {
+		blockID, err = convert.BlockID(req.GetBlockId())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := DryRunOptions{
+		SkipSignatures:     req.GetOptions().GetSkipSignatures(),
+		SkipSequenceNumber: req.GetOptions().GetSkipSequenceNumber(),
+		SkipFees:           req.GetOptions().GetSkipFees(),
+	}
+
+	result, err := h.api.DryRunTransaction(ctx, &tx, blockID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Events, err = encodeEvents(ctx, result.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return DryRunResultToMessage(result), nil
+}
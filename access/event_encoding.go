@@ -0,0 +1,71 @@
+package access
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/encoding/events"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventEncodingMetadataKey is the gRPC metadata key with which clients request
+// the encoding of the event payloads in responses, such as "json-cdc" or "cbor".
+// Payloads are returned in JSON-CDC if it's not set, so that existing clients
+// keep working whatever the encoding the events were stored in.
+const EventEncodingMetadataKey = "event-encoding"
+
+// requestedEventEncoding returns the event encoding requested in the metadata
+// of an incoming request.
+func requestedEventEncoding(ctx context.Context) (flow.EventEncodingVersion, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return flow.EventEncodingVersionJSONCDC, nil
+	}
+
+	values := md.Get(EventEncodingMetadataKey)
+	if len(values) == 0 {
+		return flow.EventEncodingVersionJSONCDC, nil
+	}
+
+	version, err := flow.ParseEventEncodingVersion(values[0])
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return version, nil
+}
+
+// encodeEvents converts the payloads of events to the encoding requested in
+// the metadata of an incoming request.
+func encodeEvents(ctx context.Context, flowEvents []flow.Event) ([]flow.Event, error) {
+	version, err := requestedEventEncoding(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := events.ConvertEvents(flowEvents, version)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode events: %v", err)
+	}
+
+	return converted, nil
+}
+
+// encodeBlockEvents converts the payloads of the events of blocks to the
+// encoding requested in the metadata of an incoming request.
+func encodeBlockEvents(ctx context.Context, blocks []flow.BlockEvents) ([]flow.BlockEvents, error) {
+	converted := make([]flow.BlockEvents, len(blocks))
+	for i, block := range blocks {
+		blockEvents, err := encodeEvents(ctx, block.Events)
+		if err != nil {
+			return nil, err
+		}
+		block.Events = blockEvents
+		converted[i] = block
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,64 @@
+package access
+
+import (
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventFilter selects the events delivered by an event subscription. Events
+// must have one of the event types. If addresses or contracts are given,
+// events must also be emitted by one of the accounts and one of the contracts.
+// As core events are not emitted by a contract, they never pass a filter with
+// addresses or contracts.
+type EventFilter struct {
+	EventTypes []string
+	Addresses  []flow.Address
+	Contracts  []string
+}
+
+// Match returns true if the given event passes the filter.
+func (f EventFilter) Match(event flow.Event) bool {
+
+	if !containsString(f.EventTypes, string(event.Type)) {
+		return false
+	}
+	if len(f.Addresses) == 0 && len(f.Contracts) == 0 {
+		return true
+	}
+
+	// contract events have a type of the form A.<address>.<contract>.<event>
+	parts := strings.Split(string(event.Type), ".")
+	if len(parts) != 4 || parts[0] != "A" {
+		return false
+	}
+
+	if len(f.Addresses) > 0 {
+		address := flow.HexToAddress(parts[1])
+		found := false
+		for _, a := range f.Addresses {
+			if a == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Contracts) > 0 && !containsString(f.Contracts, parts[2]) {
+		return false
+	}
+
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,6 @@ package access
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/onflow/flow/protobuf/go/flow/access"
@@ -36,9 +35,16 @@ func (h *Handler) Ping(ctx context.Context, _ *access.PingRequest) (*access.Ping
 	return &access.PingResponse{}, nil
 }
 
-func (h *Handler) GetLatestProtocolStateSnapshot(_ context.Context,
-	_ *access.GetLatestProtocolStateSnapshotRequest) (*access.ProtocolStateSnapshotResponse, error) {
-	return nil, fmt.Errorf("unimplemented method")
+// GetLatestProtocolStateSnapshot returns the latest serializable Snapshot
+func (h *Handler) GetLatestProtocolStateSnapshot(ctx context.Context, _ *access.GetLatestProtocolStateSnapshotRequest) (*access.ProtocolStateSnapshotResponse, error) {
+	snapshot, err := h.api.GetLatestProtocolStateSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &access.ProtocolStateSnapshotResponse{
+		SerializedSnapshot: snapshot,
+	}, nil
 }
 
 func (h *Handler) GetNetworkParameters(
@@ -224,6 +230,11 @@ func (h *Handler) GetTransactionResult(
 		return nil, err
 	}
 
+	result.Events, err = encodeEvents(ctx, result.Events)
+	if err != nil {
+		return nil, err
+	}
+
 	return TransactionResultToMessage(result), nil
 }
 
@@ -372,6 +383,11 @@ func (h *Handler) GetEventsForHeightRange(
 		return nil, err
 	}
 
+	results, err = encodeBlockEvents(ctx, results)
+	if err != nil {
+		return nil, err
+	}
+
 	resultEvents, err := blockEventsToMessages(results)
 	if err != nil {
 		return nil, err
@@ -401,6 +417,11 @@ func (h *Handler) GetEventsForBlockIDs(
 		return nil, err
 	}
 
+	results, err = encodeBlockEvents(ctx, results)
+	if err != nil {
+		return nil, err
+	}
+
 	resultEvents, err := blockEventsToMessages(results)
 	if err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/access/legacy/convert"
+	eventEncoding "github.com/onflow/flow-go/model/encoding/events"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -194,6 +195,11 @@ func (h *Handler) GetTransactionResult(
 		return nil, err
 	}
 
+	result.Events, err = jsonEvents(result.Events)
+	if err != nil {
+		return nil, err
+	}
+
 	return convert.TransactionResultToMessage(*result), nil
 }
 
@@ -318,6 +324,13 @@ func (h *Handler) GetEventsForHeightRange(
 		return nil, err
 	}
 
+	for i := range results {
+		results[i].Events, err = jsonEvents(results[i].Events)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &accessproto.EventsResponse{
 		Results: blockEventsToMessages(results),
 	}, nil
@@ -336,6 +349,13 @@ func (h *Handler) GetEventsForBlockIDs(
 		return nil, err
 	}
 
+	for i := range results {
+		results[i].Events, err = jsonEvents(results[i].Events)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &accessproto.EventsResponse{
 		Results: blockEventsToMessages(results),
 	}, nil
@@ -363,6 +383,16 @@ func blockHeaderResponse(header *flow.Header) (*accessproto.BlockHeaderResponse,
 	}, nil
 }
 
+// jsonEvents converts the payloads of events to JSON-CDC, the only encoding
+// legacy clients can decode.
+func jsonEvents(events []flow.Event) ([]flow.Event, error) {
+	converted, err := eventEncoding.ConvertEvents(events, flow.EventEncodingVersionJSONCDC)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode events: %v", err)
+	}
+	return converted, nil
+}
+
 func blockEventsToMessages(blocks []flow.BlockEvents) []*accessproto.EventsResponse_Result {
 	results := make([]*accessproto.EventsResponse_Result, len(blocks))
 
@@ -0,0 +1,134 @@
+package access
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	streamproto "github.com/onflow/flow-go/engine/access/rpc/protobuf"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// StreamHandler implements the streaming API of the access node.
+type StreamHandler struct {
+	api   API
+	chain flow.Chain
+}
+
+func NewStreamHandler(api API, chain flow.Chain) *StreamHandler {
+	return &StreamHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// SubscribeEvents streams the events matching the filter for each sealed
+// block, starting at the requested height.
+func (h *StreamHandler) SubscribeEvents(
+	req *streamproto.SubscribeEventsRequest,
+	stream streamproto.AccessStreamAPI_SubscribeEventsServer,
+) error {
+	filter, err := h.eventFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	return h.api.SubscribeEvents(stream.Context(), req.GetStartHeight(), filter, func(block flow.BlockEvents) error {
+		timestamp, err := ptypes.TimestampProto(block.BlockTimestamp)
+		if err != nil {
+			return err
+		}
+		blockEvents, err := encodeEvents(stream.Context(), block.Events)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&streamproto.SubscribeEventsResponse{
+			BlockId:        block.BlockID[:],
+			BlockHeight:    block.BlockHeight,
+			BlockTimestamp: timestamp,
+			Events:         convert.EventsToMessages(blockEvents),
+		})
+	})
+}
+
+// SubscribeTransactionStatus streams the result of a transaction whenever its
+// status changes.
+func (h *StreamHandler) SubscribeTransactionStatus(
+	req *streamproto.SubscribeTransactionStatusRequest,
+	stream streamproto.AccessStreamAPI_SubscribeTransactionStatusServer,
+) error {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	return h.subscribeTransactionStatus(id, stream)
+}
+
+// SendAndSubscribeTransactionStatus sends a transaction and streams its result
+// whenever its status changes.
+func (h *StreamHandler) SendAndSubscribeTransactionStatus(
+	req *streamproto.SendAndSubscribeTransactionStatusRequest,
+	stream streamproto.AccessStreamAPI_SendAndSubscribeTransactionStatusServer,
+) error {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = h.api.SendTransaction(stream.Context(), &tx)
+	if err != nil {
+		return err
+	}
+
+	return h.subscribeTransactionStatus(tx.ID(), stream)
+}
+
+func (h *StreamHandler) subscribeTransactionStatus(
+	id flow.Identifier,
+	stream streamproto.AccessStreamAPI_SubscribeTransactionStatusServer,
+) error {
+	return h.api.SubscribeTransactionStatus(stream.Context(), id, func(result *TransactionResult) error {
+		resultEvents, err := encodeEvents(stream.Context(), result.Events)
+		if err != nil {
+			return err
+		}
+		msg := &streamproto.TransactionStatusResponse{
+			Id:           id[:],
+			Status:       entities.TransactionStatus(result.Status),
+			BlockHeight:  result.BlockHeight,
+			StatusCode:   uint32(result.StatusCode),
+			ErrorMessage: result.ErrorMessage,
+			Events:       convert.EventsToMessages(resultEvents),
+		}
+		if result.BlockID != flow.ZeroID {
+			msg.BlockId = result.BlockID[:]
+		}
+		return stream.Send(msg)
+	})
+}
+
+func (h *StreamHandler) eventFilter(msg *streamproto.EventFilter) (EventFilter, error) {
+	filter := EventFilter{
+		EventTypes: make([]string, 0, len(msg.GetEventTypes())),
+		Addresses:  make([]flow.Address, 0, len(msg.GetAddresses())),
+		Contracts:  msg.GetContracts(),
+	}
+	for _, t := range msg.GetEventTypes() {
+		eventType, err := convert.EventType(t)
+		if err != nil {
+			return EventFilter{}, err
+		}
+		filter.EventTypes = append(filter.EventTypes, eventType)
+	}
+	for _, a := range msg.GetAddresses() {
+		address, err := convert.Address(a, h.chain)
+		if err != nil {
+			return EventFilter{}, err
+		}
+		filter.Addresses = append(filter.Addresses, address)
+	}
+	return filter, nil
+}
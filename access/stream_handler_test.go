@@ -0,0 +1,124 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	streamproto "github.com/onflow/flow-go/engine/access/rpc/protobuf"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// statusAPI is an access API which accepts sent transactions and streams a
+// fixed sequence of results for them.
+type statusAPI struct {
+	API
+	sendErr error
+	sent    []flow.Identifier
+	results []*TransactionResult
+}
+
+func (a *statusAPI) SendTransaction(_ context.Context, tx *flow.TransactionBody) error {
+	if a.sendErr != nil {
+		return a.sendErr
+	}
+	a.sent = append(a.sent, tx.ID())
+	return nil
+}
+
+func (a *statusAPI) SubscribeTransactionStatus(_ context.Context, id flow.Identifier, handler func(*TransactionResult) error) error {
+	if len(a.sent) == 0 || a.sent[len(a.sent)-1] != id {
+		return errors.New("transaction not sent")
+	}
+	for _, result := range a.results {
+		err := handler(result)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusStream records the responses sent on a transaction status stream.
+type statusStream struct {
+	grpc.ServerStream
+	responses []*streamproto.TransactionStatusResponse
+}
+
+func (s *statusStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *statusStream) Send(resp *streamproto.TransactionStatusResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+// TestSendAndSubscribeTransactionStatus tests that a sent transaction is
+// streamed from its pending status on, and that only transactions included
+// in a block have a block.
+func TestSendAndSubscribeTransactionStatus(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	tx := unittest.TransactionBodyFixture(func(tb *flow.TransactionBody) {
+		tb.SetProposalKey(chain.ServiceAddress(), 0, 0)
+		tb.SetPayer(chain.ServiceAddress())
+		tb.Authorizers = []flow.Address{chain.ServiceAddress()}
+	})
+	blockID := unittest.IdentifierFixture()
+
+	api := &statusAPI{
+		results: []*TransactionResult{
+			{Status: flow.TransactionStatusPending},
+			{Status: flow.TransactionStatusFinalized, BlockID: blockID, BlockHeight: 10},
+			{Status: flow.TransactionStatusSealed, BlockID: blockID, BlockHeight: 10},
+		},
+	}
+	handler := NewStreamHandler(api, chain)
+	stream := &statusStream{}
+
+	req := &streamproto.SendAndSubscribeTransactionStatusRequest{
+		Transaction: convert.TransactionToMessage(tx),
+	}
+	err := handler.SendAndSubscribeTransactionStatus(req, stream)
+	require.NoError(t, err)
+
+	txID := tx.ID()
+	require.Equal(t, []flow.Identifier{txID}, api.sent)
+	require.Len(t, stream.responses, 3)
+	for _, resp := range stream.responses {
+		assert.Equal(t, txID[:], resp.Id)
+	}
+
+	assert.Equal(t, entities.TransactionStatus_PENDING, stream.responses[0].Status)
+	assert.Empty(t, stream.responses[0].BlockId)
+	assert.Zero(t, stream.responses[0].BlockHeight)
+
+	assert.Equal(t, entities.TransactionStatus_FINALIZED, stream.responses[1].Status)
+	assert.Equal(t, blockID[:], stream.responses[1].BlockId)
+	assert.Equal(t, uint64(10), stream.responses[1].BlockHeight)
+
+	assert.Equal(t, entities.TransactionStatus_SEALED, stream.responses[2].Status)
+}
+
+// TestSendAndSubscribeTransactionStatus_SendFailed tests that no status is
+// streamed for a transaction which could not be sent.
+func TestSendAndSubscribeTransactionStatus_SendFailed(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	api := &statusAPI{sendErr: errors.New("invalid transaction")}
+	handler := NewStreamHandler(api, chain)
+	stream := &statusStream{}
+
+	req := &streamproto.SendAndSubscribeTransactionStatusRequest{
+		Transaction: convert.TransactionToMessage(unittest.TransactionBodyFixture()),
+	}
+	err := handler.SendAndSubscribeTransactionStatus(req, stream)
+	assert.True(t, errors.Is(err, api.sendErr))
+	assert.Empty(t, stream.responses)
+}
@@ -67,6 +67,9 @@ func main() {
 		collectionsToMarkExecuted    *stdmap.Times
 		blocksToMarkExecuted         *stdmap.Times
 		transactionMetrics           module.TransactionMetrics
+		connectionPoolMetrics        module.ConnectionPoolMetrics
+		executionQueryMetrics        module.ExecutionQueryMetrics
+		executionQueryMode           string
 		pingMetrics                  module.PingMetrics
 		logTxTimeToFinalized         bool
 		logTxTimeToExecuted          bool
@@ -89,6 +92,11 @@ func main() {
 			flags.StringVarP(&rpcConf.HistoricalAccessAddrs, "historical-access-addr", "", "", "comma separated rpc addresses for historical access nodes")
 			flags.DurationVar(&rpcConf.CollectionClientTimeout, "collection-client-timeout", 3*time.Second, "grpc client timeout for a collection node")
 			flags.DurationVar(&rpcConf.ExecutionClientTimeout, "execution-client-timeout", 3*time.Second, "grpc client timeout for an execution node")
+			flags.DurationVar(&rpcConf.ConnectionIdleTimeout, "connection-idle-timeout", 5*time.Minute, "time after which an unused grpc connection to a collection or execution node is closed")
+			flags.UintVar(&rpcConf.CircuitBreakerThreshold, "circuit-breaker-threshold", 5, "number of consecutive failed requests after which a collection or execution node is skipped (0 to disable)")
+			flags.DurationVar(&rpcConf.CircuitBreakerTimeout, "circuit-breaker-timeout", 10*time.Second, "time for which a failing collection or execution node is skipped")
+			flags.StringVar(&executionQueryMode, "execution-query-mode", string(backend.ExecutionQueryFirst), "how requests are sent to execution nodes: first (one after the other), compare (all, reporting diverging results) or match (all, failing on diverging results)")
+			flags.BoolVar(&rpcConf.PreferFastestExecutionNodes, "prefer-fastest-execution-nodes", false, "whether to query execution nodes in the order of their measured latency")
 			flags.BoolVar(&logTxTimeToFinalized, "log-tx-time-to-finalized", false, "log transaction time to finalized")
 			flags.BoolVar(&logTxTimeToExecuted, "log-tx-time-to-executed", false, "log transaction time to executed")
 			flags.BoolVar(&logTxTimeToFinalizedExecuted, "log-tx-time-to-finalized-executed", false, "log transaction time to finalized and executed")
@@ -207,11 +215,23 @@ func main() {
 				logTxTimeToExecuted, logTxTimeToFinalizedExecuted)
 			return nil
 		}).
+		Module("connection pool metrics", func(node *cmd.FlowNodeBuilder) error {
+			connectionPoolMetrics = metrics.NewConnectionPoolCollector()
+			return nil
+		}).
+		Module("execution query metrics", func(node *cmd.FlowNodeBuilder) error {
+			executionQueryMetrics = metrics.NewExecutionQueryCollector()
+			return nil
+		}).
 		Module("ping metrics", func(node *cmd.FlowNodeBuilder) error {
 			pingMetrics = metrics.NewPingCollector()
 			return nil
 		}).
 		Component("RPC engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			rpcConf.ExecutionQueryMode, err = backend.ParseExecutionQueryMode(executionQueryMode)
+			if err != nil {
+				return nil, err
+			}
 			rpcEng = rpc.New(
 				node.Logger,
 				node.State,
@@ -226,6 +246,8 @@ func main() {
 				node.Storage.Receipts,
 				node.RootChainID,
 				transactionMetrics,
+				connectionPoolMetrics,
+				executionQueryMetrics,
 				collectionGRPCPort,
 				executionGRPCPort,
 				retryEnabled,
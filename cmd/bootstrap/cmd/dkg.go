@@ -5,6 +5,7 @@ import (
 
 	"github.com/onflow/flow-go/cmd/bootstrap/run"
 	model "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encodable"
 )
 
@@ -32,7 +33,7 @@ func runDKG(nodes []model.NodeInfo) model.DKGData {
 		log.Debug().Int("i", i).Str("nodeId", nodeID.String()).Msg("assembling dkg data")
 
 		encKey := encodable.RandomBeaconPrivKey{PrivateKey: privKey}
-		privParticpant := model.DKGParticipantPriv{
+		privParticpant := dkg.DKGParticipantPriv{
 			NodeID:              nodeID,
 			RandomBeaconPrivKey: encKey,
 			GroupIndex:          i,
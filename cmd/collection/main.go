@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/onflow/flow-go-sdk/client"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
 
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
@@ -59,6 +62,12 @@ func main() {
 		hotstuffTimeoutVoteAggregationFraction float64
 		blockRateDelay                         time.Duration
 
+		qcAccessAddress     string // address of the access node used to submit QC votes
+		qcContractAddress   string // address of the QC aggregator contract
+		qcAccountAddress    string // address of the account used to submit QC votes
+		qcAccountKeyIndex   uint   // index of the account key used to sign QC vote transactions
+		qcAccountPrivateKey string // hex-encoded private key used to sign QC vote transactions
+
 		followerState protocol.MutableState
 		ingestConf    ingest.Config
 		ingressConf   ingress.Config
@@ -117,6 +126,16 @@ func main() {
 				"additional fraction of replica timeout that the primary will wait for votes")
 			flags.DurationVar(&blockRateDelay, "block-rate-delay", 250*time.Millisecond,
 				"the delay to broadcast block proposal in order to control block production rate")
+			flags.StringVar(&qcAccessAddress, "qc-access-address", "",
+				"the address of the access node used to submit cluster root QC votes")
+			flags.StringVar(&qcContractAddress, "qc-contract-address", "",
+				"the address of the cluster QC aggregator contract")
+			flags.StringVar(&qcAccountAddress, "qc-account-address", "",
+				"the address of the account used to submit cluster root QC votes")
+			flags.UintVar(&qcAccountKeyIndex, "qc-account-key-index", 0,
+				"the index of the account key used to sign cluster root QC vote transactions")
+			flags.StringVar(&qcAccountPrivateKey, "qc-account-private-key", "",
+				"the hex-encoded ECDSA P-256 private key used to sign cluster root QC vote transactions")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -359,12 +378,32 @@ func main() {
 
 			staking := signature.NewAggregationProvider(encoding.CollectorVoteTag, node.Me)
 			signer := verification.NewSingleSigner(staking, node.Me.NodeID())
+
+			// the QC contract client is only available if the node is
+			// configured with an account to submit votes
+			var qcContractClient module.QCContractClient
+			if qcAccessAddress != "" {
+				qcContractClient, err = createQCContractClient(
+					node,
+					qcAccessAddress,
+					qcContractAddress,
+					qcAccountAddress,
+					qcAccountKeyIndex,
+					qcAccountPrivateKey,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("could not create qc contract client: %w", err)
+				}
+			} else {
+				node.Logger.Warn().Msg("no qc access address configured - cluster root QC votes will not be submitted")
+			}
+
 			rootQCVoter := epochs.NewRootQCVoter(
 				node.Logger,
 				node.Me,
 				signer,
 				node.State,
-				nil, // TODO
+				qcContractClient,
 			)
 
 			factory := factories.NewEpochComponentsFactory(
@@ -400,3 +439,36 @@ func main() {
 		}).
 		Run()
 }
+
+// createQCContractClient creates a client to the cluster QC aggregator
+// contract, which submits votes through the given access node.
+func createQCContractClient(
+	node *cmd.FlowNodeBuilder,
+	accessAddress string,
+	contractAddress string,
+	accountAddress string,
+	accountKeyIndex uint,
+	accountPrivateKey string,
+) (module.QCContractClient, error) {
+
+	sk, err := sdkcrypto.DecodePrivateKeyHex(sdkcrypto.ECDSA_P256, accountPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode account private key: %w", err)
+	}
+	signer := sdkcrypto.NewInMemorySigner(sk, sdkcrypto.SHA3_256)
+
+	flowClient, err := client.New(accessAddress, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("could not create access api client: %w", err)
+	}
+
+	return epochs.NewQCContractClient(
+		node.Logger,
+		flowClient,
+		node.Me.NodeID(),
+		accountAddress,
+		accountKeyIndex,
+		contractAddress,
+		signer,
+	), nil
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -23,10 +24,12 @@ import (
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/consensus/compliance"
+	dkgeng "github.com/onflow/flow-go/engine/consensus/dkg"
 	"github.com/onflow/flow-go/engine/consensus/ingestion"
 	"github.com/onflow/flow-go/engine/consensus/matching"
 	"github.com/onflow/flow-go/engine/consensus/provider"
 	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -34,6 +37,7 @@ import (
 	"github.com/onflow/flow-go/module/buffer"
 	builder "github.com/onflow/flow-go/module/builder/consensus"
 	chmodule "github.com/onflow/flow-go/module/chunks"
+	dkgmodule "github.com/onflow/flow-go/module/dkg"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
 	"github.com/onflow/flow-go/module/mempool"
 	consensusMempools "github.com/onflow/flow-go/module/mempool/consensus"
@@ -45,6 +49,8 @@ import (
 	"github.com/onflow/flow-go/module/validation"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/events/gadgets"
+	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/io"
 )
@@ -74,7 +80,8 @@ func main() {
 
 		err               error
 		mutableState      protocol.MutableState
-		privateDKGData    *bootstrap.DKGParticipantPriv
+		dkgKeys           storage.DKGKeys
+		dkgBrokerTunnel   *dkgmodule.BrokerTunnel
 		guarantees        mempool.Guarantees
 		results           mempool.IncorporatedResults
 		receipts          mempool.ExecutionTree
@@ -171,9 +178,30 @@ func main() {
 				sealValidator)
 			return err
 		}).
+		Module("dkg key storage", func(node *cmd.FlowNodeBuilder) error {
+			dkgKeys = bstorage.NewDKGKeys(node.DB)
+			return nil
+		}).
 		Module("random beacon key", func(node *cmd.FlowNodeBuilder) error {
-			privateDKGData, err = loadDKGPrivateData(node.BaseConfig.BootstrapDir, node.NodeID)
-			return err
+			// the key share of the root epoch is provided by the bootstrap files,
+			// those of later epochs are stored by the DKG reactor engine
+			root, err := node.State.Params().Root()
+			if err != nil {
+				return fmt.Errorf("could not get root block: %w", err)
+			}
+			counter, err := node.State.AtBlockID(root.ID()).Epochs().Current().Counter()
+			if err != nil {
+				return fmt.Errorf("could not get root epoch counter: %w", err)
+			}
+			_, err = dkgKeys.RetrieveMyDKGPrivateInfo(counter)
+			if !errors.Is(err, storage.ErrNotFound) {
+				return err
+			}
+			privateDKGData, err := loadDKGPrivateData(node.BaseConfig.BootstrapDir, node.NodeID)
+			if err != nil {
+				return fmt.Errorf("could not load random beacon key: %w", err)
+			}
+			return dkgKeys.InsertMyDKGPrivateInfo(counter, privateDKGData)
 		}).
 		Module("collection guarantees mempool", func(node *cmd.FlowNodeBuilder) error {
 			guarantees, err = stdmap.NewGuarantees(guaranteeLimit)
@@ -361,8 +389,10 @@ func main() {
 			// initialize the aggregating signature module for staking signatures
 			staking := signature.NewAggregationProvider(encoding.ConsensusVoteTag, node.Me)
 
-			// initialize the threshold signature module for random beacon signatures
-			beacon := signature.NewThresholdProvider(encoding.RandomBeaconTag, privateDKGData.RandomBeaconPrivKey)
+			// initialize the threshold signature modules for random beacon
+			// signatures, signing with the key share of each view's epoch
+			beaconVerifier := signature.NewThresholdVerifier(encoding.RandomBeaconTag)
+			beaconStore := signature.NewEpochAwareSignerStore(encoding.RandomBeaconTag, node.State, dkgKeys)
 
 			// initialize the simple merger to combine staking & beacon signatures
 			merger := signature.NewCombiner()
@@ -380,8 +410,9 @@ func main() {
 			signer = verification.NewCombinedSigner(
 				committee,
 				staking,
-				beacon,
+				beaconVerifier,
 				merger,
+				beaconStore,
 				node.NodeID,
 			)
 			signer = verification.NewMetricsWrapper(signer, mainMetrics) // wrapper for measuring time spent with crypto-related operations
@@ -460,17 +491,52 @@ func main() {
 			// created with matching engine
 			return receiptRequester, nil
 		}).
+		Component("DKG messaging engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			dkgBrokerTunnel = dkgmodule.NewBrokerTunnel()
+			messagingEngine, err := dkgeng.NewMessagingEngine(
+				node.Logger,
+				node.Network,
+				node.Me,
+				dkgBrokerTunnel,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not initialize DKG messaging engine: %w", err)
+			}
+			return messagingEngine, nil
+		}).
+		Component("DKG reactor engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			heightEvents := gadgets.NewHeights()
+			node.ProtocolEvents.AddConsumer(heightEvents)
+
+			reactorEngine := dkgeng.NewReactorEngine(
+				node.Logger,
+				node.Me,
+				node.State,
+				dkgKeys,
+				dkgmodule.NewControllerFactory(
+					node.Logger,
+					node.Me,
+					dkgBrokerTunnel,
+				),
+				heightEvents,
+			)
+
+			// register the reactor for protocol events
+			node.ProtocolEvents.AddConsumer(reactorEngine)
+
+			return reactorEngine, nil
+		}).
 		Run()
 }
 
-func loadDKGPrivateData(dir string, myID flow.Identifier) (*bootstrap.DKGParticipantPriv, error) {
+func loadDKGPrivateData(dir string, myID flow.Identifier) (*dkg.DKGParticipantPriv, error) {
 	path := fmt.Sprintf(bootstrap.PathRandomBeaconPriv, myID)
 	data, err := io.ReadFile(filepath.Join(dir, path))
 	if err != nil {
 		return nil, err
 	}
 
-	var priv bootstrap.DKGParticipantPriv
+	var priv dkg.DKGParticipantPriv
 	err = json.Unmarshal(data, &priv)
 	if err != nil {
 		return nil, err
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +11,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/onflow/cadence/runtime"
 	"github.com/spf13/pflag"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/onflow/flow-go/engine/common/provider"
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/engine/execution/admin"
 	"github.com/onflow/flow-go/engine/execution/checker"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
@@ -29,9 +33,14 @@ import (
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
+	"github.com/onflow/flow-go/engine/execution/statesync"
+	"github.com/onflow/flow-go/engine/execution/stopcontrol"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/extralog"
+	ledgerapi "github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/archive"
 	wal "github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encoding"
@@ -45,7 +54,9 @@ import (
 	chainsync "github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
+	storageapi "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/badger"
+	sutil "github.com/onflow/flow-go/storage/util"
 )
 
 func main() {
@@ -53,6 +64,7 @@ func main() {
 	var (
 		followerState         protocol.MutableState
 		ledgerStorage         *ledger.Ledger
+		stateLedger           ledgerapi.Ledger
 		events                *storage.Events
 		txResults             *storage.TransactionResults
 		results               *storage.ExecutionResults
@@ -68,21 +80,28 @@ func main() {
 		collectionRequester   *requester.Engine
 		ingestionEng          *ingestion.Engine
 		rpcConf               rpc.Config
+		adminConf             admin.Config
 		err                   error
 		executionState        state.ExecutionState
 		triedir               string
+		archiveDir            string
 		collector             module.ExecutionMetrics
 		mTrieCacheSize        uint32
 		checkpointDistance    uint
 		checkpointsToKeep     uint
+		checkpointVersion     uint
 		stateDeltasLimit      uint
 		cadenceExecutionCache uint
+		parallelExecution     uint
 		requestInterval       time.Duration
 		preferredExeNodeIDStr string
 		syncByBlocks          bool
-		syncFast              bool
-		syncThreshold         int
+		syncConfig            = statesync.DefaultConfig()
 		extensiveLog          bool
+		stopAtHeight          uint64
+		registerIndex         bool
+		eventEncoding         string
+		registers             storageapi.RegisterIndex // nil if the register index is disabled
 	)
 
 	cmd.FlowNode(flow.RoleExecution.String()).
@@ -91,17 +110,29 @@ func main() {
 			datadir := filepath.Join(homedir, ".flow", "execution")
 
 			flags.StringVarP(&rpcConf.ListenAddr, "rpc-addr", "i", "localhost:9000", "the address the gRPC server listens on")
+			flags.StringVar(&adminConf.ListenAddr, "admin-addr", "localhost:9002", "the address the admin gRPC server listens on")
 			flags.StringVar(&triedir, "triedir", datadir, "directory to store the execution State")
+			flags.StringVar(&archiveDir, "archive-dir", "", "directory to archive all historic execution state tries in (empty to disable archiving)")
 			flags.Uint32Var(&mTrieCacheSize, "mtrie-cache-size", 1000, "cache size for MTrie")
 			flags.UintVar(&checkpointDistance, "checkpoint-distance", 10, "number of WAL segments between checkpoints")
 			flags.UintVar(&checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+			flags.UintVar(&checkpointVersion, "checkpoint-version", uint(wal.VersionV3), "version of the checkpoints to write, 3 or 4 (only use 4 once all nodes loading the checkpoints can read it)")
 			flags.UintVar(&stateDeltasLimit, "state-deltas-limit", 1000, "maximum number of state deltas in the memory pool")
 			flags.UintVar(&cadenceExecutionCache, "cadence-execution-cache", computation.DefaultProgramsCacheSize, "cache size for Cadence execution")
+			flags.UintVar(&parallelExecution, "parallel-execution-workers", 0, "number of workers to execute the transactions of a collection in parallel (0 or 1 for serial execution)")
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "deprecated, sync by blocks instead of execution state deltas")
-			flags.BoolVar(&syncFast, "sync-fast", false, "fast sync allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up")
-			flags.IntVar(&syncThreshold, "sync-threshold", 100, "the maximum number of sealed and unexecuted blocks before triggering state syncing")
+			flags.BoolVar(&syncConfig.Enabled, "state-sync", false, "sync execution state from other execution nodes instead of executing every block, when falling behind the sealed state")
+			flags.BoolVar(&syncConfig.Serve, "state-sync-serve", syncConfig.Serve, "persist state interactions and serve execution state deltas to syncing execution nodes")
+			flags.BoolVar(&syncConfig.Fast, "sync-fast", false, "fast sync allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up")
+			flags.Uint64Var(&syncConfig.Threshold, "sync-threshold", 100, "the maximum number of sealed and unexecuted blocks before triggering state syncing")
+			flags.Uint64Var(&syncConfig.BatchSize, "state-sync-batch-size", syncConfig.BatchSize, "the maximum number of blocks requested or served in a single state sync request")
+			flags.DurationVar(&syncConfig.RequestTimeout, "state-sync-request-timeout", syncConfig.RequestTimeout, "the time without progress after which a state sync request is sent to another execution node")
+			flags.Float64Var(&syncConfig.ServeRate, "state-sync-serve-rate", syncConfig.ServeRate, "the number of state sync requests served per second and execution node (0 for no limit)")
+			flags.Uint64Var(&stopAtHeight, "stop-at-height", 0, "height of the last block to execute before stopping, persisted across restarts and ignored once executed (0 to keep the persisted stop height)")
+			flags.BoolVar(&registerIndex, "register-index", false, "index the registers updated by each block, to serve scripts and accounts at heights no longer held by the ledger, backfilled from the WAL when first enabled")
+			flags.StringVar(&eventEncoding, "event-encoding", flow.EventEncodingVersionJSONCDC.String(), "encoding of the event payloads stored and served by this node (json-cdc or cbor), events are always emitted and included in execution results in json-cdc; access nodes must support the encoding before it's enabled")
 			flags.BoolVar(&extensiveLog, "extensive-logging", false, "extensive logging logs tx contents and block headers")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
@@ -143,6 +174,7 @@ func main() {
 				vm,
 				vmCtx,
 				cadenceExecutionCache,
+				parallelExecution,
 			)
 			computationManager = manager
 
@@ -161,6 +193,12 @@ func main() {
 			receipts = storage.NewExecutionReceipts(node.Metrics.Cache, node.DB, results)
 			return nil
 		}).
+		Module("register index", func(node *cmd.FlowNodeBuilder) error {
+			if registerIndex {
+				registers = storage.NewRegisterIndex(node.DB)
+			}
+			return nil
+		}).
 		Module("pending block cache", func(node *cmd.FlowNodeBuilder) error {
 			pendingBlocks = buffer.NewPendingBlocks() // for following main chain consensus
 			return nil
@@ -204,8 +242,41 @@ func main() {
 				}
 			}
 
-			ledgerStorage, err = ledger.NewLedger(triedir, int(mTrieCacheSize), collector, node.Logger.With().Str("subcomponent", "ledger").Logger(), node.MetricsRegisterer, ledger.DefaultPathFinderVersion)
-			return ledgerStorage, err
+			// the register index is backfilled when it's first enabled, before the
+			// ledger opens the WAL
+			if registers != nil {
+				_, _, err = registers.Heights()
+				if errors.Is(err, storageapi.ErrNotFound) {
+					err = backfillRegisterIndex(node, triedir, int(mTrieCacheSize), registers)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("could not initialize register index: %w", err)
+				}
+			}
+
+			ledgerLogger := node.Logger.With().Str("subcomponent", "ledger").Logger()
+			if archiveDir == "" {
+				ledgerStorage, err = ledger.NewLedger(triedir, int(mTrieCacheSize), collector, ledgerLogger, node.MetricsRegisterer, ledger.DefaultPathFinderVersion)
+				stateLedger = ledgerStorage
+				return ledgerStorage, err
+			}
+
+			// in archive mode, all tries are persisted so that the execution state
+			// at any previously executed block can still be read
+			archiveDB, err := badger.Open(badger.DefaultOptions(archiveDir).WithLogger(sutil.NewLogger(ledgerLogger)))
+			if err != nil {
+				return nil, fmt.Errorf("could not open archive database: %w", err)
+			}
+			archiveLedger, err := ledger.NewArchiveLedger(triedir, int(mTrieCacheSize), collector, ledgerLogger, node.MetricsRegisterer, ledger.DefaultPathFinderVersion, archive.NewNodeStore(archiveDB))
+			if err != nil {
+				_ = archiveDB.Close()
+				return nil, fmt.Errorf("could not create archive ledger: %w", err)
+			}
+			ledgerStorage = archiveLedger.Ledger
+			stateLedger = archiveLedger
+
+			// the archive ledger closes the archive database when it's done
+			return archiveLedger, nil
 		}).
 		Component("execution state ledger WAL compactor", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
@@ -213,6 +284,10 @@ func main() {
 			if err != nil {
 				return nil, fmt.Errorf("cannot create checkpointer: %w", err)
 			}
+			err = checkpointer.SetVersion(uint16(checkpointVersion))
+			if err != nil {
+				return nil, fmt.Errorf("invalid checkpoint version: %w", err)
+			}
 			compactor := wal.NewCompactor(checkpointer, 10*time.Second, checkpointDistance, checkpointsToKeep)
 
 			return compactor, nil
@@ -222,7 +297,7 @@ func main() {
 			stateCommitments := storage.NewCommits(node.Metrics.Cache, node.DB)
 
 			executionState = state.NewExecutionState(
-				ledgerStorage,
+				stateLedger,
 				stateCommitments,
 				node.Storage.Blocks,
 				node.Storage.Collections,
@@ -231,6 +306,7 @@ func main() {
 				receipts,
 				node.Storage.Headers,
 				node.DB,
+				registers,
 				node.Tracer,
 			)
 
@@ -274,9 +350,20 @@ func main() {
 			}
 
 			// Needed for gRPC server, make sure to assign to main scoped vars
-			events = storage.NewEvents(node.DB)
+			eventEncodingVersion, err := flow.ParseEventEncodingVersion(eventEncoding)
+			if err != nil {
+				return nil, fmt.Errorf("invalid event encoding: %w", err)
+			}
+
+			events = storage.NewEventsWithEncoding(node.DB, eventEncodingVersion)
 			serviceEvents := storage.NewServiceEvents(node.DB)
 			txResults = storage.NewTransactionResults(node.DB)
+
+			stopControl, err := stopcontrol.New(node.Logger, node.DB, collector)
+			if err != nil {
+				return nil, fmt.Errorf("could not create stop control: %w", err)
+			}
+
 			ingestionEng, err = ingestion.New(
 				node.Logger,
 				node.Network,
@@ -284,6 +371,7 @@ func main() {
 				collectionRequester,
 				node.State,
 				node.Storage.Blocks,
+				node.Storage.Seals,
 				node.Storage.Collections,
 				events,
 				serviceEvents,
@@ -296,9 +384,32 @@ func main() {
 				extensiveLog,
 				preferredExeFilter,
 				deltas,
-				syncThreshold,
-				syncFast,
+				syncConfig,
+				stopControl,
 			)
+			if err != nil {
+				return nil, err
+			}
+
+			// the flag is given again on every restart, so it is ignored once the
+			// execution passed it, keeping the stop height set by the admin command
+			if stopAtHeight > 0 {
+				executedHeight, _, err := executionState.GetHighestExecutedBlockID(context.Background())
+				if err != nil {
+					return nil, fmt.Errorf("could not get highest executed height: %w", err)
+				}
+				if stopAtHeight > executedHeight {
+					err = ingestionEng.SetStopHeight(stopAtHeight)
+					if err != nil {
+						return nil, fmt.Errorf("could not set stop height: %w", err)
+					}
+				} else {
+					node.Logger.Warn().
+						Uint64("stop_height", stopAtHeight).
+						Uint64("executed_height", executedHeight).
+						Msg("ignoring stop height at or below the highest executed height")
+				}
+			}
 
 			// TODO: we should solve these mutual dependencies better
 			// => https://github.com/dapperlabs/flow-go/issues/4360
@@ -306,7 +417,7 @@ func main() {
 
 			node.ProtocolEvents.AddConsumer(ingestionEng)
 
-			return ingestionEng, err
+			return ingestionEng, nil
 		}).
 		Component("follower engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
@@ -408,6 +519,10 @@ func main() {
 		Component("grpc server", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 			rpcEng := rpc.New(node.Logger, rpcConf, ingestionEng, node.Storage.Blocks, events, results, txResults, node.RootChainID)
 			return rpcEng, nil
+		}).
+		Component("admin server", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			adminEng := admin.New(node.Logger, adminConf, ingestionEng)
+			return adminEng, nil
 		}).Run()
 }
 
@@ -479,3 +594,37 @@ func copyBootstrapState(dir, trie string) error {
 
 	return out.Close()
 }
+
+// backfillRegisterIndex bootstraps the register index from the latest
+// checkpoint in the trie folder, and indexes the finalized blocks in the
+// WAL segments after it.
+func backfillRegisterIndex(node *cmd.FlowNodeBuilder, trie string, capacity int, registers storageapi.RegisterIndex) error {
+	final, err := node.State.Final().Head()
+	if err != nil {
+		return fmt.Errorf("could not get finalized block: %w", err)
+	}
+
+	// the root moves up when blocks are pruned, and the headers below it are
+	// not available anymore
+	root, err := node.State.Params().Root()
+	if err != nil {
+		return fmt.Errorf("could not get root block: %w", err)
+	}
+
+	w, err := wal.NewWAL(node.Logger, nil, trie, capacity, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		return fmt.Errorf("could not open WAL: %w", err)
+	}
+	defer w.Close()
+
+	return state.BackfillRegisterIndex(
+		node.Logger.With().Str("subcomponent", "register_index").Logger(),
+		w,
+		capacity,
+		node.Storage.Headers,
+		storage.NewCommits(node.Metrics.Cache, node.DB),
+		registers,
+		root.Height,
+		final.Height,
+	)
+}
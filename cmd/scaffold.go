@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"math/rand"
@@ -26,13 +27,16 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
+	cborcodec "github.com/onflow/flow-go/network/codec/cbor"
 	jsoncodec "github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/network/codec/versioned"
 	"github.com/onflow/flow-go/network/p2p"
 	"github.com/onflow/flow-go/network/topology"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/events"
 	"github.com/onflow/flow-go/state/protocol/events/gadgets"
+	"github.com/onflow/flow-go/state/protocol/inmem"
 	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/storage/badger/operation"
@@ -59,6 +63,11 @@ type BaseConfig struct {
 	profilerInterval time.Duration
 	profilerDuration time.Duration
 	tracerEnabled    bool
+	pruningRetention uint64
+	rateLimitScale   float64
+	networkCodec     string
+	compression      string
+	compressionMin   int
 }
 
 type Metrics struct {
@@ -132,6 +141,7 @@ type FlowNodeBuilder struct {
 	postInitFns       []func(*FlowNodeBuilder)
 	stakingKey        crypto.PrivateKey
 	networkKey        crypto.PrivateKey
+	pruner            *bstorage.Pruner
 
 	// root state information
 	RootBlock   *flow.Block
@@ -160,26 +170,64 @@ func (fnb *FlowNodeBuilder) baseFlags() {
 		"the duration to run the auto-profile for")
 	fnb.flags.BoolVar(&fnb.BaseConfig.tracerEnabled, "tracer-enabled", false,
 		"whether to enable tracer")
+	fnb.flags.Uint64Var(&fnb.BaseConfig.pruningRetention, "pruning-retention", 0,
+		"number of blocks below the latest sealed block to keep in storage when pruning, 0 disables pruning")
+	fnb.flags.Float64Var(&fnb.BaseConfig.rateLimitScale, "rate-limit-scale", 1,
+		"factor applied to the default limits on inbound messages of each peer, 0 disables rate limiting")
+	fnb.flags.StringVar(&fnb.BaseConfig.networkCodec, "network-codec", "json",
+		"codec used to encode network messages (json or cbor), messages of both codecs are always decoded")
+	fnb.flags.StringVar(&fnb.BaseConfig.compression, "unicast-compression", p2p.CompressionNone,
+		"algorithm used to compress large unicast messages (none or snappy), compressed messages are always accepted")
+	fnb.flags.IntVar(&fnb.BaseConfig.compressionMin, "unicast-compression-threshold", p2p.DefaultCompressionThreshold,
+		"size in bytes from which unicast messages are compressed")
 
 }
 
 func (fnb *FlowNodeBuilder) enqueueNetworkInit() {
 	fnb.Component("network", func(builder *FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
-		codec := jsoncodec.NewCodec()
+		// messages are encoded with the configured codec, and decoded with any codec, so that the network can
+		// switch codecs without a spork
+		var encoder network.Codec
+		switch fnb.BaseConfig.networkCodec {
+		case "json":
+			encoder = jsoncodec.NewCodec()
+		case "cbor":
+			encoder = cborcodec.NewCodec()
+		default:
+			return nil, fmt.Errorf("invalid network codec: %s", fnb.BaseConfig.networkCodec)
+		}
+		codec := versioned.NewCodec(encoder)
 
 		myAddr := fnb.Me.Address()
 		if fnb.BaseConfig.bindAddr != notSet {
 			myAddr = fnb.BaseConfig.bindAddr
 		}
 
+		// tracks the score of other nodes, lowered by the misbehavior reported by engines
+		scorer := p2p.NewPeerScorer(fnb.Logger, fnb.Metrics.Network)
+
+		// limits the rate of inbound messages of each peer, based on the role of this node
+		role, err := flow.ParseRole(fnb.BaseConfig.nodeRole)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse node role: %w", err)
+		}
+		limiter := p2p.NewRateLimiter(p2p.DefaultRateLimits(role).Scale(fnb.BaseConfig.rateLimitScale))
+
+		// compresses large unicast messages to the nodes which support the configured algorithm
+		compression, err := p2p.NewUnicastCompression(fnb.BaseConfig.compression, fnb.BaseConfig.compressionMin)
+		if err != nil {
+			return nil, fmt.Errorf("could not configure unicast compression: %w", err)
+		}
+
 		libP2PNodeFactory, err := p2p.DefaultLibP2PNodeFactory(fnb.Logger.Level(zerolog.ErrorLevel),
 			fnb.Me.NodeID(),
 			myAddr,
 			fnb.networkKey,
 			fnb.RootBlock.ID().String(),
 			p2p.DefaultMaxPubSubMsgSize,
-			fnb.Metrics.Network)
+			fnb.Metrics.Network,
+			scorer)
 		if err != nil {
 			return nil, fmt.Errorf("could not generate libp2p node factory: %w", err)
 		}
@@ -188,6 +236,9 @@ func (fnb *FlowNodeBuilder) enqueueNetworkInit() {
 			libP2PNodeFactory,
 			fnb.Me.NodeID(),
 			fnb.Metrics.Network,
+			scorer,
+			limiter,
+			compression,
 			fnb.RootBlock.ID().String(),
 			fnb.MsgValidators...)
 
@@ -216,6 +267,7 @@ func (fnb *FlowNodeBuilder) enqueueNetworkInit() {
 			10e6,
 			topologyCache,
 			subscriptionManager,
+			limiter,
 			fnb.Metrics.Network)
 		if err != nil {
 			return nil, fmt.Errorf("could not initialize network: %w", err)
@@ -400,6 +452,17 @@ func (fnb *FlowNodeBuilder) initStorage() {
 	commits := bstorage.NewEpochCommits(fnb.Metrics.Cache, fnb.DB)
 	statuses := bstorage.NewEpochStatuses(fnb.Metrics.Cache, fnb.DB)
 
+	// the pruner evicts pruned entities from the caches of the storage
+	// components; the retention has to cover the transaction expiry, so that
+	// reference blocks can be checked
+	if fnb.BaseConfig.pruningRetention > 0 {
+		if fnb.BaseConfig.pruningRetention < flow.DefaultTransactionExpiry {
+			fnb.Logger.Fatal().Msgf("pruning retention must be at least the transaction expiry (%d)", flow.DefaultTransactionExpiry)
+		}
+		fnb.pruner = bstorage.NewPruner(fnb.Logger, fnb.DB, metrics.NewPrunerCollector(),
+			headers, index, guarantees, seals, receipts, statuses, fnb.BaseConfig.pruningRetention)
+	}
+
 	fnb.Storage = Storage{
 		Headers:      headers,
 		Guarantees:   guarantees,
@@ -441,14 +504,44 @@ func (fnb *FlowNodeBuilder) initState() {
 		// but the protocol state is not updated, so they don't match
 		// when this happens during a spork, we could try deleting the protocol state database.
 		// TODO: revisit this check when implementing Epoch
-		rootBlock, err := loadRootBlock(fnb.BaseConfig.BootstrapDir)
-		fnb.MustNot(err).Msg("could not load root block")
+		var rootBlock *flow.Block
+		var rootQC *flow.QuorumCertificate
+		var rootResult *flow.ExecutionResult
+		var rootSeal *flow.Seal
+		if rootSnapshotExists(fnb.BaseConfig.BootstrapDir) {
+			rootBlock, rootQC, rootResult, rootSeal, err = loadRootFromSnapshot(fnb.BaseConfig.BootstrapDir)
+			fnb.MustNot(err).Msg("could not load root from root snapshot")
+		} else {
+			rootBlock, err = loadRootBlock(fnb.BaseConfig.BootstrapDir)
+			fnb.MustNot(err).Msg("could not load root block")
+			rootQC, err = loadRootQC(fnb.BaseConfig.BootstrapDir)
+			fnb.MustNot(err).Msg("could not load root QC")
+			rootResult, err = loadRootResult(fnb.BaseConfig.BootstrapDir)
+			fnb.MustNot(err).Msg("could not load root execution result")
+			rootSeal, err = loadRootSeal(fnb.BaseConfig.BootstrapDir)
+			fnb.MustNot(err).Msg("could not load root seal")
+		}
+		// When the protocol state has been pruned, its root was moved forward
+		// from the bootstrap root block, whose ID was recorded by the pruner.
 		if rootBlock.ID() != stateRoot.Block().ID() {
-			fnb.Logger.Fatal().Msgf("mismatching root block ID, protocol state block ID: %v, bootstrap root block ID: %v",
-				rootBlock.ID(),
-				fnb.RootBlock.ID())
+			var prunedRootID flow.Identifier
+			err = fnb.DB.View(operation.RetrievePrunedRoot(&prunedRootID))
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				fnb.Logger.Fatal().Err(err).Msg("could not retrieve pruned root block ID")
+			}
+			if prunedRootID != rootBlock.ID() {
+				fnb.Logger.Fatal().Msgf("mismatching root block ID, protocol state block ID: %v, bootstrap root block ID: %v",
+					stateRoot.Block().ID(),
+					rootBlock.ID())
+			}
+			fnb.Logger.Info().
+				Uint64("bootstrap_root_height", rootBlock.Header.Height).
+				Uint64("pruned_root_height", stateRoot.Block().Header.Height).
+				Msg("protocol state has been pruned above bootstrap root block")
 		}
-		fnb.RootBlock = stateRoot.Block()
+		// the bootstrap root block is kept, as it is certified by the root QC, and
+		// so are the root result and seal, which have to match the root block
+		fnb.RootBlock = rootBlock
 
 		// TODO: we shouldn't have to load any files again after bootstrapping; in
 		// order to make it unnecessary, we need to changes:
@@ -464,12 +557,48 @@ func (fnb *FlowNodeBuilder) initState() {
 		// => https://github.com/dapperlabs/flow-go/issues/4167
 		fnb.RootChainID = stateRoot.Block().Header.ChainID
 
-		// load the root QC data from bootstrap files
-		fnb.RootQC, err = loadRootQC(fnb.BaseConfig.BootstrapDir)
-		fnb.MustNot(err).Msg("could not load root QC")
+		fnb.RootQC = rootQC
+		fnb.RootResult = rootResult
+		fnb.RootSeal = rootSeal
+	} else if rootSnapshotExists(fnb.BaseConfig.BootstrapDir) {
+		// Bootstrap from a sealed protocol state snapshot!
+
+		fnb.Logger.Info().Msg("bootstrapping empty protocol state from root snapshot")
+
+		rootSnapshot, err := loadRootSnapshot(fnb.BaseConfig.BootstrapDir)
+		fnb.MustNot(err).Msg("could not load root snapshot")
+
+		segment, err := rootSnapshot.SealingSegment()
+		fnb.MustNot(err).Msg("could not get sealing segment from root snapshot")
+		fnb.RootBlock = segment[len(segment)-1]
+		fnb.RootChainID = fnb.RootBlock.Header.ChainID
+
+		fnb.RootQC, err = rootSnapshot.QuorumCertificate()
+		fnb.MustNot(err).Msg("could not get root QC from root snapshot")
+		fnb.RootResult, err = rootSnapshot.LatestResult()
+		fnb.MustNot(err).Msg("could not get root execution result from root snapshot")
+		fnb.RootSeal, err = rootSnapshot.LatestSeal()
+		fnb.MustNot(err).Msg("could not get root seal from root snapshot")
 
-		fnb.RootResult = stateRoot.Result()
-		fnb.RootSeal = stateRoot.Seal()
+		fnb.State, err = badgerState.BootstrapFromSnapshot(
+			fnb.Metrics.Compliance,
+			fnb.DB,
+			fnb.Storage.Headers,
+			fnb.Storage.Seals,
+			fnb.Storage.Blocks,
+			fnb.Storage.Setups,
+			fnb.Storage.Commits,
+			fnb.Storage.Statuses,
+			rootSnapshot,
+		)
+		fnb.MustNot(err).Msg("could not bootstrap protocol state from root snapshot")
+
+		fnb.Logger.Info().
+			Hex("root_result_id", logging.Entity(fnb.RootResult)).
+			Hex("root_state_commitment", fnb.RootSeal.FinalState).
+			Hex("root_block_id", logging.Entity(fnb.RootBlock)).
+			Uint64("root_block_height", fnb.RootBlock.Header.Height).
+			Msg("protocol state bootstrapped from root snapshot")
 	} else {
 		// Bootstrap!
 
@@ -562,6 +691,13 @@ func (fnb *FlowNodeBuilder) initState() {
 		Hex("block_id", logging.Entity(lastFinalized)).
 		Uint64("height", lastFinalized.Height).
 		Msg("last finalized block")
+
+	// prune historical blocks as new blocks are finalized
+	if fnb.pruner != nil {
+		fnb.ProtocolEvents.AddConsumer(gadgets.NewFinalization(func(*flow.Header) {
+			fnb.pruner.RunPruning()
+		}))
+	}
 }
 
 func (fnb *FlowNodeBuilder) initFvmOptions() {
@@ -816,6 +952,52 @@ func loadRootSeal(dir string) (*flow.Seal, error) {
 	return &seal, err
 }
 
+// rootSnapshotExists returns true if a root protocol state snapshot is present
+// in the bootstrap directory.
+func rootSnapshotExists(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, bootstrap.PathRootSnapshot))
+	return err == nil
+}
+
+func loadRootSnapshot(dir string) (*inmem.Snapshot, error) {
+	data, err := io.ReadFile(filepath.Join(dir, bootstrap.PathRootSnapshot))
+	if err != nil {
+		return nil, err
+	}
+	var enc inmem.EncodableSnapshot
+	err = json.Unmarshal(data, &enc)
+	if err != nil {
+		return nil, err
+	}
+	return inmem.SnapshotFromEncodable(enc), nil
+}
+
+// loadRootFromSnapshot loads the root block of the root snapshot, along with
+// its QC, and the latest execution result and seal as of the root block.
+func loadRootFromSnapshot(dir string) (*flow.Block, *flow.QuorumCertificate, *flow.ExecutionResult, *flow.Seal, error) {
+	snapshot, err := loadRootSnapshot(dir)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	segment, err := snapshot.SealingSegment()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("could not get sealing segment: %w", err)
+	}
+	qc, err := snapshot.QuorumCertificate()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("could not get root QC: %w", err)
+	}
+	result, err := snapshot.LatestResult()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("could not get root execution result: %w", err)
+	}
+	seal, err := snapshot.LatestSeal()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("could not get root seal: %w", err)
+	}
+	return segment[len(segment)-1], qc, result, seal, nil
+}
+
 // Loads the private info for this node from disk (eg. private staking/network keys).
 func loadPrivateNodeInfo(dir string, myID flow.Identifier) (*bootstrap.NodeInfoPriv, error) {
 	data, err := io.ReadFile(filepath.Join(dir, fmt.Sprintf(bootstrap.PathNodeInfoPriv, myID)))
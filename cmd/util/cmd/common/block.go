@@ -0,0 +1,52 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/storage"
+)
+
+// ExecutableBlock assembles the block with the given ID and the transactions
+// of its collections from storage, to be executed on the state of its parent.
+func ExecutableBlock(storages *storage.All, blockID flow.Identifier) (*entity.ExecutableBlock, error) {
+	block, err := storages.Blocks.ByID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve block: %w", err)
+	}
+
+	startState, err := storages.Commits.ByBlockID(block.Header.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve state commitment of parent block: %w", err)
+	}
+
+	executableBlock := &entity.ExecutableBlock{
+		Block:               block,
+		CompleteCollections: make(map[flow.Identifier]*entity.CompleteCollection),
+		StartState:          startState,
+	}
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := storages.Collections.ByID(guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve collection %x: %w", guarantee.CollectionID, err)
+		}
+		executableBlock.CompleteCollections[guarantee.ID()] = &entity.CompleteCollection{
+			Guarantee:    guarantee,
+			Transactions: collection.Transactions,
+		}
+	}
+
+	return executableBlock, nil
+}
+
+// ParseChain returns the chain with the given name.
+func ParseChain(chainName string) (chain flow.Chain, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid chain: %s", r)
+		}
+	}()
+	chain = flow.ChainID(chainName).Chain()
+	return
+}
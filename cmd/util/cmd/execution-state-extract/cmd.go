@@ -2,11 +2,16 @@ package extract
 
 import (
 	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/badger"
@@ -18,6 +23,10 @@ var (
 	flagBlockHash         string
 	flagStateCommitment   string
 	flagDatadir           string
+	flagMigrations        []string
+	flagDryRun            bool
+	flagMigrationWorkers  int
+	flagProgressDir       string
 )
 
 var Cmd = &cobra.Command{
@@ -43,6 +52,19 @@ func init() {
 
 	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
 		"directory that stores the protocol state")
+
+	Cmd.Flags().StringSliceVar(&flagMigrations, "migrations", nil,
+		fmt.Sprintf("migrations to run, with their dependencies (available: %s)",
+			strings.Join(migrations.Registered.Names(), ", ")))
+
+	Cmd.Flags().BoolVar(&flagDryRun, "dry-run", false,
+		"run the migrations and write their report, without writing a checkpoint")
+
+	Cmd.Flags().IntVar(&flagMigrationWorkers, "migration-workers", runtime.NumCPU(),
+		"number of accounts migrated in parallel")
+
+	Cmd.Flags().StringVar(&flagProgressDir, "progress-dir", "",
+		"directory to save the progress of the migrations to, to resume them if interrupted")
 }
 
 func run(*cobra.Command, []string) {
@@ -81,7 +103,29 @@ func run(*cobra.Command, []string) {
 
 	log.Info().Msgf("Block state commitment: %s", hex.EncodeToString(stateCommitment))
 
-	err := extractExecutionState(flagExecutionStateDir, stateCommitment, flagOutputDir, log.Logger)
+	if flagDryRun && len(flagMigrations) == 0 {
+		log.Fatal().Msg("cannot run the command in dry-run mode without migrations")
+	}
+
+	var runner *migrations.Runner
+	if len(flagMigrations) > 0 {
+		plan, err := migrations.Registered.Plan(flagMigrations...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot plan the migrations")
+		}
+
+		opts := []migrations.RunnerOption{
+			migrations.WithWorkers(flagMigrationWorkers),
+			migrations.WithReport(flagOutputDir),
+			migrations.WithDryRun(flagDryRun),
+		}
+		if len(flagProgressDir) > 0 {
+			opts = append(opts, migrations.WithProgress(flagProgressDir, ledger.State(stateCommitment)))
+		}
+		runner = migrations.NewRunner(log.Logger, plan, opts...)
+	}
+
+	err := extractExecutionState(flagExecutionStateDir, stateCommitment, flagOutputDir, log.Logger, runner)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("error extracting the execution state: %s", err.Error())
 	}
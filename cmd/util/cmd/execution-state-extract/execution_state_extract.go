@@ -1,6 +1,7 @@
 package extract
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/rs/zerolog"
@@ -18,7 +19,11 @@ func getStateCommitment(commits storage.Commits, blockHash flow.Identifier) (flo
 	return commits.ByBlockID(blockHash)
 }
 
-func extractExecutionState(dir string, targetHash flow.StateCommitment, outputDir string, log zerolog.Logger) error {
+// extractExecutionState exports the execution state at the given commitment
+// to a root checkpoint in outputDir, after running the given migrations, if
+// any. In dry-run mode, the migrations are reported but no checkpoint is
+// written.
+func extractExecutionState(dir string, targetHash flow.StateCommitment, outputDir string, log zerolog.Logger, runner *migrations.Runner) error {
 
 	led, err := complete.NewLedger(
 		dir,
@@ -31,12 +36,21 @@ func extractExecutionState(dir string, targetHash flow.StateCommitment, outputDi
 		return fmt.Errorf("cannot create ledger from write-a-head logs and checkpoints: %w", err)
 	}
 
+	var ms []ledger.Migration
+	if runner != nil {
+		ms = append(ms, runner.Migrate)
+	}
+
 	newState, err := led.ExportCheckpointAt(targetHash,
-		[]ledger.Migration{},
+		ms,
 		[]ledger.Reporter{migrations.StorageReporter{Log: log, OutputDir: outputDir}},
 		complete.DefaultPathFinderVersion,
 		outputDir,
 		wal.RootCheckpointFilename)
+	if errors.Is(err, migrations.ErrDryRun) {
+		log.Info().Msgf("Migrations ran in dry-run mode, no checkpoint was written")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("cannot generate the output checkpoint: %w", err)
 	}
@@ -59,7 +59,7 @@ func TestExtractExecutionState(t *testing.T) {
 
 	t.Run("empty WAL doesn't find anything", func(t *testing.T) {
 		withDirs(t, func(datadir, execdir, outdir string) {
-			err := extractExecutionState(execdir, unittest.StateCommitmentFixture(), outdir, zerolog.Nop())
+			err := extractExecutionState(execdir, unittest.StateCommitmentFixture(), outdir, zerolog.Nop(), nil)
 			require.Error(t, err)
 		})
 	})
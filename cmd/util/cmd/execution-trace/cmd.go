@@ -0,0 +1,113 @@
+package execution_trace
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var (
+	flagExecutionStateDir string
+	flagDatadir           string
+	flagChain             string
+	flagBlockID           string
+	flagTransactionID     string
+	flagFormat            string
+	flagOutput            string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "execution-trace",
+	Short: "Re-executes a block with tracing, and exports the traces of its transactions as JSON or flame graphs",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs are written")
+	_ = Cmd.MarkFlagRequired("execution-state-dir")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagChain, "chain", "",
+		"Chain name")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().StringVar(&flagBlockID, "block-id", "",
+		"ID of the block to re-execute (hex-encoded, 64 characters)")
+	_ = Cmd.MarkFlagRequired("block-id")
+
+	Cmd.Flags().StringVar(&flagTransactionID, "transaction-id", "",
+		"only export the trace of this transaction of the block (hex-encoded, 64 characters)")
+
+	Cmd.Flags().StringVar(&flagFormat, "format", formatJSON,
+		fmt.Sprintf("export format: %q, %q (time in folded stacks) or %q (statements in folded stacks)",
+			formatJSON, formatFlameGraph, formatStatements))
+
+	Cmd.Flags().StringVar(&flagOutput, "output", "",
+		"file to export the traces to (defaults to stdout)")
+}
+
+func run(*cobra.Command, []string) {
+
+	blockID, err := flow.HexStringToIdentifier(flagBlockID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("malformed block ID")
+	}
+
+	var transactionID *flow.Identifier
+	if len(flagTransactionID) > 0 {
+		id, err := flow.HexStringToIdentifier(flagTransactionID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("malformed transaction ID")
+		}
+		transactionID = &id
+	}
+
+	chain, err := common.ParseChain(flagChain)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid chain name")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+
+	traces, err := traceBlock(log.Logger, flagExecutionStateDir, storages, chain, blockID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not re-execute block")
+	}
+
+	if transactionID != nil {
+		traces = filterTraces(traces, *transactionID)
+		if len(traces) == 0 {
+			log.Fatal().Hex("transaction_id", transactionID[:]).Msg("transaction is not in the block")
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if len(flagOutput) > 0 {
+		file, err := os.Create(flagOutput)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not create output file")
+		}
+		defer file.Close()
+		out = file
+	}
+
+	err = exportTraces(out, traces, flagFormat)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not export traces")
+	}
+
+	log.Info().Int("traces", len(traces)).Msg("traces exported")
+}
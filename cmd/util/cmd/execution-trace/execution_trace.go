@@ -0,0 +1,129 @@
+package execution_trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	formatJSON       = "json"
+	formatFlameGraph = "flamegraph"
+	formatStatements = "statements"
+)
+
+// traceBlock re-executes a block on the execution state of its parent, and
+// returns the traces of the transactions executed, including the system chunk
+// transaction. The execution state is left untouched.
+func traceBlock(
+	log zerolog.Logger,
+	dir string,
+	storages *storage.All,
+	chain flow.Chain,
+	blockID flow.Identifier,
+) ([]*fvm.ExecutionTrace, error) {
+
+	executableBlock, err := common.ExecutableBlock(storages, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	ldg, err := complete.NewLedger(
+		dir,
+		complete.DefaultCacheSize,
+		&metrics.NoopCollector{},
+		log,
+		nil,
+		complete.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create ledger from write-a-head logs and checkpoints: %w", err)
+	}
+	defer func() {
+		<-ldg.Done()
+	}()
+
+	tracer := fvm.NewExecutionTracer()
+
+	vmOpts := []fvm.Option{
+		fvm.WithChain(chain),
+		fvm.WithBlocks(fvm.NewBlockFinder(storages.Headers)),
+		fvm.WithExecutionTracer(tracer),
+	}
+	if chain == flow.Testnet.Chain() {
+		vmOpts = append(vmOpts,
+			fvm.WithRestrictedAccountCreation(false),
+			fvm.WithRestrictedDeployment(false),
+		)
+	}
+
+	vm := fvm.New(runtime.NewInterpreterRuntime())
+	vmCtx := fvm.NewContext(log, vmOpts...)
+
+	// transactions are executed serially, as required for tracing
+	blockComputer, err := computer.NewBlockComputer(vm, vmCtx, nil, nil, log)
+	if err != nil {
+		return nil, fmt.Errorf("could not create block computer: %w", err)
+	}
+
+	view := delta.NewView(state.LedgerGetRegister(ldg, executableBlock.StartState))
+
+	_, err = blockComputer.ExecuteBlock(context.Background(), executableBlock, view, fvm.NewEmptyPrograms())
+	if err != nil {
+		return nil, fmt.Errorf("could not execute block: %w", err)
+	}
+
+	return tracer.Traces(), nil
+}
+
+// filterTraces returns the traces of the given transaction.
+func filterTraces(traces []*fvm.ExecutionTrace, transactionID flow.Identifier) []*fvm.ExecutionTrace {
+	var filtered []*fvm.ExecutionTrace
+	for _, trace := range traces {
+		if trace.ID == transactionID {
+			filtered = append(filtered, trace)
+		}
+	}
+	return filtered
+}
+
+// exportTraces writes the traces in the given format.
+func exportTraces(w io.Writer, traces []*fvm.ExecutionTrace, format string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(traces)
+	case formatFlameGraph:
+		for _, trace := range traces {
+			err := trace.WriteFoldedStacks(w)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatStatements:
+		for _, trace := range traces {
+			err := trace.WriteFoldedStatements(w)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
@@ -0,0 +1,146 @@
+package re_execute
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagExecutionStateDir string
+	flagCheckpoint        int
+	flagDatadir           string
+	flagChain             string
+	flagFromHeight        uint64
+	flagToHeight          uint64
+)
+
+var Cmd = &cobra.Command{
+	Use:   "re-execute",
+	Short: "Re-executes finalized blocks from a checkpoint and reports the first divergence from the stored execution results",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs and checkpoints are written), which must not be in use")
+	_ = Cmd.MarkFlagRequired("execution-state-dir")
+
+	Cmd.Flags().IntVar(&flagCheckpoint, "checkpoint", -1,
+		"number of the checkpoint to load (defaults to the latest one, or to the root checkpoint if there are none)")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagChain, "chain", "",
+		"Chain name")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().Uint64Var(&flagFromHeight, "from-height", 0,
+		"height of the first block to re-execute, whose parent state must be in the checkpoint")
+	_ = Cmd.MarkFlagRequired("from-height")
+
+	Cmd.Flags().Uint64Var(&flagToHeight, "to-height", 0,
+		"height of the last block to re-execute (defaults to the last executed block)")
+}
+
+func run(*cobra.Command, []string) {
+
+	if flagToHeight != 0 && flagToHeight < flagFromHeight {
+		log.Fatal().Msg("--to-height must not be lower than --from-height")
+	}
+
+	chain, err := common.ParseChain(flagChain)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid chain name")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+
+	// registers are compared with the register index when the node kept one
+	var registers storage.RegisterIndex
+	index := badger.NewRegisterIndex(db)
+	_, _, err = index.Heights()
+	if err == nil {
+		registers = index
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		log.Fatal().Err(err).Msg("could not read register index")
+	}
+
+	forest, err := loadCheckpoint(log.Logger, flagExecutionStateDir, flagCheckpoint, complete.DefaultCacheSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load checkpoint")
+	}
+
+	reExecutor, err := newReExecutor(log.Logger, storages, registers, forest, chain)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create re-executor")
+	}
+
+	height := flagFromHeight
+	for ; flagToHeight == 0 || height <= flagToHeight; height++ {
+
+		// without an upper bound, stop at the first block that was not executed
+		if flagToHeight == 0 {
+			executed, err := isExecuted(storages, height)
+			if err != nil {
+				log.Fatal().Err(err).Uint64("height", height).Msg("could not check whether block was executed")
+			}
+			if !executed {
+				break
+			}
+		}
+
+		err = reExecutor.reExecuteBlock(height)
+		var diverged *divergence
+		if errors.As(err, &diverged) {
+			log.Fatal().
+				Uint64("height", diverged.height).
+				Hex("block_id", diverged.blockID[:]).
+				Str("divergence", diverged.reason).
+				Msg("re-executed block diverges from the stored execution result")
+		}
+		if err != nil {
+			log.Fatal().Err(err).Uint64("height", height).Msg("could not re-execute block")
+		}
+
+		log.Info().Uint64("height", height).Msg("block re-executed, results match")
+	}
+
+	log.Info().
+		Uint64("from_height", flagFromHeight).
+		Uint64("to_height", height-1).
+		Msg("all blocks re-executed, results match")
+}
+
+// isExecuted returns whether the finalized block at the given height has a
+// stored execution result.
+func isExecuted(storages *storage.All, height uint64) (bool, error) {
+	header, err := storages.Headers.ByHeight(height)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = storages.Commits.ByBlockID(header.ID())
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,446 @@
+package re_execute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/encoding/events"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+)
+
+// A divergence is the first difference found between the results of the
+// re-execution of a block and the results stored when it was executed.
+type divergence struct {
+	height  uint64
+	blockID flow.Identifier
+	reason  string
+}
+
+func (d *divergence) Error() string {
+	return fmt.Sprintf("block %x at height %d diverges: %s", d.blockID, d.height, d.reason)
+}
+
+// reExecutor re-executes finalized blocks on an execution state loaded from a
+// checkpoint, and compares their results with the stored ones.
+type reExecutor struct {
+	log       zerolog.Logger
+	storages  *storage.All
+	registers storage.RegisterIndex
+	forest    *mtrie.Forest
+	computer  computer.BlockComputer
+}
+
+func newReExecutor(
+	log zerolog.Logger,
+	storages *storage.All,
+	registers storage.RegisterIndex,
+	forest *mtrie.Forest,
+	chain flow.Chain,
+) (*reExecutor, error) {
+
+	vmOpts := []fvm.Option{
+		fvm.WithChain(chain),
+		fvm.WithBlocks(fvm.NewBlockFinder(storages.Headers)),
+	}
+	if chain == flow.Testnet.Chain() {
+		vmOpts = append(vmOpts,
+			fvm.WithRestrictedAccountCreation(false),
+			fvm.WithRestrictedDeployment(false),
+		)
+	}
+
+	vm := fvm.New(runtime.NewInterpreterRuntime())
+	vmCtx := fvm.NewContext(log, vmOpts...)
+
+	blockComputer, err := computer.NewBlockComputer(vm, vmCtx, nil, nil, log)
+	if err != nil {
+		return nil, fmt.Errorf("could not create block computer: %w", err)
+	}
+
+	return &reExecutor{
+		log:       log,
+		storages:  storages,
+		registers: registers,
+		forest:    forest,
+		computer:  blockComputer,
+	}, nil
+}
+
+// loadCheckpoint loads the tries of a checkpoint of the execution state into a
+// new forest. The latest checkpoint is loaded if the checkpoint is negative,
+// or the root checkpoint if there are none.
+func loadCheckpoint(log zerolog.Logger, dir string, checkpoint int, capacity int) (*mtrie.Forest, error) {
+	w, err := wal.NewWAL(log, nil, dir, capacity, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not open WAL: %w", err)
+	}
+	defer w.Close()
+
+	checkpointer := wal.NewCheckpointer(w, pathfinder.PathByteSize, capacity)
+
+	if checkpoint < 0 {
+		checkpoint, err = checkpointer.LatestCheckpoint()
+		if err != nil {
+			return nil, fmt.Errorf("could not find latest checkpoint: %w", err)
+		}
+	}
+
+	var tries []*trie.MTrie
+	if checkpoint < 0 {
+		log.Info().Msg("loading root checkpoint")
+		tries, err = checkpointer.LoadRootCheckpoint()
+	} else {
+		log.Info().Int("checkpoint", checkpoint).Msg("loading checkpoint")
+		tries, err = checkpointer.LoadCheckpoint(checkpoint)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load checkpoint: %w", err)
+	}
+
+	forest, err := mtrie.NewForest(pathfinder.PathByteSize, "", capacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create forest: %w", err)
+	}
+
+	err = forest.AddTries(tries)
+	if err != nil {
+		return nil, fmt.Errorf("could not add tries to forest: %w", err)
+	}
+
+	return forest, nil
+}
+
+// reExecuteBlock re-executes the finalized block at the given height on the
+// state of its parent, and compares the results with the stored ones. It
+// returns a divergence error if they differ.
+func (r *reExecutor) reExecuteBlock(height uint64) error {
+	header, err := r.storages.Headers.ByHeight(height)
+	if err != nil {
+		return fmt.Errorf("could not retrieve finalized block at height %d: %w", height, err)
+	}
+	blockID := header.ID()
+
+	diverges := func(format string, args ...interface{}) error {
+		return &divergence{height: height, blockID: blockID, reason: fmt.Sprintf(format, args...)}
+	}
+
+	block, err := common.ExecutableBlock(r.storages, blockID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.forest.GetTrie(block.StartState)
+	if err != nil {
+		return fmt.Errorf("state of the parent block is not available (%x): %w", block.StartState, err)
+	}
+
+	result, err := r.storages.Results.ByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve execution result: %w", err)
+	}
+
+	view := delta.NewView(r.getRegister(block.StartState))
+	computed, err := r.computer.ExecuteBlock(context.Background(), block, view, fvm.NewEmptyPrograms())
+	if err != nil {
+		return fmt.Errorf("could not execute block: %w", err)
+	}
+
+	// chunks
+
+	if len(computed.StateSnapshots) != len(result.Chunks) {
+		return diverges("%d chunks, expected %d", len(computed.StateSnapshots), len(result.Chunks))
+	}
+
+	endState := block.StartState
+	for i, snapshot := range computed.StateSnapshots {
+		endState, err = r.commitDelta(snapshot.Delta, endState)
+		if err != nil {
+			return fmt.Errorf("could not commit delta of chunk %d: %w", i, err)
+		}
+
+		expected := result.Chunks[i].EndState
+		if bytes.Equal(endState, expected) {
+			continue
+		}
+
+		reason := fmt.Sprintf("chunk %d ends in state %x, expected %x", i, endState, expected)
+		register, err := r.divergingRegister(height, view.Delta(), result.Chunks[i], snapshot)
+		if err != nil {
+			return err
+		}
+		if register != "" {
+			reason += ", " + register
+		}
+		return diverges("%s", reason)
+	}
+
+	// transaction results and events
+
+	computedEvents := make(map[flow.Identifier][]flow.Event)
+	for _, event := range computed.Events {
+		computedEvents[event.TransactionID] = append(computedEvents[event.TransactionID], event)
+	}
+
+	for _, txResult := range computed.TransactionResult {
+		txID := txResult.TransactionID
+
+		stored, err := r.storages.TransactionResults.ByBlockIDTransactionID(blockID, txID)
+		if err != nil {
+			return fmt.Errorf("could not retrieve result of transaction %x: %w", txID, err)
+		}
+		if txResult.ErrorMessage != stored.ErrorMessage {
+			return diverges("transaction %x fails with %q, expected %q", txID, txResult.ErrorMessage, stored.ErrorMessage)
+		}
+
+		storedEvents, err := r.storages.Events.ByBlockIDTransactionID(blockID, txID)
+		if err != nil {
+			return fmt.Errorf("could not retrieve events of transaction %x: %w", txID, err)
+		}
+		txEvents := computedEvents[txID]
+		if len(txEvents) != len(storedEvents) {
+			return diverges("transaction %x emits %d events, expected %d", txID, len(txEvents), len(storedEvents))
+		}
+		for i, event := range txEvents {
+			reason, err := compareEvents(event, storedEvents[i])
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				return diverges("event %d of transaction %x %s", i, txID, reason)
+			}
+		}
+	}
+
+	return nil
+}
+
+// divergingRegister looks up a register whose value after the re-execution of
+// a chunk differs from its value after the original execution, and returns how
+// it differs, or an empty string if none can be found.
+//
+// The updates of the block are compared with the register index, if registers
+// are indexed at the height of the block. The registers touched by the chunk
+// are then compared with the trie at the expected end state of the chunk, if
+// it is in the forest, and with the registers proven by the stored chunk data
+// pack of the original execution.
+func (r *reExecutor) divergingRegister(height uint64, blockUpdates delta.Delta, chunk *flow.Chunk, snapshot *delta.SpockSnapshot) (string, error) {
+	if r.registers != nil {
+		register, err := r.divergingIndexedRegister(height, blockUpdates)
+		if err != nil || register != "" {
+			return register, err
+		}
+	}
+
+	_, err := r.forest.GetTrie(chunk.EndState)
+	if err == nil {
+		register, err := r.divergingEndStateRegister(chunk, snapshot)
+		if err != nil || register != "" {
+			return register, err
+		}
+	}
+
+	return r.divergingProvenRegister(chunk, snapshot)
+}
+
+// divergingIndexedRegister looks up the first register updated by a block
+// whose value differs from the indexed one. Nothing is found if registers are
+// not indexed at the height of the block.
+func (r *reExecutor) divergingIndexedRegister(height uint64, updates delta.Delta) (string, error) {
+	ids, values := updates.RegisterUpdates()
+	for i, id := range ids {
+		expected, err := r.registers.Get(id, height)
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("could not read indexed register %s: %w", id.String(), err)
+		}
+		if !bytes.Equal(values[i], expected) {
+			return fmt.Sprintf("register %s is %x, expected %x", id.String(), values[i], expected), nil
+		}
+	}
+
+	return "", nil
+}
+
+// divergingEndStateRegister looks up the first register touched by the
+// re-execution of a chunk whose value differs from its value at the expected
+// end state of the chunk, which must be in the forest.
+func (r *reExecutor) divergingEndStateRegister(chunk *flow.Chunk, snapshot *delta.SpockSnapshot) (string, error) {
+	getStart := r.getRegister(chunk.StartState)
+	getExpected := r.getRegister(chunk.EndState)
+
+	for _, id := range sortedRegisters(snapshot) {
+		value, updated := snapshot.Delta.Get(id.Owner, id.Controller, id.Key)
+		if !updated {
+			var err error
+			value, err = getStart(id.Owner, id.Controller, id.Key)
+			if err != nil {
+				return "", fmt.Errorf("could not read register %s at the start state of the chunk: %w", id.String(), err)
+			}
+		}
+
+		expected, err := getExpected(id.Owner, id.Controller, id.Key)
+		if err != nil {
+			return "", fmt.Errorf("could not read register %s at the end state of the chunk: %w", id.String(), err)
+		}
+
+		if !bytes.Equal(value, expected) {
+			return fmt.Sprintf("register %s is %x, expected %x", id.String(), value, expected), nil
+		}
+	}
+
+	return "", nil
+}
+
+// divergingProvenRegister looks up the first register touched by either the
+// re-execution or the original execution of a chunk, but not by both. The
+// registers touched by the original execution are the ones proven by its
+// chunk data pack; nothing is found if it is not stored.
+func (r *reExecutor) divergingProvenRegister(chunk *flow.Chunk, snapshot *delta.SpockSnapshot) (string, error) {
+	chunkDataPack, err := r.storages.ChunkDataPacks.ByChunkID(chunk.ID())
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve chunk data pack: %w", err)
+	}
+
+	proof, err := encoding.DecodeTrieBatchProof(chunkDataPack.Proof)
+	if err != nil {
+		return "", fmt.Errorf("could not decode chunk data pack proof: %w", err)
+	}
+
+	proven := make(map[string]*ledger.TrieProof, len(proof.Proofs))
+	for _, p := range proof.Proofs {
+		proven[string(p.Path)] = p
+	}
+
+	for _, id := range sortedRegisters(snapshot) {
+		path, err := pathfinder.KeyToPath(state.RegisterIDToKey(id), complete.DefaultPathFinderVersion)
+		if err != nil {
+			return "", fmt.Errorf("could not compute path of register %s: %w", id.String(), err)
+		}
+		if _, ok := proven[string(path)]; !ok {
+			return fmt.Sprintf("register %s was not touched by the original execution", id.String()), nil
+		}
+		delete(proven, string(path))
+	}
+
+	for _, p := range proof.Proofs {
+		if _, ok := proven[string(p.Path)]; !ok {
+			continue
+		}
+		if p.Payload == nil || len(p.Payload.Key.KeyParts) == 0 {
+			return fmt.Sprintf("register at path %x was touched only by the original execution", p.Path), nil
+		}
+		id, err := state.KeyToRegisterID(p.Payload.Key)
+		if err != nil {
+			return "", fmt.Errorf("could not convert proven key at path %x: %w", p.Path, err)
+		}
+		return fmt.Sprintf("register %s was touched only by the original execution", id.String()), nil
+	}
+
+	return "", nil
+}
+
+// sortedRegisters returns the registers read or updated by a chunk, ordered
+// so that the first divergence found is the same across runs.
+func sortedRegisters(snapshot *delta.SpockSnapshot) []flow.RegisterID {
+	ids := snapshot.AllRegisters()
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+// compareEvents returns how a re-executed event differs from the stored one,
+// or an empty string if they are the same.
+func compareEvents(computed flow.Event, stored flow.Event) (string, error) {
+	if computed.Type != stored.Type {
+		return fmt.Sprintf("has type %s, expected %s", computed.Type, stored.Type), nil
+	}
+	if computed.TransactionIndex != stored.TransactionIndex || computed.EventIndex != stored.EventIndex {
+		return fmt.Sprintf("has index %d/%d, expected %d/%d",
+			computed.TransactionIndex, computed.EventIndex, stored.TransactionIndex, stored.EventIndex), nil
+	}
+
+	// the stored payload may have been encoded differently
+	payload, err := events.Convert(computed.Payload, computed.EncodingVersion, stored.EncodingVersion)
+	if err != nil {
+		return "", fmt.Errorf("could not convert event payload: %w", err)
+	}
+	if !bytes.Equal(payload, stored.Payload) {
+		return fmt.Sprintf("has payload %x, expected %x", payload, stored.Payload), nil
+	}
+
+	return "", nil
+}
+
+func (r *reExecutor) getRegister(commit flow.StateCommitment) delta.GetRegisterFunc {
+	return func(owner, controller, key string) (flow.RegisterValue, error) {
+		query, err := ledger.NewQuery(commit, []ledger.Key{
+			state.RegisterIDToKey(flow.NewRegisterID(owner, controller, key)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot create ledger query: %w", err)
+		}
+
+		read, err := pathfinder.QueryToTrieRead(query, complete.DefaultPathFinderVersion)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create trie read: %w", err)
+		}
+
+		payloads, err := r.forest.Read(read)
+		if err != nil {
+			return nil, fmt.Errorf("error getting register (%s) value at %x: %w", key, commit, err)
+		}
+
+		return flow.RegisterValue(payloads[0].Value), nil
+	}
+}
+
+func (r *reExecutor) commitDelta(updates delta.Delta, base flow.StateCommitment) (flow.StateCommitment, error) {
+	ids, values := updates.RegisterUpdates()
+
+	update, err := ledger.NewUpdate(
+		base,
+		state.RegisterIDSToKeys(ids),
+		state.RegisterValuesToValues(values),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create ledger update: %w", err)
+	}
+
+	trieUpdate, err := pathfinder.UpdateToTrieUpdate(update, complete.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trie update: %w", err)
+	}
+
+	rootHash, err := r.forest.Update(trieUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update forest: %w", err)
+	}
+
+	return flow.StateCommitment(rootHash), nil
+}
@@ -0,0 +1,254 @@
+package re_execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/engine/execution/testutil"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReExecuteBlock(t *testing.T) {
+	chain := flow.Testnet.Chain()
+
+	t.Run("same results", func(t *testing.T) {
+		tx := saveTransaction(t, chain, "a")
+
+		withExecutedBlock(t, chain, tx, tx, true, func(r *reExecutor, height uint64) {
+			err := r.reExecuteBlock(height)
+			require.NoError(t, err)
+		})
+	})
+
+	// the stored block has a different transaction than the executed one, so
+	// its re-execution updates a register the original execution didn't touch
+	t.Run("diverging register at expected end state", func(t *testing.T) {
+		original := saveTransaction(t, chain, "a")
+		stored := saveTransaction(t, chain, "b")
+
+		withExecutedBlock(t, chain, original, stored, true, func(r *reExecutor, height uint64) {
+			err := r.reExecuteBlock(height)
+
+			var diverged *divergence
+			require.True(t, errors.As(err, &diverged), err)
+			assert.Equal(t, height, diverged.height)
+			assert.Contains(t, diverged.reason, "chunk 0 ends in state")
+			assert.Contains(t, diverged.reason, storageRegister(chain, "b")+" is ")
+		})
+	})
+
+	t.Run("diverging register from chunk data pack", func(t *testing.T) {
+		original := saveTransaction(t, chain, "a")
+		stored := saveTransaction(t, chain, "b")
+
+		withExecutedBlock(t, chain, original, stored, false, func(r *reExecutor, height uint64) {
+			err := r.reExecuteBlock(height)
+
+			var diverged *divergence
+			require.True(t, errors.As(err, &diverged), err)
+			assert.Equal(t, height, diverged.height)
+			assert.Contains(t, diverged.reason, "chunk 0 ends in state")
+			assert.Contains(t, diverged.reason,
+				storageRegister(chain, "b")+" was not touched by the original execution")
+		})
+	})
+}
+
+// saveTransaction returns a transaction of the service account which saves a
+// value to the given storage path.
+func saveTransaction(t *testing.T, chain flow.Chain, path string) *flow.TransactionBody {
+	tx := flow.NewTransactionBody().
+		SetScript([]byte(fmt.Sprintf(`
+			transaction {
+				prepare(signer: AuthAccount) {
+					signer.save(42, to: /storage/%s)
+				}
+			}`, path))).
+		AddAuthorizer(chain.ServiceAddress())
+
+	err := testutil.SignTransactionAsServiceAccount(tx, 0, chain)
+	require.NoError(t, err)
+
+	return tx
+}
+
+// storageRegister returns the register of a storage path of the service
+// account, as reported in divergences.
+func storageRegister(chain flow.Chain, path string) string {
+	id := flow.NewRegisterID(string(chain.ServiceAddress().Bytes()), "", strings.Join([]string{"storage", path}, "\x1f"))
+	return id.String()
+}
+
+// withExecutedBlock executes a block with the original transaction on a
+// bootstrapped execution state, and stores the block with the stored
+// transaction instead, along with the results and chunk data packs of the
+// original execution. The execution state is checkpointed, including the end
+// state of the original execution only if checkpointEndState is set, and a
+// re-executor is created on the checkpoint without a register index.
+func withExecutedBlock(
+	t *testing.T,
+	chain flow.Chain,
+	original *flow.TransactionBody,
+	stored *flow.TransactionBody,
+	checkpointEndState bool,
+	f func(r *reExecutor, height uint64),
+) {
+	unittest.RunWithTempDir(t, func(datadir string) {
+		unittest.RunWithTempDir(t, func(execdir string) {
+			unittest.RunWithTempDir(t, func(otherdir string) {
+
+				db := common.InitStorage(datadir)
+				defer db.Close()
+				storages := common.InitStorages(db)
+
+				checkpointed, startState := bootstrappedLedger(t, chain, execdir)
+				ldg := checkpointed
+				if !checkpointEndState {
+					ldg, _ = bootstrappedLedger(t, chain, otherdir)
+				}
+
+				// store the block with the stored collection
+
+				parent := unittest.BlockHeaderFixture()
+				collection := &flow.Collection{Transactions: []*flow.TransactionBody{stored}}
+				block := unittest.BlockWithParentFixture(&parent)
+				block.SetPayload(flow.Payload{
+					Guarantees: []*flow.CollectionGuarantee{{CollectionID: collection.ID()}},
+				})
+				blockID := block.ID()
+				height := block.Header.Height
+
+				require.NoError(t, storages.Commits.Store(parent.ID(), startState))
+				require.NoError(t, storages.Blocks.Store(&block))
+				require.NoError(t, db.Update(operation.IndexBlockHeight(height, blockID)))
+				require.NoError(t, storages.Collections.Store(collection))
+
+				// execute it with the original collection instead
+
+				executor, err := newReExecutor(zerolog.Nop(), storages, nil, nil, chain)
+				require.NoError(t, err)
+
+				guarantee := block.Payload.Guarantees[0]
+				executableBlock := &entity.ExecutableBlock{
+					Block: &block,
+					CompleteCollections: map[flow.Identifier]*entity.CompleteCollection{
+						guarantee.ID(): {
+							Guarantee:    guarantee,
+							Transactions: []*flow.TransactionBody{original},
+						},
+					},
+					StartState: startState,
+				}
+
+				view := delta.NewView(state.LedgerGetRegister(ldg, startState))
+				computed, err := executor.computer.ExecuteBlock(context.Background(), executableBlock, view, fvm.NewEmptyPrograms())
+				require.NoError(t, err)
+
+				result := &flow.ExecutionResult{BlockID: blockID}
+				endState := startState
+				for i, snapshot := range computed.StateSnapshots {
+					chunk := &flow.Chunk{
+						ChunkBody: flow.ChunkBody{
+							CollectionIndex: uint(i),
+							StartState:      endState,
+							BlockID:         blockID,
+						},
+						Index: uint64(i),
+					}
+
+					query, err := ledger.NewQuery(endState, state.RegisterIDSToKeys(snapshot.AllRegisters()))
+					require.NoError(t, err)
+					proof, err := ldg.Prove(query)
+					require.NoError(t, err)
+					require.NoError(t, storages.ChunkDataPacks.Store(&flow.ChunkDataPack{
+						ChunkID:    chunk.ID(),
+						StartState: endState,
+						Proof:      proof,
+					}))
+
+					endState, err = state.CommitDelta(ldg, snapshot.Delta, endState)
+					require.NoError(t, err)
+					chunk.EndState = endState
+
+					result.Chunks = append(result.Chunks, chunk)
+				}
+
+				require.NoError(t, storages.Results.Store(result))
+				require.NoError(t, storages.Results.Index(blockID, result.ID()))
+				require.NoError(t, storages.TransactionResults.BatchStore(blockID, computed.TransactionResult))
+				require.NoError(t, storages.Events.Store(blockID, computed.Events))
+
+				<-ldg.Done()
+				if ldg != checkpointed {
+					<-checkpointed.Done()
+				}
+
+				checkpoint(t, execdir)
+
+				forest, err := loadCheckpoint(zerolog.Nop(), execdir, -1, 100)
+				require.NoError(t, err)
+
+				r, err := newReExecutor(zerolog.Nop(), storages, nil, forest, chain)
+				require.NoError(t, err)
+
+				f(r, height)
+			})
+		})
+	})
+}
+
+// bootstrappedLedger creates a ledger in the given directory, and bootstraps
+// the execution state of the chain on it.
+func bootstrappedLedger(t *testing.T, chain flow.Chain, dir string) (*complete.Ledger, flow.StateCommitment) {
+	ldg, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Nop(), nil, complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	commit, err := bootstrap.NewBootstrapper(zerolog.Nop()).BootstrapLedger(
+		ldg,
+		unittest.ServiceAccountPublicKey,
+		unittest.GenesisTokenSupply,
+		chain,
+	)
+	require.NoError(t, err)
+
+	return ldg, commit
+}
+
+// checkpoint checkpoints all the segments of the WAL in the given directory.
+func checkpoint(t *testing.T, dir string) {
+	w, err := wal.NewWAL(zerolog.Nop(), nil, dir, 100, pathfinder.PathByteSize, wal.SegmentSize)
+	require.NoError(t, err)
+	defer w.Close()
+
+	checkpointer, err := w.NewCheckpointer()
+	require.NoError(t, err)
+
+	_, to, err := checkpointer.NotCheckpointedSegments()
+	require.NoError(t, err)
+
+	err = checkpointer.Checkpoint(to, func() (io.WriteCloser, error) {
+		return checkpointer.CheckpointWriter(to)
+	})
+	require.NoError(t, err)
+}
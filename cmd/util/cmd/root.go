@@ -12,6 +12,8 @@ import (
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
+	execution_trace "github.com/onflow/flow-go/cmd/util/cmd/execution-trace"
+	re_execute "github.com/onflow/flow-go/cmd/util/cmd/re-execute"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
 )
 
@@ -50,6 +52,8 @@ func addCommands() {
 	rootCmd.AddCommand(export.Cmd)
 	rootCmd.AddCommand(checkpoint_list_tries.Cmd)
 	rootCmd.AddCommand(truncate_database.Cmd)
+	rootCmd.AddCommand(execution_trace.Cmd)
+	rootCmd.AddCommand(re_execute.Cmd)
 }
 
 func initConfig() {
@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// An AccountMigration migrates the registers of a single account. It must only
+// read and return registers owned by the account, so that accounts can be
+// migrated in parallel.
+type AccountMigration func(address flow.Address, payloads []ledger.Payload) ([]ledger.Payload, error)
+
+// An AccountValidator checks the registers of an account after a migration,
+// given its registers before the migration.
+type AccountValidator func(address flow.Address, before []ledger.Payload, after []ledger.Payload) error
+
+// A NamedMigration is a migration which can be registered, and planned with
+// the migrations it depends on.
+//
+// A migration either migrates each account separately, or all the payloads at
+// once, for migrations which need to look at several accounts.
+type NamedMigration struct {
+	Name string
+	// Dependencies are the names of the migrations to run before this one.
+	Dependencies []string
+	// MigrateAccount migrates the registers of each account in parallel.
+	// Registers which are not owned by an account are left untouched.
+	MigrateAccount AccountMigration
+	// Migrate migrates all the payloads, if MigrateAccount is not set.
+	Migrate ledger.Migration
+	// Validators check each account after the migration.
+	Validators []AccountValidator
+}
+
+// A Registry holds the migrations which can be run by name.
+type Registry struct {
+	migrations map[string]*NamedMigration
+}
+
+// Registered holds the migrations available to execution-state-extract.
+var Registered = NewRegistry()
+
+func init() {
+	Registered.MustRegister(&NamedMigration{
+		Name:    "noop",
+		Migrate: NoOpMigration,
+	})
+	Registered.MustRegister(&NamedMigration{
+		Name:    "multiple-contracts",
+		Migrate: MultipleContractMigration,
+	})
+	Registered.MustRegister(&NamedMigration{
+		Name:       "storage-fees",
+		Migrate:    StorageFeesMigration,
+		Validators: []AccountValidator{ValidateStorageUsed},
+	})
+	Registered.MustRegister(&NamedMigration{
+		Name:       "add-missing-keys",
+		Migrate:    AddMissingKeysMigration,
+		Validators: []AccountValidator{ValidateKeyCount},
+	})
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		migrations: make(map[string]*NamedMigration),
+	}
+}
+
+// Register adds a migration to the registry.
+func (r *Registry) Register(migration *NamedMigration) error {
+	if migration.Name == "" {
+		return fmt.Errorf("migration has no name")
+	}
+	if _, ok := r.migrations[migration.Name]; ok {
+		return fmt.Errorf("migration %s is already registered", migration.Name)
+	}
+	if (migration.MigrateAccount == nil) == (migration.Migrate == nil) {
+		return fmt.Errorf("migration %s must either migrate accounts or all payloads", migration.Name)
+	}
+
+	r.migrations[migration.Name] = migration
+	return nil
+}
+
+// MustRegister adds a migration to the registry, and panics if it can't.
+func (r *Registry) MustRegister(migration *NamedMigration) {
+	err := r.Register(migration)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Names returns the names of the registered migrations, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.migrations))
+	for name := range r.migrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Plan returns the migrations with the given names and their dependencies,
+// in an order in which each migration runs after its dependencies, and
+// otherwise in the given order.
+func (r *Registry) Plan(names ...string) ([]*NamedMigration, error) {
+	var plan []*NamedMigration
+	planned := make(map[string]bool)
+	visiting := make(map[string]bool)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if planned[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("migrations have a dependency cycle: %v", append(path, name))
+		}
+
+		migration, ok := r.migrations[name]
+		if !ok {
+			if len(path) > 0 {
+				return fmt.Errorf("migration %s depends on unknown migration %s", path[len(path)-1], name)
+			}
+			return fmt.Errorf("unknown migration %s", name)
+		}
+
+		visiting[name] = true
+		for _, dependency := range migration.Dependencies {
+			err := visit(dependency, append(path, name))
+			if err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+
+		planned[name] = true
+		plan = append(plan, migration)
+		return nil
+	}
+
+	for _, name := range names {
+		err := visit(name, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
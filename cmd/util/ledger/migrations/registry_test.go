@@ -0,0 +1,91 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+)
+
+func TestRegistry(t *testing.T) {
+
+	registry := func(deps map[string][]string) *migrations.Registry {
+		r := migrations.NewRegistry()
+		for name, dependencies := range deps {
+			r.MustRegister(&migrations.NamedMigration{
+				Name:         name,
+				Dependencies: dependencies,
+				Migrate:      migrations.NoOpMigration,
+			})
+		}
+		return r
+	}
+
+	names := func(plan []*migrations.NamedMigration) []string {
+		var names []string
+		for _, migration := range plan {
+			names = append(names, migration.Name)
+		}
+		return names
+	}
+
+	t.Run("plans dependencies first", func(t *testing.T) {
+		r := registry(map[string][]string{
+			"a": nil,
+			"b": {"a"},
+			"c": {"b", "a"},
+			"d": nil,
+		})
+
+		plan, err := r.Plan("d", "c")
+		require.NoError(t, err)
+		require.Equal(t, []string{"d", "a", "b", "c"}, names(plan))
+
+		plan, err = r.Plan("b", "a")
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, names(plan))
+	})
+
+	t.Run("unknown migrations can't be planned", func(t *testing.T) {
+		r := registry(map[string][]string{
+			"a": {"missing"},
+		})
+
+		_, err := r.Plan("b")
+		require.Error(t, err)
+
+		_, err = r.Plan("a")
+		require.Error(t, err)
+	})
+
+	t.Run("dependency cycles can't be planned", func(t *testing.T) {
+		r := registry(map[string][]string{
+			"a": {"c"},
+			"b": {"a"},
+			"c": {"b"},
+		})
+
+		_, err := r.Plan("a")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid migrations can't be registered", func(t *testing.T) {
+		r := registry(map[string][]string{
+			"a": nil,
+		})
+
+		err := r.Register(&migrations.NamedMigration{Name: "a", Migrate: migrations.NoOpMigration})
+		require.Error(t, err)
+
+		err = r.Register(&migrations.NamedMigration{Name: "b"})
+		require.Error(t, err)
+
+		require.Equal(t, []string{"a"}, r.Names())
+	})
+
+	t.Run("existing migrations are registered", func(t *testing.T) {
+		_, err := migrations.Registered.Plan("multiple-contracts", "storage-fees")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,505 @@
+package migrations
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrDryRun is returned by a runner in dry-run mode once the migrations have
+// run and have been reported, so that the migrated payloads are not used.
+var ErrDryRun = errors.New("migrations ran in dry-run mode")
+
+const (
+	progressFilename = "progress.json"
+	payloadsFilename = "payloads"
+	reportFilename   = "migration_report.csv"
+)
+
+// A Runner runs planned migrations on the payloads of a ledger, migrating
+// accounts in parallel and validating them after each migration.
+type Runner struct {
+	log         zerolog.Logger
+	migrations  []*NamedMigration
+	workers     int
+	dryRun      bool
+	reportDir   string
+	progressDir string
+	input       ledger.State
+}
+
+// A RunnerOption configures a runner.
+type RunnerOption func(*Runner)
+
+// WithWorkers sets the number of accounts migrated and validated in parallel.
+func WithWorkers(workers int) RunnerOption {
+	return func(r *Runner) {
+		r.workers = workers
+	}
+}
+
+// WithReport writes a report of the registers added, removed and updated in
+// each account by each migration to the given directory.
+func WithReport(dir string) RunnerOption {
+	return func(r *Runner) {
+		r.reportDir = dir
+	}
+}
+
+// WithDryRun makes the runner return ErrDryRun once the migrations have run
+// and have been reported, instead of the migrated payloads.
+func WithDryRun(dryRun bool) RunnerOption {
+	return func(r *Runner) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithProgress saves the payloads after each migration to the given
+// directory, so that a run interrupted on the same input state resumes
+// after the last completed migration.
+func WithProgress(dir string, input ledger.State) RunnerOption {
+	return func(r *Runner) {
+		r.progressDir = dir
+		r.input = input
+	}
+}
+
+// NewRunner returns a runner of the given migrations, in order.
+func NewRunner(log zerolog.Logger, migrations []*NamedMigration, opts ...RunnerOption) *Runner {
+	r := &Runner{
+		log:        log,
+		migrations: migrations,
+		workers:    1,
+	}
+	for _, apply := range opts {
+		apply(r)
+	}
+	if r.workers < 1 {
+		r.workers = 1
+	}
+	return r
+}
+
+// progress records the migrations completed on an input state.
+type progress struct {
+	Input     string   `json:"input"`
+	Completed []string `json:"completed"`
+}
+
+// Migrate runs the migrations on the payloads. It can be used as a
+// ledger.Migration.
+func (r *Runner) Migrate(payloads []ledger.Payload) ([]ledger.Payload, error) {
+
+	completed, payloads, err := r.resume(payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	var report *bufio.Writer
+	if r.reportDir != "" {
+		file, err := os.Create(filepath.Join(r.reportDir, reportFilename))
+		if err != nil {
+			return nil, fmt.Errorf("could not create report: %w", err)
+		}
+		defer file.Close()
+
+		report = bufio.NewWriter(file)
+		_, err = report.WriteString("migration,owner,added,removed,updated,size_before,size_after\n")
+		if err != nil {
+			return nil, fmt.Errorf("could not write report: %w", err)
+		}
+	}
+
+	for i, migration := range r.migrations[completed:] {
+		log := r.log.With().Str("migration", migration.Name).Logger()
+		log.Info().Int("payloads", len(payloads)).Msg("running migration")
+
+		before, err := groupByOwner(payloads)
+		if err != nil {
+			return nil, fmt.Errorf("could not group payloads of migration %s: %w", migration.Name, err)
+		}
+
+		var migrated []ledger.Payload
+		if migration.MigrateAccount != nil {
+			migrated, err = r.migrateAccounts(migration, before)
+		} else {
+			migrated, err = migration.Migrate(payloads)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not run migration %s: %w", migration.Name, err)
+		}
+
+		after, err := groupByOwner(migrated)
+		if err != nil {
+			return nil, fmt.Errorf("could not group payloads migrated by %s: %w", migration.Name, err)
+		}
+
+		err = r.validate(migration, before, after)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s is invalid: %w", migration.Name, err)
+		}
+
+		if report != nil {
+			err = writeDiff(report, migration.Name, before, after)
+			if err != nil {
+				return nil, fmt.Errorf("could not write report: %w", err)
+			}
+		}
+
+		payloads = migrated
+
+		err = r.saveProgress(r.migrations[:completed+i+1], payloads)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info().Int("payloads", len(payloads)).Msg("migration done")
+	}
+
+	if report != nil {
+		err = report.Flush()
+		if err != nil {
+			return nil, fmt.Errorf("could not write report: %w", err)
+		}
+	}
+
+	if r.dryRun {
+		return nil, ErrDryRun
+	}
+
+	return payloads, nil
+}
+
+// migrateAccounts runs an account migration on each account in parallel.
+// Registers which are not owned by an account are left untouched.
+func (r *Runner) migrateAccounts(migration *NamedMigration, owners map[string][]ledger.Payload) ([]ledger.Payload, error) {
+	results := make(map[string][]ledger.Payload, len(owners))
+	var mu sync.Mutex
+
+	err := r.forEachOwner(owners, func(owner string, payloads []ledger.Payload) error {
+		if len(owner) != flow.AddressLength {
+			mu.Lock()
+			results[owner] = payloads
+			mu.Unlock()
+			return nil
+		}
+
+		address := flow.BytesToAddress([]byte(owner))
+		migrated, err := migration.MigrateAccount(address, payloads)
+		if err != nil {
+			return fmt.Errorf("could not migrate account %s: %w", address, err)
+		}
+
+		for _, p := range migrated {
+			id, err := keyToRegisterID(p.Key)
+			if err != nil {
+				return err
+			}
+			if id.Owner != owner {
+				return fmt.Errorf("migration of account %s returned register of owner %x", address, id.Owner)
+			}
+		}
+
+		mu.Lock()
+		results[owner] = migrated
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated []ledger.Payload
+	for _, owner := range sortedOwners(results) {
+		migrated = append(migrated, results[owner]...)
+	}
+	return migrated, nil
+}
+
+// validate runs the validators of a migration on each account.
+func (r *Runner) validate(migration *NamedMigration, before map[string][]ledger.Payload, after map[string][]ledger.Payload) error {
+	if len(migration.Validators) == 0 {
+		return nil
+	}
+
+	return r.forEachOwner(after, func(owner string, payloads []ledger.Payload) error {
+		if len(owner) != flow.AddressLength {
+			return nil
+		}
+
+		address := flow.BytesToAddress([]byte(owner))
+		for _, validate := range migration.Validators {
+			err := validate(address, before[owner], payloads)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// forEachOwner calls f on the payloads of each owner on the workers of the
+// runner, and returns the first error.
+func (r *Runner) forEachOwner(owners map[string][]ledger.Payload, f func(owner string, payloads []ledger.Payload) error) error {
+	jobs := make(chan string)
+	errs := make(chan error, r.workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for owner := range jobs {
+				err := f(owner, owners[owner])
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	var err error
+	for _, owner := range sortedOwners(owners) {
+		select {
+		case err = <-errs:
+		case jobs <- owner:
+		}
+		if err != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	if err != nil {
+		return err
+	}
+	return <-errs
+}
+
+// resume returns the number of migrations already completed on the input
+// state, and the payloads after them.
+func (r *Runner) resume(payloads []ledger.Payload) (int, []ledger.Payload, error) {
+	if r.progressDir == "" {
+		return 0, payloads, nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(r.progressDir, progressFilename))
+	if os.IsNotExist(err) {
+		return 0, payloads, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not read progress: %w", err)
+	}
+
+	var saved progress
+	err = json.Unmarshal(data, &saved)
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not decode progress: %w", err)
+	}
+
+	if saved.Input != hex.EncodeToString(r.input) {
+		r.log.Info().Str("input", saved.Input).Msg("ignoring progress of migrations on another state")
+		return 0, payloads, nil
+	}
+	if len(saved.Completed) > len(r.migrations) {
+		r.log.Info().Strs("completed", saved.Completed).Msg("ignoring progress of other migrations")
+		return 0, payloads, nil
+	}
+	for i, name := range saved.Completed {
+		if r.migrations[i].Name != name {
+			r.log.Info().Strs("completed", saved.Completed).Msg("ignoring progress of other migrations")
+			return 0, payloads, nil
+		}
+	}
+
+	file, err := os.Open(filepath.Join(r.progressDir, payloadsFilename))
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not open migrated payloads: %w", err)
+	}
+	defer file.Close()
+
+	payloads, err = readPayloads(bufio.NewReader(file))
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not read migrated payloads: %w", err)
+	}
+
+	r.log.Info().Strs("completed", saved.Completed).Msg("resuming migrations")
+
+	return len(saved.Completed), payloads, nil
+}
+
+// saveProgress saves the payloads after the completed migrations.
+func (r *Runner) saveProgress(completed []*NamedMigration, payloads []ledger.Payload) error {
+	if r.progressDir == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	err := writePayloads(&buf, payloads)
+	if err != nil {
+		return fmt.Errorf("could not encode migrated payloads: %w", err)
+	}
+	err = writeFileAtomically(filepath.Join(r.progressDir, payloadsFilename), buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not save migrated payloads: %w", err)
+	}
+
+	saved := progress{
+		Input: hex.EncodeToString(r.input),
+	}
+	for _, migration := range completed {
+		saved.Completed = append(saved.Completed, migration.Name)
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("could not encode progress: %w", err)
+	}
+	err = writeFileAtomically(filepath.Join(r.progressDir, progressFilename), data)
+	if err != nil {
+		return fmt.Errorf("could not save progress: %w", err)
+	}
+
+	return nil
+}
+
+func writeFileAtomically(path string, data []byte) error {
+	tmp := path + ".tmp"
+	err := ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// writePayloads writes the payloads prefixed by their length.
+func writePayloads(w io.Writer, payloads []ledger.Payload) error {
+	var length [4]byte
+	for i := range payloads {
+		encoded := encoding.EncodePayload(&payloads[i])
+		binary.BigEndian.PutUint32(length[:], uint32(len(encoded)))
+		_, err := w.Write(length[:])
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(encoded)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPayloads(r io.Reader) ([]ledger.Payload, error) {
+	var payloads []ledger.Payload
+	var length [4]byte
+	for {
+		_, err := io.ReadFull(r, length[:])
+		if err == io.EOF {
+			return payloads, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := make([]byte, binary.BigEndian.Uint32(length[:]))
+		_, err = io.ReadFull(r, encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := encoding.DecodePayload(encoded)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, *payload)
+	}
+}
+
+// writeDiff reports the registers added, removed and updated in each owner
+// with changes.
+func writeDiff(w io.Writer, name string, before map[string][]ledger.Payload, after map[string][]ledger.Payload) error {
+	owners := make(map[string][]ledger.Payload, len(before))
+	for owner := range before {
+		owners[owner] = nil
+	}
+	for owner := range after {
+		owners[owner] = nil
+	}
+
+	for _, owner := range sortedOwners(owners) {
+		values := make(map[string][]byte, len(before[owner]))
+		var sizeBefore, sizeAfter int
+		for _, p := range before[owner] {
+			values[p.Key.String()] = p.Value
+			sizeBefore += p.Size()
+		}
+
+		var added, removed, updated int
+		for _, p := range after[owner] {
+			sizeAfter += p.Size()
+			key := p.Key.String()
+			value, ok := values[key]
+			if !ok {
+				added++
+				continue
+			}
+			if !bytes.Equal(value, p.Value) {
+				updated++
+			}
+			delete(values, key)
+		}
+		removed = len(values)
+
+		if added == 0 && removed == 0 && updated == 0 {
+			continue
+		}
+
+		_, err := fmt.Fprintf(w, "%s,%x,%d,%d,%d,%d,%d\n", name, owner, added, removed, updated, sizeBefore, sizeAfter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// groupByOwner groups payloads by the owner of their register.
+func groupByOwner(payloads []ledger.Payload) (map[string][]ledger.Payload, error) {
+	owners := make(map[string][]ledger.Payload)
+	for _, p := range payloads {
+		id, err := keyToRegisterID(p.Key)
+		if err != nil {
+			return nil, err
+		}
+		owners[id.Owner] = append(owners[id.Owner], p)
+	}
+	return owners, nil
+}
+
+func sortedOwners(owners map[string][]ledger.Payload) []string {
+	sorted := make([]string, 0, len(owners))
+	for owner := range owners {
+		sorted = append(sorted, owner)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,230 @@
+package migrations_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func registerPayload(owner []byte, controller []byte, key string, value []byte) ledger.Payload {
+	return ledger.Payload{
+		Key: ledger.Key{
+			KeyParts: []ledger.KeyPart{
+				ledger.NewKeyPart(state.KeyPartOwner, owner),
+				ledger.NewKeyPart(state.KeyPartController, controller),
+				ledger.NewKeyPart(state.KeyPartKey, []byte(key)),
+			},
+		},
+		Value: value,
+	}
+}
+
+// appendMigration appends a suffix to the value of each register of each
+// account.
+func appendMigration(name string, suffix string, calls *int32) *migrations.NamedMigration {
+	return &migrations.NamedMigration{
+		Name: name,
+		MigrateAccount: func(address flow.Address, payloads []ledger.Payload) ([]ledger.Payload, error) {
+			atomic.AddInt32(calls, 1)
+			migrated := make([]ledger.Payload, len(payloads))
+			for i, p := range payloads {
+				p.Value = append(append([]byte{}, p.Value...), suffix...)
+				migrated[i] = p
+			}
+			return migrated, nil
+		},
+	}
+}
+
+func TestRunner(t *testing.T) {
+
+	var payloads []ledger.Payload
+	for i := 1; i <= 10; i++ {
+		address := flow.HexToAddress(fmt.Sprintf("%02x", i)).Bytes()
+		payloads = append(payloads, registerPayload(address, nil, "a", []byte("1")))
+		payloads = append(payloads, registerPayload(address, nil, "b", []byte("2")))
+	}
+	global := registerPayload([]byte("global"), nil, "uuid", []byte("3"))
+	payloads = append(payloads, global)
+
+	t.Run("migrates accounts in parallel", func(t *testing.T) {
+		var calls int32
+		runner := migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{
+			appendMigration("x", "x", &calls),
+			appendMigration("y", "y", &calls),
+		}, migrations.WithWorkers(4))
+
+		migrated, err := runner.Migrate(payloads)
+		require.NoError(t, err)
+		require.Len(t, migrated, len(payloads))
+		require.Equal(t, int32(20), calls)
+
+		for _, p := range migrated {
+			if p.Key.KeyParts[0].Value[0] == 'g' {
+				// registers not owned by accounts are not migrated
+				require.Equal(t, global, p)
+				continue
+			}
+			require.Contains(t, []string{"1xy", "2xy"}, string(p.Value))
+		}
+	})
+
+	t.Run("invalid accounts fail the migration", func(t *testing.T) {
+		var calls int32
+		migration := appendMigration("x", "x", &calls)
+		migration.Validators = []migrations.AccountValidator{
+			func(address flow.Address, before []ledger.Payload, after []ledger.Payload) error {
+				if address == flow.HexToAddress("07") {
+					return fmt.Errorf("invalid")
+				}
+				return nil
+			},
+		}
+
+		runner := migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{migration}, migrations.WithWorkers(3))
+		_, err := runner.Migrate(payloads)
+		require.Error(t, err)
+	})
+
+	t.Run("accounts can only migrate their own registers", func(t *testing.T) {
+		runner := migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{{
+			Name: "steal",
+			MigrateAccount: func(address flow.Address, payloads []ledger.Payload) ([]ledger.Payload, error) {
+				return []ledger.Payload{global}, nil
+			},
+		}})
+		_, err := runner.Migrate(payloads)
+		require.Error(t, err)
+	})
+
+	t.Run("dry run reports changes", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var calls int32
+			runner := migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{
+				appendMigration("x", "x", &calls),
+				{
+					Name: "remove-global",
+					Migrate: func(payloads []ledger.Payload) ([]ledger.Payload, error) {
+						return payloads[:len(payloads)-1], nil
+					},
+				},
+			}, migrations.WithDryRun(true), migrations.WithReport(dir))
+
+			_, err := runner.Migrate(payloads)
+			require.True(t, errors.Is(err, migrations.ErrDryRun))
+
+			report, err := ioutil.ReadFile(filepath.Join(dir, "migration_report.csv"))
+			require.NoError(t, err)
+
+			expected := "migration,owner,added,removed,updated,size_before,size_after\n"
+			for i := 1; i <= 10; i++ {
+				address := flow.HexToAddress(fmt.Sprintf("%02x", i))
+				size := payloads[0].Size() * 2
+				expected += fmt.Sprintf("x,%s,0,0,2,%d,%d\n", address.Hex(), size, size+2)
+			}
+			expected += fmt.Sprintf("remove-global,%x,0,1,0,%d,0\n", "global", global.Size())
+			require.Equal(t, expected, string(report))
+		})
+	})
+
+	t.Run("interrupted migrations resume", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			input := ledger.State(unittest.StateCommitmentFixture())
+
+			var calls int32
+			failing := &migrations.NamedMigration{
+				Name: "y",
+				Migrate: func([]ledger.Payload) ([]ledger.Payload, error) {
+					return nil, fmt.Errorf("interrupted")
+				},
+			}
+
+			runner := migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{
+				appendMigration("x", "x", &calls),
+				failing,
+			}, migrations.WithProgress(dir, input))
+			_, err := runner.Migrate(payloads)
+			require.Error(t, err)
+			require.Equal(t, int32(10), calls)
+
+			// the first migration is not run again
+			runner = migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{
+				appendMigration("x", "x", &calls),
+				appendMigration("y", "y", &calls),
+			}, migrations.WithProgress(dir, input))
+			migrated, err := runner.Migrate(payloads)
+			require.NoError(t, err)
+			require.Equal(t, int32(20), calls)
+			require.Len(t, migrated, len(payloads))
+			require.Equal(t, "1xy", string(migrated[0].Value))
+
+			// progress on another state is ignored
+			runner = migrations.NewRunner(zerolog.Nop(), []*migrations.NamedMigration{
+				appendMigration("x", "x", &calls),
+			}, migrations.WithProgress(dir, ledger.State(unittest.StateCommitmentFixture())))
+			migrated, err = runner.Migrate(payloads)
+			require.NoError(t, err)
+			require.Equal(t, int32(30), calls)
+			require.Equal(t, "1x", string(migrated[0].Value))
+		})
+	})
+}
+
+func TestValidators(t *testing.T) {
+	address := flow.HexToAddress("01")
+	owner := address.Bytes()
+
+	t.Run("storage used", func(t *testing.T) {
+		registers := []ledger.Payload{
+			registerPayload(owner, nil, "a", []byte("1234")),
+		}
+
+		valid, err := migrations.StorageFeesMigration(registers)
+		require.NoError(t, err)
+		require.NoError(t, migrations.ValidateStorageUsed(address, registers, valid))
+
+		invalid := append(valid, registerPayload(owner, nil, "b", []byte("5678")))
+		require.Error(t, migrations.ValidateStorageUsed(address, registers, invalid))
+
+		// accounts without storage used are not checked
+		require.NoError(t, migrations.ValidateStorageUsed(address, nil, registers))
+	})
+
+	t.Run("key count", func(t *testing.T) {
+		before := []ledger.Payload{
+			registerPayload(owner, owner, "public_key_count", []byte{1}),
+			registerPayload(owner, owner, "public_key_0", []byte("key")),
+		}
+		require.NoError(t, migrations.ValidateKeyCount(address, nil, before))
+
+		added := append([]ledger.Payload{
+			registerPayload(owner, owner, "public_key_count", []byte{2}),
+			registerPayload(owner, owner, "public_key_1", []byte("key")),
+		}, before[1])
+		require.NoError(t, migrations.ValidateKeyCount(address, before, added))
+
+		missing := []ledger.Payload{
+			registerPayload(owner, owner, "public_key_count", []byte{2}),
+			registerPayload(owner, owner, "public_key_0", []byte("key")),
+		}
+		require.Error(t, migrations.ValidateKeyCount(address, before, missing))
+
+		removed := []ledger.Payload{
+			registerPayload(owner, owner, "public_key_count", utils.Uint64ToBinary(0)),
+		}
+		require.Error(t, migrations.ValidateKeyCount(address, before, removed))
+	})
+}
@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const publicKeyPrefix = "public_key_"
+
+// ValidateStorageUsed checks that the storage used register of an account
+// matches the size of its registers, if the account has one.
+func ValidateStorageUsed(address flow.Address, _ []ledger.Payload, after []ledger.Payload) error {
+	var storageUsed []byte
+	var size uint64
+	for _, p := range after {
+		id, err := keyToRegisterID(p.Key)
+		if err != nil {
+			return err
+		}
+		if id.Controller == "" && id.Key == state.KeyStorageUsed {
+			storageUsed = p.Value
+		}
+		size += uint64(registerSize(id, p))
+	}
+
+	if storageUsed == nil {
+		return nil
+	}
+
+	used, _, err := utils.ReadUint64(storageUsed)
+	if err != nil {
+		return fmt.Errorf("invalid storage used of account %s: %w", address, err)
+	}
+	if used != size {
+		return fmt.Errorf("storage used of account %s is %d, but its registers use %d", address, used, size)
+	}
+
+	return nil
+}
+
+// ValidateKeyCount checks that the public key count register of an account
+// matches its public key registers, and that no key was removed.
+func ValidateKeyCount(address flow.Address, before []ledger.Payload, after []ledger.Payload) error {
+	countBefore, _, err := publicKeyCounts(before)
+	if err != nil {
+		return fmt.Errorf("invalid public keys of account %s before migration: %w", address, err)
+	}
+	count, keys, err := publicKeyCounts(after)
+	if err != nil {
+		return fmt.Errorf("invalid public keys of account %s: %w", address, err)
+	}
+
+	if count != keys {
+		return fmt.Errorf("public key count of account %s is %d, but it has %d keys", address, count, keys)
+	}
+	if count < countBefore {
+		return fmt.Errorf("public key count of account %s decreased from %d to %d", address, countBefore, count)
+	}
+
+	return nil
+}
+
+// publicKeyCounts returns the value of the public key count register of an
+// account, and the number of public keys registers.
+func publicKeyCounts(payloads []ledger.Payload) (uint64, uint64, error) {
+	var count, keys uint64
+	for _, p := range payloads {
+		id, err := keyToRegisterID(p.Key)
+		if err != nil {
+			return 0, 0, err
+		}
+		if id.Controller == "" || len(p.Value) == 0 {
+			continue
+		}
+
+		if id.Key == state.KeyPublicKeyCount {
+			countInt := new(big.Int).SetBytes(p.Value)
+			if !countInt.IsUint64() {
+				return 0, 0, fmt.Errorf("public key count %x is not a valid uint64", p.Value)
+			}
+			count = countInt.Uint64()
+		} else if strings.HasPrefix(id.Key, publicKeyPrefix) {
+			keys++
+		}
+	}
+	return count, keys, nil
+}
@@ -19,23 +19,25 @@ import (
 // be used to reconstruct a threshold signature.
 type CombinedSigner struct {
 	*CombinedVerifier
-	staking  module.AggregatingSigner
-	beacon   module.ThresholdSigner
-	merger   module.Merger
-	signerID flow.Identifier
+	staking     module.AggregatingSigner
+	beaconStore module.ThresholdSignerStore
+	merger      module.Merger
+	signerID    flow.Identifier
 }
 
 // NewCombinedSigner creates a new combined signer with the given dependencies:
 // - the hotstuff committee's state is used to retrieve public keys for signers;
 // - the signer ID is used as the identity when creating signatures;
 // - the staking signer is used to create aggregatable signatures for the first signature part;
-// - the threshold signer is used to create threshold signture shres for the second signature part;
+// - the threshold verifier is used to verify threshold signature shares for the second signature part;
 // - the merger is used to join and split the two signature parts on our models;
-func NewCombinedSigner(committee hotstuff.Committee, staking module.AggregatingSigner, beacon module.ThresholdSigner, merger module.Merger, signerID flow.Identifier) *CombinedSigner {
+// - the threshold signer store provides the signer creating threshold signature shares
+//   with the random beacon key of the epoch of each view.
+func NewCombinedSigner(committee hotstuff.Committee, staking module.AggregatingSigner, beaconVerifier module.ThresholdVerifier, merger module.Merger, beaconStore module.ThresholdSignerStore, signerID flow.Identifier) *CombinedSigner {
 	sc := &CombinedSigner{
-		CombinedVerifier: NewCombinedVerifier(committee, staking, beacon, merger),
+		CombinedVerifier: NewCombinedVerifier(committee, staking, beaconVerifier, merger),
 		staking:          staking,
-		beacon:           beacon,
+		beaconStore:      beaconStore,
 		merger:           merger,
 		signerID:         signerID,
 	}
@@ -153,7 +155,11 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 	}
 
 	// construct the threshold signature from the shares
-	beaconThresSig, err := c.beacon.Combine(dkg.Size(), beaconShares, dkgIndices)
+	beacon, err := c.beaconStore.GetThresholdSigner(votes[0].View)
+	if err != nil {
+		return nil, fmt.Errorf("could not get threshold signer for view %d: %w", votes[0].View, err)
+	}
+	beaconThresSig, err := beacon.Combine(dkg.Size(), beaconShares, dkgIndices)
 	if err != nil {
 		return nil, fmt.Errorf("could not aggregate second signatures: %w", err)
 	}
@@ -183,12 +189,16 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 
 	// create the message to be signed and generate signatures
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 	stakingSig, err := c.staking.Sign(msg)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate first signature: %w", err)
 	}
-	beaconShare, err := c.beacon.Sign(msg)
+	beacon, err := c.beaconStore.GetThresholdSigner(block.View)
+	if err != nil {
+		return nil, fmt.Errorf("could not get threshold signer for view %d: %w", block.View, err)
+	}
+	beaconShare, err := beacon.Sign(msg)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate second signature: %w", err)
 	}
@@ -43,7 +43,7 @@ func NewCombinedVerifier(committee hotstuff.Committee, staking module.Aggregatin
 func (c *CombinedVerifier) VerifyVote(voterID flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
 
 	// create the to-be-signed message
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 
 	// get the set of signing participants
 	participants, err := c.committee.Identities(block.BlockID, filter.Any)
@@ -130,7 +130,7 @@ func (c *CombinedVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte,
 	beaconThresSig := splitSigs[1]
 
 	// verify the aggregated staking signature first
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 	stakingValid, err := c.staking.VerifyMany(msg, stakingAggSig, signers.StakingKeys())
 	if err != nil {
 		return false, fmt.Errorf("could not verify staking signature: %w", err)
@@ -51,7 +51,7 @@ func MakeBeaconSigner(t *testing.T, committee hotstuff.Committee, signerID flow.
 	staking := signature.NewAggregationProvider("test_staking", local)
 	beacon := signature.NewThresholdProvider("test_beacon", beaconPriv)
 	combiner := signature.NewCombiner()
-	signer := NewCombinedSigner(committee, staking, beacon, combiner, signerID)
+	signer := NewCombinedSigner(committee, staking, beacon, combiner, signature.NewSingleSignerStore(beacon), signerID)
 	return signer
 }
 
@@ -51,7 +51,7 @@ func (s *SingleSigner) CreateProposal(block *model.Block) (*model.Proposal, erro
 	}
 
 	// create the message to be signed and generate signature
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 	sig, err := s.signer.Sign(msg)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate staking signature: %w", err)
@@ -70,7 +70,7 @@ func (s *SingleSigner) CreateProposal(block *model.Block) (*model.Proposal, erro
 func (s *SingleSigner) CreateVote(block *model.Block) (*model.Vote, error) {
 
 	// create the message to be signed and generate signature
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 	sig, err := s.signer.Sign(msg)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate staking signature: %w", err)
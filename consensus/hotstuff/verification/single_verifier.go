@@ -45,7 +45,7 @@ func (s *SingleVerifier) VerifyVote(voterID flow.Identifier, sigData []byte, blo
 	}
 
 	// create the message we verify against and check signature
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 	valid, err := s.verifier.Verify(msg, sigData, voter.StakingPubKey)
 	if err != nil {
 		return false, fmt.Errorf("could not verify signature: %w", err)
@@ -68,7 +68,7 @@ func (s *SingleVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte, bl
 	signers = signers.Order(order.ByReferenceOrder(voterIDs)) // re-arrange Identities into the same order as in voterIDs
 
 	// create the message we verify against and check signature
-	msg := makeVoteMessage(block.View, block.BlockID)
+	msg := model.MakeVoteMessage(block.View, block.BlockID)
 	valid, err := s.verifier.VerifyMany(msg, sigData, signers.StakingKeys())
 	if err != nil {
 		return false, fmt.Errorf("could not verify signature: %w", err)
@@ -188,6 +188,9 @@ func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Ident
 	return c.net.multicast(event, c.channel, num, targetIDs...)
 }
 
+func (c *Conduit) ReportMisbehavior(originID flow.Identifier, severity network.Severity, reason string) {
+}
+
 func (c *Conduit) Close() error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit closed")
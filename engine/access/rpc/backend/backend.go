@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -15,7 +16,9 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
 	"github.com/onflow/flow-go/storage"
 )
 
@@ -32,6 +35,7 @@ const maxExecutionNodesCnt = 3
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Dry-run calls are handled by backendDryRun.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -41,6 +45,7 @@ type Backend struct {
 	backendBlockHeaders
 	backendBlockDetails
 	backendAccounts
+	backendDryRun
 
 	executionRPC      execproto.ExecutionAPIClient
 	state             protocol.State
@@ -48,6 +53,7 @@ type Backend struct {
 	collections       storage.Collections
 	executionReceipts storage.ExecutionReceipts
 	connFactory       ConnectionFactory
+	notifier          *blockNotifier
 }
 
 func New(
@@ -64,6 +70,7 @@ func New(
 	transactionMetrics module.TransactionMetrics,
 	connFactory ConnectionFactory,
 	retryEnabled bool,
+	queryStrategy *ExecutionQueryStrategy,
 	log zerolog.Logger,
 ) *Backend {
 	retry := newRetry()
@@ -71,6 +78,13 @@ func New(
 		retry.Activate()
 	}
 
+	// by default, query one execution node after the other
+	if queryStrategy == nil {
+		queryStrategy = NewExecutionQueryStrategy(ExecutionQueryFirst, false, defaultClientTimeout, metrics.NewNoopCollector(), log)
+	}
+
+	notifier := newBlockNotifier()
+
 	b := &Backend{
 		executionRPC: executionRPC,
 		state:        state,
@@ -81,6 +95,7 @@ func New(
 			staticExecutionRPC: executionRPC,
 			connFactory:        connFactory,
 			state:              state,
+			queryStrategy:      queryStrategy,
 			log:                log,
 		},
 		backendTransactions: backendTransactions{
@@ -96,7 +111,9 @@ func New(
 			transactionMetrics:   transactionMetrics,
 			retry:                retry,
 			connFactory:          connFactory,
+			notifier:             notifier,
 			previousAccessNodes:  historicalAccessNodes,
+			queryStrategy:        queryStrategy,
 			log:                  log,
 		},
 		backendEvents: backendEvents{
@@ -105,6 +122,8 @@ func New(
 			blocks:             blocks,
 			executionReceipts:  executionReceipts,
 			connFactory:        connFactory,
+			notifier:           notifier,
+			queryStrategy:      queryStrategy,
 			log:                log,
 		},
 		backendBlockHeaders: backendBlockHeaders{
@@ -121,12 +140,21 @@ func New(
 			headers:            headers,
 			executionReceipts:  executionReceipts,
 			connFactory:        connFactory,
+			queryStrategy:      queryStrategy,
 			log:                log,
 		},
+		backendDryRun: backendDryRun{
+			state:             state,
+			executionReceipts: executionReceipts,
+			connFactory:       connFactory,
+			queryStrategy:     queryStrategy,
+			log:               log,
+		},
 		collections:       collections,
 		executionReceipts: executionReceipts,
 		connFactory:       connFactory,
 		chainID:           chainID,
+		notifier:          notifier,
 	}
 
 	retry.SetBackend(b)
@@ -189,6 +217,30 @@ func (b *Backend) GetNetworkParameters(_ context.Context) access.NetworkParamete
 	}
 }
 
+// GetLatestProtocolStateSnapshot returns the latest sealed protocol state
+// snapshot, serialized as JSON.
+func (b *Backend) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, error) {
+	snapshot, err := inmem.FromSnapshot(b.state.Sealed())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert snapshot: %v", err)
+	}
+
+	data, err := json.Marshal(snapshot.Encodable())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode snapshot: %v", err)
+	}
+
+	return data, nil
+}
+
+// NotifyFinalizedBlockHeight is called for each newly finalized block. Besides
+// retrying pending transactions, it wakes up all streaming subscriptions, as
+// new blocks may have been sealed as well.
+func (b *Backend) NotifyFinalizedBlockHeight(height uint64) {
+	b.backendTransactions.NotifyFinalizedBlockHeight(height)
+	b.notifier.Notify()
+}
+
 func convertStorageError(err error) error {
 	if err == nil {
 		return nil
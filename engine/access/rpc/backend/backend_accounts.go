@@ -2,8 +2,10 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
@@ -22,6 +24,7 @@ type backendAccounts struct {
 	headers            storage.Headers
 	executionReceipts  storage.ExecutionReceipts
 	connFactory        ConnectionFactory
+	queryStrategy      *ExecutionQueryStrategy
 	log                zerolog.Logger
 }
 
@@ -122,15 +125,13 @@ func getAccountError(err error) error {
 }
 
 func (b *backendAccounts) getAccountFromAnyExeNode(ctx context.Context, execNodes flow.IdentityList, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
-	var errors *multierror.Error // captures all error except
-	for _, execNode := range execNodes {
+	call := func(ctx context.Context, execNode *flow.Identity) (proto.Message, error) {
 		// TODO: use the GRPC Client interceptor
 		start := time.Now()
 
 		resp, err := b.tryGetAccount(ctx, execNode, req)
 		duration := time.Since(start)
 		if err == nil {
-			// return if any execution node replied successfully
 			b.log.Debug().
 				Str("execution_node", execNode.String()).
 				Hex("block_id", req.GetBlockId()).
@@ -146,21 +147,30 @@ func (b *backendAccounts) getAccountFromAnyExeNode(ctx context.Context, execNode
 			Int64("rtt_ms", duration.Milliseconds()).
 			Err(err).
 			Msg("failed to execute GetAccount")
-		errors = multierror.Append(errors, err)
+		return nil, err
+	}
+
+	resp, _, err := b.queryStrategy.Query(ctx, "GetAccountAtBlockID", execNodes, call, nil)
+	if err == nil {
+		return resp.(*execproto.GetAccountAtBlockIDResponse), nil
+	}
+
+	// if the execution nodes disagreed, there is no error from each of them
+	var execErrors *multierror.Error
+	if !errors.As(err, &execErrors) {
+		return nil, err
 	}
-	// if we made it till here means there was at least one error
-	errToReturn := errors.ErrorOrNil()
 
 	// if there were an any errors other than codes.NotFound, return those
-	for _, err := range errors.Errors {
+	for _, err := range execErrors.Errors {
 		errStatus, _ := status.FromError(err)
 		if errStatus.Code() != codes.NotFound {
-			return nil, status.Errorf(codes.Internal, "failed to get account from the execution node: %v", errToReturn)
+			return nil, status.Errorf(codes.Internal, "failed to get account from the execution node: %v", execErrors)
 		}
 	}
 
 	// if all errors were codes.NotFound, then return a codes.NotFound error wrapping all those error
-	return nil, status.Errorf(codes.NotFound, "failed to get account from the execution node: %v", errToReturn)
+	return nil, status.Errorf(codes.NotFound, "failed to get account from the execution node: %v", execErrors)
 }
 
 func (b *backendAccounts) tryGetAccount(ctx context.Context, execNode *flow.Identity, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+type backendDryRun struct {
+	state             protocol.State
+	executionReceipts storage.ExecutionReceipts
+	connFactory       ConnectionFactory
+	queryStrategy     *ExecutionQueryStrategy
+	log               zerolog.Logger
+}
+
+// DryRunTransaction runs the transaction on an execution node at the given
+// block, or at the latest sealed block if the block ID is zero. The
+// transaction is not committed.
+func (b *backendDryRun) DryRunTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockID flow.Identifier,
+	opts access.DryRunOptions,
+) (*access.DryRunResult, error) {
+
+	if blockID == flow.ZeroID {
+		latestHeader, err := b.state.Sealed().Head()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+		}
+		blockID = latestHeader.ID()
+	}
+
+	req := dryrunproto.DryRunTransactionRequest{
+		BlockId:     blockID[:],
+		Transaction: convert.TransactionToMessage(*tx),
+		Options: &dryrunproto.DryRunOptions{
+			SkipSignatures:     opts.SkipSignatures,
+			SkipSequenceNumber: opts.SkipSequenceNumber,
+			SkipFees:           opts.SkipFees,
+		},
+	}
+
+	// find few execution nodes which have executed the block earlier and provided an execution receipt for it
+	execNodes, err := executionNodesForBlockID(blockID, b.executionReceipts, b.state)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dry-run the transaction on the execution node: %v", err)
+	}
+	if len(execNodes) == 0 {
+		return nil, status.Errorf(codes.Internal, "failed to dry-run the transaction: no execution node executed block %v", blockID)
+	}
+
+	call := func(ctx context.Context, execNode *flow.Identity) (proto.Message, error) {
+		return b.tryDryRunTransaction(ctx, execNode, req)
+	}
+	resp, execNode, err := b.queryStrategy.Query(ctx, "DryRunTransactionAtBlockID", execNodes, call, nil)
+	if err != nil {
+		return nil, err
+	}
+	txID := tx.ID()
+	b.log.Debug().
+		Str("execution_node", execNode.String()).
+		Hex("block_id", blockID[:]).
+		Hex("transaction_id", txID[:]).
+		Msg("successfully dry-ran transaction")
+
+	return access.MessageToDryRunResult(resp.(*dryrunproto.DryRunTransactionResponse)), nil
+}
+
+func (b *backendDryRun) tryDryRunTransaction(ctx context.Context, execNode *flow.Identity, req dryrunproto.DryRunTransactionRequest) (*dryrunproto.DryRunTransactionResponse, error) {
+	dryRunClient, closer, err := b.connFactory.GetExecutionDryRunAPIClient(execNode.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dry-run the transaction on the execution node %s: %v", execNode.String(), err)
+	}
+	defer closer.Close()
+	resp, err := dryRunClient.DryRunTransactionAtBlockID(ctx, &req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dry-run the transaction on the execution node %s: %v", execNode.String(), err)
+	}
+	return resp, nil
+}
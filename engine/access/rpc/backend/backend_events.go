@@ -5,13 +5,15 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 
-	"github.com/hashicorp/go-multierror"
+	"github.com/golang/protobuf/proto"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
@@ -24,9 +26,15 @@ type backendEvents struct {
 	executionReceipts  storage.ExecutionReceipts
 	state              protocol.State
 	connFactory        ConnectionFactory
+	notifier           *blockNotifier
+	queryStrategy      *ExecutionQueryStrategy
 	log                zerolog.Logger
 }
 
+// maxEventStreamBatchSize is the max number of blocks for which events are
+// requested from an execution node at once, while a subscription catches up.
+const maxEventStreamBatchSize = 50
+
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
 // the end block height (inclusive) that have the given type.
 func (b *backendEvents) GetEventsForHeightRange(
@@ -93,6 +101,129 @@ func (b *backendEvents) GetEventsForBlockIDs(
 	return b.getBlockEventsFromExecutionNode(ctx, blockHeaders, eventType)
 }
 
+// SubscribeEvents passes the events matching the filter to the handler for each
+// sealed block, starting at the given height, or at the latest sealed block if
+// the height is zero. Blocks are passed in order of height, including blocks
+// without matching events. It returns once the context is cancelled or the
+// handler returns an error.
+func (b *backendEvents) SubscribeEvents(
+	ctx context.Context,
+	startHeight uint64,
+	filter access.EventFilter,
+	handler func(flow.BlockEvents) error,
+) error {
+
+	if len(filter.EventTypes) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one event type is required")
+	}
+
+	height := startHeight
+	if height == 0 {
+		head, err := b.state.Sealed().Head()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get last sealed block: %v", err)
+		}
+		height = head.Height
+	}
+
+	for {
+		// get the notification channel before checking the sealed head, so we
+		// don't miss blocks sealed while we process the current ones
+		updated := b.notifier.Updated()
+
+		head, err := b.state.Sealed().Head()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get last sealed block: %v", err)
+		}
+
+		for height <= head.Height {
+			endHeight := height + maxEventStreamBatchSize - 1
+			if endHeight > head.Height {
+				endHeight = head.Height
+			}
+			results, err := b.getFilteredEventsForHeightRange(ctx, height, endHeight, filter)
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				err = handler(result)
+				if err != nil {
+					return err
+				}
+			}
+			height = endHeight + 1
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		case <-updated:
+		}
+	}
+}
+
+// getFilteredEventsForHeightRange retrieves the events matching the filter for
+// all blocks between the start and end height (inclusive), which must be sealed.
+func (b *backendEvents) getFilteredEventsForHeightRange(
+	ctx context.Context,
+	startHeight, endHeight uint64,
+	filter access.EventFilter,
+) ([]flow.BlockEvents, error) {
+
+	blockHeaders := make([]*flow.Header, 0, endHeight-startHeight+1)
+	results := make([]flow.BlockEvents, 0, endHeight-startHeight+1)
+	resultIndices := make(map[flow.Identifier]int)
+	for i := startHeight; i <= endHeight; i++ {
+		block, err := b.blocks.ByHeight(i)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
+		}
+		blockHeaders = append(blockHeaders, block.Header)
+		resultIndices[block.ID()] = len(results)
+		results = append(results, flow.BlockEvents{
+			BlockID:        block.ID(),
+			BlockHeight:    block.Header.Height,
+			BlockTimestamp: block.Header.Timestamp,
+			Events:         []flow.Event{},
+		})
+	}
+
+	// execution nodes only serve events of a single type per request
+	requested := make(map[string]struct{})
+	for _, eventType := range filter.EventTypes {
+		if _, ok := requested[eventType]; ok {
+			continue
+		}
+		requested[eventType] = struct{}{}
+
+		blockEvents, err := b.getBlockEventsFromExecutionNode(ctx, blockHeaders, eventType)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range blockEvents {
+			result := &results[resultIndices[block.BlockID]]
+			for _, event := range block.Events {
+				if filter.Match(event) {
+					result.Events = append(result.Events, event)
+				}
+			}
+		}
+	}
+
+	// restore the order in which the events were emitted
+	for _, result := range results {
+		events := result.Events
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+			return events[i].EventIndex < events[j].EventIndex
+		})
+	}
+
+	return results, nil
+}
+
 func (b *backendEvents) getBlockEventsFromExecutionNode(
 	ctx context.Context,
 	blockHeaders []*flow.Header,
@@ -193,16 +324,14 @@ func verifyAndConvertToAccessEvents(execEvents []*execproto.GetEventsForBlockIDs
 func (b *backendEvents) getEventsFromAnyExeNode(ctx context.Context,
 	execNodes flow.IdentityList,
 	req execproto.GetEventsForBlockIDsRequest) (*execproto.GetEventsForBlockIDsResponse, *flow.Identity, error) {
-	var errors *multierror.Error
-	// try to get events from one of the execution nodes
-	for _, execNode := range execNodes {
-		resp, err := b.tryGetEvents(ctx, execNode, req)
-		if err == nil {
-			return resp, execNode, nil
-		}
-		errors = multierror.Append(errors, err)
+	call := func(ctx context.Context, execNode *flow.Identity) (proto.Message, error) {
+		return b.tryGetEvents(ctx, execNode, req)
+	}
+	resp, execNode, err := b.queryStrategy.Query(ctx, "GetEventsForBlockIDs", execNodes, call, nil)
+	if err != nil {
+		return nil, nil, err
 	}
-	return nil, nil, errors.ErrorOrNil()
+	return resp.(*execproto.GetEventsForBlockIDsResponse), execNode, nil
 }
 
 func (b *backendEvents) tryGetEvents(ctx context.Context,
@@ -3,7 +3,7 @@ package backend
 import (
 	"context"
 
-	"github.com/hashicorp/go-multierror"
+	"github.com/golang/protobuf/proto"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
@@ -20,6 +20,7 @@ type backendScripts struct {
 	state              protocol.State
 	staticExecutionRPC execproto.ExecutionAPIClient
 	connFactory        ConnectionFactory
+	queryStrategy      *ExecutionQueryStrategy
 	log                zerolog.Logger
 }
 
@@ -107,24 +108,22 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 	}
 
 	// try each of the execution nodes found
-	var errors *multierror.Error
-	// try to execute the script on one of the execution nodes
-	for _, execNode := range execNodes {
-		result, err := b.tryExecuteScript(ctx, execNode, execReq)
-		if err == nil {
-			b.log.Debug().
-				Str("execution_node", execNode.String()).
-				Hex("block_id", blockID[:]).
-				Str("script", string(script)).
-				Msg("Successfully executed script")
-			return result, nil
-		}
-		errors = multierror.Append(errors, err)
+	call := func(ctx context.Context, execNode *flow.Identity) (proto.Message, error) {
+		return b.tryExecuteScript(ctx, execNode, execReq)
+	}
+	resp, execNode, err := b.queryStrategy.Query(ctx, "ExecuteScriptAtBlockID", execNodes, call, nil)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.ErrorOrNil()
+	b.log.Debug().
+		Str("execution_node", execNode.String()).
+		Hex("block_id", blockID[:]).
+		Str("script", string(script)).
+		Msg("Successfully executed script")
+	return resp.(*execproto.ExecuteScriptAtBlockIDResponse).GetValue(), nil
 }
 
-func (b *backendScripts) tryExecuteScript(ctx context.Context, execNode *flow.Identity, req execproto.ExecuteScriptAtBlockIDRequest) ([]byte, error) {
+func (b *backendScripts) tryExecuteScript(ctx context.Context, execNode *flow.Identity, req execproto.ExecuteScriptAtBlockIDRequest) (*execproto.ExecuteScriptAtBlockIDResponse, error) {
 	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(execNode.Address)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution node %s: %v", execNode.String(), err)
@@ -134,5 +133,5 @@ func (b *backendScripts) tryExecuteScript(ctx context.Context, execNode *flow.Id
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution node %s: %v", execNode.String(), err)
 	}
-	return execResp.GetValue(), nil
+	return execResp, nil
 }
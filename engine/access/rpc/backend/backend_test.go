@@ -2,6 +2,8 @@ package backend
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
@@ -16,9 +18,11 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	accessapi "github.com/onflow/flow-go/access"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
@@ -87,6 +91,7 @@ func (suite *Suite) TestPing() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -109,6 +114,7 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -137,6 +143,7 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -170,6 +177,7 @@ func (suite *Suite) TestGetTransaction() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -199,6 +207,7 @@ func (suite *Suite) TestGetCollection() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -281,6 +290,7 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		metrics.NewNoopCollector(),
 		connFactory,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -333,6 +343,223 @@ func (suite *Suite) TestTransactionStatusTransition() {
 	suite.assertAllExpectations()
 }
 
+// TestSubscribeTransactionStatus tests that each status change of a transaction
+// is streamed once, including the block the transaction is included in
+func (suite *Suite) TestSubscribeTransactionStatus() {
+
+	ctx := context.Background()
+	collection := unittest.CollectionFixture(1)
+	transactionBody := collection.Transactions[0]
+	block := unittest.BlockFixture()
+	block.Header.Height = 2
+	headBlock := unittest.BlockFixture()
+	headBlock.Header.Height = block.Header.Height - 1 // head is behind the current block
+
+	suite.snapshot.
+		On("Head").
+		Return(headBlock.Header, nil)
+
+	light := collection.Light()
+	suite.transactions.
+		On("ByID", transactionBody.ID()).
+		Return(transactionBody, nil)
+	suite.collections.
+		On("LightByTransactionID", transactionBody.ID()).
+		Return(&light, nil)
+	suite.blocks.
+		On("ByCollectionID", collection.ID()).
+		Return(&block, nil)
+
+	txID := transactionBody.ID()
+	blockID := block.ID()
+
+	ids := unittest.IdentityListFixture(1)
+	receipt := unittest.ReceiptForBlockFixture(&block)
+	receipt.ExecutorID = ids[0].NodeID
+	suite.receipts.
+		On("ByBlockIDAllExecutionReceipts", mock.Anything).
+		Return([]*flow.ExecutionReceipt{receipt}, nil)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionAPIClient", mock.Anything).Return(suite.execClient, &mockCloser{}, nil)
+
+	exeEventReq := execproto.GetTransactionResultRequest{
+		BlockId:       blockID[:],
+		TransactionId: txID[:],
+	}
+	exeEventResp := execproto.GetTransactionResultResponse{
+		Events: nil,
+	}
+
+	// the transaction is not executed at first
+	suite.execClient.
+		On("GetTransactionResult", ctx, &exeEventReq).
+		Return(&exeEventResp, status.Errorf(codes.NotFound, "not found")).
+		Once()
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		nil,
+		suite.log,
+	)
+
+	var statuses []flow.TransactionStatus
+	err := backend.SubscribeTransactionStatus(ctx, txID, func(result *accessapi.TransactionResult) error {
+		statuses = append(statuses, result.Status)
+		suite.Assert().Equal(blockID, result.BlockID)
+		suite.Assert().Equal(block.Header.Height, result.BlockHeight)
+
+		switch result.Status {
+		case flow.TransactionStatusFinalized:
+			// the transaction is executed with the next block
+			suite.execClient.
+				On("GetTransactionResult", ctx, &exeEventReq).
+				Return(&exeEventResp, nil)
+			backend.NotifyFinalizedBlockHeight(headBlock.Header.Height + 1)
+		case flow.TransactionStatusExecuted:
+			// the block including the transaction is sealed
+			headBlock.Header.Height = block.Header.Height + 1
+			backend.NotifyFinalizedBlockHeight(headBlock.Header.Height + 1)
+		}
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	// the stream ends once the transaction is sealed
+	suite.Assert().Equal([]flow.TransactionStatus{
+		flow.TransactionStatusFinalized,
+		flow.TransactionStatusExecuted,
+		flow.TransactionStatusSealed,
+	}, statuses)
+
+	suite.assertAllExpectations()
+}
+
+// TestSubscribeTransactionStatus_Pending tests that a transaction which is not
+// included in a finalized block yet is streamed as pending, without a block,
+// until it is included.
+func (suite *Suite) TestSubscribeTransactionStatus_Pending() {
+
+	ctx := context.Background()
+	collection := unittest.CollectionFixture(1)
+	transactionBody := collection.Transactions[0]
+	refBlock := unittest.BlockFixture()
+	refBlock.Header.Height = 2
+	transactionBody.SetReferenceBlockID(refBlock.ID())
+	block := unittest.BlockWithParentFixture(refBlock.Header)
+
+	headBlock := unittest.BlockFixture()
+	headBlock.Header.Height = refBlock.Header.Height
+
+	suite.snapshot.
+		On("Head").
+		Return(headBlock.Header, nil)
+	snapshotAtBlock := new(protocol.Snapshot)
+	snapshotAtBlock.On("Head").Return(refBlock.Header, nil)
+	suite.state.
+		On("AtBlockID", refBlock.ID()).
+		Return(snapshotAtBlock, nil)
+
+	txID := transactionBody.ID()
+	blockID := block.ID()
+	suite.transactions.
+		On("ByID", txID).
+		Return(transactionBody, nil)
+
+	// the transaction is not included in a block at first, which is looked up
+	// both for the execution result and for the status
+	suite.collections.
+		On("LightByTransactionID", txID).
+		Return(nil, storage.ErrNotFound).
+		Twice()
+
+	ids := unittest.IdentityListFixture(1)
+	receipt := unittest.ReceiptForBlockFixture(&block)
+	receipt.ExecutorID = ids[0].NodeID
+	suite.receipts.
+		On("ByBlockIDAllExecutionReceipts", mock.Anything).
+		Return([]*flow.ExecutionReceipt{receipt}, nil)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionAPIClient", mock.Anything).Return(suite.execClient, &mockCloser{}, nil)
+
+	exeEventReq := execproto.GetTransactionResultRequest{
+		BlockId:       blockID[:],
+		TransactionId: txID[:],
+	}
+	suite.execClient.
+		On("GetTransactionResult", ctx, &exeEventReq).
+		Return(nil, status.Errorf(codes.NotFound, "not found"))
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		nil,
+		suite.log,
+	)
+
+	errStop := errors.New("stop")
+	var statuses []flow.TransactionStatus
+	err := backend.SubscribeTransactionStatus(ctx, txID, func(result *accessapi.TransactionResult) error {
+		statuses = append(statuses, result.Status)
+
+		switch result.Status {
+		case flow.TransactionStatusPending:
+			suite.Assert().Equal(flow.ZeroID, result.BlockID)
+			suite.Assert().Zero(result.BlockHeight)
+
+			// the transaction is included in the next finalized block
+			light := collection.Light()
+			suite.collections.
+				On("LightByTransactionID", txID).
+				Return(&light, nil)
+			suite.blocks.
+				On("ByCollectionID", collection.ID()).
+				Return(&block, nil)
+			headBlock.Header.Height = block.Header.Height
+			backend.NotifyFinalizedBlockHeight(headBlock.Header.Height)
+			return nil
+		case flow.TransactionStatusFinalized:
+			suite.Assert().Equal(blockID, result.BlockID)
+			suite.Assert().Equal(block.Header.Height, result.BlockHeight)
+		}
+		return errStop
+	})
+	suite.Require().True(errors.Is(err, errStop))
+
+	suite.Assert().Equal([]flow.TransactionStatus{
+		flow.TransactionStatusPending,
+		flow.TransactionStatusFinalized,
+	}, statuses)
+
+	suite.assertAllExpectations()
+}
+
 // TestTransactionExpiredStatusTransition tests that the status of transaction changes from Unknown to Expired
 // when enough blocks pass
 func (suite *Suite) TestTransactionExpiredStatusTransition() {
@@ -391,6 +618,7 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -472,6 +700,7 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -507,6 +736,7 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -617,6 +847,7 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			metrics.NewNoopCollector(),
 			nil,
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -641,6 +872,7 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -665,6 +897,7 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -763,6 +996,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			metrics.NewNoopCollector(),
 			nil,
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -794,6 +1028,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			metrics.NewNoopCollector(),
 			nil,
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -824,6 +1059,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			metrics.NewNoopCollector(),
 			nil,
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -856,6 +1092,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			metrics.NewNoopCollector(),
 			nil,
 			false,
+			nil,
 			suite.log,
 		)
 
@@ -865,6 +1102,135 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 
 }
 
+func (suite *Suite) TestSubscribeEvents() {
+	ctx := context.Background()
+
+	address := unittest.AddressFixture()
+	fooType := flow.EventType(fmt.Sprintf("A.%s.Foo.Deposited", address.Hex()))
+	barType := flow.EventType(fmt.Sprintf("A.%s.Foo.Withdrawn", address.Hex()))
+	otherType := flow.EventType(fmt.Sprintf("A.%s.Other.Deposited", unittest.RandomAddressFixture().Hex()))
+
+	setupHeadHeight := func(height uint64) {
+		header := unittest.BlockHeaderFixture()
+		header.Height = height
+		suite.snapshot.On("Head").Return(&header, nil).Once()
+	}
+
+	setupStorage := func(min uint64, max uint64) []*flow.Header {
+		headers := make([]*flow.Header, 0)
+		for i := min; i <= max; i++ {
+			b := unittest.BlockFixture()
+			b.Header.Height = i
+			suite.blocks.
+				On("ByHeight", i).
+				Return(&b, nil).Once()
+			headers = append(headers, b.Header)
+		}
+		return headers
+	}
+
+	// use the static execution node
+	suite.receipts.
+		On("ByBlockIDAllExecutionReceipts", mock.Anything).
+		Return([]*flow.ExecutionReceipt{}, nil)
+
+	// each block has one event of each type, the events of the first type
+	// being emitted after the ones of the other types
+	setupExecClient := func(headers []*flow.Header, eventType flow.EventType, txIndex uint32) {
+		blockIDs := make([]flow.Identifier, len(headers))
+		exeResults := make([]*execproto.GetEventsForBlockIDsResponse_Result, len(headers))
+		for i, header := range headers {
+			blockIDs[i] = header.ID()
+			exeResults[i] = &execproto.GetEventsForBlockIDsResponse_Result{
+				BlockId:     convert.IdentifierToMessage(header.ID()),
+				BlockHeight: header.Height,
+				Events:      convert.EventsToMessages([]flow.Event{unittest.EventFixture(eventType, txIndex, 0, unittest.IdentifierFixture())}),
+			}
+		}
+		execReq := &execproto.GetEventsForBlockIDsRequest{
+			BlockIds: convert.IdentifiersToMessages(blockIDs),
+			Type:     string(eventType),
+		}
+		suite.execClient.
+			On("GetEventsForBlockIDs", mock.Anything, execReq).
+			Return(&execproto.GetEventsForBlockIDsResponse{Results: exeResults}, nil).
+			Once()
+	}
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil, nil,
+		suite.blocks,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		nil,
+		suite.log,
+	)
+
+	suite.Run("invalid request without event types", func() {
+		err := backend.SubscribeEvents(ctx, 1, accessapi.EventFilter{}, func(flow.BlockEvents) error {
+			return nil
+		})
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("streams sealed blocks and waits for new ones", func() {
+
+		// blocks 5 to 7 are sealed when subscribing, block 8 is sealed later
+		setupHeadHeight(7)
+		sealedHeaders := setupStorage(5, 7)
+		setupExecClient(sealedHeaders, fooType, 1)
+		setupExecClient(sealedHeaders, otherType, 0)
+		setupExecClient(sealedHeaders, barType, 0)
+
+		setupHeadHeight(8)
+		newHeaders := setupStorage(8, 8)
+		setupExecClient(newHeaders, fooType, 1)
+		setupExecClient(newHeaders, otherType, 0)
+		setupExecClient(newHeaders, barType, 0)
+
+		filter := accessapi.EventFilter{
+			EventTypes: []string{string(fooType), string(otherType), string(barType), string(fooType)},
+			Addresses:  []flow.Address{address},
+		}
+
+		errDone := errors.New("done")
+		var received []flow.BlockEvents
+		err := backend.SubscribeEvents(ctx, 5, filter, func(block flow.BlockEvents) error {
+			received = append(received, block)
+			switch block.BlockHeight {
+			case 7:
+				backend.NotifyFinalizedBlockHeight(8)
+			case 8:
+				return errDone
+			}
+			return nil
+		})
+		suite.Require().True(errors.Is(err, errDone))
+
+		headers := append(sealedHeaders, newHeaders...)
+		suite.Require().Len(received, len(headers))
+		for i, header := range headers {
+			suite.Assert().Equal(header.ID(), received[i].BlockID)
+			suite.Assert().Equal(header.Height, received[i].BlockHeight)
+
+			// events of other accounts are filtered and the remaining ones are in emission order
+			suite.Require().Len(received[i].Events, 2)
+			suite.Assert().Equal(barType, received[i].Events[0].Type)
+			suite.Assert().Equal(fooType, received[i].Events[1].Type)
+		}
+
+		suite.assertAllExpectations()
+	})
+}
+
 func (suite *Suite) TestGetAccount() {
 
 	address, err := suite.chainID.Chain().NewAddressGenerator().NextAddress()
@@ -928,6 +1294,7 @@ func (suite *Suite) TestGetAccount() {
 		metrics.NewNoopCollector(),
 		connFactory,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -941,6 +1308,92 @@ func (suite *Suite) TestGetAccount() {
 	})
 }
 
+func (suite *Suite) TestDryRunTransaction() {
+	ctx := context.Background()
+
+	// setup the latest sealed block
+	block := unittest.BlockFixture()
+	header := block.Header
+	blockID := header.ID()
+
+	suite.snapshot.
+		On("Head").
+		Return(header, nil).
+		Once()
+
+	tx := unittest.TransactionBodyFixture()
+	opts := accessapi.DryRunOptions{SkipSequenceNumber: true, SkipFees: true}
+
+	// create the expected execution API request
+	exeReq := &dryrunproto.DryRunTransactionRequest{
+		BlockId:     blockID[:],
+		Transaction: convert.TransactionToMessage(tx),
+		Options: &dryrunproto.DryRunOptions{
+			SkipSequenceNumber: true,
+			SkipFees:           true,
+		},
+	}
+
+	// create the expected execution API response
+	updates := flow.RegisterEntries{
+		{Key: flow.RegisterID{Owner: "owner", Controller: "", Key: "key"}, Value: flow.RegisterValue("value")},
+	}
+	events := []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID())}
+	exeResp := &dryrunproto.DryRunTransactionResponse{
+		Events:          convert.EventsToMessages(events),
+		Logs:            []string{"log"},
+		ComputationUsed: 10,
+		RegisterUpdates: convert.RegisterEntriesToMessages(updates),
+	}
+
+	dryRunClient := new(access.ExecutionDryRunAPIClient)
+	dryRunClient.
+		On("DryRunTransactionAtBlockID", ctx, exeReq).
+		Return(exeResp, nil).
+		Once()
+
+	ids := unittest.IdentityListFixture(1)
+	receipt := unittest.ReceiptForBlockFixture(&block)
+	receipt.ExecutorID = ids[0].NodeID
+	suite.receipts.
+		On("ByBlockIDAllExecutionReceipts", blockID).
+		Return([]*flow.ExecutionReceipt{receipt}, nil).Once()
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	// create a mock connection factory
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionDryRunAPIClient", mock.Anything).Return(dryRunClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil, nil,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		nil,
+		suite.log,
+	)
+
+	suite.Run("happy path - transaction is run at the latest sealed block", func() {
+		result, err := backend.DryRunTransaction(ctx, &tx, flow.ZeroID, opts)
+		suite.checkResponse(result, err)
+
+		suite.Require().Equal(events, result.Events)
+		suite.Require().Equal([]string{"log"}, result.Logs)
+		suite.Require().Equal(uint64(10), result.ComputationUsed)
+		suite.Require().Equal(updates, result.RegisterUpdates)
+		suite.Require().Zero(result.StatusCode)
+
+		suite.assertAllExpectations()
+		dryRunClient.AssertExpectations(suite.T())
+	})
+}
+
 func (suite *Suite) TestGetAccountAtBlockHeight() {
 	height := uint64(5)
 	address := unittest.AddressFixture()
@@ -992,6 +1445,7 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
@@ -1015,6 +1469,7 @@ func (suite *Suite) TestGetNetworkParameters() {
 		metrics.NewNoopCollector(),
 		nil,
 		false,
+		nil,
 		suite.log,
 	)
 
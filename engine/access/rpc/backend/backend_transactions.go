@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
@@ -37,8 +38,10 @@ type backendTransactions struct {
 	transactionValidator *access.TransactionValidator
 	retry                *Retry
 	connFactory          ConnectionFactory
+	notifier             *blockNotifier
 
 	previousAccessNodes []accessproto.AccessAPIClient
+	queryStrategy       *ExecutionQueryStrategy
 	log                 zerolog.Logger
 }
 
@@ -144,7 +147,6 @@ func (b *backendTransactions) sendTransactionToCollector(ctx context.Context,
 	tx *flow.TransactionBody,
 	collectionNodeAddr string) error {
 
-	// TODO: Use a connection pool to cache connections
 	collectionRPC, conn, err := b.connFactory.GetAccessAPIClient(collectionNodeAddr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to collection node at %s: %w", collectionNodeAddr, err)
@@ -220,6 +222,72 @@ func (b *backendTransactions) GetTransactionResult(
 		return nil, txErr
 	}
 
+	return b.transactionResult(ctx, tx)
+}
+
+// SubscribeTransactionStatus passes the result of the transaction to the
+// handler whenever its status changes, starting with its current status. The
+// status is checked again for every newly finalized block. It returns once the
+// transaction is sealed or expired, the context is cancelled or the handler
+// returns an error.
+func (b *backendTransactions) SubscribeTransactionStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+	handler func(*access.TransactionResult) error,
+) error {
+
+	tx, err := b.transactions.ByID(txID)
+	txErr := convertStorageError(err)
+	if txErr != nil {
+		if status.Code(txErr) != codes.NotFound {
+			return txErr
+		}
+		// transactions from previous sporks will not change their status anymore
+		historicalTxResult, err := b.getHistoricalTransactionResult(ctx, txID)
+		if err != nil {
+			return err
+		}
+		return handler(historicalTxResult)
+	}
+
+	lastStatus := flow.TransactionStatusUnknown
+	for {
+		// get the notification channel before deriving the status, so we don't
+		// miss blocks finalized in between
+		updated := b.notifier.Updated()
+
+		result, err := b.transactionResult(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if result.Status != lastStatus {
+			err = handler(result)
+			if err != nil {
+				return err
+			}
+			lastStatus = result.Status
+		}
+		if result.Status == flow.TransactionStatusSealed || result.Status == flow.TransactionStatusExpired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		case <-updated:
+		}
+	}
+}
+
+// transactionResult derives the current result of the given transaction, which
+// was sent through this node.
+func (b *backendTransactions) transactionResult(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+) (*access.TransactionResult, error) {
+
+	txID := tx.ID()
+
 	// get events for the transaction
 	executed, events, statusCode, txError, err := b.lookupTransactionResult(ctx, txID)
 	if err != nil {
@@ -227,45 +295,55 @@ func (b *backendTransactions) GetTransactionResult(
 	}
 
 	// derive status of the transaction
-	status, err := b.deriveTransactionStatus(tx, executed)
+	status, block, err := b.deriveTransactionStatus(tx, executed)
 	if err != nil {
 		return nil, convertStorageError(err)
 	}
 
 	// TODO: Set correct values for StatusCode and ErrorMessage
 
-	return &access.TransactionResult{
+	result := &access.TransactionResult{
 		Status:       status,
 		StatusCode:   uint(statusCode),
 		Events:       events,
 		ErrorMessage: txError,
-	}, nil
+	}
+
+	// only transactions included in a finalized block have a block
+	if block != nil {
+		result.BlockID = block.ID()
+		result.BlockHeight = block.Header.Height
+	}
+
+	return result, nil
 }
 
-// deriveTransactionStatus derives the transaction status based on current protocol state
+// deriveTransactionStatus derives the transaction status based on current protocol state.
+// It also returns the finalized block including the transaction, which is nil
+// if the transaction is not included in a finalized block yet.
 func (b *backendTransactions) deriveTransactionStatus(
 	tx *flow.TransactionBody,
 	executed bool,
-) (flow.TransactionStatus, error) {
+) (flow.TransactionStatus, *flow.Block, error) {
 
 	block, err := b.lookupBlock(tx.ID())
 	if errors.Is(err, storage.ErrNotFound) {
 		// Not in a block, let's see if it's expired
 		referenceBlock, err := b.state.AtBlockID(tx.ReferenceBlockID).Head()
 		if err != nil {
-			return flow.TransactionStatusUnknown, err
+			return flow.TransactionStatusUnknown, nil, err
 		}
 		refHeight := referenceBlock.Height
 		// get the latest finalized block from the state
 		finalized, err := b.state.Final().Head()
 		if err != nil {
-			return flow.TransactionStatusUnknown, err
+			return flow.TransactionStatusUnknown, nil, err
 		}
 		finalizedHeight := finalized.Height
 
 		// if we haven't seen the expiry block for this transaction, it's not expired
 		if !b.isExpired(refHeight, finalizedHeight) {
-			return flow.TransactionStatusPending, nil
+			return flow.TransactionStatusPending, nil, nil
 		}
 
 		// At this point, we have seen the expiry block for the transaction.
@@ -279,26 +357,26 @@ func (b *backendTransactions) deriveTransactionStatus(
 		// collections for all blocks with a lower height
 		fullHeight, err := b.blocks.GetLastFullBlockHeight()
 		if err != nil {
-			return flow.TransactionStatusUnknown, err
+			return flow.TransactionStatusUnknown, nil, err
 		}
 
 		// if we have received collections for all blocks up to the expiry block, the transaction is expired
 		if b.isExpired(refHeight, fullHeight) {
-			return flow.TransactionStatusExpired, err
+			return flow.TransactionStatusExpired, nil, err
 		}
 
 		// tx found in transaction storage and collection storage but not in block storage
 		// However, this will not happen as of now since the ingestion engine doesn't subscribe
 		// for collections
-		return flow.TransactionStatusPending, nil
+		return flow.TransactionStatusPending, nil, nil
 	}
 	if err != nil {
-		return flow.TransactionStatusUnknown, err
+		return flow.TransactionStatusUnknown, nil, err
 	}
 
 	if !executed {
 		// If we've gotten here, but the block has not yet been executed, report it as only been finalized
-		return flow.TransactionStatusFinalized, nil
+		return flow.TransactionStatusFinalized, block, nil
 	}
 
 	// From this point on, we know for sure this transaction has at least been executed
@@ -306,16 +384,16 @@ func (b *backendTransactions) deriveTransactionStatus(
 	// get the latest sealed block from the state
 	sealed, err := b.state.Sealed().Head()
 	if err != nil {
-		return flow.TransactionStatusUnknown, err
+		return flow.TransactionStatusUnknown, nil, err
 	}
 
 	if block.Header.Height > sealed.Height {
 		// The block is not yet sealed, so we'll report it as only executed
-		return flow.TransactionStatusExecuted, nil
+		return flow.TransactionStatusExecuted, block, nil
 	}
 
 	// otherwise, this block has been executed, and sealed, so report as sealed
-	return flow.TransactionStatusSealed, nil
+	return flow.TransactionStatusSealed, block, nil
 }
 
 // isExpired checks whether a transaction is expired given the height of the
@@ -478,24 +556,23 @@ func (b *backendTransactions) NotifyFinalizedBlockHeight(height uint64) {
 }
 
 func (b *backendTransactions) getTransactionResultFromAnyExeNode(ctx context.Context, execNodes flow.IdentityList, req execproto.GetTransactionResultRequest) (*execproto.GetTransactionResultResponse, error) {
-	var errors *multierror.Error
-	// try to execute the script on one of the execution nodes
-	for _, execNode := range execNodes {
-		resp, err := b.tryGetTransactionResult(ctx, execNode, req)
-		if err == nil {
-			b.log.Debug().
-				Str("execution_node", execNode.String()).
-				Hex("block_id", req.GetBlockId()).
-				Hex("transaction_id", req.GetTransactionId()).
-				Msg("Successfully got account info")
-			return resp, nil
-		}
-		if status.Code(err) == codes.NotFound {
-			return nil, err
-		}
-		errors = multierror.Append(errors, err)
+	call := func(ctx context.Context, execNode *flow.Identity) (proto.Message, error) {
+		return b.tryGetTransactionResult(ctx, execNode, req)
+	}
+	// a node which has not found the transaction is not retried on the other nodes
+	isNotFound := func(err error) bool {
+		return status.Code(err) == codes.NotFound
+	}
+	resp, execNode, err := b.queryStrategy.Query(ctx, "GetTransactionResult", execNodes, call, isNotFound)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.ErrorOrNil()
+	b.log.Debug().
+		Str("execution_node", execNode.String()).
+		Hex("block_id", req.GetBlockId()).
+		Hex("transaction_id", req.GetTransactionId()).
+		Msg("Successfully got transaction result")
+	return resp.(*execproto.GetTransactionResultResponse), nil
 }
 
 func (b *backendTransactions) tryGetTransactionResult(ctx context.Context, execNode *flow.Identity, req execproto.GetTransactionResultRequest) (*execproto.GetTransactionResultResponse, error) {
@@ -5,29 +5,209 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
 // the default timeout used when making a GRPC request to a collection node or an execution node
 const defaultClientTimeout = 3 * time.Second
 
+// the default time after which an unused cached connection is closed
+const defaultConnectionIdleTimeout = 5 * time.Minute
+
+// the default time for which requests to a node are rejected once its circuit breaker opens
+const defaultCircuitBreakerTimeout = 10 * time.Second
+
 // ConnectionFactory is used to create an access api client
 type ConnectionFactory interface {
 	GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error)
 	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
+	GetExecutionDryRunAPIClient(address string) (dryrunproto.ExecutionDryRunAPIClient, io.Closer, error)
 }
 
+// ConnectionFactoryImpl creates API clients for collection and execution nodes.
+//
+// Connections are cached by address and shared between all clients for the
+// same node. The closer returned with a client only releases the connection;
+// it is closed once it has not been used for the idle timeout, or when it is
+// found to be unhealthy. Nodes failing the configured number of consecutive
+// requests are not contacted until the circuit breaker timeout passes.
 type ConnectionFactoryImpl struct {
 	CollectionGRPCPort        uint
 	ExecutionGRPCPort         uint
 	CollectionNodeGRPCTimeout time.Duration
 	ExecutionNodeGRPCTimeout  time.Duration
+	ConnectionIdleTimeout     time.Duration // zero uses the default idle timeout
+	CircuitBreakerThreshold   uint          // zero disables the circuit breaker
+	CircuitBreakerTimeout     time.Duration // zero uses the default circuit breaker timeout
+	Metrics                   module.ConnectionPoolMetrics
+
+	mu          sync.Mutex
+	connections map[string]*cachedConnection
+	breakers    map[string]*circuitBreaker
+}
+
+// cachedConnection is a connection shared by all clients for the same node.
+type cachedConnection struct {
+	conn     *grpc.ClientConn
+	users    uint
+	lastUsed time.Time
+	evicted  bool
+}
+
+// circuitBreaker tracks the consecutive failed requests to a node.
+type circuitBreaker struct {
+	failures  uint
+	openUntil time.Time
+}
+
+// connectionCloser releases a cached connection when its client is closed.
+type connectionCloser struct {
+	once    sync.Once
+	release func()
+}
+
+func (c *connectionCloser) Close() error {
+	c.once.Do(c.release)
+	return nil
+}
+
+// retrieveConnection returns the cached connection to the given address, or
+// creates a new one, along with a closer to release it.
+func (cf *ConnectionFactoryImpl) retrieveConnection(address string, timeout time.Duration) (*grpc.ClientConn, io.Closer, error) {
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	if cf.connections == nil {
+		cf.connections = make(map[string]*cachedConnection)
+		cf.breakers = make(map[string]*circuitBreaker)
+	}
+	if cf.Metrics == nil {
+		cf.Metrics = metrics.NewNoopCollector()
+	}
+
+	now := time.Now()
+	cf.evictIdleConnections(now)
+
+	breaker, ok := cf.breakers[address]
+	if ok && cf.CircuitBreakerThreshold > 0 && breaker.failures >= cf.CircuitBreakerThreshold && now.Before(breaker.openUntil) {
+		cf.Metrics.ConnectionRejected()
+		return nil, nil, status.Errorf(codes.Unavailable, "circuit breaker for %s is open after %d failed requests", address, breaker.failures)
+	}
+
+	cached, ok := cf.connections[address]
+	if ok {
+		state := cached.conn.GetState()
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			cf.evictConnection(address, cached)
+			ok = false
+		}
+	}
+
+	if ok {
+		cf.Metrics.ConnectionFromPoolReused()
+	} else {
+		conn, err := cf.createConnection(address, timeout)
+		if err != nil {
+			return nil, nil, err
+		}
+		cached = &cachedConnection{conn: conn}
+		cf.connections[address] = cached
+		cf.Metrics.NewConnectionEstablished()
+		cf.Metrics.TotalConnectionsInPool(uint(len(cf.connections)))
+	}
+
+	cached.users++
+	cached.lastUsed = now
+	closer := &connectionCloser{
+		release: func() {
+			cf.mu.Lock()
+			defer cf.mu.Unlock()
+			cached.users--
+			cached.lastUsed = time.Now()
+			if cached.evicted && cached.users == 0 {
+				_ = cached.conn.Close()
+			}
+		},
+	}
+
+	return cached.conn, closer, nil
+}
+
+// evictIdleConnections closes all unused connections which have been idle for
+// longer than the idle timeout. Must be called with the lock held.
+func (cf *ConnectionFactoryImpl) evictIdleConnections(now time.Time) {
+	idleTimeout := cf.ConnectionIdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = defaultConnectionIdleTimeout
+	}
+	for address, cached := range cf.connections {
+		if cached.users == 0 && now.Sub(cached.lastUsed) > idleTimeout {
+			cf.evictConnection(address, cached)
+		}
+	}
+}
+
+// evictConnection removes the connection from the cache and closes it once it
+// is no longer used. Must be called with the lock held.
+func (cf *ConnectionFactoryImpl) evictConnection(address string, cached *cachedConnection) {
+	delete(cf.connections, address)
+	cf.Metrics.ConnectionFromPoolEvicted()
+	cf.Metrics.TotalConnectionsInPool(uint(len(cf.connections)))
+
+	// clients still holding the connection keep using it, and the last one to
+	// release it closes it
+	cached.evicted = true
+	if cached.users == 0 {
+		_ = cached.conn.Close()
+	}
+}
+
+// recordResult updates the circuit breaker of the node at the given address
+// with the result of a request.
+func (cf *ConnectionFactoryImpl) recordResult(address string, err error) {
+	if cf.CircuitBreakerThreshold == 0 {
+		return
+	}
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	breaker, ok := cf.breakers[address]
+	if !ok {
+		breaker = &circuitBreaker{}
+		cf.breakers[address] = breaker
+	}
+
+	// only errors indicating the node could not be reached or did not respond
+	// in time count as failures
+	code := status.Code(err)
+	if code != codes.Unavailable && code != codes.DeadlineExceeded {
+		breaker.failures = 0
+		return
+	}
+
+	breaker.failures++
+	if breaker.failures >= cf.CircuitBreakerThreshold {
+		timeout := cf.CircuitBreakerTimeout
+		if timeout == 0 {
+			timeout = defaultCircuitBreakerTimeout
+		}
+		breaker.openUntil = time.Now().Add(timeout)
+	}
 }
 
 // createConnection creates new gRPC connections to remote node
@@ -41,25 +221,45 @@ func (cf *ConnectionFactoryImpl) createConnection(address string, timeout time.D
 		address,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
 		grpc.WithInsecure(),
-		WithClientUnaryInterceptor(timeout))
+		grpc.WithChainUnaryInterceptor(
+			cf.circuitBreakerInterceptor(address),
+			clientTimeoutInterceptor(timeout),
+		))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to address %s: %w", address, err)
 	}
 	return conn, nil
 }
 
+// circuitBreakerInterceptor returns an interceptor which records the
+// results of all requests to the node at the given address.
+func (cf *ConnectionFactoryImpl) circuitBreakerInterceptor(address string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req interface{},
+		reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		cf.recordResult(address, err)
+		return err
+	}
+}
+
 func (cf *ConnectionFactoryImpl) GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error) {
 
 	grpcAddress, err := getGRPCAddress(address, cf.CollectionGRPCPort)
 	if err != nil {
 		return nil, nil, err
 	}
-	conn, err := cf.createConnection(grpcAddress, cf.CollectionNodeGRPCTimeout)
+	conn, closer, err := cf.retrieveConnection(grpcAddress, cf.CollectionNodeGRPCTimeout)
 	if err != nil {
 		return nil, nil, err
 	}
 	accessAPIClient := access.NewAccessAPIClient(conn)
-	closer := io.Closer(conn)
 	return accessAPIClient, closer, nil
 }
 
@@ -70,15 +270,29 @@ func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (executio
 		return nil, nil, err
 	}
 
-	conn, err := cf.createConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	conn, closer, err := cf.retrieveConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
 	if err != nil {
 		return nil, nil, err
 	}
 	executionAPIClient := execution.NewExecutionAPIClient(conn)
-	closer := io.Closer(conn)
 	return executionAPIClient, closer, nil
 }
 
+func (cf *ConnectionFactoryImpl) GetExecutionDryRunAPIClient(address string) (dryrunproto.ExecutionDryRunAPIClient, io.Closer, error) {
+
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, closer, err := cf.retrieveConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	dryRunAPIClient := dryrunproto.NewExecutionDryRunAPIClient(conn)
+	return dryRunAPIClient, closer, nil
+}
+
 // getExecutionNodeAddress translates flow.Identity address to the GRPC address of the node by switching the port to the
 // GRPC port from the libp2p port
 func getGRPCAddress(address string, grpcPort uint) (string, error) {
@@ -94,8 +308,14 @@ func getGRPCAddress(address string, grpcPort uint) (string, error) {
 }
 
 func WithClientUnaryInterceptor(timeout time.Duration) grpc.DialOption {
+	return grpc.WithUnaryInterceptor(clientTimeoutInterceptor(timeout))
+}
 
-	clientTimeoutInterceptor := func(
+// clientTimeoutInterceptor returns an interceptor which limits the duration of
+// each request to the given timeout.
+func clientTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+
+	return func(
 		ctx context.Context,
 		method string,
 		req interface{},
@@ -115,6 +335,4 @@ func WithClientUnaryInterceptor(timeout time.Duration) grpc.DialOption {
 
 		return err
 	}
-
-	return grpc.WithUnaryInterceptor(clientTimeoutInterceptor)
 }
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/engine/access/mock"
+	modulemock "github.com/onflow/flow-go/module/mock"
 )
 
 // TestExecutionNodeClientTimeout tests that the execution API client times out after the timeout duration
@@ -89,6 +90,111 @@ func TestCollectionNodeClientTimeout(t *testing.T) {
 	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
 }
 
+// TestConnectionPoolReuse tests that connections to the same node are shared by its clients
+func TestConnectionPoolReuse(t *testing.T) {
+
+	// create an execution node
+	en := new(executionNode)
+	en.start(t)
+	defer en.stop(t)
+
+	req := &execution.PingRequest{}
+	resp := &execution.PingResponse{}
+	en.handler.On("Ping", testifymock.Anything, req).Return(resp, nil)
+
+	// the first client creates a connection, which the second one reuses
+	poolMetrics := new(modulemock.ConnectionPoolMetrics)
+	poolMetrics.On("NewConnectionEstablished").Once()
+	poolMetrics.On("TotalConnectionsInPool", uint(1)).Once()
+	poolMetrics.On("ConnectionFromPoolReused").Once()
+
+	connectionFactory := new(ConnectionFactoryImpl)
+	connectionFactory.ExecutionGRPCPort = en.port
+	connectionFactory.Metrics = poolMetrics
+
+	for i := 0; i < 2; i++ {
+		client, closer, err := connectionFactory.GetExecutionAPIClient(en.listener.Addr().String())
+		assert.NoError(t, err)
+		_, err = client.Ping(context.Background(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, closer.Close())
+	}
+
+	poolMetrics.AssertExpectations(t)
+}
+
+// TestConnectionPoolIdleEviction tests that connections are closed once they have been idle for the idle timeout
+func TestConnectionPoolIdleEviction(t *testing.T) {
+
+	// create an execution node
+	en := new(executionNode)
+	en.start(t)
+	defer en.stop(t)
+
+	poolMetrics := new(modulemock.ConnectionPoolMetrics)
+	poolMetrics.On("NewConnectionEstablished").Twice()
+	poolMetrics.On("ConnectionFromPoolEvicted").Once()
+	poolMetrics.On("TotalConnectionsInPool", testifymock.Anything)
+
+	connectionFactory := new(ConnectionFactoryImpl)
+	connectionFactory.ExecutionGRPCPort = en.port
+	connectionFactory.ConnectionIdleTimeout = 10 * time.Millisecond
+	connectionFactory.Metrics = poolMetrics
+
+	_, closer, err := connectionFactory.GetExecutionAPIClient(en.listener.Addr().String())
+	assert.NoError(t, err)
+	assert.NoError(t, closer.Close())
+
+	// the idle connection is replaced by a new one
+	time.Sleep(20 * time.Millisecond)
+	_, closer, err = connectionFactory.GetExecutionAPIClient(en.listener.Addr().String())
+	assert.NoError(t, err)
+	assert.NoError(t, closer.Close())
+
+	assert.Len(t, connectionFactory.connections, 1)
+	poolMetrics.AssertExpectations(t)
+}
+
+// TestCircuitBreaker tests that a node is not contacted anymore after failing the threshold number of requests
+func TestCircuitBreaker(t *testing.T) {
+
+	// create an execution node
+	en := new(executionNode)
+	en.start(t)
+	defer en.stop(t)
+
+	req := &execution.PingRequest{}
+	en.handler.On("Ping", testifymock.Anything, req).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+
+	poolMetrics := new(modulemock.ConnectionPoolMetrics)
+	poolMetrics.On("NewConnectionEstablished").Once()
+	poolMetrics.On("TotalConnectionsInPool", uint(1)).Once()
+	poolMetrics.On("ConnectionFromPoolReused").Once()
+	poolMetrics.On("ConnectionRejected").Once()
+
+	connectionFactory := new(ConnectionFactoryImpl)
+	connectionFactory.ExecutionGRPCPort = en.port
+	connectionFactory.CircuitBreakerThreshold = 2
+	connectionFactory.CircuitBreakerTimeout = time.Minute
+	connectionFactory.Metrics = poolMetrics
+
+	// the node fails the threshold number of requests
+	for i := 0; i < 2; i++ {
+		client, closer, err := connectionFactory.GetExecutionAPIClient(en.listener.Addr().String())
+		assert.NoError(t, err)
+		_, err = client.Ping(context.Background(), req)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.NoError(t, closer.Close())
+	}
+
+	// further requests are rejected without contacting the node
+	_, _, err := connectionFactory.GetExecutionAPIClient(en.listener.Addr().String())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	en.handler.AssertNumberOfCalls(t, "Ping", 2)
+
+	poolMetrics.AssertExpectations(t)
+}
+
 // node mocks a flow node that runs a GRPC server
 type node struct {
 	server   *grpc.Server
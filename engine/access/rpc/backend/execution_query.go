@@ -0,0 +1,275 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// ExecutionQueryMode defines how a request is sent to the execution nodes
+// which have executed the requested block.
+type ExecutionQueryMode string
+
+const (
+	// ExecutionQueryFirst sends the request to one execution node after the
+	// other and returns the first successful response.
+	ExecutionQueryFirst ExecutionQueryMode = "first"
+
+	// ExecutionQueryCompare sends the request to all execution nodes in
+	// parallel and returns the response of the most nodes. Diverging responses
+	// are reported, but do not fail the request.
+	ExecutionQueryCompare ExecutionQueryMode = "compare"
+
+	// ExecutionQueryMatch sends the request to all execution nodes in parallel
+	// and fails the request if their responses diverge.
+	ExecutionQueryMatch ExecutionQueryMode = "match"
+)
+
+// ParseExecutionQueryMode parses the name of an execution query mode.
+func ParseExecutionQueryMode(name string) (ExecutionQueryMode, error) {
+	mode := ExecutionQueryMode(name)
+	switch mode {
+	case ExecutionQueryFirst, ExecutionQueryCompare, ExecutionQueryMatch:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid execution query mode: %s", name)
+	}
+}
+
+// the weight of the latest measurement in the moving average of the latency
+const latencyWeight = 0.2
+
+// ExecutionQueryStrategy sends requests to execution nodes according to the
+// configured mode. If enabled, execution nodes are queried in the order of
+// their measured latency, so in the default mode the fastest node answers.
+type ExecutionQueryStrategy struct {
+	mode           ExecutionQueryMode
+	preferFastest  bool
+	failureLatency time.Duration // latency recorded for failed requests
+	metrics        module.ExecutionQueryMetrics
+	log            zerolog.Logger
+
+	mu        sync.Mutex
+	latencies map[flow.Identifier]time.Duration // moving average of the latency of each execution node
+}
+
+// executionCall sends a request to the given execution node.
+type executionCall func(ctx context.Context, execNode *flow.Identity) (proto.Message, error)
+
+// NewExecutionQueryStrategy creates a new strategy to query execution nodes.
+// Failed requests are recorded with the given failure latency, which should be
+// the client timeout, so that failing nodes are queried after working ones.
+// Zero uses the default client timeout.
+func NewExecutionQueryStrategy(
+	mode ExecutionQueryMode,
+	preferFastest bool,
+	failureLatency time.Duration,
+	metrics module.ExecutionQueryMetrics,
+	log zerolog.Logger,
+) *ExecutionQueryStrategy {
+	if failureLatency == 0 {
+		failureLatency = defaultClientTimeout
+	}
+	return &ExecutionQueryStrategy{
+		mode:           mode,
+		preferFastest:  preferFastest,
+		failureLatency: failureLatency,
+		metrics:        metrics,
+		log:            log.With().Str("component", "execution_query").Logger(),
+		latencies:      make(map[flow.Identifier]time.Duration),
+	}
+}
+
+// Query sends a request to the given execution nodes and returns the selected
+// response, along with the node which returned it. Errors for which isFinal
+// returns true are returned as is, without trying the remaining nodes. If all
+// nodes fail, all errors are returned as a multierror.
+func (q *ExecutionQueryStrategy) Query(
+	ctx context.Context,
+	method string,
+	execNodes flow.IdentityList,
+	call executionCall,
+	isFinal func(error) bool,
+) (proto.Message, *flow.Identity, error) {
+
+	execNodes = q.order(execNodes)
+
+	if q.mode == ExecutionQueryFirst || len(execNodes) == 1 {
+		return q.queryFirst(ctx, execNodes, call, isFinal)
+	}
+
+	return q.queryAll(ctx, method, execNodes, call, isFinal)
+}
+
+// queryFirst tries one node after the other and returns the first response.
+func (q *ExecutionQueryStrategy) queryFirst(
+	ctx context.Context,
+	execNodes flow.IdentityList,
+	call executionCall,
+	isFinal func(error) bool,
+) (proto.Message, *flow.Identity, error) {
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		resp, err := q.timedCall(ctx, execNode, call, isFinal)
+		if err == nil {
+			return resp, execNode, nil
+		}
+		if isFinal != nil && isFinal(err) {
+			return nil, nil, err
+		}
+		errors = multierror.Append(errors, err)
+	}
+	return nil, nil, errors.ErrorOrNil()
+}
+
+// queryAll queries all nodes in parallel and compares their responses.
+func (q *ExecutionQueryStrategy) queryAll(
+	ctx context.Context,
+	method string,
+	execNodes flow.IdentityList,
+	call executionCall,
+	isFinal func(error) bool,
+) (proto.Message, *flow.Identity, error) {
+
+	responses := make([]proto.Message, len(execNodes))
+	errs := make([]error, len(execNodes))
+	var wg sync.WaitGroup
+	for i, execNode := range execNodes {
+		wg.Add(1)
+		go func(i int, execNode *flow.Identity) {
+			defer wg.Done()
+			responses[i], errs[i] = q.timedCall(ctx, execNode, call, isFinal)
+		}(i, execNode)
+	}
+	wg.Wait()
+
+	// group the nodes by identical responses, keeping the order of preference
+	type group struct {
+		resp  proto.Message
+		nodes flow.IdentityList
+	}
+	var groups []*group
+	var errors *multierror.Error
+	var finalErr error
+	for i, execNode := range execNodes {
+		if errs[i] != nil {
+			if isFinal != nil && isFinal(errs[i]) && finalErr == nil {
+				finalErr = errs[i]
+			}
+			errors = multierror.Append(errors, errs[i])
+			continue
+		}
+		found := false
+		for _, g := range groups {
+			if proto.Equal(g.resp, responses[i]) {
+				g.nodes = append(g.nodes, execNode)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, &group{resp: responses[i], nodes: flow.IdentityList{execNode}})
+		}
+	}
+
+	if len(groups) == 0 {
+		if finalErr != nil {
+			return nil, nil, finalErr
+		}
+		return nil, nil, errors.ErrorOrNil()
+	}
+
+	if len(groups) > 1 {
+		q.metrics.ExecutionResultMismatch(method)
+		log := q.log.Warn().Str("method", method)
+		for i, g := range groups {
+			log = log.Strs(fmt.Sprintf("result_%d_execution_nodes", i), nodeIDStrings(g.nodes))
+		}
+		log.Msg("execution nodes returned diverging results")
+
+		if q.mode == ExecutionQueryMatch {
+			return nil, nil, status.Errorf(codes.Internal, "execution nodes returned diverging results for %s", method)
+		}
+	}
+
+	// select the response returned by the most nodes; for equally many nodes,
+	// the response of the preferred node wins
+	selected := groups[0]
+	for _, g := range groups[1:] {
+		if len(g.nodes) > len(selected.nodes) {
+			selected = g
+		}
+	}
+
+	return selected.resp, selected.nodes[0], nil
+}
+
+// timedCall sends the request to the node and records its latency. Final
+// errors are responses of the node, so their latency is recorded as is. For
+// other errors, the failure latency is recorded, unless the request was
+// cancelled by the caller.
+func (q *ExecutionQueryStrategy) timedCall(
+	ctx context.Context,
+	execNode *flow.Identity,
+	call executionCall,
+	isFinal func(error) bool,
+) (proto.Message, error) {
+	start := time.Now()
+	resp, err := call(ctx, execNode)
+	switch {
+	case err == nil || (isFinal != nil && isFinal(err)):
+		q.recordLatency(execNode.NodeID, time.Since(start))
+	case ctx.Err() == nil:
+		q.recordLatency(execNode.NodeID, q.failureLatency)
+	}
+	return resp, err
+}
+
+func (q *ExecutionQueryStrategy) recordLatency(nodeID flow.Identifier, latency time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	average, ok := q.latencies[nodeID]
+	if !ok {
+		q.latencies[nodeID] = latency
+		return
+	}
+	q.latencies[nodeID] = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(average))
+}
+
+// order returns the execution nodes in the order they should be queried. Nodes
+// without measurements are tried first, so that every node gets measured.
+func (q *ExecutionQueryStrategy) order(execNodes flow.IdentityList) flow.IdentityList {
+	if !q.preferFastest {
+		return execNodes
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ordered := execNodes.Copy()
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return q.latencies[ordered[i].NodeID] < q.latencies[ordered[j].NodeID]
+	})
+	return ordered
+}
+
+func nodeIDStrings(nodes flow.IdentityList) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.NodeID.String())
+	}
+	return ids
+}
@@ -0,0 +1,176 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestExecutionQueryFirst tests that in the default mode, execution nodes are
+// queried one after the other until one of them responds.
+func TestExecutionQueryFirst(t *testing.T) {
+	execNodes := unittest.IdentityListFixture(3)
+	responses := map[flow.Identifier]proto.Message{
+		execNodes[1].NodeID: scriptResponse("first"),
+		execNodes[2].NodeID: scriptResponse("second"),
+	}
+	queried, call := queryCall(responses)
+
+	strategy := NewExecutionQueryStrategy(ExecutionQueryFirst, false, time.Second, metrics.NewNoopCollector(), zerolog.Nop())
+	resp, execNode, err := strategy.Query(context.Background(), "test", execNodes, call, nil)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(scriptResponse("first"), resp))
+	assert.Equal(t, execNodes[1], execNode)
+	assert.Equal(t, []flow.Identifier{execNodes[0].NodeID, execNodes[1].NodeID}, *queried)
+
+	// if all nodes fail, all errors are returned
+	_, _, err = strategy.Query(context.Background(), "test", execNodes[:1], call, nil)
+	require.Error(t, err)
+
+	// final errors are returned without trying the other nodes
+	isFinal := func(err error) bool { return status.Code(err) == codes.NotFound }
+	*queried = nil
+	_, _, err = strategy.Query(context.Background(), "test", execNodes, call, isFinal)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Len(t, *queried, 1)
+}
+
+// TestExecutionQueryCompare tests that in compare mode, the response of the
+// most execution nodes is returned and diverging responses are reported.
+func TestExecutionQueryCompare(t *testing.T) {
+	execNodes := unittest.IdentityListFixture(4)
+	responses := map[flow.Identifier]proto.Message{
+		execNodes[0].NodeID: scriptResponse("minority"),
+		execNodes[1].NodeID: scriptResponse("majority"),
+		execNodes[2].NodeID: scriptResponse("majority"),
+	}
+	_, call := queryCall(responses)
+
+	collector := new(modulemock.ExecutionQueryMetrics)
+	collector.On("ExecutionResultMismatch", "test").Once()
+
+	strategy := NewExecutionQueryStrategy(ExecutionQueryCompare, false, time.Second, collector, zerolog.Nop())
+	resp, execNode, err := strategy.Query(context.Background(), "test", execNodes, call, nil)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(scriptResponse("majority"), resp))
+	assert.Equal(t, execNodes[1], execNode)
+	collector.AssertExpectations(t)
+
+	// matching responses are not reported
+	resp, _, err = strategy.Query(context.Background(), "test", execNodes[1:], call, nil)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(scriptResponse("majority"), resp))
+	collector.AssertExpectations(t)
+}
+
+// TestExecutionQueryMatch tests that in match mode, diverging responses fail
+// the request.
+func TestExecutionQueryMatch(t *testing.T) {
+	execNodes := unittest.IdentityListFixture(3)
+	responses := map[flow.Identifier]proto.Message{
+		execNodes[0].NodeID: scriptResponse("one"),
+		execNodes[1].NodeID: scriptResponse("one"),
+		execNodes[2].NodeID: scriptResponse("two"),
+	}
+	_, call := queryCall(responses)
+
+	collector := new(modulemock.ExecutionQueryMetrics)
+	collector.On("ExecutionResultMismatch", "test").Once()
+
+	strategy := NewExecutionQueryStrategy(ExecutionQueryMatch, false, time.Second, collector, zerolog.Nop())
+	_, _, err := strategy.Query(context.Background(), "test", execNodes, call, nil)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	collector.AssertExpectations(t)
+
+	resp, _, err := strategy.Query(context.Background(), "test", execNodes[:2], call, nil)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(scriptResponse("one"), resp))
+}
+
+// TestExecutionQueryPreferFastest tests that execution nodes are queried in the
+// order of their measured latency.
+func TestExecutionQueryPreferFastest(t *testing.T) {
+	execNodes := unittest.IdentityListFixture(2)
+
+	strategy := NewExecutionQueryStrategy(ExecutionQueryFirst, true, time.Second, metrics.NewNoopCollector(), zerolog.Nop())
+	strategy.recordLatency(execNodes[0].NodeID, time.Second)
+	strategy.recordLatency(execNodes[1].NodeID, time.Millisecond)
+
+	ordered := strategy.order(execNodes)
+	assert.Equal(t, flow.IdentityList{execNodes[1], execNodes[0]}, ordered)
+
+	// nodes without measurements are tried first
+	unknown := unittest.IdentityFixture()
+	ordered = strategy.order(append(execNodes, unknown))
+	assert.Equal(t, flow.IdentityList{unknown, execNodes[1], execNodes[0]}, ordered)
+}
+
+// TestExecutionQueryPreferFastest_Failures tests that a node which fails is
+// queried after nodes which respond, even if they are slower.
+func TestExecutionQueryPreferFastest_Failures(t *testing.T) {
+	execNodes := unittest.IdentityListFixture(2)
+
+	var mu sync.Mutex
+	var queried []flow.Identifier
+	call := func(_ context.Context, execNode *flow.Identity) (proto.Message, error) {
+		mu.Lock()
+		queried = append(queried, execNode.NodeID)
+		mu.Unlock()
+		if execNode.NodeID == execNodes[0].NodeID {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return scriptResponse("slow"), nil
+	}
+
+	strategy := NewExecutionQueryStrategy(ExecutionQueryFirst, true, time.Second, metrics.NewNoopCollector(), zerolog.Nop())
+
+	// without measurements, the failing node is queried first
+	_, execNode, err := strategy.Query(context.Background(), "ExecuteScriptAtBlockID", execNodes, call, nil)
+	require.NoError(t, err)
+	assert.Equal(t, execNodes[1], execNode)
+	assert.Equal(t, []flow.Identifier{execNodes[0].NodeID, execNodes[1].NodeID}, queried)
+
+	// afterwards, the slower working node is queried first
+	queried = nil
+	_, execNode, err = strategy.Query(context.Background(), "ExecuteScriptAtBlockID", execNodes, call, nil)
+	require.NoError(t, err)
+	assert.Equal(t, execNodes[1], execNode)
+	assert.Equal(t, []flow.Identifier{execNodes[1].NodeID}, queried)
+}
+
+func scriptResponse(value string) *execproto.ExecuteScriptAtBlockIDResponse {
+	return &execproto.ExecuteScriptAtBlockIDResponse{Value: []byte(value)}
+}
+
+// queryCall returns a call which responds with the response of each node, or
+// a not found error for nodes without a response, and the nodes queried so far.
+func queryCall(responses map[flow.Identifier]proto.Message) (*[]flow.Identifier, executionCall) {
+	queried := &[]flow.Identifier{}
+	var mu sync.Mutex
+	call := func(_ context.Context, execNode *flow.Identity) (proto.Message, error) {
+		mu.Lock()
+		*queried = append(*queried, execNode.NodeID)
+		mu.Unlock()
+		resp, ok := responses[execNode.NodeID]
+		if !ok {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		return resp, nil
+	}
+	return queried, call
+}
@@ -10,6 +10,8 @@ import (
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
+
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 )
 
 // ConnectionFactory is an autogenerated mock type for the ConnectionFactory type
@@ -80,3 +82,35 @@ func (_m *ConnectionFactory) GetExecutionAPIClient(address string) (execution.Ex
 
 	return r0, r1, r2
 }
+
+// GetExecutionDryRunAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetExecutionDryRunAPIClient(address string) (dryrunproto.ExecutionDryRunAPIClient, io.Closer, error) {
+	ret := _m.Called(address)
+
+	var r0 dryrunproto.ExecutionDryRunAPIClient
+	if rf, ok := ret.Get(0).(func(string) dryrunproto.ExecutionDryRunAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dryrunproto.ExecutionDryRunAPIClient)
+		}
+	}
+
+	var r1 io.Closer
+	if rf, ok := ret.Get(1).(func(string) io.Closer); ok {
+		r1 = rf(address)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Closer)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,36 @@
+package backend
+
+import (
+	"sync"
+)
+
+// blockNotifier wakes up streaming subscriptions waiting for new blocks. It
+// does not carry any data; woken up subscriptions check the protocol state
+// for the blocks they are interested in.
+type blockNotifier struct {
+	mu      sync.Mutex
+	updated chan struct{}
+}
+
+func newBlockNotifier() *blockNotifier {
+	return &blockNotifier{
+		updated: make(chan struct{}),
+	}
+}
+
+// Notify wakes up all subscriptions currently waiting for new blocks.
+func (n *blockNotifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.updated)
+	n.updated = make(chan struct{})
+}
+
+// Updated returns a channel which is closed upon the next notification.
+// Subscriptions should get the channel before checking the protocol state,
+// so that they don't miss blocks added in between.
+func (n *blockNotifier) Updated() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.updated
+}
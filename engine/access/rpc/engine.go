@@ -20,6 +20,8 @@ import (
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	streamproto "github.com/onflow/flow-go/engine/access/rpc/protobuf"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
@@ -37,6 +39,12 @@ type Config struct {
 	MaxMsgSize              int           // GRPC max message size
 	ExecutionClientTimeout  time.Duration // execution API GRPC client timeout
 	CollectionClientTimeout time.Duration // collection API GRPC client timeout
+	ConnectionIdleTimeout   time.Duration // time after which an unused connection to an upstream node is closed
+	CircuitBreakerThreshold uint          // number of consecutive failed requests after which an upstream node is skipped
+	CircuitBreakerTimeout   time.Duration // time for which a failing upstream node is skipped
+
+	ExecutionQueryMode          backend.ExecutionQueryMode // how requests are sent to the execution nodes of a block
+	PreferFastestExecutionNodes bool                       // whether execution nodes are queried in the order of their latency
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
@@ -63,6 +71,8 @@ func New(log zerolog.Logger,
 	executionReceipts storage.ExecutionReceipts,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
+	connectionPoolMetrics module.ConnectionPoolMetrics,
+	executionQueryMetrics module.ExecutionQueryMetrics,
 	collectionGRPCPort uint,
 	executionGRPCPort uint,
 	retryEnabled bool,
@@ -98,7 +108,22 @@ func New(log zerolog.Logger,
 		ExecutionGRPCPort:         executionGRPCPort,
 		CollectionNodeGRPCTimeout: time.Duration(config.CollectionClientTimeout),
 		ExecutionNodeGRPCTimeout:  time.Duration(config.ExecutionClientTimeout),
+		ConnectionIdleTimeout:     config.ConnectionIdleTimeout,
+		CircuitBreakerThreshold:   config.CircuitBreakerThreshold,
+		CircuitBreakerTimeout:     config.CircuitBreakerTimeout,
+		Metrics:                   connectionPoolMetrics,
+	}
+
+	if config.ExecutionQueryMode == "" {
+		config.ExecutionQueryMode = backend.ExecutionQueryFirst
 	}
+	queryStrategy := backend.NewExecutionQueryStrategy(
+		config.ExecutionQueryMode,
+		config.PreferFastestExecutionNodes,
+		config.ExecutionClientTimeout,
+		executionQueryMetrics,
+		log,
+	)
 
 	backend := backend.New(
 		state,
@@ -114,6 +139,7 @@ func New(log zerolog.Logger,
 		transactionMetrics,
 		connectionFactory,
 		retryEnabled,
+		queryStrategy,
 		log,
 	)
 
@@ -131,6 +157,16 @@ func New(log zerolog.Logger,
 		access.NewHandler(backend, chainID.Chain()),
 	)
 
+	streamproto.RegisterAccessStreamAPIServer(
+		eng.grpcServer,
+		access.NewStreamHandler(backend, chainID.Chain()),
+	)
+
+	dryrunproto.RegisterAccessDryRunAPIServer(
+		eng.grpcServer,
+		access.NewDryRunHandler(backend, chainID.Chain()),
+	)
+
 	if rpcMetricsEnabled {
 		// Not interested in legacy metrics, so initialize here
 		grpc_prometheus.EnableHandlingTimeHistogram()
@@ -0,0 +1,11 @@
+package wrapper
+
+import (
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+)
+
+// ExecutionDryRunAPIClient allows for generation of a mock (via mockery) for the ExecutionDryRunAPIClient generated
+// from the execution node dry-run protobuf definitions
+type ExecutionDryRunAPIClient interface {
+	dryrunproto.ExecutionDryRunAPIClient
+}
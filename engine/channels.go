@@ -14,11 +14,15 @@ import (
 // It creates and initializes the channelRoleMap map.
 func init() {
 	initializeChannelRoleMap()
+	initializeChannelSenderRoleMap()
 }
 
 // channelRoleMap keeps a map between channels and the list of flow roles involved in them.
 var channelRoleMap map[network.Channel]flow.RoleList
 
+// channelSenderRoleMap keeps a map between channels and the list of flow roles which send messages on them.
+var channelSenderRoleMap map[network.Channel]flow.RoleList
+
 // RolesByChannel returns list of flow roles involved in the channel.
 func RolesByChannel(channel network.Channel) (flow.RoleList, bool) {
 	if clusterChannel, isCluster := ClusterChannel(channel); isCluster {
@@ -29,6 +33,18 @@ func RolesByChannel(channel network.Channel) (flow.RoleList, bool) {
 	return roles, ok
 }
 
+// SenderRolesByChannel returns list of flow roles which send messages on the channel.
+// They differ from the roles involved in the channel, e.g. nodes of all roles send
+// requests on the sync and request channels, which only some roles subscribe to.
+func SenderRolesByChannel(channel network.Channel) (flow.RoleList, bool) {
+	if clusterChannel, isCluster := ClusterChannel(channel); isCluster {
+		// replaces channel with the stripped-off prefix
+		channel = clusterChannel
+	}
+	roles, ok := channelSenderRoleMap[channel]
+	return roles, ok
+}
+
 // Exists returns true if channel exists in channelRoleMap.
 // At the current state, any developer-defined channel should be added
 // to channelRoleMap as a constant channel type manually.
@@ -130,6 +146,9 @@ const (
 	ProvideChunks            = RequestChunks
 	ProvideReceiptsByBlockID = RequestReceiptsByBlockID
 	ProvideApprovalsByChunk  = RequestApprovalsByChunk
+
+	// Channel for running the distributed key generation between consensus nodes
+	DKGCommittee = network.Channel("dkg-committee")
 )
 
 // initializeChannelRoleMap initializes an instance of channelRoleMap and populates it with the channels and their
@@ -179,10 +198,53 @@ func initializeChannelRoleMap() {
 	channelRoleMap[ProvideReceiptsByBlockID] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution}
 	channelRoleMap[ProvideApprovalsByChunk] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
 
+	channelRoleMap[DKGCommittee] = flow.RoleList{flow.RoleConsensus}
+
 	channelRoleMap[syncClusterPrefix] = flow.RoleList{flow.RoleCollection}
 	channelRoleMap[consensusClusterPrefix] = flow.RoleList{flow.RoleCollection}
 }
 
+// initializeChannelSenderRoleMap initializes an instance of channelSenderRoleMap and populates it with the channels
+// and the roles which send messages on them.
+// Note: Please update this map, if a new channel is defined or the roles sending on a channel have changed.
+func initializeChannelSenderRoleMap() {
+	channelSenderRoleMap = make(map[network.Channel]flow.RoleList)
+
+	allRoles := flow.RoleList{flow.RoleCollection, flow.RoleConsensus, flow.RoleExecution,
+		flow.RoleVerification, flow.RoleAccess}
+
+	// Channels for test
+	channelSenderRoleMap[TestNetwork] = allRoles
+	channelSenderRoleMap[TestMetrics] = allRoles
+
+	// Channels for consensus protocols
+	channelSenderRoleMap[ConsensusCommittee] = flow.RoleList{flow.RoleConsensus}
+
+	// Channels for protocols actively synchronizing state across nodes,
+	// nodes of all roles synchronize the protocol state with consensus nodes
+	channelSenderRoleMap[SyncCommittee] = allRoles
+	channelSenderRoleMap[SyncExecution] = flow.RoleList{flow.RoleExecution}
+
+	// Channels for actively pushing entities to subscribers
+	channelSenderRoleMap[PushTransactions] = flow.RoleList{flow.RoleCollection, flow.RoleAccess}
+	channelSenderRoleMap[PushGuarantees] = flow.RoleList{flow.RoleCollection, flow.RoleConsensus}
+	channelSenderRoleMap[PushBlocks] = flow.RoleList{flow.RoleConsensus}
+	channelSenderRoleMap[PushReceipts] = flow.RoleList{flow.RoleExecution}
+	channelSenderRoleMap[PushApprovals] = flow.RoleList{flow.RoleVerification}
+
+	// Channels for actively requesting missing entities, nodes of any role may
+	// request entities from the providers
+	channelSenderRoleMap[RequestCollections] = allRoles
+	channelSenderRoleMap[RequestChunks] = allRoles
+	channelSenderRoleMap[RequestReceiptsByBlockID] = allRoles
+	channelSenderRoleMap[RequestApprovalsByChunk] = allRoles
+
+	channelSenderRoleMap[DKGCommittee] = flow.RoleList{flow.RoleConsensus}
+
+	channelSenderRoleMap[syncClusterPrefix] = flow.RoleList{flow.RoleCollection}
+	channelSenderRoleMap[consensusClusterPrefix] = flow.RoleList{flow.RoleCollection}
+}
+
 // ClusterChannel returns true if channel is cluster-based.
 // At the current implementation, only collection nodes are involved in a cluster-based channels.
 // If the channel is a cluster-based one, this method also strips off the channel prefix and returns it.
@@ -44,6 +44,40 @@ func TestGetRolesByChannel_ClusterChannel(t *testing.T) {
 	assert.Contains(t, roles, flow.RoleCollection)
 }
 
+// TestGetSenderRolesByChannel evaluates correctness of SenderRolesByChannel function against
+// roles which send on channels they do not subscribe to, and against cluster channels.
+func TestGetSenderRolesByChannel(t *testing.T) {
+	// nodes of all roles synchronize blocks with consensus nodes
+	roles, ok := SenderRolesByChannel(SyncCommittee)
+	assert.True(t, ok)
+	assert.Len(t, roles, 5)
+
+	// access nodes request collections
+	roles, ok = SenderRolesByChannel(RequestCollections)
+	assert.True(t, ok)
+	assert.Contains(t, roles, flow.RoleAccess)
+
+	// only consensus nodes push blocks
+	roles, ok = SenderRolesByChannel(PushBlocks)
+	assert.True(t, ok)
+	assert.Equal(t, flow.RoleList{flow.RoleConsensus}, roles)
+
+	roles, ok = SenderRolesByChannel(ChannelSyncCluster("some-cluster-id"))
+	assert.True(t, ok)
+	assert.Equal(t, flow.RoleList{flow.RoleCollection}, roles)
+
+	// asserts a non-existing topic
+	roles, ok = SenderRolesByChannel("non-existing-topic")
+	assert.False(t, ok)
+	assert.Nil(t, roles)
+
+	// all channels have senders
+	for _, channel := range Channels() {
+		_, ok := SenderRolesByChannel(channel)
+		assert.True(t, ok, channel)
+	}
+}
+
 // TestGetChannelByRole evaluates retrieving channels associated with a role from the
 // channelRoleMap using ChannelsByRole. Essentially it evaluates that ChannelsByRole
 // operates on top of channelRoleMap.
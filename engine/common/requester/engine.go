@@ -397,35 +397,47 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 		return fmt.Errorf("could not get providers: %w", err)
 	}
 	if len(providers) == 0 {
+		e.con.ReportMisbehavior(originID, network.SeverityMedium, "entity response from invalid provider")
 		return engine.NewInvalidInputErrorf("invalid provider origin (%x)", originID)
 	}
 
 	// build a list of needed entities; if not available, process anyway,
-	// but in that case we can't re-queue missing items
+	// but in that case we can't re-queue missing items; the requested entities
+	// may have been received in another response in the meantime
 	needed := make(map[flow.Identifier]struct{})
-	req, exists := e.requests[res.Nonce]
-	if exists {
+	requested := make(map[flow.Identifier]struct{})
+	req, known := e.requests[res.Nonce]
+	if known {
 		delete(e.requests, req.Nonce)
 		for _, entityID := range req.EntityIDs {
 			needed[entityID] = struct{}{}
+			requested[entityID] = struct{}{}
 		}
 	}
 
 	// ensure the response is correctly formed
 	if len(res.Blobs) != len(res.EntityIDs) {
+		e.con.ReportMisbehavior(originID, network.SeverityHigh, "malformed entity response")
 		return engine.NewInvalidInputErrorf("invalid response with %d blobs, %d IDs", len(res.Blobs), len(res.EntityIDs))
 	}
 
-	// process each entity in the response
+	// process each entity in the response, collecting the misbehavior of the
+	// provider so that it is reported at most once per response
 	// NOTE: this requires engines to be somewhat idempotent, which is a good
 	// thing, as it increases the robustness of their code
+	var unsolicited, mismatched []flow.Identifier
 	for i := 0; i < len(res.Blobs); i++ {
 		blob := res.Blobs[i]
 		entityID := res.EntityIDs[i]
 
-		// the entity might already have been returned in another response
+		// the entity might already have been returned in another response, or it was never requested;
+		// without the request, which we forget about quickly, it can't be told apart from a late response
 		item, exists := e.items[entityID]
 		if !exists {
+			_, wasRequested := requested[entityID]
+			if known && !wasRequested {
+				unsolicited = append(unsolicited, entityID)
+			}
 			continue
 		}
 
@@ -433,6 +445,7 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 		entity := e.create()
 		err := msgpack.Unmarshal(blob, &entity)
 		if err != nil {
+			e.con.ReportMisbehavior(originID, network.SeverityHigh, fmt.Sprintf("undecodable entity (%x)", entityID))
 			return fmt.Errorf("could not decode entity: %w", err)
 		}
 
@@ -445,6 +458,7 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 					Hex("stated_entity_id", logging.ID(entityID)).
 					Hex("provided_entity", logging.ID(actualEntityID)).
 					Msg("provided entity does not match stated ID")
+				mismatched = append(mismatched, entityID)
 				continue
 			}
 		}
@@ -457,6 +471,14 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 		go e.handle(originID, entity)
 	}
 
+	if len(mismatched) > 0 {
+		e.con.ReportMisbehavior(originID, network.SeverityHigh,
+			fmt.Sprintf("%d entities do not match stated ID (first: %x)", len(mismatched), mismatched[0]))
+	} else if len(unsolicited) > 0 {
+		e.con.ReportMisbehavior(originID, network.SeverityLow,
+			fmt.Sprintf("%d unsolicited entities (first: %x)", len(unsolicited), unsolicited[0]))
+	}
+
 	// requeue requested entities that have not been delivered in the response
 	// NOTE: this logic allows a provider to send back an empty response to
 	// indicate that none of the requested entities are available, thus allowing
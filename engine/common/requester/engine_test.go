@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,7 +16,9 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/network/p2p"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -266,11 +269,16 @@ func TestOnEntityResponseValid(t *testing.T) {
 		EntityIDs: []flow.Identifier{wanted1.ID(), wanted2.ID(), unavailable.ID()},
 	}
 
+	// the unwanted entity should be reported as unsolicited
+	con := &mocknetwork.Conduit{}
+	con.On("ReportMisbehavior", targetID, network.SeverityLow, mock.Anything).Once()
+
 	called := 0
 	request := Engine{
 		unit:     engine.NewUnit(),
 		metrics:  metrics.NewNoopCollector(),
 		state:    state,
+		con:      con,
 		items:    make(map[flow.Identifier]*Item),
 		requests: make(map[uint64]*messages.EntityRequest),
 		selector: filter.HasNodeID(targetID),
@@ -304,6 +312,8 @@ func TestOnEntityResponseValid(t *testing.T) {
 
 	// check that the missing items timestamp was reset
 	assert.Equal(t, iunavailable.LastRequested, time.Time{})
+
+	con.AssertExpectations(t)
 }
 
 func TestOnEntityIntegrityCheck(t *testing.T) {
@@ -351,11 +361,16 @@ func TestOnEntityIntegrityCheck(t *testing.T) {
 		EntityIDs: []flow.Identifier{wanted.ID()},
 	}
 
+	// the entity not matching its stated ID should be reported
+	con := &mocknetwork.Conduit{}
+	con.On("ReportMisbehavior", targetID, network.SeverityHigh, mock.Anything).Once()
+
 	called := 0
 	request := Engine{
 		unit:     engine.NewUnit(),
 		metrics:  metrics.NewNoopCollector(),
 		state:    state,
+		con:      con,
 		items:    make(map[flow.Identifier]*Item),
 		requests: make(map[uint64]*messages.EntityRequest),
 		selector: filter.HasNodeID(targetID),
@@ -390,4 +405,104 @@ func TestOnEntityIntegrityCheck(t *testing.T) {
 
 	// make sure we process item without checking integrity
 	assert.Equal(t, 1, called)
+
+	con.AssertExpectations(t)
+}
+
+// TestOnEntityResponseLate tests that an honest provider responding late to a full batch request,
+// after the entities were received from another provider, is not penalized, while a provider sending
+// unsolicited entities is reported once per response.
+func TestOnEntityResponseLate(t *testing.T) {
+	identities := unittest.IdentityListFixture(2)
+	lateID := identities[0].NodeID
+	otherID := identities[1].NodeID
+
+	final := &protocol.Snapshot{}
+	final.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+
+	state := &protocol.State{}
+	state.On("Final").Return(final)
+
+	// misbehavior reports lower the scores of the providers
+	scorer := p2p.NewPeerScorer(zerolog.Nop(), metrics.NewNoopCollector())
+	con := &mocknetwork.Conduit{}
+	con.On("ReportMisbehavior", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			scorer.Report(args.Get(0).(flow.Identifier), engine.RequestCollections, args.Get(1).(network.Severity), args.String(2))
+		})
+
+	request := Engine{
+		unit:     engine.NewUnit(),
+		metrics:  metrics.NewNoopCollector(),
+		state:    state,
+		con:      con,
+		items:    make(map[flow.Identifier]*Item),
+		requests: make(map[uint64]*messages.EntityRequest),
+		selector: filter.Any,
+		create:   func() flow.Entity { return &flow.Collection{} },
+		handle:   func(flow.Identifier, flow.Entity) {},
+	}
+
+	// a full batch of the default size, requested from both providers
+	batch := func() (*messages.EntityRequest, *messages.EntityResponse) {
+		req := &messages.EntityRequest{Nonce: rand.Uint64()}
+		res := &messages.EntityResponse{Nonce: req.Nonce}
+		for i := 0; i < 32; i++ {
+			collection := unittest.CollectionFixture(1)
+			blob, err := msgpack.Marshal(collection)
+			require.NoError(t, err)
+			req.EntityIDs = append(req.EntityIDs, collection.ID())
+			res.EntityIDs = append(res.EntityIDs, collection.ID())
+			res.Blobs = append(res.Blobs, blob)
+			request.items[collection.ID()] = &Item{
+				EntityID:      collection.ID(),
+				LastRequested: time.Now(),
+				ExtraSelector: filter.Any,
+			}
+		}
+		return req, res
+	}
+
+	t.Run("late response to known request", func(t *testing.T) {
+		req, res := batch()
+		otherReq := &messages.EntityRequest{Nonce: rand.Uint64(), EntityIDs: req.EntityIDs}
+		request.requests[req.Nonce] = req
+		request.requests[otherReq.Nonce] = otherReq
+
+		otherRes := *res
+		otherRes.Nonce = otherReq.Nonce
+		require.NoError(t, request.onEntityResponse(otherID, &otherRes))
+		require.NoError(t, request.onEntityResponse(lateID, res))
+
+		con.AssertNotCalled(t, "ReportMisbehavior", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, p2p.PenaltyNone, scorer.Penalty(lateID))
+	})
+
+	t.Run("late response to forgotten request", func(t *testing.T) {
+		_, res := batch()
+		otherRes := *res
+		otherRes.Nonce = rand.Uint64()
+		require.NoError(t, request.onEntityResponse(otherID, &otherRes))
+		require.NoError(t, request.onEntityResponse(lateID, res))
+
+		con.AssertNotCalled(t, "ReportMisbehavior", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, p2p.PenaltyNone, scorer.Penalty(lateID))
+	})
+
+	t.Run("unsolicited entities", func(t *testing.T) {
+		_, res := batch()
+		request.items = make(map[flow.Identifier]*Item)
+		request.requests[res.Nonce] = &messages.EntityRequest{Nonce: res.Nonce}
+
+		require.NoError(t, request.onEntityResponse(lateID, res))
+
+		con.AssertNumberOfCalls(t, "ReportMisbehavior", 1)
+		con.AssertCalled(t, "ReportMisbehavior", lateID, network.SeverityLow, mock.Anything)
+		assert.Equal(t, p2p.PenaltyNone, scorer.Penalty(lateID))
+	})
 }
@@ -9,6 +9,8 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/crypto/hash"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	eventEncoding "github.com/onflow/flow-go/model/encoding/events"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -324,6 +326,8 @@ func MessageToEvent(m *entities.Event) flow.Event {
 		TransactionIndex: m.GetTransactionIndex(),
 		EventIndex:       m.GetEventIndex(),
 		Payload:          m.GetPayload(),
+		// the encoding is not part of the message, so it's detected from the payload
+		EncodingVersion: eventEncoding.Detect(m.GetPayload()),
 	}
 }
 
@@ -359,3 +363,31 @@ func MessagesToIdentifiers(l [][]byte) []flow.Identifier {
 	}
 	return results
 }
+
+func RegisterEntriesToMessages(entries flow.RegisterEntries) []*dryrunproto.RegisterUpdate {
+	results := make([]*dryrunproto.RegisterUpdate, len(entries))
+	for i, entry := range entries {
+		results[i] = &dryrunproto.RegisterUpdate{
+			Owner:      []byte(entry.Key.Owner),
+			Controller: []byte(entry.Key.Controller),
+			Key:        []byte(entry.Key.Key),
+			Value:      entry.Value,
+		}
+	}
+	return results
+}
+
+func MessagesToRegisterEntries(l []*dryrunproto.RegisterUpdate) flow.RegisterEntries {
+	results := make(flow.RegisterEntries, len(l))
+	for i, item := range l {
+		results[i] = flow.RegisterEntry{
+			Key: flow.RegisterID{
+				Owner:      string(item.GetOwner()),
+				Controller: string(item.GetController()),
+				Key:        string(item.GetKey()),
+			},
+			Value: item.GetValue(),
+		}
+	}
+	return results
+}
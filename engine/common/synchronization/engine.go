@@ -317,6 +317,11 @@ func (e *Engine) onBlockResponse(originID flow.Identifier, res *messages.BlockRe
 
 	// process the blocks one by one
 	for _, block := range res.Blocks {
+		// a block whose payload does not match its header can't have been sent by an honest node
+		if block.Header == nil || block.Payload == nil || !block.Valid() {
+			e.con.ReportMisbehavior(originID, network.SeverityHigh, "malformed block in block response")
+			continue
+		}
 		e.processIncomingBlock(originID, block)
 	}
 
@@ -350,6 +350,12 @@ func (ss *SyncSuite) TestOnBlockResponse() {
 	ss.core.On("HandleBlock", unprocessable.Header).Return(false)
 	res.Blocks = append(res.Blocks, &unprocessable)
 
+	// add one block whose payload does not match its header, which should be reported
+	malformed := unittest.BlockFixture()
+	malformed.Header.PayloadHash = unittest.IdentifierFixture()
+	res.Blocks = append(res.Blocks, &malformed)
+	ss.con.On("ReportMisbehavior", originID, netint.SeverityHigh, mock.Anything).Once()
+
 	ss.comp.On("SubmitLocal", mock.Anything).Run(func(args mock.Arguments) {
 		res := args.Get(0).(*events.SyncedBlock)
 		ss.Assert().Equal(&processable, res.Block)
@@ -361,6 +367,7 @@ func (ss *SyncSuite) TestOnBlockResponse() {
 	ss.Assert().Nil(err)
 	ss.comp.AssertExpectations(ss.T())
 	ss.core.AssertExpectations(ss.T())
+	ss.con.AssertExpectations(ss.T())
 }
 
 func (ss *SyncSuite) TestPollHeight() {
@@ -15,6 +15,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -38,6 +39,7 @@ type Core struct {
 	pending           module.PendingBlockBuffer // pending block cache
 	sync              module.BlockRequester
 	hotstuff          module.HotStuff
+	reporter          network.MisbehaviorReporter // used to report nodes sending invalid blocks
 }
 
 // NewCore creates a new consensus propagation engine.
@@ -68,6 +70,7 @@ func NewCore(
 		pending:           pending,
 		sync:              sync,
 		hotstuff:          nil, // use `WithConsensus`
+		reporter:          nil, // set by the engine once registered with the network
 	}
 
 	e.mempool.MempoolEntries(metrics.ResourceProposal, e.pending.Size())
@@ -196,7 +199,7 @@ func (c *Core) OnBlockProposal(originID flow.Identifier, proposal *messages.Bloc
 	// proposal's pending children. There is another span within
 	// processBlockProposal that measures the time spent for a single proposal.
 	recursiveProcessSpan := c.tracer.StartSpanFromParent(onBlockProposalSpan, trace.CONCompOnBlockProposalProcessRecursive)
-	err = c.processBlockAndDescendants(proposal)
+	err = c.processBlockAndDescendants(originID, proposal)
 	c.mempool.MempoolEntries(metrics.ResourceProposal, c.pending.Size())
 	recursiveProcessSpan.Finish()
 	if err != nil {
@@ -215,8 +218,8 @@ func (c *Core) OnBlockProposal(originID flow.Identifier, proposal *messages.Bloc
 // processBlockAndDescendants is a recursive function that processes a block and
 // its pending proposals for its children. By induction, any children connected
 // to a valid proposal are validly connected to the finalized state and can be
-// processed as well.
-func (c *Core) processBlockAndDescendants(proposal *messages.BlockProposal) error {
+// processed as well. The origin ID is the node which sent the proposal.
+func (c *Core) processBlockAndDescendants(originID flow.Identifier, proposal *messages.BlockProposal) error {
 	blockID := proposal.Header.ID()
 
 	// process block itself
@@ -230,7 +233,8 @@ func (c *Core) processBlockAndDescendants(proposal *messages.BlockProposal) erro
 	// the block is invalid; log as error as we desire honest participation
 	// ToDo: potential slashing
 	if engine.IsInvalidInputError(err) {
-		c.log.Warn().Err(err).Msg("received invalid block from other node (potential slashing evidence?)")
+		c.log.Warn().Err(err).Hex("origin_id", originID[:]).Msg("received invalid block from other node (potential slashing evidence?)")
+		c.reporter.ReportMisbehavior(originID, invalidProposalSeverity(proposal), fmt.Sprintf("invalid block proposal (%x)", blockID))
 		return nil
 	}
 	if err != nil {
@@ -250,7 +254,7 @@ func (c *Core) processBlockAndDescendants(proposal *messages.BlockProposal) erro
 			Header:  child.Header,
 			Payload: child.Payload,
 		}
-		cpr := c.processBlockAndDescendants(childProposal)
+		cpr := c.processBlockAndDescendants(child.OriginID, childProposal)
 		if cpr != nil {
 			// unexpected error: potentially corrupted internal state => abort processing and escalate error
 			return cpr
@@ -263,6 +267,18 @@ func (c *Core) processBlockAndDescendants(proposal *messages.BlockProposal) erro
 	return nil
 }
 
+// invalidProposalSeverity returns the severity of sending an invalid block proposal.
+// Only a payload which does not match the payload hash of the header proves that
+// the sender of the proposal misbehaves: the protocol state may also reject blocks
+// because this node misses some of the state they reference, and the sender of a
+// block may have relayed it without being its proposer.
+func invalidProposalSeverity(proposal *messages.BlockProposal) network.Severity {
+	if proposal.Payload.Hash() != proposal.Header.PayloadHash {
+		return network.SeverityCritical
+	}
+	return network.SeverityMedium
+}
+
 // processBlockProposal processes the given block proposal. The proposal must connect to
 // the finalized state.
 func (c *Core) processBlockProposal(proposal *messages.BlockProposal) error {
@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/module/trace"
 	netint "github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/state"
 	protint "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storerr "github.com/onflow/flow-go/storage"
@@ -246,6 +247,7 @@ func (cs *ComplianceCoreSuite) SetupTest() {
 	cs.core = e
 	// assign engine with consensus & synchronization
 	cs.core.hotstuff = cs.hotstuff
+	cs.core.reporter = cs.con
 }
 
 func (cs *ComplianceCoreSuite) TestOnBlockProposalValidParent() {
@@ -330,6 +332,72 @@ func (cs *ComplianceCoreSuite) TestOnBlockProposalInvalidExtension() {
 	cs.hotstuff.AssertExpectations(cs.T())
 }
 
+func (cs *ComplianceCoreSuite) TestOnBlockProposalInvalidBlock() {
+
+	// create a proposal with a parent on disk
+	originID := cs.participants[1].NodeID
+	parent := unittest.BlockWithParentFixture(cs.head)
+	block := unittest.BlockWithParentFixture(parent.Header)
+	proposal := unittest.ProposalFromBlock(&block)
+
+	// store the data for retrieval
+	cs.headerDB[parent.ID()] = parent.Header
+
+	// make sure the block is invalid
+	*cs.state = protocol.MutableState{}
+	cs.state.On("Final").Return(
+		func() protint.Snapshot {
+			return cs.snapshot
+		},
+	)
+	cs.state.On("Extend", mock.Anything).Return(state.NewInvalidExtensionError("dummy error"))
+
+	// the origin of the invalid block should be reported, but not as an attack, as this node may
+	// only lack the state to validate the block
+	cs.con.On("ReportMisbehavior", originID, netint.SeverityMedium, mock.Anything).Return().Once()
+
+	// the invalid block should be dropped without error
+	err := cs.core.OnBlockProposal(originID, proposal)
+	require.NoError(cs.T(), err, "proposal with invalid block should be dropped")
+
+	// we should not submit the proposal to hotstuff
+	cs.hotstuff.AssertExpectations(cs.T())
+	cs.con.AssertCalled(cs.T(), "ReportMisbehavior", originID, netint.SeverityMedium, mock.Anything)
+}
+
+func (cs *ComplianceCoreSuite) TestOnBlockProposalInvalidPayload() {
+
+	// create a proposal with a parent on disk, whose payload does not match its header
+	originID := cs.participants[1].NodeID
+	parent := unittest.BlockWithParentFixture(cs.head)
+	block := unittest.BlockWithParentFixture(parent.Header)
+	proposal := unittest.ProposalFromBlock(&block)
+	proposal.Header.PayloadHash = unittest.IdentifierFixture()
+
+	// store the data for retrieval
+	cs.headerDB[parent.ID()] = parent.Header
+
+	// the protocol state rejects the block
+	*cs.state = protocol.MutableState{}
+	cs.state.On("Final").Return(
+		func() protint.Snapshot {
+			return cs.snapshot
+		},
+	)
+	cs.state.On("Extend", mock.Anything).Return(state.NewInvalidExtensionError("payload integrity check failed"))
+
+	// the origin of the invalid payload should be reported as an attack
+	cs.con.On("ReportMisbehavior", originID, netint.SeverityCritical, mock.Anything).Return().Once()
+
+	// the invalid block should be dropped without error
+	err := cs.core.OnBlockProposal(originID, proposal)
+	require.NoError(cs.T(), err, "proposal with invalid payload should be dropped")
+
+	// we should not submit the proposal to hotstuff
+	cs.hotstuff.AssertExpectations(cs.T())
+	cs.con.AssertCalled(cs.T(), "ReportMisbehavior", originID, netint.SeverityCritical, mock.Anything)
+}
+
 func (cs *ComplianceCoreSuite) TestProcessBlockAndDescendants() {
 
 	// create three children blocks
@@ -359,7 +427,7 @@ func (cs *ComplianceCoreSuite) TestProcessBlockAndDescendants() {
 	cs.hotstuff.On("SubmitProposal", block3.Header, parent.Header.View).Return().Once()
 
 	// execute the connected children handling
-	err := cs.core.processBlockAndDescendants(proposal)
+	err := cs.core.processBlockAndDescendants(unittest.IdentifierFixture(), proposal)
 	require.NoError(cs.T(), err, "should pass handling children")
 
 	// check that we submitted each child to hotstuff
@@ -90,6 +90,9 @@ func NewEngine(
 		return nil, fmt.Errorf("could not register core: %w", err)
 	}
 
+	// report nodes sending invalid blocks through the conduit
+	e.core.reporter = e.con
+
 	// FIFO queue for block proposals
 	e.pendingBlocks, err = fifoqueue.NewFifoQueue(
 		fifoqueue.WithCapacity(defaultBlockQueueCapacity),
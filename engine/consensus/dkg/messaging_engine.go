@@ -0,0 +1,127 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/dkg"
+	"github.com/onflow/flow-go/network"
+)
+
+// MessagingEngine is a network engine that enables consensus nodes to exchange
+// DKG messages over a dedicated channel. It relays messages between the network
+// and the DKG broker of the running DKG instance through a BrokerTunnel.
+type MessagingEngine struct {
+	unit    *engine.Unit
+	log     zerolog.Logger
+	me      module.Local      // used to identify this node
+	conduit network.Conduit   // used to send messages to other DKG participants
+	tunnel  *dkg.BrokerTunnel // used to forward messages to and from the broker
+}
+
+// NewMessagingEngine returns a new MessagingEngine.
+func NewMessagingEngine(
+	log zerolog.Logger,
+	net module.Network,
+	me module.Local,
+	tunnel *dkg.BrokerTunnel) (*MessagingEngine, error) {
+
+	log = log.With().Str("engine", "dkg_messaging").Logger()
+
+	eng := MessagingEngine{
+		unit:   engine.NewUnit(),
+		log:    log,
+		me:     me,
+		tunnel: tunnel,
+	}
+
+	var err error
+	eng.conduit, err = net.Register(engine.DKGCommittee, &eng)
+	if err != nil {
+		return nil, fmt.Errorf("could not register dkg network engine: %w", err)
+	}
+
+	return &eng, nil
+}
+
+// Ready implements the module ReadyDoneAware interface. It starts forwarding
+// outgoing DKG messages to the network.
+func (e *MessagingEngine) Ready() <-chan struct{} {
+	return e.unit.Ready(func() {
+		e.unit.Launch(e.forwardOutgoingMessages)
+	})
+}
+
+// Done implements the module ReadyDoneAware interface.
+func (e *MessagingEngine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+// SubmitLocal implements the network Engine interface.
+func (e *MessagingEngine) SubmitLocal(event interface{}) {
+	e.Submit(e.me.NodeID(), event)
+}
+
+// Submit implements the network Engine interface.
+func (e *MessagingEngine) Submit(originID flow.Identifier, event interface{}) {
+	e.unit.Launch(func() {
+		err := e.Process(originID, event)
+		if err != nil {
+			engine.LogError(e.log, err)
+		}
+	})
+}
+
+// ProcessLocal implements the network Engine interface.
+func (e *MessagingEngine) ProcessLocal(event interface{}) error {
+	return e.Process(e.me.NodeID(), event)
+}
+
+// Process implements the network Engine interface.
+func (e *MessagingEngine) Process(originID flow.Identifier, event interface{}) error {
+	return e.unit.Do(func() error {
+		return e.process(originID, event)
+	})
+}
+
+func (e *MessagingEngine) process(originID flow.Identifier, event interface{}) error {
+	switch v := event.(type) {
+	case *messages.DKGMessage:
+		ok := e.tunnel.SendIn(dkg.MessageIn{
+			DKGMessage: *v,
+			OriginID:   originID,
+		})
+		if !ok {
+			e.log.Warn().
+				Hex("origin_id", originID[:]).
+				Str("dkg_instance_id", v.DKGInstanceID).
+				Msg("dropping dkg message, as the broker tunnel is full")
+		}
+		return nil
+	default:
+		return engine.NewInvalidInputErrorf("expecting input with type msg.DKGMessage, but got %T", event)
+	}
+}
+
+// forwardOutgoingMessages sends the messages produced by the broker to their
+// recipients, until the engine is shut down.
+func (e *MessagingEngine) forwardOutgoingMessages() {
+	for {
+		select {
+		case msg := <-e.tunnel.MsgChOut:
+			for _, destID := range msg.DestIDs {
+				err := e.conduit.Unicast(&msg.DKGMessage, destID)
+				if err != nil {
+					e.log.Error().Err(err).Hex("dest_id", destID[:]).Msg("could not send dkg message")
+				}
+			}
+		case <-e.unit.Quit():
+			return
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package dkg
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultPhaseLength is the number of finalized blocks each of the three DKG
+// phases lasts. The phases start with the first block of the epoch setup phase.
+// All participants must use the same value.
+const DefaultPhaseLength uint64 = 100
+
+// ReactorEngine is an engine that reacts to protocol events to run the DKG for
+// the next epoch. When the epoch setup phase starts, it creates a DKG
+// controller for the next epoch's consensus committee and ends each DKG phase
+// at pre-defined heights. Once the DKG has ended, our random beacon private
+// key share for the next epoch is persisted.
+//
+// The DKG state is kept in memory only, so a node restarting during the
+// epoch setup phase does not participate in the rest of the DKG.
+type ReactorEngine struct {
+	events.Noop // satisfy protocol events consumer interface
+
+	unit              *engine.Unit
+	log               zerolog.Logger
+	me                module.Local
+	state             protocol.State
+	keyStorage        storage.DKGKeys
+	controllerFactory module.DKGControllerFactory
+	heightEvents      events.Heights
+	phaseLength       uint64
+
+	controllerLock sync.Mutex
+	controller     module.DKGController // controller of the latest DKG, shut down with the engine
+}
+
+// NewReactorEngine returns a new ReactorEngine.
+func NewReactorEngine(
+	log zerolog.Logger,
+	me module.Local,
+	state protocol.State,
+	keyStorage storage.DKGKeys,
+	controllerFactory module.DKGControllerFactory,
+	heightEvents events.Heights,
+) *ReactorEngine {
+
+	return &ReactorEngine{
+		unit:              engine.NewUnit(),
+		log:               log.With().Str("engine", "dkg_reactor").Logger(),
+		me:                me,
+		state:             state,
+		keyStorage:        keyStorage,
+		controllerFactory: controllerFactory,
+		heightEvents:      heightEvents,
+		phaseLength:       DefaultPhaseLength,
+	}
+}
+
+// Ready implements the module ReadyDoneAware interface. It returns a channel
+// that will close when the engine has successfully started.
+func (e *ReactorEngine) Ready() <-chan struct{} {
+	return e.unit.Ready()
+}
+
+// Done implements the module ReadyDoneAware interface. It returns a channel
+// that will close when the engine has successfully stopped. A running DKG is
+// shut down, as its controller only returns once the DKG has ended.
+func (e *ReactorEngine) Done() <-chan struct{} {
+	return e.unit.Done(func() {
+		e.controllerLock.Lock()
+		defer e.controllerLock.Unlock()
+		if e.controller != nil {
+			e.controller.Shutdown()
+		}
+	})
+}
+
+// EpochSetupPhaseStarted handles the EpochSetupPhaseStarted protocol event by
+// starting the DKG for the next epoch.
+func (e *ReactorEngine) EpochSetupPhaseStarted(_ uint64, first *flow.Header) {
+	e.unit.Launch(func() {
+		err := e.startDKGForEpoch(first)
+		if err != nil {
+			e.log.Error().Err(err).Msg("could not start dkg for next epoch")
+		}
+	})
+}
+
+// startDKGForEpoch creates a DKG controller for the epoch following the one
+// containing the given block, starts it, and registers the callbacks ending
+// each DKG phase.
+func (e *ReactorEngine) startDKGForEpoch(first *flow.Header) error {
+
+	nextEpoch := e.state.AtBlockID(first.ID()).Epochs().Next()
+	counter, err := nextEpoch.Counter()
+	if err != nil {
+		return fmt.Errorf("could not get next epoch counter: %w", err)
+	}
+	identities, err := nextEpoch.InitialIdentities()
+	if err != nil {
+		return fmt.Errorf("could not get next epoch identities: %w", err)
+	}
+	committee := identities.Filter(filter.HasRole(flow.RoleConsensus))
+
+	log := e.log.With().
+		Uint64("next_epoch", counter).
+		Uint64("first_block_height", first.Height).
+		Logger()
+
+	_, ok := committee.ByNodeID(e.me.NodeID())
+	if !ok {
+		log.Info().Msg("not a member of the next epoch's dkg committee, skipping dkg")
+		return nil
+	}
+
+	// each participant seeds its part of the DKG with local randomness, which
+	// must not be known to other participants
+	seed := make([]byte, crypto.SeedMinLenDKG)
+	_, err = rand.Read(seed)
+	if err != nil {
+		return fmt.Errorf("could not generate dkg seed: %w", err)
+	}
+
+	dkgInstanceID := fmt.Sprintf("dkg-%s-%d", first.ChainID, counter)
+	controller, err := e.controllerFactory.Create(dkgInstanceID, committee, seed)
+	if err != nil {
+		return fmt.Errorf("could not create dkg controller: %w", err)
+	}
+
+	e.controllerLock.Lock()
+	e.controller = controller
+	e.controllerLock.Unlock()
+
+	e.unit.Launch(func() {
+		err := controller.Run()
+		if err != nil {
+			log.Error().Err(err).Msg("dkg failed")
+		}
+	})
+
+	log.Info().Str("dkg_instance_id", dkgInstanceID).Msg("dkg started")
+
+	phase1End := first.Height + e.phaseLength
+	phase2End := phase1End + e.phaseLength
+	phase3End := phase2End + e.phaseLength
+
+	e.registerPoll(phase1End, func() {
+		err := controller.EndPhase1()
+		if err != nil {
+			log.Error().Err(err).Msg("could not end dkg phase 1")
+		}
+	})
+	e.registerPoll(phase2End, func() {
+		err := controller.EndPhase2()
+		if err != nil {
+			log.Error().Err(err).Msg("could not end dkg phase 2")
+		}
+	})
+	e.registerPoll(phase3End, func() {
+		err := e.endDKG(counter, controller)
+		if err != nil {
+			log.Error().Err(err).Msg("could not end dkg")
+			return
+		}
+		log.Info().Msg("dkg completed, private key share stored")
+	})
+
+	return nil
+}
+
+// registerPoll runs the given callback in the engine's unit once the given
+// height is finalized.
+func (e *ReactorEngine) registerPoll(height uint64, callback func()) {
+	e.heightEvents.OnHeight(height, func() {
+		e.unit.Launch(callback)
+	})
+}
+
+// endDKG ends the DKG and persists our private key share for the epoch with
+// the given counter.
+func (e *ReactorEngine) endDKG(counter uint64, controller module.DKGController) error {
+	defer controller.Shutdown()
+
+	err := controller.End()
+	if err != nil {
+		return fmt.Errorf("dkg did not complete: %w", err)
+	}
+
+	privateShare, _, _ := controller.GetArtifacts()
+	if privateShare == nil {
+		return fmt.Errorf("dkg completed without a private key share")
+	}
+
+	info := &dkg.DKGParticipantPriv{
+		NodeID:              e.me.NodeID(),
+		RandomBeaconPrivKey: encodable.RandomBeaconPrivKey{PrivateKey: privateShare},
+		GroupIndex:          controller.GetIndex(),
+	}
+	err = e.keyStorage.InsertMyDKGPrivateInfo(counter, info)
+	if err != nil {
+		return fmt.Errorf("could not store dkg private info: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package dkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/state/protocol/events/gadgets"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+// TestEpochSetup checks that the reactor engine runs the DKG for the next
+// epoch when the setup phase starts, ends the DKG phases at the right heights,
+// and stores the resulting private key share.
+func TestEpochSetup(t *testing.T) {
+
+	currentCounter := uint64(1)
+	nextCounter := currentCounter + 1
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	committee := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	myIndex := 0
+	me := new(module.Local)
+	me.On("NodeID").Return(committee[myIndex].NodeID)
+
+	firstBlock := unittest.BlockHeaderFixture()
+	firstBlock.Height = 100
+
+	// the next epoch, whose consensus nodes run the DKG
+	nextEpoch := new(protocol.Epoch)
+	nextEpoch.On("Counter").Return(nextCounter, nil)
+	nextEpoch.On("InitialIdentities").Return(identities, nil)
+	currentEpoch := new(protocol.Epoch)
+	currentEpoch.On("Counter").Return(currentCounter, nil)
+	epochQuery := mocks.NewEpochQuery(t, currentCounter, currentEpoch, nextEpoch)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Epochs").Return(epochQuery)
+	state := new(protocol.State)
+	state.On("AtBlockID", firstBlock.ID()).Return(snapshot)
+
+	// the controller records the phase transitions
+	privateShare := unittest.KeyFixture(crypto.ECDSAP256)
+	ended := make(chan struct{})
+	controller := new(module.DKGController)
+	controller.On("Run").Return(nil)
+	controller.On("EndPhase1").Return(nil).Once()
+	controller.On("EndPhase2").Return(nil).Once()
+	controller.On("End").Return(nil).Once()
+	controller.On("Shutdown") // when the dkg ends, and when the engine stops
+	controller.On("GetArtifacts").Return(privateShare, nil, nil)
+	controller.On("GetIndex").Return(myIndex)
+	created := make(chan struct{})
+	factory := new(module.DKGControllerFactory)
+	factory.On("Create", mock.Anything, committee, mock.Anything).
+		Run(func(mock.Arguments) { close(created) }).
+		Return(controller, nil).Once()
+
+	keyStorage := new(storage.DKGKeys)
+	keyStorage.On("InsertMyDKGPrivateInfo", nextCounter, mock.Anything).
+		Run(func(args mock.Arguments) {
+			info := args.Get(1).(*dkg.DKGParticipantPriv)
+			assert.Equal(t, committee[myIndex].NodeID, info.NodeID)
+			assert.Equal(t, myIndex, info.GroupIndex)
+			assert.Equal(t, privateShare, info.RandomBeaconPrivKey.PrivateKey)
+			close(ended)
+		}).
+		Return(nil).Once()
+
+	heights := gadgets.NewHeights()
+	engine := NewReactorEngine(zerolog.Nop(), me, state, keyStorage, factory, heights)
+	engine.phaseLength = 10
+
+	engine.EpochSetupPhaseStarted(currentCounter, &firstBlock)
+
+	// wait for the controller to be created before finalizing blocks
+	unittest.AssertClosesBefore(t, created, time.Second)
+
+	for height := firstBlock.Height; height <= firstBlock.Height+3*engine.phaseLength; height++ {
+		header := unittest.BlockHeaderFixture()
+		header.Height = height
+		heights.BlockFinalized(&header)
+	}
+
+	unittest.AssertClosesBefore(t, ended, time.Second)
+	<-engine.Done()
+
+	controller.AssertExpectations(t)
+	factory.AssertExpectations(t)
+	keyStorage.AssertExpectations(t)
+}
+
+// TestEpochSetup_NotParticipant checks that the reactor engine does not run
+// the DKG when we are not a member of the next epoch's consensus committee.
+func TestEpochSetup_NotParticipant(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	me := new(module.Local)
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+
+	firstBlock := unittest.BlockHeaderFixture()
+
+	nextEpoch := new(protocol.Epoch)
+	nextEpoch.On("Counter").Return(uint64(2), nil)
+	nextEpoch.On("InitialIdentities").Return(identities, nil)
+	currentEpoch := new(protocol.Epoch)
+	currentEpoch.On("Counter").Return(uint64(1), nil)
+	epochQuery := mocks.NewEpochQuery(t, 1, currentEpoch, nextEpoch)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Epochs").Return(epochQuery)
+	state := new(protocol.State)
+	state.On("AtBlockID", firstBlock.ID()).Return(snapshot)
+
+	factory := new(module.DKGControllerFactory)
+	heights := gadgets.NewHeights()
+	engine := NewReactorEngine(zerolog.Nop(), me, state, new(storage.DKGKeys), factory, heights)
+
+	engine.EpochSetupPhaseStarted(1, &firstBlock)
+	<-engine.Done()
+
+	factory.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestEpochSetup_ShutdownDuringDKG checks that the reactor engine stops while
+// the DKG is running, by shutting down its controller.
+func TestEpochSetup_ShutdownDuringDKG(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	committee := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	me := new(module.Local)
+	me.On("NodeID").Return(committee[0].NodeID)
+
+	firstBlock := unittest.BlockHeaderFixture()
+
+	nextEpoch := new(protocol.Epoch)
+	nextEpoch.On("Counter").Return(uint64(2), nil)
+	nextEpoch.On("InitialIdentities").Return(identities, nil)
+	currentEpoch := new(protocol.Epoch)
+	currentEpoch.On("Counter").Return(uint64(1), nil)
+	epochQuery := mocks.NewEpochQuery(t, 1, currentEpoch, nextEpoch)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Epochs").Return(epochQuery)
+	state := new(protocol.State)
+	state.On("AtBlockID", firstBlock.ID()).Return(snapshot)
+
+	// the controller runs until it is shut down
+	running := make(chan struct{})
+	shutdown := make(chan struct{})
+	controller := new(module.DKGController)
+	controller.On("Run").
+		Run(func(mock.Arguments) {
+			close(running)
+			<-shutdown
+		}).
+		Return(nil).Once()
+	controller.On("Shutdown").
+		Run(func(mock.Arguments) { close(shutdown) }).
+		Once()
+	factory := new(module.DKGControllerFactory)
+	factory.On("Create", mock.Anything, committee, mock.Anything).Return(controller, nil).Once()
+
+	engine := NewReactorEngine(zerolog.Nop(), me, state, new(storage.DKGKeys), factory, gadgets.NewHeights())
+
+	engine.EpochSetupPhaseStarted(1, &firstBlock)
+	unittest.AssertClosesBefore(t, running, time.Second)
+
+	unittest.AssertClosesBefore(t, engine.Done(), time.Second)
+	controller.AssertExpectations(t)
+}
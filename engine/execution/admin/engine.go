@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"context"
+	"net"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine"
+	adminproto "github.com/onflow/flow-go/engine/execution/admin/protobuf"
+	"github.com/onflow/flow-go/engine/execution/ingestion"
+)
+
+// Config defines the configurable options for the admin gRPC server.
+type Config struct {
+	ListenAddr string
+}
+
+// Engine implements a gRPC server with the admin API of the execution node.
+// It should only listen on an address which is reachable by the operators of
+// the node.
+type Engine struct {
+	unit    *engine.Unit
+	log     zerolog.Logger
+	handler *handler     // the gRPC service implementation
+	server  *grpc.Server // the gRPC server
+	config  Config
+}
+
+// New returns a new admin engine.
+func New(log zerolog.Logger, config Config, e ingestion.IngestAdmin) *Engine {
+	log = log.With().Str("engine", "admin").Logger()
+
+	eng := &Engine{
+		log:  log,
+		unit: engine.NewUnit(),
+		handler: &handler{
+			log:    log,
+			engine: e,
+		},
+		server: grpc.NewServer(),
+		config: config,
+	}
+
+	adminproto.RegisterExecutionAdminAPIServer(eng.server, eng.handler)
+
+	return eng
+}
+
+// Ready returns a ready channel that is closed once the engine has fully
+// started. The admin engine is ready when the gRPC server has successfully
+// started.
+func (e *Engine) Ready() <-chan struct{} {
+	e.unit.Launch(e.serve)
+	return e.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped.
+// It sends a signal to stop the gRPC server, then closes the channel.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done(e.server.GracefulStop)
+}
+
+// serve starts the gRPC server.
+//
+// When this function returns, the server is considered ready.
+func (e *Engine) serve() {
+	e.log.Info().Msgf("starting server on address %s", e.config.ListenAddr)
+
+	l, err := net.Listen("tcp", e.config.ListenAddr)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start server")
+		return
+	}
+
+	err = e.server.Serve(l)
+	if err != nil {
+		e.log.Err(err).Msg("fatal error in server")
+	}
+}
+
+// handler implements the admin API.
+type handler struct {
+	log    zerolog.Logger
+	engine ingestion.IngestAdmin
+}
+
+var _ adminproto.ExecutionAdminAPIServer = &handler{}
+
+// SetStopHeight sets the height of the last block to execute.
+func (h *handler) SetStopHeight(
+	_ context.Context,
+	req *adminproto.SetStopHeightRequest,
+) (*adminproto.SetStopHeightResponse, error) {
+
+	err := h.engine.SetStopHeight(req.GetHeight())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to set stop height: %v", err)
+	}
+
+	h.log.Info().Uint64("stop_height", req.GetHeight()).Msg("stop height set by admin")
+
+	return &adminproto.SetStopHeightResponse{}, nil
+}
+
+// GetStopHeight returns the stop height, and whether the execution stopped at it.
+func (h *handler) GetStopHeight(
+	_ context.Context,
+	_ *adminproto.GetStopHeightRequest,
+) (*adminproto.GetStopHeightResponse, error) {
+
+	height, _, stopped := h.engine.StopHeight()
+
+	return &adminproto.GetStopHeightResponse{
+		Height:  height,
+		Stopped: stopped,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminproto "github.com/onflow/flow-go/engine/execution/admin/protobuf"
+	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+)
+
+func TestSetStopHeight(t *testing.T) {
+	mockEngine := new(ingestion.IngestAdmin)
+	h := &handler{log: zerolog.Nop(), engine: mockEngine}
+
+	mockEngine.On("SetStopHeight", uint64(100)).Return(nil).Once()
+
+	_, err := h.SetStopHeight(context.Background(), &adminproto.SetStopHeightRequest{Height: 100})
+	require.NoError(t, err)
+
+	t.Run("rejected", func(t *testing.T) {
+		mockEngine.On("SetStopHeight", uint64(10)).Return(errors.New("already executed")).Once()
+
+		_, err := h.SetStopHeight(context.Background(), &adminproto.SetStopHeightRequest{Height: 10})
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	mockEngine.AssertExpectations(t)
+}
+
+func TestGetStopHeight(t *testing.T) {
+	mockEngine := new(ingestion.IngestAdmin)
+	h := &handler{log: zerolog.Nop(), engine: mockEngine}
+
+	mockEngine.On("StopHeight").Return(uint64(100), true, true).Once()
+
+	resp, err := h.GetStopHeight(context.Background(), &adminproto.GetStopHeightRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), resp.GetHeight())
+	assert.True(t, resp.GetStopped())
+
+	mockEngine.AssertExpectations(t)
+}
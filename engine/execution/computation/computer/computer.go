@@ -29,12 +29,28 @@ type BlockComputer interface {
 }
 
 type blockComputer struct {
-	vm             VirtualMachine
-	vmCtx          fvm.Context
-	metrics        module.ExecutionMetrics
-	tracer         module.Tracer
-	log            zerolog.Logger
-	systemChunkCtx fvm.Context
+	vm              VirtualMachine
+	vmCtx           fvm.Context
+	metrics         module.ExecutionMetrics
+	tracer          module.Tracer
+	log             zerolog.Logger
+	systemChunkCtx  fvm.Context
+	parallelWorkers int
+}
+
+// BlockComputerOption is an option for the block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithParallelExecution enables the speculative parallel execution of the
+// transactions within a collection on the given number of workers. Results are
+// identical to the serial execution of the transactions.
+//
+// Parallel execution can't be combined with an execution tracer, which
+// requires transactions to be executed serially.
+func WithParallelExecution(workers int) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.parallelWorkers = workers
+	}
 }
 
 // NewBlockComputer creates a new block executor.
@@ -44,6 +60,7 @@ func NewBlockComputer(
 	metrics module.ExecutionMetrics,
 	tracer module.Tracer,
 	logger zerolog.Logger,
+	opts ...BlockComputerOption,
 ) (BlockComputer, error) {
 
 	systemChunkCtx := fvm.NewContextFromParent(
@@ -53,14 +70,23 @@ func NewBlockComputer(
 		fvm.WithTransactionProcessors(fvm.NewTransactionInvocator(logger)),
 	)
 
-	return &blockComputer{
+	e := &blockComputer{
 		vm:             vm,
 		vmCtx:          vmCtx,
 		metrics:        metrics,
 		tracer:         tracer,
 		log:            logger,
 		systemChunkCtx: systemChunkCtx,
-	}, nil
+	}
+	for _, apply := range opts {
+		apply(e)
+	}
+
+	if e.parallelWorkers > 1 && vmCtx.ExecutionTracer != nil {
+		return nil, fmt.Errorf("parallel execution can not be combined with an execution tracer")
+	}
+
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -211,6 +237,10 @@ func (e *blockComputer) executeCollection(
 		gasUsed       uint64
 	)
 
+	if e.parallelWorkers > 1 && len(collection.Transactions) > 1 {
+		return e.executeCollectionParallel(txIndex, blockCtx, colSpan, collectionView, programs, collection)
+	}
+
 	txMetrics := fvm.NewMetricsCollector()
 
 	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics))
@@ -243,6 +273,32 @@ func (e *blockComputer) executeTransaction(
 	ctx fvm.Context,
 	txIndex uint32,
 ) ([]flow.Event, []flow.Event, flow.TransactionResult, uint64, error) {
+
+	txView := collectionView.NewChild()
+
+	tx, err := e.runTransaction(txBody, colSpan, txMetrics, txView, programs, ctx, txIndex)
+	if err != nil {
+		return nil, nil, flow.TransactionResult{}, 0, err
+	}
+
+	if tx.Err == nil {
+		collectionView.MergeView(txView)
+	}
+
+	return tx.Events, tx.ServiceEvents, transactionResult(tx), tx.GasUsed, nil
+}
+
+// runTransaction runs the transaction on the given view, without merging the
+// view into its parent.
+func (e *blockComputer) runTransaction(
+	txBody *flow.TransactionBody,
+	colSpan opentracing.Span,
+	txMetrics *fvm.MetricsCollector,
+	txView *delta.View,
+	programs *fvm.Programs,
+	ctx fvm.Context,
+	txIndex uint32,
+) (*fvm.TransactionProcedure, error) {
 	if e.tracer != nil {
 		txSpan := e.tracer.StartSpanFromParent(colSpan, trace.EXEComputeTransaction)
 
@@ -271,8 +327,6 @@ func (e *blockComputer) executeTransaction(
 		Hex("tx_id", logging.Entity(txBody)).
 		Msg("executing transaction")
 
-	txView := collectionView.NewChild()
-
 	tx := fvm.Transaction(txBody, txIndex)
 
 	err := e.vm.Run(ctx, tx, txView, programs)
@@ -284,15 +338,10 @@ func (e *blockComputer) executeTransaction(
 	}
 
 	if err != nil {
-		return nil, nil, flow.TransactionResult{}, 0, fmt.Errorf("failed to execute transaction: %w", err)
-	}
-
-	txResult := flow.TransactionResult{
-		TransactionID: tx.ID,
+		return nil, fmt.Errorf("failed to execute transaction: %w", err)
 	}
 
 	if tx.Err != nil {
-		txResult.ErrorMessage = tx.Err.Error()
 		e.log.Debug().
 			Hex("tx_id", logging.Entity(txBody)).
 			Str("error_message", tx.Err.Error()).
@@ -304,9 +353,15 @@ func (e *blockComputer) executeTransaction(
 			Msg("transaction executed successfully")
 	}
 
-	if tx.Err == nil {
-		collectionView.MergeView(txView)
-	}
+	return tx, nil
+}
 
-	return tx.Events, tx.ServiceEvents, txResult, tx.GasUsed, nil
+func transactionResult(tx *fvm.TransactionProcedure) flow.TransactionResult {
+	txResult := flow.TransactionResult{
+		TransactionID: tx.ID,
+	}
+	if tx.Err != nil {
+		txResult.ErrorMessage = tx.Err.Error()
+	}
+	return txResult
 }
@@ -0,0 +1,231 @@
+package computer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// speculativeResult is the result of running a transaction against the state
+// at the start of its collection.
+type speculativeResult struct {
+	tx       *fvm.TransactionProcedure
+	view     *delta.View
+	programs *fvm.Programs
+	reads    []flow.RegisterID // registers read from the collection, in order
+	err      error
+}
+
+// executeCollectionParallel executes the transactions of a collection with
+// optimistic concurrency.
+//
+// All transactions are first run in parallel, each on its own view of the
+// state at the start of the collection. The results are then committed in
+// order. A result is only valid if none of the registers the transaction read
+// from the collection were written by an earlier transaction, and if it did
+// not depend on changes to the programs cache. Valid results are committed by
+// replaying the reads of the transaction on the collection view, so that the
+// SPoCK secrets match serial execution. Invalid results are discarded and the
+// transaction is executed again, serially.
+func (e *blockComputer) executeCollectionParallel(
+	txIndex uint32,
+	blockCtx fvm.Context,
+	colSpan opentracing.Span,
+	collectionView *delta.View,
+	programs *fvm.Programs,
+	collection *entity.CompleteCollection,
+) ([]flow.Event, []flow.Event, []flow.TransactionResult, uint32, uint64, error) {
+
+	results := e.speculate(txIndex, blockCtx, colSpan, collectionView, programs, collection)
+
+	var (
+		events        []flow.Event
+		serviceEvents []flow.Event
+		txResults     []flow.TransactionResult
+		gasUsed       uint64
+		reexecuted    int
+	)
+
+	txMetrics := fvm.NewMetricsCollector()
+
+	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics))
+
+	// once the programs cache was cleared, speculative results can't be used
+	serial := false
+
+	for i, result := range results {
+
+		tx := result.tx
+		txView := result.view
+
+		if serial || !result.valid(collectionView) {
+			reexecuted++
+
+			var err error
+			txView = collectionView.NewChild()
+			tx, err = e.runTransaction(collection.Transactions[i], colSpan, txMetrics, txView, programs, txCtx, txIndex)
+			if err != nil {
+				return nil, nil, nil, txIndex, 0, err
+			}
+		} else {
+			// replay the reads to record them in the collection view
+			for _, id := range result.reads {
+				_, err := collectionView.Get(id.Owner, id.Controller, id.Key)
+				if err != nil {
+					return nil, nil, nil, txIndex, 0, fmt.Errorf("failed to replay read of register %s: %w", id.String(), err)
+				}
+			}
+		}
+
+		if tx.Err == nil {
+			collectionView.MergeView(txView)
+			if updatesContracts(txView.Delta()) {
+				serial = true
+			}
+		}
+		if tx.Retried > 0 {
+			serial = true
+		}
+
+		txIndex++
+		events = append(events, tx.Events...)
+		serviceEvents = append(serviceEvents, tx.ServiceEvents...)
+		txResults = append(txResults, transactionResult(tx))
+		gasUsed += tx.GasUsed
+	}
+
+	e.log.Debug().
+		Hex("collection_id", logging.Entity(collection.Guarantee)).
+		Int("transactions", len(results)).
+		Int("reexecuted", reexecuted).
+		Msg("executed collection in parallel")
+
+	return events, serviceEvents, txResults, txIndex, gasUsed, nil
+}
+
+// speculate runs all transactions of the collection in parallel against the
+// state at the start of the collection.
+func (e *blockComputer) speculate(
+	txIndex uint32,
+	blockCtx fvm.Context,
+	colSpan opentracing.Span,
+	collectionView *delta.View,
+	programs *fvm.Programs,
+	collection *entity.CompleteCollection,
+) []*speculativeResult {
+
+	reader := newSnapshotReader(collectionView)
+	results := make([]*speculativeResult, len(collection.Transactions))
+
+	indices := make(chan int, len(collection.Transactions))
+	for i := range collection.Transactions {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	for w := 0; w < e.parallelWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				result := &speculativeResult{
+					programs: programs.ChildPrograms(),
+				}
+				result.view = delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+					result.reads = append(result.reads, flow.RegisterID{Owner: owner, Controller: controller, Key: key})
+					return reader.Get(owner, controller, key)
+				})
+
+				txMetrics := fvm.NewMetricsCollector()
+				txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics))
+				result.tx, result.err = e.runTransaction(collection.Transactions[i], colSpan, txMetrics, result.view, result.programs, txCtx, txIndex+uint32(i))
+
+				results[i] = result
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// valid returns true if the result of the transaction is the same as if it was
+// executed on the current collection view.
+func (r *speculativeResult) valid(collectionView *delta.View) bool {
+
+	if r.err != nil || r.tx.Retried > 0 {
+		return false
+	}
+
+	// loading programs (or clearing them) means the transaction depends on the
+	// state of the programs cache, which may have changed in the meantime
+	if r.programs.HasChanges() {
+		return false
+	}
+
+	written := collectionView.Delta()
+	for _, id := range r.reads {
+		_, conflict := written.Get(id.Owner, id.Controller, id.Key)
+		if conflict {
+			return false
+		}
+	}
+
+	return true
+}
+
+// updatesContracts returns true if the delta changes any contracts, which
+// clears the programs cache.
+func updatesContracts(d delta.Delta) bool {
+	for _, id := range d.RegisterIDs() {
+		if id.Key == state.KeyContractNames || strings.HasPrefix(id.Key, state.KeyCode+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotReader reads registers from a view without recording the reads. It
+// is safe for concurrent use, as long as the view is not modified.
+type snapshotReader struct {
+	mu     sync.Mutex
+	view   *delta.View
+	values map[string]flow.RegisterValue
+}
+
+func newSnapshotReader(view *delta.View) *snapshotReader {
+	return &snapshotReader{
+		view:   view,
+		values: make(map[string]flow.RegisterValue),
+	}
+}
+
+// Get returns the value of the register in the view.
+func (r *snapshotReader) Get(owner, controller, key string) (flow.RegisterValue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := flow.RegisterID{Owner: owner, Controller: controller, Key: key}
+	value, ok := r.values[id.String()]
+	if ok {
+		return value, nil
+	}
+
+	value, err := r.view.Peek(owner, controller, key)
+	if err != nil {
+		return nil, err
+	}
+	r.values[id.String()] = value
+
+	return value, nil
+}
@@ -0,0 +1,357 @@
+package computer_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/engine/execution/testutil"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/handler"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+)
+
+// TestParallelExecutionDeterminism executes random blocks serially and in
+// parallel, and checks that the results are identical.
+func TestParallelExecutionDeterminism(t *testing.T) {
+
+	t.Run("independent transactions", func(t *testing.T) {
+		block := generateRegisterBlock(3, 20, func(i int) string {
+			return fmt.Sprintf("r a%d\nw a%d\nr shared", i, i)
+		})
+		compareExecution(t, block)
+	})
+
+	t.Run("conflicting transactions", func(t *testing.T) {
+		block := generateRegisterBlock(2, 20, func(i int) string {
+			return fmt.Sprintf("r counter\nw counter\nr a%d\nw a%d", i, i)
+		})
+		compareExecution(t, block)
+	})
+
+	t.Run("programs and contract updates", func(t *testing.T) {
+		block := generateRegisterBlock(2, 20, func(i int) string {
+			switch i % 7 {
+			case 3:
+				return fmt.Sprintf("p Token\nc Token\nw a%d", i)
+			case 5:
+				return fmt.Sprintf("p Other%d\nr a%d", i, i)
+			default:
+				return fmt.Sprintf("p Token\nr a%d\nw a%d", i, i)
+			}
+		})
+		compareExecution(t, block)
+	})
+
+	t.Run("random workload", func(t *testing.T) {
+		for round := 0; round < 10; round++ {
+			block := generateRegisterBlock(3, 30, func(i int) string {
+				ops := make([]string, 0)
+				for j := 0; j < 1+rand.Intn(6); j++ {
+					key := fmt.Sprintf("k%d", rand.Intn(40))
+					switch rand.Intn(10) {
+					case 0:
+						ops = append(ops, "fail")
+					case 1:
+						ops = append(ops, "p Token")
+					case 2, 3, 4:
+						ops = append(ops, "w "+key)
+					default:
+						ops = append(ops, "r "+key)
+					}
+				}
+				return strings.Join(ops, "\n")
+			})
+			compareExecution(t, block)
+		}
+	})
+}
+
+// TestParallelExecutionDeterminism_FVM executes a block of token transfers
+// with the FVM serially and in parallel. All transactions run the fee
+// deduction and update the token contract, and each sender sends
+// several transactions with consecutive sequence numbers of the same key, so
+// the transactions conflict on shared registers.
+func TestParallelExecutionDeterminism_FVM(t *testing.T) {
+
+	chain := flow.Testnet.Chain()
+	vm := fvm.New(runtime.NewInterpreterRuntime())
+	ctx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+
+	ledger := testutil.RootBootstrappedLedger(vm, ctx)
+	privateKeys, err := testutil.GenerateAccountPrivateKeys(8)
+	require.NoError(t, err)
+	created, err := testutil.CreateAccounts(vm, ledger, fvm.NewEmptyPrograms(), privateKeys, chain)
+	require.NoError(t, err)
+
+	// The runtime orders the storage writes of a transaction with a
+	// comparison which is only consistent for accounts whose address is
+	// greater than the address of the token contract. For other accounts the
+	// writes, and hence the SPoCK secrets, depend on map iteration order even
+	// for serial execution, so only those accounts are used.
+	var (
+		accounts []flow.Address
+		keys     []flow.AccountPrivateKey
+	)
+	tokenAddress := fvm.FlowTokenAddress(chain)
+	for i, account := range created {
+		if bytes.Compare(account.Bytes(), tokenAddress.Bytes()) > 0 {
+			accounts = append(accounts, account)
+			keys = append(keys, privateKeys[i])
+		}
+	}
+	require.GreaterOrEqual(t, len(accounts), 2)
+
+	// fund the accounts from the service account
+	fundCtx := fvm.NewContextFromParent(ctx, fvm.WithTransactionProcessors(fvm.NewTransactionInvocator(zerolog.Nop())))
+	for i, account := range accounts {
+		fund := fvm.Transaction(transferTransaction(chain, chain.ServiceAddress(), account, "100.0"), uint32(i))
+		err = vm.Run(fundCtx, fund, ledger, fvm.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, fund.Err)
+	}
+
+	// each account sends three transfers to the next account, spread over
+	// two collections
+	var transactions []*flow.TransactionBody
+	for seq := uint64(0); seq < 3; seq++ {
+		for i, account := range accounts {
+			tx := transferTransaction(chain, account, accounts[(i+1)%len(accounts)], "1.0")
+			err = testutil.SignTransaction(tx, account, keys[i], seq)
+			require.NoError(t, err)
+			transactions = append(transactions, tx)
+		}
+	}
+	block := generateBlock(2, len(transactions)/2)
+	i := 0
+	for _, collection := range block.Collections() {
+		copy(collection.Transactions, transactions[i:])
+		i += len(collection.Transactions)
+	}
+
+	read := func(owner, controller, key string) (flow.RegisterValue, error) {
+		return ledger.Get(owner, controller, key)
+	}
+	serialResult, serialView := executeBlock(t, vm, ctx, block, read)
+	parallelResult, parallelView := executeBlock(t, vm, ctx, block, read, computer.WithParallelExecution(4))
+
+	// all transactions succeed, so the sequence numbers were checked in order
+	for _, result := range serialResult.TransactionResult {
+		assert.Empty(t, result.ErrorMessage)
+	}
+	compareResults(t, serialResult, serialView, parallelResult, parallelView)
+}
+
+// TestParallelExecution_ExecutionTracer tests that parallel execution can't be
+// enabled together with an execution tracer, which changes the coverage report
+// of the runtime shared by all transactions.
+func TestParallelExecution_ExecutionTracer(t *testing.T) {
+	ctx := fvm.NewContext(zerolog.Nop(), fvm.WithExecutionTracer(fvm.NewExecutionTracer()))
+
+	_, err := computer.NewBlockComputer(&registerVM{}, ctx, nil, nil, zerolog.Nop(), computer.WithParallelExecution(4))
+	require.Error(t, err)
+
+	_, err = computer.NewBlockComputer(&registerVM{}, ctx, nil, nil, zerolog.Nop())
+	require.NoError(t, err)
+}
+
+// transferTransaction returns a transaction transferring the given amount of
+// tokens between the given accounts.
+func transferTransaction(chain flow.Chain, from flow.Address, to flow.Address, amount string) *flow.TransactionBody {
+	return flow.NewTransactionBody().
+		SetScript([]byte(fmt.Sprintf(`
+			import FungibleToken from 0x%s
+			import FlowToken from 0x%s
+
+			transaction {
+				prepare(signer: AuthAccount) {
+					let vault = signer.borrow<&FlowToken.Vault>(from: /storage/flowTokenVault)
+						?? panic("could not borrow vault")
+					let payment <- vault.withdraw(amount: %s)
+					let receiver = getAccount(0x%s).getCapability(/public/flowTokenReceiver)!
+						.borrow<&{FungibleToken.Receiver}>()
+						?? panic("could not borrow receiver")
+					receiver.deposit(from: <-payment)
+				}
+			}`, fvm.FungibleTokenAddress(chain), fvm.FlowTokenAddress(chain), amount, to))).
+		AddAuthorizer(from)
+}
+
+// executeBlock executes the block with the given VM, on a view reading
+// registers with the given function.
+func executeBlock(
+	t *testing.T,
+	vm computer.VirtualMachine,
+	ctx fvm.Context,
+	block *entity.ExecutableBlock,
+	read delta.GetRegisterFunc,
+	opts ...computer.BlockComputerOption,
+) (*execution.ComputationResult, *delta.View) {
+
+	exe, err := computer.NewBlockComputer(vm, ctx, nil, nil, zerolog.Nop(), opts...)
+	require.NoError(t, err)
+
+	view := delta.NewView(read)
+	result, err := exe.ExecuteBlock(context.Background(), block, view, fvm.NewEmptyPrograms())
+	require.NoError(t, err)
+
+	return result, view
+}
+
+// compareExecution executes the block serially and in parallel, and checks
+// that the results and the resulting views are identical.
+func compareExecution(t *testing.T, block *entity.ExecutableBlock) {
+
+	execute := func(opts ...computer.BlockComputerOption) (*execution.ComputationResult, *delta.View) {
+		exe, err := computer.NewBlockComputer(&registerVM{}, fvm.NewContext(zerolog.Nop()), nil, nil, zerolog.Nop(), opts...)
+		require.NoError(t, err)
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return flow.RegisterValue(key), nil
+		})
+
+		programs := fvm.NewEmptyPrograms()
+		programs.Set(common.AddressLocation{Name: "Token"}, &interpreter.Program{})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.ChildPrograms())
+		require.NoError(t, err)
+
+		return result, view
+	}
+
+	serialResult, serialView := execute()
+	parallelResult, parallelView := execute(computer.WithParallelExecution(4))
+	compareResults(t, serialResult, serialView, parallelResult, parallelView)
+}
+
+// compareResults checks that the results and the resulting views of a serial
+// and a parallel execution of the same block are identical.
+func compareResults(
+	t *testing.T,
+	serialResult *execution.ComputationResult,
+	serialView *delta.View,
+	parallelResult *execution.ComputationResult,
+	parallelView *delta.View,
+) {
+
+	assert.Equal(t, serialResult.Events, parallelResult.Events)
+	assert.Equal(t, serialResult.ServiceEvents, parallelResult.ServiceEvents)
+	assert.Equal(t, serialResult.TransactionResult, parallelResult.TransactionResult)
+	assert.Equal(t, serialResult.GasUsed, parallelResult.GasUsed)
+	assert.Equal(t, serialResult.StateReads, parallelResult.StateReads)
+
+	require.Len(t, parallelResult.StateSnapshots, len(serialResult.StateSnapshots))
+	for i, snapshot := range serialResult.StateSnapshots {
+		assert.Equal(t, snapshot.SpockSecret, parallelResult.StateSnapshots[i].SpockSecret)
+		assert.Equal(t, snapshot.Delta, parallelResult.StateSnapshots[i].Delta)
+		assert.ElementsMatch(t, snapshot.Reads, parallelResult.StateSnapshots[i].Reads)
+	}
+
+	assert.Equal(t, serialView.Delta(), parallelView.Delta())
+	assert.Equal(t, serialView.SpockSecret(), parallelView.SpockSecret())
+	assert.ElementsMatch(t, serialView.Interactions().Reads, parallelView.Interactions().Reads)
+}
+
+// generateRegisterBlock generates a block whose transactions have scripts for
+// the register VM.
+func generateRegisterBlock(collectionCount, transactionCount int, script func(i int) string) *entity.ExecutableBlock {
+	block := generateBlock(collectionCount, transactionCount)
+	i := 0
+	for _, collection := range block.Collections() {
+		for _, tx := range collection.Transactions {
+			tx.Script = []byte(script(i))
+			i++
+		}
+	}
+	return block
+}
+
+// registerVM is a virtual machine which executes scripts of register
+// operations, one per line:
+//   - "r <key>" reads the register
+//   - "w <key>" writes a value derived from all values read so far
+//   - "p <name>" loads the program of a contract, reading its code if it is not cached
+//   - "c <name>" updates the code of a contract
+//   - "fail" fails the transaction
+type registerVM struct{}
+
+func (vm *registerVM) Run(ctx fvm.Context, proc fvm.Procedure, ledger state.Ledger, programs *fvm.Programs) error {
+	tx, ok := proc.(*fvm.TransactionProcedure)
+	if !ok {
+		return fmt.Errorf("unexpected procedure")
+	}
+
+	hasher := hash.NewSHA3_256()
+	_, _ = hasher.Write(tx.ID[:])
+
+	var failed, updated bool
+	for _, line := range strings.Split(string(tx.Transaction.Script), "\n") {
+		op := strings.Fields(line)
+		if len(op) == 0 {
+			continue
+		}
+
+		var err error
+		switch op[0] {
+		case "r":
+			var value flow.RegisterValue
+			value, err = ledger.Get("owner", "", op[1])
+			_, _ = hasher.Write(value)
+		case "w":
+			err = ledger.Set("owner", "", op[1], hasher.SumHash())
+		case "p":
+			location := common.AddressLocation{Name: op[1]}
+			if programs.Get(location) != nil {
+				err = ledger.Touch("owner", "", state.KeyCode+"."+op[1])
+				break
+			}
+			_, err = ledger.Get("owner", "", state.KeyCode+"."+op[1])
+			programs.Set(location, &interpreter.Program{})
+		case "c":
+			err = ledger.Set("owner", "", state.KeyCode+"."+op[1], hasher.SumHash())
+			updated = true
+		case "fail":
+			failed = true
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if failed {
+		programs.Cleanup(nil)
+		tx.Err = &fvm.MissingPayerError{}
+		return nil
+	}
+	if updated {
+		programs.Cleanup([]handler.ContractUpdateKey{{}})
+	} else {
+		programs.Cleanup(nil)
+	}
+
+	tx.Events = []flow.Event{{
+		Type:             "test",
+		TransactionID:    tx.ID,
+		TransactionIndex: tx.TxIndex,
+		Payload:          hasher.SumHash(),
+	}}
+	tx.GasUsed = uint64(len(tx.Transaction.Script))
+
+	return nil
+}
@@ -26,6 +26,7 @@ type VirtualMachine interface {
 
 type ComputationManager interface {
 	ExecuteScript([]byte, [][]byte, *flow.Header, *delta.View) ([]byte, error)
+	DryRunTransaction(*flow.TransactionBody, fvm.DryRunOptions, *flow.Header, *delta.View) (*fvm.TransactionProcedure, error)
 	ComputeBlock(
 		ctx context.Context,
 		block *entity.ExecutableBlock,
@@ -54,6 +55,7 @@ func New(
 	vm VirtualMachine,
 	vmCtx fvm.Context,
 	programsCacheSize uint,
+	parallelExecutionWorkers uint,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
@@ -63,6 +65,7 @@ func New(
 		metrics,
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
+		computer.WithParallelExecution(int(parallelExecutionWorkers)),
 	)
 
 	if err != nil {
@@ -119,6 +122,23 @@ func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *fl
 	return encodedValue, nil
 }
 
+// DryRunTransaction runs the transaction on the given view without committing
+// it. The changes the transaction would apply are recorded in the view.
+func (e *Manager) DryRunTransaction(txBody *flow.TransactionBody, opts fvm.DryRunOptions, blockHeader *flow.Header, view *delta.View) (*fvm.TransactionProcedure, error) {
+	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(blockHeader), fvm.WithDryRun(opts))
+
+	tx := fvm.Transaction(txBody, 0)
+
+	programs := e.getChildProgramsOrEmpty(blockHeader.ID())
+
+	err := e.vm.Run(blockCtx, tx, view, programs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dry-run transaction (internal error): %w", err)
+	}
+
+	return tx, nil
+}
+
 func (e *Manager) ComputeBlock(
 	ctx context.Context,
 	block *entity.ExecutableBlock,
@@ -13,6 +13,8 @@ import (
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
+
+	fvm "github.com/onflow/flow-go/fvm"
 )
 
 // ComputationManager is an autogenerated mock type for the ComputationManager type
@@ -43,6 +45,29 @@ func (_m *ComputationManager) ComputeBlock(ctx context.Context, block *entity.Ex
 	return r0, r1
 }
 
+// DryRunTransaction provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ComputationManager) DryRunTransaction(_a0 *flow.TransactionBody, _a1 fvm.DryRunOptions, _a2 *flow.Header, _a3 *delta.View) (*fvm.TransactionProcedure, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *fvm.TransactionProcedure
+	if rf, ok := ret.Get(0).(func(*flow.TransactionBody, fvm.DryRunOptions, *flow.Header, *delta.View) *fvm.TransactionProcedure); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fvm.TransactionProcedure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*flow.TransactionBody, fvm.DryRunOptions, *flow.Header, *delta.View) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScript provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *ComputationManager) ExecuteScript(_a0 []byte, _a1 [][]byte, _a2 *flow.Header, _a3 *delta.View) ([]byte, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
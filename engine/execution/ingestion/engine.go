@@ -1,6 +1,7 @@
 package ingestion
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -18,9 +19,13 @@ import (
 	"github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/engine/execution/statesync"
+	"github.com/onflow/flow-go/engine/execution/stopcontrol"
 	"github.com/onflow/flow-go/engine/execution/utils"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/entity"
@@ -45,6 +50,7 @@ type Engine struct {
 	state              protocol.State
 	receiptHasher      hash.Hasher // used as hasher to sign the execution receipt
 	blocks             storage.Blocks
+	seals              storage.Seals
 	collections        storage.Collections
 	events             storage.Events
 	serviceEvents      storage.ServiceEvents
@@ -57,11 +63,13 @@ type Engine struct {
 	tracer             module.Tracer
 	extensiveLogging   bool
 	spockHasher        hash.Hasher
-	syncThreshold      int                 // the threshold for how many sealed unexecuted blocks to trigger state syncing.
-	syncFilter         flow.IdentityFilter // specify the filter to sync state from
-	syncConduit        network.Conduit     // sending state syncing requests
-	syncDeltas         mempool.Deltas      // storing the synced state deltas
-	syncFast           bool                // sync fast allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up
+	syncConfig         statesync.Config       // configures syncing state from other execution nodes
+	syncFilter         flow.IdentityFilter    // specify the filter to sync state from
+	syncConduit        network.Conduit        // sending state syncing requests
+	syncDeltas         mempool.Deltas         // storing the synced state deltas
+	syncCore           *statesync.Core        // tracking state sync requests and peers
+	syncLimiter        *statesync.RateLimiter // limiting state sync requests served to peers
+	stopControl        *stopcontrol.Control   // holding back blocks above the stop height
 }
 
 func New(
@@ -71,6 +79,7 @@ func New(
 	request module.Requester,
 	state protocol.State,
 	blocks storage.Blocks,
+	seals storage.Seals,
 	collections storage.Collections,
 	events storage.Events,
 	serviceEvents storage.ServiceEvents,
@@ -83,8 +92,8 @@ func New(
 	extLog bool,
 	syncFilter flow.IdentityFilter,
 	syncDeltas mempool.Deltas,
-	syncThreshold int,
-	syncFast bool,
+	syncConfig statesync.Config,
+	stopControl *stopcontrol.Control,
 ) (*Engine, error) {
 	log := logger.With().Str("engine", "ingestion").Logger()
 
@@ -99,6 +108,7 @@ func New(
 		receiptHasher:      utils.NewExecutionReceiptHasher(),
 		spockHasher:        utils.NewSPOCKHasher(),
 		blocks:             blocks,
+		seals:              seals,
 		collections:        collections,
 		events:             events,
 		serviceEvents:      serviceEvents,
@@ -110,10 +120,12 @@ func New(
 		metrics:            metrics,
 		tracer:             tracer,
 		extensiveLogging:   extLog,
+		syncConfig:         syncConfig,
 		syncFilter:         syncFilter,
-		syncThreshold:      syncThreshold,
 		syncDeltas:         syncDeltas,
-		syncFast:           syncFast,
+		syncCore:           statesync.New(log, syncConfig),
+		syncLimiter:        statesync.NewRateLimiter(syncConfig.ServeRate, syncConfig.ServeBurst),
+		stopControl:        stopControl,
 	}
 
 	// move to state syncing engine
@@ -130,11 +142,21 @@ func New(
 // Ready returns a channel that will close when the engine has
 // successfully started.
 func (e *Engine) Ready() <-chan struct{} {
-	err := e.reloadUnexecutedBlocks()
+	executedHeight, _, err := e.execState.GetHighestExecutedBlockID(e.unit.Ctx())
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("failed to get highest executed height")
+	}
+	e.stopControl.Executed(executedHeight)
+
+	err = e.reloadUnexecutedBlocks()
 	if err != nil {
 		e.log.Fatal().Err(err).Msg("failed to load all unexecuted blocks")
 	}
 
+	if e.syncConfig.Enabled {
+		e.unit.LaunchPeriodically(e.checkStateSync, e.syncConfig.CheckInterval, 0)
+	}
+
 	return e.unit.Ready()
 }
 
@@ -173,7 +195,14 @@ func (e *Engine) Process(originID flow.Identifier, event interface{}) error {
 }
 
 func (e *Engine) process(originID flow.Identifier, event interface{}) error {
-	return nil
+	switch ev := event.(type) {
+	case *messages.ExecutionStateSyncRequest:
+		return e.onExecutionStateSyncRequest(originID, ev)
+	case *messages.ExecutionStateDelta:
+		return e.onExecutionStateDelta(originID, ev)
+	default:
+		return fmt.Errorf("invalid event type (%T)", event)
+	}
 }
 
 func (e *Engine) finalizedUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.Identifier, error) {
@@ -455,14 +484,6 @@ func (e *Engine) enqueueBlockAndCheckExecutable(
 	}
 
 	firstUnexecutedHeight := queue.Head.Item.Height()
-	// disable state syncing for now
-	// if checkStateSync {
-	// 	// whenever the queue grows, we need to check whether the state sync should be
-	// 	// triggered.
-	// 	e.unit.Launch(func() {
-	// 		e.checkStateSyncStart(firstUnexecutedHeight)
-	// 	})
-	// }
 
 	// check if a block is executable.
 	// a block is executable if the following conditions are all true
@@ -603,8 +624,7 @@ func (e *Engine) onBlockExecuted(executed *entity.ExecutableBlock, finalState fl
 
 	e.metrics.ExecutionStorageStateCommitment(int64(len(finalState)))
 	e.metrics.ExecutionLastExecutedBlockHeight(executed.Block.Header.Height)
-
-	// e.checkStateSyncStop(executed.Block.Header.Height)
+	e.stopControl.Executed(executed.Block.Header.Height)
 
 	err := e.mempool.Run(
 		func(
@@ -684,29 +704,42 @@ func (e *Engine) executeBlockIfComplete(eb *entity.ExecutableBlock) bool {
 	// if the eb has parent statecommitment, and we have the delta for this block
 	// then apply the delta
 	// note the block ID is the delta's ID
-	// delta, found := e.syncDeltas.ByBlockID(eb.Block.ID())
-	// if found {
-	// 	// double check before applying the state delta
-	// 	if bytes.Equal(eb.StartState, delta.ExecutableBlock.StartState) {
-	// 		e.unit.Launch(func() {
-	// 			e.applyStateDelta(delta)
-	// 		})
-	// 		return true
-	// 	}
-	//
-	// 	// if state delta is invalid, remove the delta and log error
-	// 	e.log.Error().
-	// 		Hex("block_start_state", eb.StartState).
-	// 		Hex("delta_start_state", delta.ExecutableBlock.StartState).
-	// 		Msg("can not apply the state delta, the start state does not match")
-	//
-	// 	e.syncDeltas.Rem(eb.Block.ID())
-	// }
+	delta, found := e.syncDeltas.ByBlockID(eb.Block.ID())
+	if found {
+		// blocks above the stop height are held back, and their deltas applied
+		// once the stop height is raised or removed
+		if !e.stopControl.Begin(eb) {
+			return false
+		}
+
+		// removing the delta makes sure it is applied only once
+		removed := e.syncDeltas.Rem(eb.Block.ID())
+
+		// double check before applying the state delta
+		if removed && bytes.Equal(eb.StartState, delta.ExecutableBlock.StartState) {
+			e.unit.Launch(func() {
+				e.applyStateDelta(eb, delta)
+			})
+			return true
+		}
+
+		// if state delta is invalid, log error and fall back to executing the block
+		e.log.Error().
+			Hex("block_start_state", eb.StartState).
+			Hex("delta_start_state", delta.ExecutableBlock.StartState).
+			Msg("can not apply the state delta, the start state does not match")
+	}
 
 	// if don't have the delta, then check if everything is ready for executing
 	// the block
 	if eb.IsComplete() {
 
+		// blocks above the stop height are held back until the stop height is
+		// raised or removed
+		if !e.stopControl.Begin(eb) {
+			return false
+		}
+
 		if e.extensiveLogging {
 			e.logExecutableBlock(eb)
 		}
@@ -855,12 +888,9 @@ func (e *Engine) matchOrRequestCollections(
 	// The sync-fast mode can be turned on by the `sync-fast=true` flag.
 	// When it's turned on, it will skip fetching collections, and will
 	// rely on the state syncing to catch up.
-	// if e.syncFast {
-	// 	isSyncing := e.isSyncingState()
-	// 	if isSyncing {
-	// 		return nil
-	// 	}
-	// }
+	if e.syncConfig.Fast && e.syncCore.Syncing() {
+		return nil
+	}
 
 	// make sure that the requests are dispatched immediately by the requester
 	if len(executableBlock.Block.Payload.Guarantees) > 0 {
@@ -955,7 +985,7 @@ func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, argu
 		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
 	}
 
-	blockView := e.execState.NewView(stateCommit)
+	blockView := e.execState.NewBlockView(stateCommit, block)
 
 	if e.extensiveLogging {
 		args := make([]string, 0)
@@ -973,6 +1003,38 @@ func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, argu
 	return e.computationManager.ExecuteScript(script, arguments, block, blockView)
 }
 
+func (e *Engine) DryRunTransactionAtBlockID(ctx context.Context, tx *flow.TransactionBody, opts fvm.DryRunOptions, blockID flow.Identifier) (*fvm.TransactionProcedure, delta.Delta, error) {
+
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, delta.Delta{}, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, delta.Delta{}, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	blockView := e.execState.NewBlockView(stateCommit, block)
+
+	if e.extensiveLogging {
+		e.log.Debug().
+			Hex("block_id", logging.ID(blockID)).
+			Uint64("block_height", block.Height).
+			Hex("state_commitment", stateCommit).
+			Hex("tx_id", logging.Entity(tx)).
+			Hex("script_hex", tx.Script).
+			Msg("extensive log: dry-run transaction content")
+	}
+
+	txProc, err := e.computationManager.DryRunTransaction(tx, opts, block, blockView)
+	if err != nil {
+		return nil, delta.Delta{}, err
+	}
+
+	return txProc, blockView.Delta(), nil
+}
+
 func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow.Identifier) (*flow.Account, error) {
 	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
 	if err != nil {
@@ -984,7 +1046,7 @@ func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow
 		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
 	}
 
-	blockView := e.execState.NewView(stateCommit)
+	blockView := e.execState.NewBlockView(stateCommit, block)
 
 	return e.computationManager.GetAccount(addr, block, blockView)
 }
@@ -1015,6 +1077,7 @@ func (e *Engine) handleComputationResult(
 		result.ServiceEvents,
 		result.TransactionResult,
 		startState,
+		nil,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not save execution results: %w", err)
@@ -1047,7 +1110,9 @@ func (e *Engine) handleComputationResult(
 	return finalState, receipt, nil
 }
 
-// save the execution result of a block
+// save the execution result of a block. If the seal of the block is given, the
+// results are only saved if applying the state interactions results in the
+// sealed state, and the execution result built from them is the sealed result.
 func (e *Engine) saveExecutionResults(
 	ctx context.Context,
 	executableBlock *entity.ExecutableBlock,
@@ -1056,6 +1121,7 @@ func (e *Engine) saveExecutionResults(
 	serviceEvents []flow.Event,
 	txResults []flow.TransactionResult,
 	startState flow.StateCommitment,
+	seal *flow.Seal,
 ) (*flow.ExecutionResult, error) {
 
 	span, childCtx := e.tracer.StartSpanFromContext(ctx, trace.EXESaveExecutionResults)
@@ -1064,10 +1130,8 @@ func (e *Engine) saveExecutionResults(
 	originalState := startState
 	blockID := executableBlock.ID()
 
-	// no need to persist the state interactions, since they are used only by state
-	// syncing, which is currently disabled
-
 	chunks := make([]*flow.Chunk, len(stateInteractions))
+	chunkDataPacks := make([]*flow.ChunkDataPack, len(stateInteractions))
 
 	// TODO: check current state root == startState
 	var endState flow.StateCommitment = startState
@@ -1104,26 +1168,47 @@ func (e *Engine) saveExecutionResults(
 			)
 		}
 
-		chdp := generateChunkDataPack(chunk, collectionID, proof)
-
-		err = e.execState.PersistChunkDataPack(childCtx, chdp, blockID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to save chunk data pack: %w", err)
-		}
-
 		// TODO use view.SpockSecret() as an input to spock generator
 		chunks[i] = chunk
+		chunkDataPacks[i] = generateChunkDataPack(chunk, collectionID, proof)
 		startState = endState
 	}
 
-	err := e.execState.PersistStateCommitment(childCtx, blockID, endState)
+	executionResult, err := e.generateExecutionResultForBlock(childCtx, executableBlock.Block, chunks, endState, serviceEvents)
 	if err != nil {
-		return nil, fmt.Errorf("failed to store state commitment: %w", err)
+		return nil, fmt.Errorf("could not generate execution result: %w", err)
 	}
 
-	executionResult, err := e.generateExecutionResultForBlock(childCtx, executableBlock.Block, chunks, endState, serviceEvents)
+	// nothing is stored before the result is checked, so that the block can
+	// still be executed after the state interactions of a peer were rejected
+	if seal != nil {
+		if !bytes.Equal(endState, seal.FinalState) {
+			return nil, fmt.Errorf("%w: expected %x, got %x", errEndStateMismatch, seal.FinalState, endState)
+		}
+		if executionResult.ID() != seal.ResultID {
+			return nil, fmt.Errorf("%w: expected %x, got %x", errResultMismatch, seal.ResultID, executionResult.ID())
+		}
+	}
+
+	// the state interactions are only used to serve state deltas to syncing
+	// peers, and to index the updated registers
+	if e.syncConfig.Serve || e.execState.RegisterIndexEnabled() {
+		err := e.execState.PersistStateInteractions(childCtx, blockID, stateInteractions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to persist state interactions: %w", err)
+		}
+	}
+
+	for _, chdp := range chunkDataPacks {
+		err := e.execState.PersistChunkDataPack(childCtx, chdp, blockID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save chunk data pack: %w", err)
+		}
+	}
+
+	err = e.execState.PersistStateCommitment(childCtx, blockID, endState)
 	if err != nil {
-		return nil, fmt.Errorf("could not generate execution result: %w", err)
+		return nil, fmt.Errorf("failed to store state commitment: %w", err)
 	}
 
 	err = e.execState.PersistExecutionResult(childCtx, executionResult)
@@ -1159,6 +1244,12 @@ func (e *Engine) saveExecutionResults(
 			if err != nil {
 				return fmt.Errorf("failed to store service events: %w", err)
 			}
+			// service events are also stored by themselves, so that state deltas
+			// include them for syncing peers to rebuild the sealed result
+			err = e.serviceEvents.Store(blockID, ch)
+			if err != nil {
+				return fmt.Errorf("failed to store service events: %w", err)
+			}
 		}
 
 		return nil
@@ -5,10 +5,12 @@ import (
 	"context"
 	"crypto/rand"
 	mathRand "math/rand"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -21,6 +23,8 @@ import (
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	state "github.com/onflow/flow-go/engine/execution/state/mock"
 	executionUnittest "github.com/onflow/flow-go/engine/execution/state/unittest"
+	"github.com/onflow/flow-go/engine/execution/statesync"
+	"github.com/onflow/flow-go/engine/execution/stopcontrol"
 	"github.com/onflow/flow-go/engine/testutil/mocklocal"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -31,6 +35,7 @@ import (
 	"github.com/onflow/flow-go/network/mocknetwork"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storageerr "github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
 	storage "github.com/onflow/flow-go/storage/mocks"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/mocks"
@@ -102,6 +107,10 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 
 	var engine *Engine
 
+	db, dbDir := unittest.TempBadgerDB(t)
+	defer os.RemoveAll(dbDir)
+	defer db.Close()
+
 	defer func() {
 		<-engine.Done()
 		ctrl.Finish()
@@ -141,6 +150,9 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 	deltas, err := NewDeltas(1000)
 	require.NoError(t, err)
 
+	stopControl, err := stopcontrol.New(log, db, metrics)
+	require.NoError(t, err)
+
 	engine, err = New(
 		log,
 		net,
@@ -148,6 +160,7 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		request,
 		protocolState,
 		blocks,
+		new(storagemock.Seals),
 		collections,
 		events,
 		serviceEvents,
@@ -160,8 +173,8 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		false,
 		filter.Any,
 		deltas,
-		10,
-		false,
+		statesync.DefaultConfig(),
+		stopControl,
 	)
 	require.NoError(t, err)
 
@@ -212,6 +225,11 @@ func (ctx *testingContext) assertSuccessfulBlockComputation(executableBlock *ent
 
 	ctx.executionState.On("NewView", executableBlock.StartState).Return(new(delta.View))
 
+	// state interactions are persisted, as serving state deltas is enabled by default
+	ctx.executionState.
+		On("PersistStateInteractions", mock.Anything, executableBlock.Block.ID(), mock.Anything).
+		Return(nil)
+
 	ctx.executionState.
 		On("GetExecutionResultID", mock.Anything, executableBlock.Block.Header.ParentID).
 		Return(previousExecutionResultID, nil)
@@ -504,7 +522,7 @@ func TestExecuteScriptAtBlockID(t *testing.T) {
 
 		ctx.state.On("AtBlockID", blockA.Block.ID()).Return(snapshot)
 		view := new(delta.View)
-		ctx.executionState.On("NewView", blockA.StartState).Return(view)
+		ctx.executionState.On("NewBlockView", blockA.StartState, blockA.Block.Header).Return(view)
 
 		// Successful call to computation manager
 		ctx.computationManager.
@@ -578,7 +596,7 @@ func Test_SPOCKGeneration(t *testing.T) {
 // 	require.True(t, shouldTriggerStateSync(20, 29, 10))
 // }
 
-func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.ExecutionState) *Engine {
+func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.ExecutionState, db *badger.DB) *Engine {
 	log := unittest.Logger()
 	metrics := metrics.NewNoopCollector()
 	tracer, err := trace.NewTracer(log, "test")
@@ -611,6 +629,9 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 	deltas, err := NewDeltas(10)
 	require.NoError(t, err)
 
+	stopControl, err := stopcontrol.New(log, db, metrics)
+	require.NoError(t, err)
+
 	engine, err = New(
 		log,
 		net,
@@ -618,6 +639,7 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		request,
 		ps,
 		blocks,
+		new(storagemock.Seals),
 		collections,
 		events,
 		events,
@@ -630,8 +652,8 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		false,
 		filter.Any,
 		deltas,
-		10,
-		false,
+		statesync.DefaultConfig(),
+		stopControl,
 	)
 
 	require.NoError(t, err)
@@ -646,6 +668,10 @@ func logChain(chain []*flow.Block) {
 }
 
 func TestLoadingUnexecutedBlocks(t *testing.T) {
+	db, dbDir := unittest.TempBadgerDB(t)
+	defer os.RemoveAll(dbDir)
+	defer db.Close()
+
 	t.Run("only genesis", func(t *testing.T) {
 		ps := mocks.NewProtocolState()
 
@@ -657,7 +683,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		require.NoError(t, ps.Bootstrap(genesis, result, seal))
 
 		es := mocks.NewExecutionState(seal)
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		finalized, pending, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
@@ -682,7 +708,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		require.NoError(t, ps.Extend(blockD))
 
 		es := mocks.NewExecutionState(seal)
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		finalized, pending, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
@@ -707,7 +733,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		require.NoError(t, ps.Extend(blockD))
 
 		es := mocks.NewExecutionState(seal)
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		es.ExecuteBlock(t, blockA)
 		es.ExecuteBlock(t, blockB)
@@ -737,7 +763,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		require.NoError(t, ps.Finalize(blockC.ID()))
 
 		es := mocks.NewExecutionState(seal)
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		es.ExecuteBlock(t, blockA)
 		es.ExecuteBlock(t, blockB)
@@ -768,7 +794,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		require.NoError(t, ps.Finalize(blockC.ID()))
 
 		es := mocks.NewExecutionState(seal)
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		es.ExecuteBlock(t, blockA)
 		es.ExecuteBlock(t, blockB)
@@ -798,7 +824,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		require.NoError(t, ps.Finalize(blockA.ID()))
 
 		es := mocks.NewExecutionState(seal)
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		es.ExecuteBlock(t, blockA)
 		es.ExecuteBlock(t, blockB)
@@ -854,7 +880,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 
 		es := mocks.NewExecutionState(seal)
 
-		engine := newIngestionEngine(t, ps, es)
+		engine := newIngestionEngine(t, ps, es, db)
 
 		es.ExecuteBlock(t, blockA)
 		es.ExecuteBlock(t, blockB)
@@ -0,0 +1,12 @@
+package ingestion
+
+// IngestAdmin represents the admin calls that the execution ingest engine exposes to operators
+type IngestAdmin interface {
+
+	// SetStopHeight sets the height of the last block to execute before stopping, 0 removes the stop height
+	SetStopHeight(height uint64) error
+
+	// StopHeight returns the height of the last block to execute, whether it is set, and whether the
+	// execution stopped at it
+	StopHeight() (height uint64, set bool, stopped bool)
+}
@@ -3,6 +3,8 @@ package ingestion
 import (
 	"context"
 
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -12,6 +14,10 @@ type IngestRPC interface {
 	// ExecuteScriptAtBlockID executes a script at the given Block id
 	ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error)
 
+	// DryRunTransactionAtBlockID runs a transaction at the given Block id without committing it, and returns
+	// the executed transaction along with the register changes it would apply
+	DryRunTransactionAtBlockID(ctx context.Context, tx *flow.TransactionBody, opts fvm.DryRunOptions, blockID flow.Identifier) (*fvm.TransactionProcedure, delta.Delta, error)
+
 	// GetAccount returns the Account details at the given Block id
 	GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error)
 }
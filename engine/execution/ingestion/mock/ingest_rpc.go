@@ -8,6 +8,9 @@ import (
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
+
+	delta "github.com/onflow/flow-go/engine/execution/state/delta"
+	fvm "github.com/onflow/flow-go/fvm"
 )
 
 // IngestRPC is an autogenerated mock type for the IngestRPC type
@@ -15,6 +18,36 @@ type IngestRPC struct {
 	mock.Mock
 }
 
+// DryRunTransactionAtBlockID provides a mock function with given fields: ctx, tx, opts, blockID
+func (_m *IngestRPC) DryRunTransactionAtBlockID(ctx context.Context, tx *flow.TransactionBody, opts fvm.DryRunOptions, blockID flow.Identifier) (*fvm.TransactionProcedure, delta.Delta, error) {
+	ret := _m.Called(ctx, tx, opts, blockID)
+
+	var r0 *fvm.TransactionProcedure
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, fvm.DryRunOptions, flow.Identifier) *fvm.TransactionProcedure); ok {
+		r0 = rf(ctx, tx, opts, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fvm.TransactionProcedure)
+		}
+	}
+
+	var r1 delta.Delta
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, fvm.DryRunOptions, flow.Identifier) delta.Delta); ok {
+		r1 = rf(ctx, tx, opts, blockID)
+	} else {
+		r1 = ret.Get(1).(delta.Delta)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *flow.TransactionBody, fvm.DryRunOptions, flow.Identifier) error); ok {
+		r2 = rf(ctx, tx, opts, blockID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ExecuteScriptAtBlockID provides a mock function with given fields: ctx, script, arguments, blockID
 func (_m *IngestRPC) ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error) {
 	ret := _m.Called(ctx, script, arguments, blockID)
@@ -0,0 +1,390 @@
+package ingestion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// errEndStateMismatch is returned when applying the state interactions of a
+// block does not result in the expected state commitment.
+var errEndStateMismatch = errors.New("end state does not match")
+
+// errResultMismatch is returned when the execution result built from the state
+// interactions and service events of a block is not the sealed result.
+var errResultMismatch = errors.New("execution result does not match")
+
+// checkStateSync starts or stops state syncing, depending on how far the
+// executed state is behind the sealed state. While syncing, it requests the
+// state deltas of the next sealed blocks from other execution nodes.
+func (e *Engine) checkStateSync() {
+
+	executedHeight, _, err := e.execState.GetHighestExecutedBlockID(e.unit.Ctx())
+	if err != nil {
+		e.log.Err(err).Msg("could not get highest executed block for state sync")
+		return
+	}
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		e.log.Err(err).Msg("could not get sealed block for state sync")
+		return
+	}
+
+	syncing, changed := e.syncCore.Update(executedHeight, sealed.Height)
+	if changed {
+		e.metrics.ExecutionSync(syncing)
+
+		// in fast mode, no collections were requested while syncing, so they
+		// are requested now for all blocks which still need to be executed
+		if !syncing && e.syncConfig.Fast {
+			e.requestQueuedCollections()
+		}
+	}
+	if !syncing {
+		return
+	}
+
+	e.metrics.ExecutionSyncTargetHeight(sealed.Height)
+
+	peers, err := e.state.Final().Identities(filter.And(
+		filter.HasRole(flow.RoleExecution),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+		e.syncFilter,
+	))
+	if err != nil {
+		e.log.Err(err).Msg("could not get execution nodes for state sync")
+		return
+	}
+
+	req, peerID, ok := e.syncCore.NextRequest(time.Now(), executedHeight, peers)
+	if !ok {
+		return
+	}
+
+	err = e.syncConduit.Unicast(req, peerID)
+	if err != nil {
+		e.log.Err(err).
+			Hex("peer_id", logging.ID(peerID)).
+			Msg("could not send state sync request")
+		return
+	}
+
+	e.log.Debug().
+		Hex("peer_id", logging.ID(peerID)).
+		Uint64("from_height", req.FromHeight).
+		Uint64("to_height", req.ToHeight).
+		Msg("state sync request sent")
+}
+
+// requestQueuedCollections requests the collections of all blocks in the
+// execution queues, and executes the blocks which are ready.
+func (e *Engine) requestQueuedCollections() {
+	err := e.mempool.Run(
+		func(
+			blockByCollection *stdmap.BlockByCollectionBackdata,
+			executionQueues *stdmap.QueuesBackdata,
+		) error {
+			for _, queue := range executionQueues.All() {
+				for _, node := range queue.Nodes {
+					executableBlock := node.Item.(*entity.ExecutableBlock)
+					err := e.matchOrRequestCollections(executableBlock, blockByCollection)
+					if err != nil {
+						return fmt.Errorf("cannot send collection requests: %w", err)
+					}
+				}
+				e.executeBlockIfComplete(queue.Head.Item.(*entity.ExecutableBlock))
+			}
+			return nil
+		})
+
+	if err != nil {
+		e.log.Err(err).Msg("could not request collections after state sync")
+	}
+}
+
+// onExecutionStateSyncRequest serves the state deltas of the requested sealed
+// blocks to another execution node.
+func (e *Engine) onExecutionStateSyncRequest(originID flow.Identifier, req *messages.ExecutionStateSyncRequest) error {
+
+	if !e.syncConfig.Serve {
+		e.log.Debug().
+			Hex("origin_id", logging.ID(originID)).
+			Msg("ignoring state sync request, serving state deltas is disabled")
+		return nil
+	}
+
+	identity, err := e.state.Final().Identity(originID)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("state sync request from unknown node %x: %w", originID, err)
+	}
+	if identity.Role != flow.RoleExecution {
+		return engine.NewInvalidInputErrorf("state sync request from non-execution node %x (role: %s)", originID, identity.Role)
+	}
+
+	if !e.syncLimiter.Allow(originID, time.Now()) {
+		e.log.Debug().
+			Hex("origin_id", logging.ID(originID)).
+			Msg("dropping state sync request, rate limit exceeded")
+		return nil
+	}
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed block: %w", err)
+	}
+
+	// only sealed blocks are served, and at most one batch per request
+	fromHeight := req.FromHeight
+	toHeight := req.ToHeight
+	if toHeight > sealed.Height {
+		toHeight = sealed.Height
+	}
+	if fromHeight > toHeight {
+		return nil
+	}
+	if toHeight-fromHeight >= e.syncConfig.BatchSize {
+		toHeight = fromHeight + e.syncConfig.BatchSize - 1
+	}
+
+	for height := fromHeight; height <= toHeight; height++ {
+		header, err := e.state.AtHeight(height).Head()
+		if err != nil {
+			return fmt.Errorf("could not get sealed block at height %d: %w", height, err)
+		}
+		blockID := header.ID()
+
+		delta, err := e.execState.RetrieveStateDelta(e.unit.Ctx(), blockID)
+		if err != nil {
+			// the block might not be executed yet, or was executed before
+			// serving state deltas was enabled
+			e.log.Debug().Err(err).
+				Hex("block_id", logging.ID(blockID)).
+				Uint64("height", height).
+				Msg("could not retrieve state delta to serve")
+			return nil
+		}
+
+		err = e.syncConduit.Unicast(delta, originID)
+		if err != nil {
+			return fmt.Errorf("could not send state delta for block %x: %w", blockID, err)
+		}
+	}
+
+	e.log.Debug().
+		Hex("origin_id", logging.ID(originID)).
+		Uint64("from_height", fromHeight).
+		Uint64("to_height", toHeight).
+		Msg("served state sync request")
+
+	return nil
+}
+
+// onExecutionStateDelta handles a state delta received from another execution
+// node. Valid deltas are stored, and applied instead of executing the block
+// once its parent has been executed.
+func (e *Engine) onExecutionStateDelta(originID flow.Identifier, delta *messages.ExecutionStateDelta) error {
+
+	blockID := delta.ID()
+
+	if !e.syncCore.HandleDelta(originID, blockID, delta.Height()) {
+		return engine.NewInvalidInputErrorf("unsolicited state delta for block %x from %x", blockID, originID)
+	}
+
+	err := e.validateStateDelta(delta)
+	if engine.IsInvalidInputError(err) {
+		e.syncCore.ReportInvalid(originID)
+		e.metrics.ExecutionSyncDeltaRejected()
+		return fmt.Errorf("invalid state delta from %x: %w", originID, err)
+	}
+	if err != nil {
+		return fmt.Errorf("could not validate state delta for block %x: %w", blockID, err)
+	}
+
+	added := e.syncDeltas.Add(delta)
+	if !added {
+		return nil
+	}
+
+	// if the block is waiting to be executed, apply the delta right away
+	err = e.mempool.Run(
+		func(
+			_ *stdmap.BlockByCollectionBackdata,
+			executionQueues *stdmap.QueuesBackdata,
+		) error {
+			queue, exists := executionQueues.ByID(blockID)
+			if !exists {
+				return nil
+			}
+			e.executeBlockIfComplete(queue.Head.Item.(*entity.ExecutableBlock))
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("could not check block for state delta: %w", err)
+	}
+
+	return nil
+}
+
+// validateStateDelta checks that the delta is for a sealed block, and matches
+// the block's payload and sealed state commitment. It replaces the block of the
+// delta with our own copy. Invalid deltas are reported as invalid input errors.
+func (e *Engine) validateStateDelta(delta *messages.ExecutionStateDelta) error {
+
+	blockID := delta.ID()
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed block: %w", err)
+	}
+	if delta.Height() > sealed.Height {
+		return engine.NewInvalidInputErrorf("block %x at height %d is not sealed", blockID, delta.Height())
+	}
+
+	header, err := e.state.AtHeight(delta.Height()).Head()
+	if err != nil {
+		return fmt.Errorf("could not get finalized block at height %d: %w", delta.Height(), err)
+	}
+	if header.ID() != blockID {
+		return engine.NewInvalidInputErrorf("block %x is not finalized", blockID)
+	}
+
+	block, err := e.blocks.ByID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not get block %x: %w", blockID, err)
+	}
+	delta.Block = block
+
+	guarantees := block.Payload.Guarantees
+	if len(delta.CompleteCollections) != len(guarantees) {
+		return engine.NewInvalidInputErrorf("delta has %d collections, block has %d", len(delta.CompleteCollections), len(guarantees))
+	}
+	for _, guarantee := range guarantees {
+		collection, ok := delta.CompleteCollections[guarantee.ID()]
+		if !ok || collection.Collection().ID() != guarantee.CollectionID {
+			return engine.NewInvalidInputErrorf("delta is missing collection %x", guarantee.CollectionID)
+		}
+		collection.Guarantee = guarantee
+	}
+
+	// there is one chunk per collection, plus the system chunk
+	if len(delta.StateInteractions) != len(guarantees)+1 {
+		return engine.NewInvalidInputErrorf("delta has %d state interactions, block has %d chunks", len(delta.StateInteractions), len(guarantees)+1)
+	}
+
+	seal, err := e.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return fmt.Errorf("could not get finalized seal for block: %w", err)
+	}
+	if !bytes.Equal(delta.EndState, seal.FinalState) {
+		return engine.NewInvalidInputErrorf("end state %x does not match sealed state %x", delta.EndState, seal.FinalState)
+	}
+
+	parentCommit, err := e.execState.StateCommitmentByBlockID(e.unit.Ctx(), block.Header.ParentID)
+	if err == nil && !bytes.Equal(delta.StartState, parentCommit) {
+		return engine.NewInvalidInputErrorf("start state %x does not match parent state %x", delta.StartState, parentCommit)
+	}
+
+	return nil
+}
+
+// applyStateDelta applies a state delta received from a peer instead of
+// executing the block. If the delta does not result in the sealed state and
+// result, the peer is penalized and the block is executed with the collections
+// from the delta instead.
+//
+// The sealed result commits to the service events of the block, but not to its
+// events and transaction results. Those of the peer are therefore not stored,
+// and this node does not serve the events and transaction results of the blocks
+// it synced.
+func (e *Engine) applyStateDelta(executableBlock *entity.ExecutableBlock, delta *messages.ExecutionStateDelta) {
+
+	ctx := e.unit.Ctx()
+	blockID := executableBlock.ID()
+
+	executed, err := state.IsBlockExecuted(ctx, e.execState, blockID)
+	if err != nil {
+		e.log.Err(err).
+			Hex("block_id", logging.ID(blockID)).
+			Msg("could not check whether block was executed")
+		return
+	}
+	if executed {
+		e.log.Debug().
+			Hex("block_id", logging.ID(blockID)).
+			Msg("block already executed, skipping state delta")
+		return
+	}
+
+	// the collections of the delta were validated against the block payload
+	synced := &entity.ExecutableBlock{
+		Block:               executableBlock.Block,
+		CompleteCollections: delta.CompleteCollections,
+		StartState:          executableBlock.StartState,
+	}
+
+	seal, err := e.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		e.log.Err(err).
+			Hex("block_id", logging.ID(blockID)).
+			Msg("could not get finalized seal for block")
+		return
+	}
+
+	_, err = e.saveExecutionResults(
+		ctx,
+		synced,
+		delta.StateInteractions,
+		nil,
+		delta.ServiceEvents,
+		nil,
+		synced.StartState,
+		seal,
+	)
+	if errors.Is(err, errEndStateMismatch) || errors.Is(err, errResultMismatch) {
+		originID, ok := e.syncCore.Origin(blockID)
+		if ok {
+			e.syncCore.ReportInvalid(originID)
+		}
+		e.metrics.ExecutionSyncDeltaRejected()
+		e.log.Warn().Err(err).
+			Hex("block_id", logging.ID(blockID)).
+			Hex("origin_id", logging.ID(originID)).
+			Msg("invalid state delta, executing block instead")
+
+		e.executeBlock(ctx, synced)
+		return
+	}
+	if err != nil {
+		e.log.Err(err).
+			Hex("block_id", logging.ID(blockID)).
+			Msg("could not apply state delta")
+		return
+	}
+
+	e.syncCore.ReportValid(blockID, time.Now())
+	e.metrics.ExecutionSyncDeltaApplied()
+	e.metrics.FinishBlockReceivedToExecuted(blockID)
+
+	e.log.Info().
+		Hex("block_id", logging.ID(blockID)).
+		Uint64("block_height", executableBlock.Block.Header.Height).
+		Hex("start_state", synced.StartState).
+		Hex("final_state", delta.EndState).
+		Msg("applied state delta")
+
+	err = e.onBlockExecuted(executableBlock, delta.EndState)
+	if err != nil {
+		e.log.Err(err).Msg("failed in process block's children")
+	}
+}
@@ -0,0 +1,55 @@
+package ingestion
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+)
+
+// SetStopHeight sets the height of the last block to execute before stopping.
+// Blocks above it, including blocks which are queued already, are held back
+// until the stop height is raised or removed, while scripts and results keep
+// being served. A height of 0 removes the stop height.
+func (e *Engine) SetStopHeight(height uint64) error {
+	executedHeight, _, err := e.execState.GetHighestExecutedBlockID(e.unit.Ctx())
+	if err != nil {
+		return fmt.Errorf("could not get highest executed height: %w", err)
+	}
+
+	resumed, err := e.stopControl.SetStopHeight(height, executedHeight)
+	if err != nil {
+		return fmt.Errorf("could not set stop height: %w", err)
+	}
+
+	if len(resumed) == 0 {
+		return nil
+	}
+
+	e.log.Info().
+		Uint64("stop_height", height).
+		Int("resumed", len(resumed)).
+		Msg("resuming blocks held back by the stop height")
+
+	return e.mempool.Run(func(
+		blockByCollection *stdmap.BlockByCollectionBackdata,
+		executionQueues *stdmap.QueuesBackdata,
+	) error {
+		for _, eb := range resumed {
+			// only blocks at the head of a queue have an executed parent,
+			// blocks no longer in the queues have been executed otherwise
+			_, ok := executionQueues.ByID(eb.ID())
+			if !ok {
+				continue
+			}
+			e.executeBlockIfComplete(eb)
+		}
+		return nil
+	})
+}
+
+// StopHeight returns the height of the last block to execute, whether a stop
+// height is set, and whether the execution stopped at it.
+func (e *Engine) StopHeight() (uint64, bool, bool) {
+	height, set := e.stopControl.StopHeight()
+	return height, set, e.stopControl.Stopped()
+}
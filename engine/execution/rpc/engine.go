@@ -15,6 +15,8 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
@@ -70,6 +72,7 @@ func New(
 	}
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
+	dryrunproto.RegisterExecutionDryRunAPIServer(eng.server, eng.handler)
 
 	return eng
 }
@@ -145,6 +148,61 @@ func (h *handler) ExecuteScriptAtBlockID(
 	return res, nil
 }
 
+// DryRunTransactionAtBlockID runs a transaction at the given block without
+// committing it.
+func (h *handler) DryRunTransactionAtBlockID(
+	ctx context.Context,
+	req *dryrunproto.DryRunTransactionRequest,
+) (*dryrunproto.DryRunTransactionResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	txMsg := req.GetTransaction()
+	if txMsg == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction not specified")
+	}
+	tx, err := convert.MessageToTransaction(txMsg, h.chain.Chain())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	opts := fvm.DryRunOptions{
+		SkipSignatures:     req.GetOptions().GetSkipSignatures(),
+		SkipSequenceNumber: req.GetOptions().GetSkipSequenceNumber(),
+		SkipFees:           req.GetOptions().GetSkipFees(),
+	}
+
+	txProc, txDelta, err := h.engine.DryRunTransactionAtBlockID(ctx, &tx, opts, blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dry-run transaction: %v", err)
+	}
+
+	var statusCode uint32 = 0
+	errMsg := ""
+	if txProc.Err != nil {
+		statusCode = 1 // for now a statusCode of 1 indicates an error and 0 indicates no error
+		errMsg = txProc.Err.Error()
+	}
+
+	ids, values := txDelta.RegisterUpdates()
+	updates := make(flow.RegisterEntries, len(ids))
+	for i, id := range ids {
+		updates[i] = flow.RegisterEntry{Key: id, Value: values[i]}
+	}
+
+	return &dryrunproto.DryRunTransactionResponse{
+		Events:          convert.EventsToMessages(txProc.Events),
+		Logs:            txProc.Logs,
+		StatusCode:      statusCode,
+		ErrorMessage:    errMsg,
+		ComputationUsed: txProc.GasUsed,
+		RegisterUpdates: convert.RegisterEntriesToMessages(updates),
+	}, nil
+}
+
 func (h *handler) GetEventsForBlockIDs(_ context.Context,
 	req *execution.GetEventsForBlockIDsRequest) (*execution.GetEventsForBlockIDsResponse, error) {
 
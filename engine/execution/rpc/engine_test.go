@@ -16,6 +16,9 @@ import (
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+	dryrunproto "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -232,6 +235,86 @@ func (suite *Suite) TestGetAccountAtBlockID() {
 	})
 }
 
+// TestDryRunTransactionAtBlockID tests the DryRunTransactionAtBlockID API call
+func (suite *Suite) TestDryRunTransactionAtBlockID() {
+
+	id := unittest.IdentifierFixture()
+	tx := unittest.TransactionBodyFixture()
+	txID := tx.ID()
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler for the chain of the fixture addresses
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Testnet,
+	}
+
+	opts := fvm.DryRunOptions{SkipSignatures: true, SkipFees: true}
+	events := []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)}
+	txDelta := delta.NewDelta()
+	txDelta.Set("owner", "", "key", flow.RegisterValue("value"))
+
+	createReq := func(id []byte) *dryrunproto.DryRunTransactionRequest {
+		return &dryrunproto.DryRunTransactionRequest{
+			BlockId:     id,
+			Transaction: convert.TransactionToMessage(tx),
+			Options: &dryrunproto.DryRunOptions{
+				SkipSignatures: true,
+				SkipFees:       true,
+			},
+		}
+	}
+
+	suite.Run("happy path with valid request", func() {
+
+		txProc := fvm.Transaction(&tx, 0)
+		txProc.Events = events
+		txProc.Logs = []string{"hello"}
+		txProc.GasUsed = 42
+
+		// setup mock expectations
+		mockEngine.On("DryRunTransactionAtBlockID", mock.Anything, mock.Anything, opts, id).Return(txProc, txDelta, nil).Once()
+
+		resp, err := handler.DryRunTransactionAtBlockID(context.Background(), createReq(id[:]))
+
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint32(0), resp.GetStatusCode())
+		suite.Require().Equal(convert.EventsToMessages(events), resp.GetEvents())
+		suite.Require().Equal([]string{"hello"}, resp.GetLogs())
+		suite.Require().Equal(uint64(42), resp.GetComputationUsed())
+
+		updates := convert.MessagesToRegisterEntries(resp.GetRegisterUpdates())
+		suite.Require().Len(updates, 1)
+		suite.Require().Equal(flow.RegisterID{Owner: "owner", Key: "key"}, updates[0].Key)
+		suite.Require().Equal(flow.RegisterValue("value"), updates[0].Value)
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("failed transaction", func() {
+
+		txProc := fvm.Transaction(&tx, 0)
+		txProc.Err = &fvm.MissingPayerError{}
+
+		// setup mock expectations
+		mockEngine.On("DryRunTransactionAtBlockID", mock.Anything, mock.Anything, opts, id).Return(txProc, delta.NewDelta(), nil).Once()
+
+		resp, err := handler.DryRunTransactionAtBlockID(context.Background(), createReq(id[:]))
+
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint32(1), resp.GetStatusCode())
+		suite.Require().Equal(txProc.Err.Error(), resp.GetErrorMessage())
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid request with nil block id", func() {
+
+		_, err := handler.DryRunTransactionAtBlockID(context.Background(), createReq(nil))
+
+		suite.Require().Error(err)
+	})
+}
+
 // TestGetTransactionResult tests the GetTransactionResult API call
 func (suite *Suite) TestGetTransactionResult() {
 
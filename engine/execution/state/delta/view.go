@@ -23,6 +23,7 @@ type View struct {
 	// for views other than collection views to improve performance
 	spockSecretHasher hash.Hasher
 	readFunc          GetRegisterFunc
+	peekFunc          GetRegisterFunc // reads a register without recording the read
 }
 
 type Snapshot struct {
@@ -37,11 +38,15 @@ type SpockSnapshot struct {
 }
 
 // NewView instantiates a new ledger view with the provided read function.
+//
+// The read function is also used to peek at registers, so it must not have
+// any side effects, as is the case for a ledger read.
 func NewView(readFunc GetRegisterFunc) *View {
 	return &View{
 		delta:             NewDelta(),
 		regTouchSet:       make(map[string]flow.RegisterID),
 		readFunc:          readFunc,
+		peekFunc:          readFunc,
 		spockSecretHasher: hash.NewSHA3_256(),
 	}
 }
@@ -94,7 +99,9 @@ func (r *Snapshot) AllRegisters() []flow.RegisterID {
 
 // NewChild generates a new child view, with the current view as the base, sharing the Get function
 func (v *View) NewChild() *View {
-	return NewView(v.Get)
+	child := NewView(v.Get)
+	child.peekFunc = v.Peek
+	return child
 }
 
 // Get gets a register value from this view.
@@ -130,6 +137,18 @@ func (v *View) Get(owner, controller, key string) (flow.RegisterValue, error) {
 	return value, err
 }
 
+// Peek gets a register value from this view without recording the read,
+// so neither the touched registers nor the SPoCK secret of this view or any
+// of its parents are changed.
+func (v *View) Peek(owner, controller, key string) (flow.RegisterValue, error) {
+	value, exists := v.delta.Get(owner, controller, key)
+	if exists {
+		return value, nil
+	}
+
+	return v.peekFunc(owner, controller, key)
+}
+
 // Set sets a register value in this view.
 func (v *View) Set(owner, controller, key string, value flow.RegisterValue) error {
 	// every time we write something to delta (order preserving) we update spock
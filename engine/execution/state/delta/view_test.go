@@ -55,6 +55,54 @@ func TestView_Get(t *testing.T) {
 	})
 }
 
+func TestView_Peek(t *testing.T) {
+	registerID1 := "fruit"
+	registerID2 := "vegetable"
+
+	v := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		if owner == registerID1 {
+			return flow.RegisterValue("orange"), nil
+		}
+
+		return nil, nil
+	})
+
+	err := v.Set(registerID2, "", "", flow.RegisterValue("carrot"))
+	require.NoError(t, err)
+
+	parentSecret := v.SpockSecret()
+	child := v.NewChild()
+
+	err = child.Set(registerID2, "", "", flow.RegisterValue("potato"))
+	require.NoError(t, err)
+	childSecret := child.SpockSecret()
+
+	t.Run("reads through parents", func(t *testing.T) {
+		b, err := child.Peek(registerID1, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("orange"), b)
+	})
+
+	t.Run("reads own changes", func(t *testing.T) {
+		b, err := child.Peek(registerID2, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("potato"), b)
+
+		b, err = v.Peek(registerID2, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("carrot"), b)
+	})
+
+	t.Run("does not record reads", func(t *testing.T) {
+		assert.Equal(t, parentSecret, v.SpockSecret())
+		assert.Equal(t, childSecret, child.SpockSecret())
+		assert.Len(t, v.Interactions().RegisterTouches(), 1)
+		assert.Len(t, child.Interactions().RegisterTouches(), 1)
+		assert.Equal(t, uint64(0), v.ReadsCount())
+		assert.Equal(t, uint64(0), child.ReadsCount())
+	})
+}
+
 func TestView_Set(t *testing.T) {
 	registerID := "fruit"
 
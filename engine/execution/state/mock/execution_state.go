@@ -209,6 +209,22 @@ func (_m *ExecutionState) GetRegisters(_a0 context.Context, _a1 []byte, _a2 []fl
 	return r0, r1
 }
 
+// NewBlockView provides a mock function with given fields: _a0, _a1
+func (_m *ExecutionState) NewBlockView(_a0 []byte, _a1 *flow.Header) *delta.View {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *delta.View
+	if rf, ok := ret.Get(0).(func([]byte, *flow.Header) *delta.View); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*delta.View)
+		}
+	}
+
+	return r0
+}
+
 // NewView provides a mock function with given fields: _a0
 func (_m *ExecutionState) NewView(_a0 []byte) *delta.View {
 	ret := _m.Called(_a0)
@@ -295,6 +311,20 @@ func (_m *ExecutionState) PersistStateInteractions(_a0 context.Context, _a1 flow
 	return r0
 }
 
+// RegisterIndexEnabled provides a mock function with given fields:
+func (_m *ExecutionState) RegisterIndexEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // RetrieveStateDelta provides a mock function with given fields: _a0, _a1
 func (_m *ExecutionState) RetrieveStateDelta(_a0 context.Context, _a1 flow.Identifier) (*messages.ExecutionStateDelta, error) {
 	ret := _m.Called(_a0, _a1)
@@ -186,6 +186,22 @@ func (_m *ReadOnlyExecutionState) GetRegisters(_a0 context.Context, _a1 []byte,
 	return r0, r1
 }
 
+// NewBlockView provides a mock function with given fields: _a0, _a1
+func (_m *ReadOnlyExecutionState) NewBlockView(_a0 []byte, _a1 *flow.Header) *delta.View {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *delta.View
+	if rf, ok := ret.Get(0).(func([]byte, *flow.Header) *delta.View); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*delta.View)
+		}
+	}
+
+	return r0
+}
+
 // NewView provides a mock function with given fields: _a0
 func (_m *ReadOnlyExecutionState) NewView(_a0 []byte) *delta.View {
 	ret := _m.Called(_a0)
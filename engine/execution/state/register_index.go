@@ -0,0 +1,219 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+)
+
+// indexedBlock is a finalized block whose state commitment is known.
+type indexedBlock struct {
+	height  uint64
+	blockID flow.Identifier
+}
+
+// BackfillRegisterIndex bootstraps the register index from the latest
+// checkpoint of the WAL, and indexes the registers updated by the finalized
+// blocks in the WAL segments after it, by replaying them on a separate forest.
+//
+// Only finalized blocks between the root height and the final height which
+// have been executed are indexed. The root height has to be the current root of
+// the protocol state, as the headers of pruned blocks below it are removed.
+func BackfillRegisterIndex(
+	log zerolog.Logger,
+	w *wal.LedgerWAL,
+	capacity int,
+	headers storage.Headers,
+	commits storage.Commits,
+	registers storage.RegisterIndex,
+	rootHeight uint64,
+	finalHeight uint64,
+) error {
+
+	// the first finalized block with each state commitment, as empty blocks
+	// have the same state commitment as their parent
+	blocks := make(map[string]indexedBlock)
+	var last indexedBlock
+	for height := rootHeight; height <= finalHeight; height++ {
+		header, err := headers.ByHeight(height)
+		if err != nil {
+			return fmt.Errorf("could not retrieve finalized block at height %d: %w", height, err)
+		}
+		blockID := header.ID()
+		commit, err := commits.ByBlockID(blockID)
+		if errors.Is(err, storage.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve state commitment of block %x: %w", blockID, err)
+		}
+
+		last = indexedBlock{height: height, blockID: blockID}
+		if _, ok := blocks[string(commit)]; !ok {
+			blocks[string(commit)] = last
+		}
+	}
+
+	// the registers updated since the last finalized block, by the root
+	// hash of the trie they lead to
+	pending := make(map[string]flow.RegisterEntries)
+
+	forest, err := mtrie.NewForest(pathfinder.PathByteSize, "", capacity, metrics.NewNoopCollector(), func(evicted *trie.MTrie) error {
+		delete(pending, string(evicted.RootHash()))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not create forest: %w", err)
+	}
+
+	var first indexedBlock
+	bootstrapped := false
+
+	err = w.Replay(
+		func(rebuiltTries []*trie.MTrie) error {
+			err := forest.AddTries(rebuiltTries)
+			if err != nil {
+				return fmt.Errorf("adding rebuilt tries to forest failed: %w", err)
+			}
+
+			// bootstrap the index with the latest state of a finalized block
+			var latest *trie.MTrie
+			for _, t := range rebuiltTries {
+				block, ok := blocks[string(t.RootHash())]
+				if !ok {
+					continue
+				}
+				if latest == nil || block.height > first.height {
+					latest = t
+					first = block
+				}
+			}
+			if latest == nil {
+				return fmt.Errorf("checkpoint does not contain the state of any finalized block")
+			}
+
+			payloads := latest.AllPayloads()
+			entries := make(flow.RegisterEntries, len(payloads))
+			for i := range payloads {
+				entries[i], err = payloadToEntry(&payloads[i])
+				if err != nil {
+					return err
+				}
+			}
+
+			log.Info().
+				Uint64("height", first.height).
+				Int("registers", len(entries)).
+				Msg("bootstrapping register index from checkpoint")
+
+			err = registers.Bootstrap(first.height, first.blockID, entries)
+			if err != nil {
+				return fmt.Errorf("could not bootstrap register index: %w", err)
+			}
+
+			bootstrapped = true
+			return nil
+		},
+		func(update *ledger.TrieUpdate) error {
+			rootHash, err := forest.Update(update)
+			if err != nil {
+				return fmt.Errorf("could not apply update: %w", err)
+			}
+			if !bootstrapped {
+				return nil
+			}
+
+			// chain the updates of the chunks of a block, starting from the
+			// state of its parent
+			var entries flow.RegisterEntries
+			if _, ok := blocks[string(update.RootHash)]; !ok {
+				previous := pending[string(update.RootHash)]
+				entries = append(entries, previous...)
+			}
+			for _, payload := range update.Payloads {
+				entry, err := payloadToEntry(payload)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, entry)
+			}
+
+			block, ok := blocks[string(rootHash)]
+			if !ok {
+				pending[string(rootHash)] = entries
+				return nil
+			}
+
+			// the state of finalized blocks at or below the first indexed
+			// height is already indexed
+			if block.height <= first.height {
+				return nil
+			}
+
+			// later chunks of the block overwrite the registers updated by
+			// earlier ones
+			updates := delta.NewDelta()
+			for _, entry := range entries {
+				updates.Set(entry.Key.Owner, entry.Key.Controller, entry.Key.Key, entry.Value)
+			}
+			ids, values := updates.RegisterUpdates()
+			entries = make(flow.RegisterEntries, len(ids))
+			for i, id := range ids {
+				entries[i] = flow.RegisterEntry{Key: id, Value: values[i]}
+			}
+
+			err = registers.Store(block.height, block.blockID, entries)
+			if err != nil {
+				return fmt.Errorf("could not index registers at height %d: %w", block.height, err)
+			}
+
+			return nil
+		},
+		func(rootHash ledger.RootHash) error {
+			forest.RemoveTrie(rootHash)
+			delete(pending, string(rootHash))
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not replay WAL: %w", err)
+	}
+
+	if !bootstrapped {
+		return fmt.Errorf("no checkpoint to bootstrap the register index from")
+	}
+
+	// advance the latest indexed height past the trailing empty blocks
+	if last.height > first.height {
+		err = registers.Store(last.height, last.blockID, nil)
+		if err != nil {
+			return fmt.Errorf("could not index registers at height %d: %w", last.height, err)
+		}
+	}
+
+	log.Info().
+		Uint64("first_height", first.height).
+		Uint64("latest_height", last.height).
+		Msg("register index backfilled")
+
+	return nil
+}
+
+func payloadToEntry(payload *ledger.Payload) (flow.RegisterEntry, error) {
+	id, err := KeyToRegisterID(payload.Key)
+	if err != nil {
+		return flow.RegisterEntry{}, fmt.Errorf("could not convert key: %w", err)
+	}
+	return flow.RegisterEntry{Key: id, Value: payload.Value}, nil
+}
@@ -0,0 +1,107 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestBackfillRegisterIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		unittest.RunWithTempDir(t, func(dir string) {
+
+			// the root checkpoint holds the empty execution state
+			forest, err := mtrie.NewForest(pathfinder.PathByteSize, "", 100, metrics.NewNoopCollector(), nil)
+			require.NoError(t, err)
+			flatForest, err := flattener.FlattenForest(forest)
+			require.NoError(t, err)
+			writer, err := wal.CreateCheckpointWriterForFile(dir, wal.RootCheckpointFilename)
+			require.NoError(t, err)
+			require.NoError(t, wal.StoreCheckpoint(flatForest, writer))
+			require.NoError(t, writer.Close())
+
+			ls, err := ledger.NewLedger(dir, 100, metrics.NewNoopCollector(), zerolog.Nop(), nil, ledger.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			commit := func(base flow.StateCommitment, values map[string]string) flow.StateCommitment {
+				d := delta.NewDelta()
+				for key, value := range values {
+					d.Set("owner", "", key, []byte(value))
+				}
+				commit, err := state.CommitDelta(ls, d, base)
+				require.NoError(t, err)
+				return commit
+			}
+
+			// the root block, a block with a single chunk, a block with two
+			// chunks and an empty block
+			commits := []flow.StateCommitment{ls.InitialState()}
+			commits = append(commits, commit(commits[0], map[string]string{"a": "1"}))
+			chunk := commit(commits[1], map[string]string{"a": "2", "b": "3"})
+			commits = append(commits, commit(chunk, map[string]string{"a": "4"}))
+			commits = append(commits, commits[2])
+
+			<-ls.Done()
+
+			headers := new(storage.Headers)
+			commitments := new(storage.Commits)
+			for height, commit := range commits {
+				header := unittest.BlockHeaderFixture()
+				header.Height = uint64(height)
+				headers.On("ByHeight", header.Height).Return(&header, nil)
+				commitments.On("ByBlockID", header.ID()).Return(commit, nil)
+				require.NoError(t, db.Update(operation.IndexBlockHeight(header.Height, header.ID())))
+			}
+
+			w, err := wal.NewWAL(zerolog.Nop(), nil, dir, 100, pathfinder.PathByteSize, wal.SegmentSize)
+			require.NoError(t, err)
+			defer w.Close()
+
+			index := bstorage.NewRegisterIndex(db)
+			err = state.BackfillRegisterIndex(zerolog.Nop(), w, 100, headers, commitments, index, 0, 3)
+			require.NoError(t, err)
+
+			first, latest, err := index.Heights()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(0), first)
+			assert.Equal(t, uint64(3), latest)
+
+			regA := flow.NewRegisterID("owner", "", "a")
+			regB := flow.NewRegisterID("owner", "", "b")
+
+			value, err := index.Get(regA, 0)
+			require.NoError(t, err)
+			assert.Empty(t, value)
+
+			value, err = index.Get(regA, 1)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("1"), value)
+
+			// the last chunk of the block overwrites the earlier ones
+			value, err = index.Get(regA, 2)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("4"), value)
+
+			value, err = index.Get(regB, 3)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("3"), value)
+		})
+	})
+}
@@ -24,6 +24,11 @@ type ReadOnlyExecutionState interface {
 	// NewView creates a new ready-only view at the given state commitment.
 	NewView(flow.StateCommitment) *delta.View
 
+	// NewBlockView creates a new read-only view at the given state commitment
+	// of the given block. Registers of finalized blocks are read from the
+	// register index, if enabled, once the ledger no longer holds the state.
+	NewBlockView(flow.StateCommitment, *flow.Header) *delta.View
+
 	GetRegisters(
 		context.Context,
 		flow.StateCommitment,
@@ -94,6 +99,10 @@ type ExecutionState interface {
 
 	PersistStateInteractions(context.Context, flow.Identifier, []*delta.Snapshot) error
 
+	// RegisterIndexEnabled returns whether the registers updated by persisted
+	// state interactions are indexed.
+	RegisterIndexEnabled() bool
+
 	UpdateHighestExecutedBlockIfHigher(context.Context, *flow.Header) error
 }
 
@@ -114,6 +123,7 @@ type state struct {
 	receipts       storage.ExecutionReceipts
 	headers        storage.Headers
 	db             *badger.DB
+	registers      storage.RegisterIndex // nil if the register index is disabled
 }
 
 func (s *state) PersistExecutionResult(ctx context.Context, executionResult *flow.ExecutionResult) error {
@@ -138,7 +148,23 @@ func RegisterIDToKey(reg flow.RegisterID) ledger.Key {
 	})
 }
 
+func KeyToRegisterID(key ledger.Key) (flow.RegisterID, error) {
+	if len(key.KeyParts) != 3 ||
+		key.KeyParts[0].Type != KeyPartOwner ||
+		key.KeyParts[1].Type != KeyPartController ||
+		key.KeyParts[2].Type != KeyPartKey {
+		return flow.RegisterID{}, fmt.Errorf("key not in expected format %s", key.String())
+	}
+
+	return flow.NewRegisterID(
+		string(key.KeyParts[0].Value),
+		string(key.KeyParts[1].Value),
+		string(key.KeyParts[2].Value),
+	), nil
+}
+
 // NewExecutionState returns a new execution state access layer for the given ledger storage.
+// The register index is optional, and the updated registers are not indexed if it's nil.
 func NewExecutionState(
 	ls ledger.Ledger,
 	commits storage.Commits,
@@ -149,6 +175,7 @@ func NewExecutionState(
 	receipts storage.ExecutionReceipts,
 	headers storage.Headers,
 	db *badger.DB,
+	registers storage.RegisterIndex,
 	tracer module.Tracer,
 ) ExecutionState {
 	return &state{
@@ -162,6 +189,7 @@ func NewExecutionState(
 		receipts:       receipts,
 		headers:        headers,
 		db:             db,
+		registers:      registers,
 	}
 
 }
@@ -226,6 +254,41 @@ func (s *state) NewView(commitment flow.StateCommitment) *delta.View {
 	return delta.NewView(LedgerGetRegister(s.ls, commitment))
 }
 
+// IndexedGetRegister reads registers from the ledger at the given state
+// commitment, and falls back to the register index at the given finalized
+// height if the ledger can't read them.
+func IndexedGetRegister(ldg ledger.Ledger, registers storage.RegisterIndex, commitment flow.StateCommitment, height uint64) delta.GetRegisterFunc {
+	readLedger := LedgerGetRegister(ldg, commitment)
+	return func(owner, controller, key string) (flow.RegisterValue, error) {
+
+		value, err := readLedger(owner, controller, key)
+		if err == nil {
+			return value, nil
+		}
+
+		value, indexErr := registers.Get(flow.NewRegisterID(owner, controller, key), height)
+		if indexErr != nil {
+			return nil, fmt.Errorf("%v, and could not read register index at height %d: %w", err, height, indexErr)
+		}
+
+		return value, nil
+	}
+}
+
+func (s *state) NewBlockView(commitment flow.StateCommitment, header *flow.Header) *delta.View {
+	if s.registers == nil {
+		return s.NewView(commitment)
+	}
+
+	// the register index only holds the state of finalized blocks
+	finalized, err := s.headers.ByHeight(header.Height)
+	if err != nil || finalized.ID() != header.ID() {
+		return s.NewView(commitment)
+	}
+
+	return delta.NewView(IndexedGetRegister(s.ls, s.registers, commitment, header.Height))
+}
+
 func CommitDelta(ldg ledger.Ledger, delta delta.Delta, baseState flow.StateCommitment) (flow.StateCommitment, error) {
 	ids, values := delta.RegisterUpdates()
 
@@ -386,7 +449,40 @@ func (s *state) PersistStateInteractions(ctx context.Context, blockID flow.Ident
 		defer span.Finish()
 	}
 
-	return operation.RetryOnConflict(s.db.Update, operation.InsertExecutionStateInteractions(blockID, views))
+	// the last executed block is executed again after a restart, in which
+	// case its interactions are already stored
+	err := operation.RetryOnConflict(s.db.Update, operation.SkipDuplicates(operation.InsertExecutionStateInteractions(blockID, views)))
+	if err != nil {
+		return fmt.Errorf("could not store state interactions: %w", err)
+	}
+
+	if s.registers == nil {
+		return nil
+	}
+
+	header, err := s.headers.ByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve block header: %w", err)
+	}
+
+	// later interactions of the block overwrite earlier ones
+	updates := delta.NewDelta()
+	for _, view := range views {
+		updates.MergeWith(view.Delta)
+	}
+
+	ids, values := updates.RegisterUpdates()
+	entries := make(flow.RegisterEntries, len(ids))
+	for i, id := range ids {
+		entries[i] = flow.RegisterEntry{Key: id, Value: values[i]}
+	}
+
+	err = s.registers.Store(header.Height, blockID, entries)
+	if err != nil {
+		return fmt.Errorf("could not index registers: %w", err)
+	}
+
+	return nil
 }
 
 func (s *state) RetrieveStateDelta(ctx context.Context, blockID flow.Identifier) (*messages.ExecutionStateDelta, error) {
@@ -474,6 +570,10 @@ func (s *state) GetBlockIDByChunkID(chunkID flow.Identifier) (flow.Identifier, e
 	return s.headers.IDByChunkID(chunkID)
 }
 
+func (s *state) RegisterIndexEnabled() bool {
+	return s.registers != nil
+}
+
 func (s *state) UpdateHighestExecutedBlockIfHigher(ctx context.Context, header *flow.Header) error {
 	if s.tracer != nil {
 		span, _ := s.tracer.StartSpanFromContext(ctx, trace.EXEUpdateHighestExecutedBlockIfHigher)
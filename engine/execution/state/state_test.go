@@ -45,7 +45,7 @@ func prepareTest(f func(t *testing.T, es state.ExecutionState)) func(*testing.T)
 				receipts := new(storage.ExecutionReceipts)
 
 				es := state.NewExecutionState(
-					ls, stateCommitments, blocks, collections, chunkDataPacks, results, receipts, headers, badgerDB, nil,
+					ls, stateCommitments, blocks, collections, chunkDataPacks, results, receipts, headers, badgerDB, nil, nil,
 				)
 
 				f(t, es)
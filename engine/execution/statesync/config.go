@@ -0,0 +1,32 @@
+package statesync
+
+import (
+	"time"
+)
+
+// Config configures syncing the execution state from other execution nodes.
+type Config struct {
+	Enabled        bool          // whether to sync state from peers when falling behind the sealed state
+	Serve          bool          // whether to persist state interactions and serve state deltas to peers
+	Fast           bool          // whether to skip fetching collections while syncing, and rely on state syncing to catch up
+	Threshold      uint64        // the number of sealed and unexecuted blocks which triggers state syncing
+	BatchSize      uint64        // the maximum number of blocks requested or served in a single state sync request
+	RequestTimeout time.Duration // the time without progress after which a request is sent to another peer
+	CheckInterval  time.Duration // the interval in which we check whether to start or stop syncing, and send requests
+	ServeRate      float64       // the number of state sync requests served per second and peer, 0 for no limit
+	ServeBurst     uint          // the number of state sync requests served per peer in a burst
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Enabled:        false,
+		Serve:          true,
+		Fast:           false,
+		Threshold:      100,
+		BatchSize:      20,
+		RequestTimeout: 30 * time.Second,
+		CheckInterval:  2 * time.Second,
+		ServeRate:      1,
+		ServeBurst:     5,
+	}
+}
@@ -0,0 +1,274 @@
+package statesync
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+const (
+	// scoreValidDelta is added to the score of a peer for every valid delta.
+	scoreValidDelta = 1
+
+	// scoreTimeout is added to the score of a peer whose request timed out
+	// without any progress.
+	scoreTimeout = -5
+
+	// scoreInvalidDelta is added to the score of a peer which sent a delta that
+	// does not match the sealed state.
+	scoreInvalidDelta = -50
+
+	// maxPeerScore is the highest score a peer can reach, so that peers with a
+	// long history of valid deltas are still excluded quickly once they
+	// misbehave.
+	maxPeerScore = 50
+
+	// minPeerScore is the score below which we no longer sync from a peer.
+	minPeerScore = -100
+)
+
+// request is a state sync request which was sent to a peer and has not been
+// completed yet.
+type request struct {
+	peerID     flow.Identifier
+	fromHeight uint64
+	toHeight   uint64
+	progressed time.Time // the time the request was sent, or the last valid delta was received
+}
+
+// Core contains the logic for syncing the execution state from peers: it
+// decides when to start and stop syncing, which heights to request from which
+// peer, and keeps a score for each peer based on the deltas it sent.
+//
+// Core only tracks requests; verifying and applying the deltas is up to the
+// wrapping engine, which reports the outcome back. Core is safe for
+// concurrent use.
+type Core struct {
+	log     zerolog.Logger
+	config  Config
+	mu      sync.Mutex
+	syncing bool
+	target  uint64                              // the sealed height we are syncing to
+	pending *request                            // the outstanding request, if any
+	origins map[flow.Identifier]flow.Identifier // the peer each received delta was sent by, by block ID
+	scores  map[flow.Identifier]int
+}
+
+func New(log zerolog.Logger, config Config) *Core {
+	return &Core{
+		log:     log.With().Str("module", "state_sync").Logger(),
+		config:  config,
+		origins: make(map[flow.Identifier]flow.Identifier),
+		scores:  make(map[flow.Identifier]int),
+	}
+}
+
+// Syncing returns whether state syncing is active.
+func (c *Core) Syncing() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.syncing
+}
+
+// Update starts syncing once the number of sealed, unexecuted blocks exceeds
+// the threshold, and stops syncing once the sealed height has been executed.
+// It returns whether syncing is active, and whether this changed.
+func (c *Core) Update(executedHeight uint64, sealedHeight uint64) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.target = sealedHeight
+
+	if !c.syncing {
+		if !c.config.Enabled || sealedHeight <= executedHeight || sealedHeight-executedHeight <= c.config.Threshold {
+			return false, false
+		}
+		c.syncing = true
+		c.log.Info().
+			Uint64("executed_height", executedHeight).
+			Uint64("sealed_height", sealedHeight).
+			Msg("starting state sync")
+		return true, true
+	}
+
+	if executedHeight < sealedHeight {
+		return true, false
+	}
+
+	c.syncing = false
+	c.pending = nil
+	c.origins = make(map[flow.Identifier]flow.Identifier)
+	c.log.Info().
+		Uint64("executed_height", executedHeight).
+		Msg("state sync caught up with sealed state")
+	return false, true
+}
+
+// NextRequest returns the next state sync request, and the peer to send it
+// to. It returns false if no request should be sent, because we are not
+// syncing, the outstanding request is still making progress, or there is no
+// peer left to sync from.
+func (c *Core) NextRequest(now time.Time, executedHeight uint64, peers flow.IdentityList) (*messages.ExecutionStateSyncRequest, flow.Identifier, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.syncing || executedHeight >= c.target {
+		return nil, flow.ZeroID, false
+	}
+
+	if c.pending != nil {
+		if c.pending.toHeight > executedHeight && now.Sub(c.pending.progressed) < c.config.RequestTimeout {
+			return nil, flow.ZeroID, false
+		}
+		if c.pending.toHeight > executedHeight {
+			c.log.Debug().
+				Hex("peer_id", c.pending.peerID[:]).
+				Uint64("from_height", c.pending.fromHeight).
+				Uint64("to_height", c.pending.toHeight).
+				Msg("state sync request timed out")
+			c.adjustScore(c.pending.peerID, scoreTimeout)
+		}
+		c.pending = nil
+	}
+
+	peerID, ok := c.selectPeer(peers)
+	if !ok {
+		return nil, flow.ZeroID, false
+	}
+
+	fromHeight := executedHeight + 1
+	toHeight := fromHeight + c.config.BatchSize - 1
+	if toHeight > c.target {
+		toHeight = c.target
+	}
+
+	c.pending = &request{
+		peerID:     peerID,
+		fromHeight: fromHeight,
+		toHeight:   toHeight,
+		progressed: now,
+	}
+
+	req := &messages.ExecutionStateSyncRequest{
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+
+	return req, peerID, true
+}
+
+// selectPeer selects the peer with the highest score, choosing randomly
+// between peers with equal scores. Peers whose score dropped below the
+// minimum are never selected.
+func (c *Core) selectPeer(peers flow.IdentityList) (flow.Identifier, bool) {
+
+	candidates := make([]flow.Identifier, 0, len(peers))
+	for _, peer := range peers {
+		if c.scores[peer.NodeID] > minPeerScore {
+			candidates = append(candidates, peer.NodeID)
+		}
+	}
+	if len(candidates) == 0 {
+		return flow.ZeroID, false
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return c.scores[candidates[i]] > c.scores[candidates[j]]
+	})
+
+	return candidates[0], true
+}
+
+// HandleDelta checks whether the delta for the block at the given height was
+// requested from the peer, and if so, records the peer as its origin.
+func (c *Core) HandleDelta(originID flow.Identifier, blockID flow.Identifier, height uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending == nil || c.pending.peerID != originID {
+		return false
+	}
+	if height < c.pending.fromHeight || height > c.pending.toHeight {
+		return false
+	}
+
+	c.origins[blockID] = originID
+
+	return true
+}
+
+// Origin returns the peer which sent the delta for the given block.
+func (c *Core) Origin(blockID flow.Identifier) (flow.Identifier, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	originID, ok := c.origins[blockID]
+	return originID, ok
+}
+
+// ReportValid reports that the delta for the given block was applied, which
+// rewards the peer that sent it and counts as progress of its request.
+func (c *Core) ReportValid(blockID flow.Identifier, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	originID, ok := c.origins[blockID]
+	if !ok {
+		return
+	}
+	delete(c.origins, blockID)
+
+	c.adjustScore(originID, scoreValidDelta)
+	if c.pending != nil && c.pending.peerID == originID {
+		c.pending.progressed = now
+	}
+}
+
+// ReportInvalid reports that the peer sent an invalid delta, which penalizes
+// the peer and cancels its outstanding request, so that the next request is
+// sent to another peer.
+func (c *Core) ReportInvalid(originID flow.Identifier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.adjustScore(originID, scoreInvalidDelta)
+	if c.pending != nil && c.pending.peerID == originID {
+		c.pending = nil
+	}
+	for blockID, peerID := range c.origins {
+		if peerID == originID {
+			delete(c.origins, blockID)
+		}
+	}
+}
+
+// Score returns the current score of the peer.
+func (c *Core) Score(peerID flow.Identifier) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.scores[peerID]
+}
+
+func (c *Core) adjustScore(peerID flow.Identifier, delta int) {
+	score := c.scores[peerID] + delta
+	if score > maxPeerScore {
+		score = maxPeerScore
+	}
+	c.scores[peerID] = score
+
+	if score <= minPeerScore {
+		c.log.Warn().
+			Hex("peer_id", peerID[:]).
+			Int("score", score).
+			Msg("excluding peer from state sync")
+	}
+}
@@ -0,0 +1,164 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Threshold = 10
+	config.BatchSize = 5
+	return config
+}
+
+func TestCore_StartStop(t *testing.T) {
+	core := New(zerolog.Nop(), testConfig())
+
+	// below the threshold, we don't sync
+	syncing, changed := core.Update(100, 110)
+	assert.False(t, syncing)
+	assert.False(t, changed)
+
+	syncing, changed = core.Update(100, 111)
+	assert.True(t, syncing)
+	assert.True(t, changed)
+	assert.True(t, core.Syncing())
+
+	// we keep syncing until the sealed height is executed
+	syncing, changed = core.Update(110, 112)
+	assert.True(t, syncing)
+	assert.False(t, changed)
+
+	syncing, changed = core.Update(112, 112)
+	assert.False(t, syncing)
+	assert.True(t, changed)
+	assert.False(t, core.Syncing())
+
+	t.Run("disabled", func(t *testing.T) {
+		config := testConfig()
+		config.Enabled = false
+		core := New(zerolog.Nop(), config)
+
+		syncing, _ := core.Update(0, 1000)
+		assert.False(t, syncing)
+	})
+}
+
+func TestCore_NextRequest(t *testing.T) {
+	core := New(zerolog.Nop(), testConfig())
+	peers := unittest.IdentityListFixture(3)
+	now := time.Now()
+
+	// no requests unless syncing
+	_, _, ok := core.NextRequest(now, 100, peers)
+	assert.False(t, ok)
+
+	core.Update(100, 112)
+
+	req, peerID, ok := core.NextRequest(now, 100, peers)
+	require.True(t, ok)
+	assert.Equal(t, uint64(101), req.FromHeight)
+	assert.Equal(t, uint64(105), req.ToHeight)
+
+	// no new request while the outstanding one is making progress
+	_, _, ok = core.NextRequest(now.Add(time.Second), 102, peers)
+	assert.False(t, ok)
+
+	// once the request is completed, the next batch is requested, up to the sealed height
+	req, _, ok = core.NextRequest(now.Add(time.Second), 110, peers)
+	require.True(t, ok)
+	assert.Equal(t, uint64(111), req.FromHeight)
+	assert.Equal(t, uint64(112), req.ToHeight)
+
+	t.Run("timeout", func(t *testing.T) {
+		core := New(zerolog.Nop(), testConfig())
+		core.Update(100, 112)
+
+		_, peerID, ok = core.NextRequest(now, 100, peers)
+		require.True(t, ok)
+
+		// a request without progress is sent again after the timeout, to another peer
+		req, nextPeerID, ok := core.NextRequest(now.Add(testConfig().RequestTimeout), 100, peers)
+		require.True(t, ok)
+		assert.Equal(t, uint64(101), req.FromHeight)
+		assert.NotEqual(t, peerID, nextPeerID)
+		assert.Equal(t, scoreTimeout, core.Score(peerID))
+	})
+}
+
+func TestCore_Deltas(t *testing.T) {
+	core := New(zerolog.Nop(), testConfig())
+	peers := unittest.IdentityListFixture(2)
+	now := time.Now()
+
+	core.Update(100, 112)
+	_, peerID, ok := core.NextRequest(now, 100, peers)
+	require.True(t, ok)
+
+	other := peers.Filter(func(identity *flow.Identity) bool { return identity.NodeID != peerID })[0].NodeID
+	blockID := unittest.IdentifierFixture()
+
+	// deltas are only accepted from the peer they were requested from, at the requested heights
+	assert.False(t, core.HandleDelta(other, blockID, 101))
+	assert.False(t, core.HandleDelta(peerID, blockID, 106))
+	assert.True(t, core.HandleDelta(peerID, blockID, 101))
+
+	originID, ok := core.Origin(blockID)
+	require.True(t, ok)
+	assert.Equal(t, peerID, originID)
+
+	// a valid delta counts as progress, so the request doesn't time out
+	core.ReportValid(blockID, now.Add(testConfig().RequestTimeout))
+	assert.Equal(t, scoreValidDelta, core.Score(peerID))
+	_, _, ok = core.NextRequest(now.Add(testConfig().RequestTimeout), 101, peers)
+	assert.False(t, ok)
+
+	// an invalid delta cancels the request, which is sent to the other peer instead
+	core.ReportInvalid(peerID)
+	assert.Equal(t, scoreValidDelta+scoreInvalidDelta, core.Score(peerID))
+	_, nextPeerID, ok := core.NextRequest(now, 101, peers)
+	require.True(t, ok)
+	assert.Equal(t, other, nextPeerID)
+
+	// peers below the minimum score are not synced from
+	for i := 0; i < 2; i++ {
+		core.ReportInvalid(peerID)
+		core.ReportInvalid(other)
+	}
+	_, _, ok = core.NextRequest(now, 101, peers)
+	assert.False(t, ok)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(1, 2)
+	peerID := unittest.IdentifierFixture()
+	now := time.Now()
+
+	// the burst is available immediately
+	assert.True(t, limiter.Allow(peerID, now))
+	assert.True(t, limiter.Allow(peerID, now))
+	assert.False(t, limiter.Allow(peerID, now))
+
+	// peers are limited independently
+	assert.True(t, limiter.Allow(unittest.IdentifierFixture(), now))
+
+	// tokens are refilled over time
+	assert.True(t, limiter.Allow(peerID, now.Add(time.Second)))
+	assert.False(t, limiter.Allow(peerID, now.Add(time.Second)))
+
+	t.Run("unlimited", func(t *testing.T) {
+		limiter := NewRateLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			assert.True(t, limiter.Allow(peerID, now))
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package statesync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RateLimiter limits the rate of state sync requests served to each peer,
+// using a token bucket per peer. It is safe for concurrent use.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens added per second
+	burst   float64 // maximum number of tokens
+	buckets map[flow.Identifier]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter which allows the given number of
+// requests per second and peer, with the given burst. A rate of 0 disables
+// rate limiting.
+func NewRateLimiter(rate float64, burst uint) *RateLimiter {
+	if burst == 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[flow.Identifier]*bucket),
+	}
+}
+
+// Allow returns whether a request from the peer at the given time is within
+// the rate limit, and if so, consumes a token for it.
+func (l *RateLimiter) Allow(peerID flow.Identifier, now time.Time) bool {
+	if l.rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[peerID]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[peerID] = b
+	}
+
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+
+	return true
+}
@@ -0,0 +1,184 @@
+package stopcontrol
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// Control decides whether the execution node keeps executing blocks, or
+// stops after executing the block at a configured stop height. Blocks above
+// the stop height are held back until the stop height is raised or removed.
+//
+// The stop height is persisted, so that the node remains stopped across
+// restarts. Control is safe for concurrent use.
+type Control struct {
+	log      zerolog.Logger
+	db       *badger.DB
+	metrics  module.ExecutionMetrics
+	mu       sync.Mutex
+	height   uint64                                      // the height of the last block to execute, 0 if not set
+	started  uint64                                      // the highest height which was allowed to execute
+	executed uint64                                      // the highest executed height
+	paused   map[flow.Identifier]*entity.ExecutableBlock // blocks held back because they are above the stop height
+}
+
+// New creates a new stop control, loading the stop height persisted in the
+// database, if any.
+func New(log zerolog.Logger, db *badger.DB, metrics module.ExecutionMetrics) (*Control, error) {
+	c := &Control{
+		log:     log.With().Str("module", "stop_control").Logger(),
+		db:      db,
+		metrics: metrics,
+		paused:  make(map[flow.Identifier]*entity.ExecutableBlock),
+	}
+
+	err := db.View(operation.RetrieveExecutionStopHeight(&c.height))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not retrieve stop height: %w", err)
+	}
+
+	if c.height > 0 {
+		c.log.Info().Uint64("stop_height", c.height).Msg("loaded stop height")
+	}
+	c.metrics.ExecutionStopHeight(c.height)
+	c.metrics.ExecutionStopped(false)
+
+	return c, nil
+}
+
+// StopHeight returns the height of the last block to execute, and whether a
+// stop height is set.
+func (c *Control) StopHeight() (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.height, c.height > 0
+}
+
+// Stopped returns whether the block at the stop height has been executed, so
+// that no more blocks are executed.
+func (c *Control) Stopped() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopped()
+}
+
+func (c *Control) stopped() bool {
+	return c.height > 0 && c.executed >= c.height
+}
+
+// SetStopHeight sets the height of the last block to execute, given the
+// highest executed height, and persists it. A height of 0 removes the stop
+// height. It errors if a block above the given height was executed already,
+// or is being executed.
+//
+// Raising or removing the stop height returns the blocks which were held back
+// and can now be executed.
+func (c *Control) SetStopHeight(height uint64, executedHeight uint64) ([]*entity.ExecutableBlock, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if executedHeight > c.executed {
+		c.executed = executedHeight
+	}
+
+	if height > 0 && (height < c.executed || height < c.started) {
+		return nil, fmt.Errorf("cannot set stop height %d below the highest executed height %d or executing height %d",
+			height, c.executed, c.started)
+	}
+
+	err := operation.RetryOnConflict(c.db.Update, func(tx *badger.Txn) error {
+		if height == 0 {
+			err := operation.RemoveExecutionStopHeight()(tx)
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		err := operation.UpdateExecutionStopHeight(height)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return operation.InsertExecutionStopHeight(height)(tx)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not persist stop height: %w", err)
+	}
+
+	c.height = height
+
+	c.log.Info().
+		Uint64("stop_height", height).
+		Uint64("executed_height", c.executed).
+		Msg("stop height set")
+	c.metrics.ExecutionStopHeight(height)
+	c.metrics.ExecutionStopped(c.stopped())
+
+	var resumed []*entity.ExecutableBlock
+	for blockID, eb := range c.paused {
+		if c.allowed(eb.Height()) {
+			resumed = append(resumed, eb)
+			delete(c.paused, blockID)
+		}
+	}
+
+	return resumed, nil
+}
+
+// Begin checks whether the block may be executed. If it may not, the block is
+// held back until the stop height is raised or removed.
+func (c *Control) Begin(eb *entity.ExecutableBlock) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	height := eb.Height()
+	if !c.allowed(height) {
+		c.paused[eb.ID()] = eb
+		c.log.Debug().
+			Hex("block_id", logging.Entity(eb)).
+			Uint64("height", height).
+			Uint64("stop_height", c.height).
+			Msg("holding back block above stop height")
+		return false
+	}
+
+	if height > c.started {
+		c.started = height
+	}
+
+	return true
+}
+
+// Executed reports that the block at the given height was executed.
+func (c *Control) Executed(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height <= c.executed {
+		return
+	}
+	previous := c.executed
+	c.executed = height
+
+	if c.height > 0 && previous < c.height && height >= c.height {
+		c.log.Info().
+			Uint64("stop_height", c.height).
+			Msg("executed the block at the stop height, stopping execution")
+		c.metrics.ExecutionStopped(true)
+	}
+}
+
+func (c *Control) allowed(height uint64) bool {
+	return c.height == 0 || height <= c.height
+}
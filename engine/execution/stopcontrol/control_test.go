@@ -0,0 +1,91 @@
+package stopcontrol
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func blockAtHeight(height uint64) *entity.ExecutableBlock {
+	eb := unittest.ExecutableBlockFixture(nil)
+	eb.Block.Header.Height = height
+	return eb
+}
+
+func TestControl_StopHeight(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		control, err := New(zerolog.Nop(), db, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		// without a stop height, all blocks are executed
+		_, set := control.StopHeight()
+		assert.False(t, set)
+		assert.True(t, control.Begin(blockAtHeight(1000)))
+
+		_, err = control.SetStopHeight(1010, 1000)
+		require.NoError(t, err)
+		height, set := control.StopHeight()
+		assert.True(t, set)
+		assert.Equal(t, uint64(1010), height)
+
+		// blocks up to the stop height are executed, blocks above are held back
+		assert.True(t, control.Begin(blockAtHeight(1010)))
+		held := blockAtHeight(1011)
+		assert.False(t, control.Begin(held))
+
+		assert.False(t, control.Stopped())
+		control.Executed(1010)
+		assert.True(t, control.Stopped())
+
+		// the stop height can't be lowered below a block which was executed or is executing
+		_, err = control.SetStopHeight(1009, 1010)
+		assert.Error(t, err)
+
+		// raising the stop height resumes the blocks which were held back
+		resumed, err := control.SetStopHeight(1011, 1010)
+		require.NoError(t, err)
+		require.Len(t, resumed, 1)
+		assert.Equal(t, held.ID(), resumed[0].ID())
+		assert.False(t, control.Stopped())
+
+		// removing the stop height resumes all blocks
+		assert.False(t, control.Begin(blockAtHeight(1012)))
+		resumed, err = control.SetStopHeight(0, 1010)
+		require.NoError(t, err)
+		assert.Len(t, resumed, 1)
+		_, set = control.StopHeight()
+		assert.False(t, set)
+	})
+}
+
+func TestControl_Persisted(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		control, err := New(zerolog.Nop(), db, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		_, err = control.SetStopHeight(20, 10)
+		require.NoError(t, err)
+
+		// the stop height is loaded after a restart
+		control, err = New(zerolog.Nop(), db, metrics.NewNoopCollector())
+		require.NoError(t, err)
+		height, set := control.StopHeight()
+		assert.True(t, set)
+		assert.Equal(t, uint64(20), height)
+
+		_, err = control.SetStopHeight(0, 10)
+		require.NoError(t, err)
+
+		control, err = New(zerolog.Nop(), db, metrics.NewNoopCollector())
+		require.NoError(t, err)
+		_, set = control.StopHeight()
+		assert.False(t, set)
+	})
+}
@@ -30,6 +30,8 @@ import (
 	executionprovider "github.com/onflow/flow-go/engine/execution/provider"
 	executionState "github.com/onflow/flow-go/engine/execution/state"
 	bootstrapexec "github.com/onflow/flow-go/engine/execution/state/bootstrap"
+	"github.com/onflow/flow-go/engine/execution/statesync"
+	"github.com/onflow/flow-go/engine/execution/stopcontrol"
 	testmock "github.com/onflow/flow-go/engine/testutil/mock"
 	"github.com/onflow/flow-go/engine/verification/finder"
 	"github.com/onflow/flow-go/engine/verification/match"
@@ -349,7 +351,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	require.NoError(t, err)
 
 	execState := executionState.NewExecutionState(
-		ls, commitsStorage, node.Blocks, collectionsStorage, chunkDataPackStorage, results, receipts, node.Headers, node.DB, node.Tracer,
+		ls, commitsStorage, node.Blocks, collectionsStorage, chunkDataPackStorage, results, receipts, node.Headers, node.DB, nil, node.Tracer,
 	)
 
 	requestEngine, err := requester.New(
@@ -387,6 +389,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		vm,
 		vmCtx,
 		computation.DefaultProgramsCacheSize,
+		0,
 	)
 	require.NoError(t, err)
 
@@ -402,6 +405,12 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 
 	checkerEngine := &CheckerMock{}
 
+	syncConfig := statesync.DefaultConfig()
+	syncConfig.Threshold = uint64(syncThreshold)
+
+	stopControl, err := stopcontrol.New(node.Log, node.DB, node.Metrics)
+	require.NoError(t, err)
+
 	rootHead, rootQC := getRoot(t, &node)
 	ingestionEngine, err := ingestion.New(
 		node.Log,
@@ -410,6 +419,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		requestEngine,
 		node.State,
 		node.Blocks,
+		node.Seals,
 		collectionsStorage,
 		eventsStorage,
 		serviceEventsStorage,
@@ -422,8 +432,8 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		false,
 		filter.Any,
 		deltas,
-		syncThreshold,
-		false,
+		syncConfig,
+		stopControl,
 	)
 	require.NoError(t, err)
 	requestEngine.WithHandle(ingestionEngine.OnCollection)
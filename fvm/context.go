@@ -13,6 +13,7 @@ type Context struct {
 	Chain                            flow.Chain
 	Blocks                           Blocks
 	Metrics                          *MetricsCollector
+	ExecutionTracer                  *ExecutionTracer
 	GasLimit                         uint64
 	MaxStateKeySize                  uint64
 	MaxStateValueSize                uint64
@@ -66,6 +67,7 @@ func defaultContext(logger zerolog.Logger) Context {
 		Chain:                            flow.Mainnet.Chain(),
 		Blocks:                           nil,
 		Metrics:                          nil,
+		ExecutionTracer:                  nil,
 		GasLimit:                         DefaultGasLimit,
 		MaxStateKeySize:                  state.DefaultMaxKeySize,
 		MaxStateValueSize:                state.DefaultMaxValueSize,
@@ -177,6 +179,19 @@ func WithMetricsCollector(mc *MetricsCollector) Option {
 	}
 }
 
+// WithExecutionTracer sets the execution tracer for a virtual machine context.
+//
+// An execution tracer records a detailed trace of each transaction and script
+// executed in the context. Tracing changes the coverage report of the shared
+// runtime, so procedures must be executed serially while traced, and never
+// with parallel execution enabled.
+func WithExecutionTracer(tracer *ExecutionTracer) Option {
+	return func(ctx Context) Context {
+		ctx.ExecutionTracer = tracer
+		return ctx
+	}
+}
+
 // WithTransactionProcessors sets the transaction processors for a
 // virtual machine context.
 func WithTransactionProcessors(processors ...TransactionProcessor) Option {
@@ -186,6 +201,44 @@ func WithTransactionProcessors(processors ...TransactionProcessor) Option {
 	}
 }
 
+// DryRunOptions defines which steps of the transaction processing are skipped
+// when a transaction is dry-run.
+type DryRunOptions struct {
+	SkipSignatures     bool // do not verify signatures, so unsigned transactions can be run
+	SkipSequenceNumber bool // do not check and increment the sequence number of the proposal key
+	SkipFees           bool // do not deduct the transaction fees from the payer
+}
+
+// WithDryRun removes the transaction processors skipped by the given options
+// from a virtual machine context.
+//
+// The changes of a dry-run transaction must never be committed, as the
+// skipped steps are required for a transaction to be valid.
+func WithDryRun(opts DryRunOptions) Option {
+	return func(ctx Context) Context {
+		processors := make([]TransactionProcessor, 0, len(ctx.TransactionProcessors))
+		for _, processor := range ctx.TransactionProcessors {
+			switch processor.(type) {
+			case *TransactionSignatureVerifier:
+				if opts.SkipSignatures {
+					continue
+				}
+			case *TransactionSequenceNumberChecker:
+				if opts.SkipSequenceNumber {
+					continue
+				}
+			case *TransactionFeeDeductor:
+				if opts.SkipFees {
+					continue
+				}
+			}
+			processors = append(processors, processor)
+		}
+		ctx.TransactionProcessors = processors
+		return ctx
+	}
+}
+
 // WithServiceAccount enables or disables calls to the Flow service account.
 func WithServiceAccount(enabled bool) Option {
 	return func(ctx Context) Context {
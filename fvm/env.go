@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
@@ -42,9 +43,11 @@ type hostEnv struct {
 	transactionEnv     *transactionEnv
 	rng                *rand.Rand
 	programs           *Programs
+	trace              *ExecutionTrace
 }
 
 func (e *hostEnv) Hash(data []byte, hashAlgorithm string) ([]byte, error) {
+	defer e.traceCall("Hash")()
 
 	hasher, err := crypto.NewHasher(crypto.StringToHashAlgorithm(hashAlgorithm))
 	if err != nil {
@@ -99,10 +102,42 @@ func (e *hostEnv) seedRNG(header *flow.Header) {
 	e.rng = rand.New(source)
 }
 
+// setTrace records the execution of the procedure in the given trace.
+func (e *hostEnv) setTrace(trace *ExecutionTrace) {
+	if trace == nil {
+		return
+	}
+	e.trace = trace
+	e.Metrics = &tracingMetrics{Metrics: e.Metrics, trace: trace}
+}
+
+// traceCall starts timing a call from Cadence, and returns the function
+// recording it in the trace once the call returns.
+func (e *hostEnv) traceCall(name string) func() {
+	if e.trace == nil {
+		return noopTraceCall
+	}
+	start := time.Now()
+	return func() {
+		e.trace.addCall(name, time.Since(start))
+	}
+}
+
+func noopTraceCall() {}
+
+// nestedContext returns the context of the procedures run on behalf of the
+// procedure, which are traced as part of the call running them.
+func (e *hostEnv) nestedContext() Context {
+	if e.ctx.ExecutionTracer == nil {
+		return e.ctx
+	}
+	return NewContextFromParent(e.ctx, WithExecutionTracer(nil))
+}
+
 func (e *hostEnv) setTransaction(tx *flow.TransactionBody, txIndex uint32) {
 	e.transactionEnv = newTransactionEnv(
 		e.vm,
-		e.ctx,
+		e.nestedContext(),
 		e.st,
 		e.programs,
 		e.accounts,
@@ -126,19 +161,40 @@ func (e *hostEnv) getLogs() []string {
 }
 
 func (e *hostEnv) GetValue(owner, key []byte) ([]byte, error) {
+	var start time.Time
+	if e.trace != nil {
+		start = time.Now()
+	}
+
 	v, _ := e.accounts.GetValue(
 		flow.BytesToAddress(owner),
 		string(key),
 	)
+
+	if e.trace != nil {
+		e.trace.addRegister(owner, key, false, len(v), time.Since(start))
+	}
+
 	return v, nil
 }
 
 func (e *hostEnv) SetValue(owner, key, value []byte) error {
-	return e.accounts.SetValue(
+	var start time.Time
+	if e.trace != nil {
+		start = time.Now()
+	}
+
+	err := e.accounts.SetValue(
 		flow.BytesToAddress(owner),
 		string(key),
 		value,
 	)
+
+	if e.trace != nil {
+		e.trace.addRegister(owner, key, true, len(value), time.Since(start))
+	}
+
+	return err
 }
 
 func (e *hostEnv) ValueExists(owner, key []byte) (exists bool, err error) {
@@ -151,14 +207,17 @@ func (e *hostEnv) ValueExists(owner, key []byte) (exists bool, err error) {
 }
 
 func (e *hostEnv) GetStorageUsed(address common.Address) (value uint64, err error) {
+	defer e.traceCall("GetStorageUsed")()
 	return e.accounts.GetStorageUsed(flow.BytesToAddress(address.Bytes()))
 }
 
 func (e *hostEnv) GetStorageCapacity(address common.Address) (value uint64, err error) {
+	defer e.traceCall("GetStorageCapacity")()
+
 	script := getStorageCapacityScript(flow.BytesToAddress(address.Bytes()), e.ctx.Chain.ServiceAddress())
 
 	err = e.vm.Run(
-		e.ctx,
+		e.nestedContext(),
 		script,
 		e.st,
 		e.programs,
@@ -183,10 +242,12 @@ func (e *hostEnv) GetStorageCapacity(address common.Address) (value uint64, err
 }
 
 func (e *hostEnv) GetAccountBalance(address common.Address) (value uint64, err error) {
+	defer e.traceCall("GetAccountBalance")()
+
 	script := getFlowTokenBalanceScript(flow.BytesToAddress(address.Bytes()), e.ctx.Chain.ServiceAddress())
 
 	err = e.vm.Run(
-		e.ctx,
+		e.nestedContext(),
 		script,
 		e.st,
 		e.programs,
@@ -210,6 +271,7 @@ func (e *hostEnv) ResolveLocation(
 	identifiers []runtime.Identifier,
 	location runtime.Location,
 ) ([]runtime.ResolvedLocation, error) {
+	defer e.traceCall("ResolveLocation")()
 
 	addressLocation, isAddress := location.(common.AddressLocation)
 
@@ -270,6 +332,7 @@ func (e *hostEnv) ResolveLocation(
 }
 
 func (e *hostEnv) GetCode(location runtime.Location) ([]byte, error) {
+	defer e.traceCall("GetCode")()
 
 	contractLocation, ok := location.(common.AddressLocation)
 	if !ok {
@@ -293,6 +356,10 @@ func (e *hostEnv) GetProgram(location common.Location) (*interpreter.Program, er
 		}
 	}
 
+	if e.trace != nil {
+		e.trace.addProgramLoaded(location, program != nil)
+	}
+
 	return program, nil
 }
 
@@ -345,10 +412,17 @@ func (e *hostEnv) EmitEvent(event cadence.Event) error {
 	}
 
 	e.events = append(e.events, flowEvent)
+
+	if e.trace != nil {
+		e.trace.addEvent(flowEvent.Type, len(payload))
+	}
+
 	return nil
 }
 
 func (e *hostEnv) GenerateUUID() (uint64, error) {
+	defer e.traceCall("GenerateUUID")()
+
 	// TODO add not supported
 	uuid, err := e.uuidGenerator.GenerateUUID()
 	return uuid, err
@@ -368,6 +442,7 @@ func (e *hostEnv) SetComputationUsed(used uint64) error {
 }
 
 func (e *hostEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
+	defer e.traceCall("DecodeArgument")()
 	return jsoncdc.Decode(b)
 }
 
@@ -387,6 +462,8 @@ func (e *hostEnv) VerifySignature(
 	rawSigAlgo string,
 	rawHashAlgo string,
 ) (bool, error) {
+	defer e.traceCall("VerifySignature")()
+
 	valid, err := verifySignatureFromRuntime(
 		e.ctx.SignatureVerifier,
 		signature,
@@ -444,6 +521,8 @@ func runtimeBlockFromHeader(header *flow.Header) runtime.Block {
 
 // GetBlockAtHeight returns the block at the given height.
 func (e *hostEnv) GetBlockAtHeight(height uint64) (runtime.Block, bool, error) {
+	defer e.traceCall("GetBlockAtHeight")()
+
 	if e.ctx.Blocks == nil {
 		return runtime.Block{}, false, errors.New("getting block information is not supported")
 	}
@@ -468,6 +547,8 @@ func (e *hostEnv) GetBlockAtHeight(height uint64) (runtime.Block, bool, error) {
 // Transaction Environment Functions
 
 func (e *hostEnv) CreateAccount(payer runtime.Address) (address runtime.Address, err error) {
+	defer e.traceCall("CreateAccount")()
+
 	if e.transactionEnv == nil {
 		return runtime.Address{}, errors.New("creating accounts is not supported")
 	}
@@ -477,6 +558,8 @@ func (e *hostEnv) CreateAccount(payer runtime.Address) (address runtime.Address,
 }
 
 func (e *hostEnv) AddAccountKey(address runtime.Address, publicKey []byte) error {
+	defer e.traceCall("AddAccountKey")()
+
 	if e.transactionEnv == nil {
 		return errors.New("adding account keys is not supported")
 	}
@@ -486,6 +569,8 @@ func (e *hostEnv) AddAccountKey(address runtime.Address, publicKey []byte) error
 }
 
 func (e *hostEnv) RemoveAccountKey(address runtime.Address, index int) (publicKey []byte, err error) {
+	defer e.traceCall("RemoveAccountKey")()
+
 	if e.transactionEnv == nil {
 		return nil, errors.New("removing account keys is not supported")
 	}
@@ -495,6 +580,8 @@ func (e *hostEnv) RemoveAccountKey(address runtime.Address, index int) (publicKe
 }
 
 func (e *hostEnv) UpdateAccountContractCode(address runtime.Address, name string, code []byte) (err error) {
+	defer e.traceCall("UpdateAccountContractCode")()
+
 	if e.transactionEnv == nil {
 		return errors.New("updating account contract code is not supported")
 	}
@@ -511,6 +598,8 @@ func (e *hostEnv) GetAccountContractCode(address runtime.Address, name string) (
 }
 
 func (e *hostEnv) RemoveAccountContractCode(address runtime.Address, name string) (err error) {
+	defer e.traceCall("RemoveAccountContractCode")()
+
 	if e.transactionEnv == nil {
 		return errors.New("removing account contracts is not supported")
 	}
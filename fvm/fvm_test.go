@@ -1428,6 +1428,66 @@ func TestWithServiceAccount(t *testing.T) {
 	})
 }
 
+func TestWithDryRun(t *testing.T) {
+
+	t.Run("removes skipped processors", func(t *testing.T) {
+		ctx := fvm.NewContext(
+			zerolog.Nop(),
+			fvm.WithDryRun(fvm.DryRunOptions{
+				SkipSignatures:     true,
+				SkipSequenceNumber: true,
+				SkipFees:           true,
+			}),
+		)
+
+		require.Len(t, ctx.TransactionProcessors, 2)
+		assert.IsType(t, &fvm.TransactionInvocator{}, ctx.TransactionProcessors[0])
+		assert.IsType(t, &fvm.TransactionStorageLimiter{}, ctx.TransactionProcessors[1])
+	})
+
+	t.Run("keeps processors which are not skipped", func(t *testing.T) {
+		ctx := fvm.NewContext(
+			zerolog.Nop(),
+			fvm.WithDryRun(fvm.DryRunOptions{SkipFees: true}),
+		)
+
+		require.Len(t, ctx.TransactionProcessors, 4)
+		assert.IsType(t, &fvm.TransactionSignatureVerifier{}, ctx.TransactionProcessors[0])
+		assert.IsType(t, &fvm.TransactionSequenceNumberChecker{}, ctx.TransactionProcessors[1])
+	})
+
+	t.Run("runs unsigned transaction with wrong sequence number", newVMTest().run(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger, programs *fvm.Programs) {
+			txBody := flow.NewTransactionBody().
+				SetScript([]byte(`transaction { prepare(signer: AuthAccount) {} }`)).
+				SetProposalKey(chain.ServiceAddress(), 0, 42).
+				SetPayer(chain.ServiceAddress()).
+				AddAuthorizer(chain.ServiceAddress())
+
+			tx := fvm.Transaction(txBody, 0)
+			err := vm.Run(ctx, tx, ledger, programs)
+			require.NoError(t, err)
+			assert.Error(t, tx.Err)
+
+			dryRunCtx := fvm.NewContextFromParent(ctx, fvm.WithDryRun(fvm.DryRunOptions{
+				SkipSignatures:     true,
+				SkipSequenceNumber: true,
+				SkipFees:           true,
+			}))
+
+			tx = fvm.Transaction(txBody, 0)
+			err = vm.Run(dryRunCtx, tx, ledger, programs)
+			require.NoError(t, err)
+			assert.NoError(t, tx.Err)
+
+			// the sequence number of the proposal key is left unchanged
+			key, err := state.NewAccounts(state.NewState(ledger)).GetPublicKey(chain.ServiceAddress(), 0)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(0), key.SeqNumber)
+		}),
+	)
+}
+
 func TestEventLimits(t *testing.T) {
 
 	t.Parallel()
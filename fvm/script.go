@@ -78,6 +78,15 @@ func (i ScriptInvocator) Process(
 		return err
 	}
 
+	trace := ctx.ExecutionTracer.startTrace(TraceKindScript, proc.ID)
+	var coverage *runtime.CoverageReport
+	if trace != nil {
+		env.setTrace(trace)
+		coverage = runtime.NewCoverageReport()
+		vm.Runtime.SetCoverageReport(coverage)
+		defer vm.Runtime.SetCoverageReport(nil)
+	}
+
 	location := common.ScriptLocation(proc.ID[:])
 
 	value, err := vm.Runtime.ExecuteScript(
@@ -90,6 +99,7 @@ func (i ScriptInvocator) Process(
 			Location:  location,
 		},
 	)
+	trace.finish(err, coverage)
 	if err != nil {
 		return err
 	}
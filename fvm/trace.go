@@ -0,0 +1,325 @@
+package fvm
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	TraceKindTransaction = "transaction"
+	TraceKindScript      = "script"
+)
+
+// An ExecutionTracer records a detailed trace of each transaction and script
+// executed in a context, to find out where their time and computation go.
+//
+// Tracing is opt-in and slows down execution: it is meant for debugging tools
+// re-executing blocks, not for nodes following the chain.
+//
+// The statements executed by Cadence are counted with the coverage report of
+// the runtime of the virtual machine. Tracing replaces the coverage report of
+// that runtime, which is shared by all the procedures it runs, for the duration
+// of each procedure, so procedures must be executed serially while they are
+// traced, and tracing must not be combined with the parallel execution of
+// transactions.
+type ExecutionTracer struct {
+	mu     sync.Mutex
+	traces []*ExecutionTrace
+}
+
+// NewExecutionTracer returns a new execution tracer.
+func NewExecutionTracer() *ExecutionTracer {
+	return &ExecutionTracer{}
+}
+
+// Traces returns the traces recorded so far, in execution order.
+func (t *ExecutionTracer) Traces() []*ExecutionTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	traces := make([]*ExecutionTrace, len(t.traces))
+	copy(traces, t.traces)
+	return traces
+}
+
+// startTrace starts the trace of a procedure. It returns nil if the tracer is
+// nil, so that tracing can be disabled by not setting a tracer.
+func (t *ExecutionTracer) startTrace(kind string, id flow.Identifier) *ExecutionTrace {
+	if t == nil {
+		return nil
+	}
+
+	trace := &ExecutionTrace{
+		Kind:     kind,
+		ID:       id,
+		start:    time.Now(),
+		programs: make(map[common.LocationID]*ProgramTrace),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.traces = append(t.traces, trace)
+
+	return trace
+}
+
+// An ExecutionTrace is the trace of the execution of a transaction or script.
+//
+// Cadence doesn't report the computation used by each function call, so the
+// computation is broken down into the statements executed on each line of
+// each program. Loop iterations and function invocations are metered as well,
+// but are not counted.
+type ExecutionTrace struct {
+	Kind       string            `json:"kind"`
+	ID         flow.Identifier   `json:"id"`
+	Duration   time.Duration     `json:"duration"`
+	Retried    int               `json:"retried"`
+	Error      string            `json:"error,omitempty"`
+	Programs   []*ProgramTrace   `json:"programs"`
+	Statements []StatementsTrace `json:"statements"`
+	Registers  []RegisterTrace   `json:"registers"`
+	Calls      []CallTrace       `json:"calls"`
+	Events     []EventTrace      `json:"events"`
+
+	start    time.Time
+	programs map[common.LocationID]*ProgramTrace
+}
+
+// A ProgramTrace records the loading of a program imported by, or executed by,
+// a procedure.
+type ProgramTrace struct {
+	Location    common.LocationID `json:"location"`
+	CacheHit    bool              `json:"cache_hit"`
+	Parsed      time.Duration     `json:"parsed"`
+	Checked     time.Duration     `json:"checked"`
+	Interpreted time.Duration     `json:"interpreted"`
+}
+
+// A StatementsTrace records the number of statements executed on a line of a
+// program.
+type StatementsTrace struct {
+	Location common.LocationID `json:"location"`
+	Line     int               `json:"line"`
+	Count    int               `json:"count"`
+}
+
+// A RegisterTrace records a read or a write of a register by Cadence.
+type RegisterTrace struct {
+	Owner    flow.Address  `json:"owner"`
+	Key      string        `json:"key"`
+	Write    bool          `json:"write"`
+	Size     int           `json:"size"`
+	Duration time.Duration `json:"duration"`
+}
+
+// A CallTrace records a call from Cadence to the virtual machine.
+type CallTrace struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// An EventTrace records the emission of an event.
+type EventTrace struct {
+	Type flow.EventType `json:"type"`
+	Size int            `json:"size"`
+}
+
+// retry discards what was recorded by a failed attempt of the procedure.
+func (t *ExecutionTrace) retry() {
+	if t == nil {
+		return
+	}
+
+	t.Retried++
+	t.Programs = nil
+	t.Statements = nil
+	t.Registers = nil
+	t.Calls = nil
+	t.Events = nil
+	t.programs = make(map[common.LocationID]*ProgramTrace)
+}
+
+// finish completes the trace with the outcome of the procedure, and the
+// statements executed by it.
+func (t *ExecutionTrace) finish(err error, coverage *runtime.CoverageReport) {
+	if t == nil {
+		return
+	}
+
+	t.Duration = time.Since(t.start)
+	if err != nil {
+		t.Error = err.Error()
+	}
+
+	if coverage == nil {
+		return
+	}
+	for location, lines := range coverage.Coverage {
+		for line, count := range lines.LineHits {
+			t.Statements = append(t.Statements, StatementsTrace{
+				Location: location,
+				Line:     line,
+				Count:    count,
+			})
+		}
+	}
+	sort.Slice(t.Statements, func(i, j int) bool {
+		if t.Statements[i].Location != t.Statements[j].Location {
+			return t.Statements[i].Location < t.Statements[j].Location
+		}
+		return t.Statements[i].Line < t.Statements[j].Line
+	})
+}
+
+func (t *ExecutionTrace) program(location common.Location) *ProgramTrace {
+	id := location.ID()
+	program, ok := t.programs[id]
+	if !ok {
+		program = &ProgramTrace{Location: id}
+		t.programs[id] = program
+		t.Programs = append(t.Programs, program)
+	}
+	return program
+}
+
+func (t *ExecutionTrace) addProgramLoaded(location common.Location, cacheHit bool) {
+	if t == nil {
+		return
+	}
+	t.program(location).CacheHit = cacheHit
+}
+
+func (t *ExecutionTrace) addRegister(owner, key []byte, write bool, size int, duration time.Duration) {
+	if t == nil {
+		return
+	}
+	t.Registers = append(t.Registers, RegisterTrace{
+		Owner:    flow.BytesToAddress(owner),
+		Key:      string(key),
+		Write:    write,
+		Size:     size,
+		Duration: duration,
+	})
+}
+
+func (t *ExecutionTrace) addCall(name string, duration time.Duration) {
+	if t == nil {
+		return
+	}
+	t.Calls = append(t.Calls, CallTrace{Name: name, Duration: duration})
+}
+
+func (t *ExecutionTrace) addEvent(eventType flow.EventType, size int) {
+	if t == nil {
+		return
+	}
+	t.Events = append(t.Events, EventTrace{Type: eventType, Size: size})
+}
+
+// WriteFoldedStacks writes the time spent by the procedure in the folded stack
+// format of flame graph tools, in microseconds.
+//
+// The parsing and checking of imported programs happen while the program
+// importing them is checked, so the time of each frame is only approximately
+// exclusive of the others. The time that can't be attributed to anything else
+// is attributed to the interpretation of the procedure.
+func (t *ExecutionTrace) WriteFoldedStacks(w io.Writer) error {
+	root := t.root()
+	frames := make(map[string]time.Duration)
+	var order []string
+	var attributed time.Duration
+
+	add := func(frame string, duration time.Duration) {
+		if _, ok := frames[frame]; !ok {
+			order = append(order, frame)
+		}
+		frames[frame] += duration
+		attributed += duration
+	}
+
+	for _, program := range t.Programs {
+		add("parse;"+string(program.Location), program.Parsed)
+		add("check;"+string(program.Location), program.Checked)
+	}
+	for _, register := range t.Registers {
+		if register.Write {
+			add("interpret;storage;write", register.Duration)
+		} else {
+			add("interpret;storage;read", register.Duration)
+		}
+	}
+	for _, call := range t.Calls {
+		add("interpret;"+call.Name, call.Duration)
+	}
+
+	remaining := t.Duration - attributed
+	if remaining > 0 {
+		add("interpret", remaining)
+	}
+
+	for _, frame := range order {
+		micros := frames[frame].Microseconds()
+		if micros <= 0 {
+			continue
+		}
+		_, err := fmt.Fprintf(w, "%s;%s %d\n", root, frame, micros)
+		if err != nil {
+			return fmt.Errorf("could not write folded stacks: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// WriteFoldedStatements writes the statements executed by the procedure in
+// the folded stack format of flame graph tools, by program and line.
+func (t *ExecutionTrace) WriteFoldedStatements(w io.Writer) error {
+	root := t.root()
+	for _, statements := range t.Statements {
+		_, err := fmt.Fprintf(w, "%s;%s;line %d %d\n", root, statements.Location, statements.Line, statements.Count)
+		if err != nil {
+			return fmt.Errorf("could not write folded statements: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *ExecutionTrace) root() string {
+	return fmt.Sprintf("%s %s", t.Kind, t.ID)
+}
+
+// tracingMetrics records the durations reported by the Cadence runtime in a
+// trace, in addition to the metrics collector of the context.
+type tracingMetrics struct {
+	runtime.Metrics
+	trace *ExecutionTrace
+}
+
+func (m tracingMetrics) ProgramParsed(location common.Location, duration time.Duration) {
+	m.Metrics.ProgramParsed(location, duration)
+	if location != nil {
+		m.trace.program(location).Parsed += duration
+	}
+}
+
+func (m tracingMetrics) ProgramChecked(location common.Location, duration time.Duration) {
+	m.Metrics.ProgramChecked(location, duration)
+	if location != nil {
+		m.trace.program(location).Checked += duration
+	}
+}
+
+func (m tracingMetrics) ProgramInterpreted(location common.Location, duration time.Duration) {
+	m.Metrics.ProgramInterpreted(location, duration)
+	if location != nil {
+		m.trace.program(location).Interpreted += duration
+	}
+}
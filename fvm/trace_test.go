@@ -0,0 +1,90 @@
+package fvm
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func Test_ExecutionTrace(t *testing.T) {
+
+	id := flow.Identifier{1}
+	location := common.AddressLocation{
+		Address: common.BytesToAddress([]byte{1}),
+		Name:    "Token",
+	}
+
+	t.Run("nil tracer does not trace", func(t *testing.T) {
+		var tracer *ExecutionTracer
+		trace := tracer.startTrace(TraceKindTransaction, id)
+		require.Nil(t, trace)
+
+		// recording in a nil trace is a no-op
+		trace.addCall("GetCode", time.Millisecond)
+		trace.finish(nil, nil)
+	})
+
+	t.Run("retry discards the failed attempt", func(t *testing.T) {
+		tracer := NewExecutionTracer()
+		trace := tracer.startTrace(TraceKindTransaction, id)
+
+		trace.addProgramLoaded(location, false)
+		trace.addRegister(location.Address.Bytes(), []byte("storage"), false, 10, time.Millisecond)
+		trace.retry()
+		trace.addProgramLoaded(location, true)
+		trace.finish(errors.New("failed"), nil)
+
+		traces := tracer.Traces()
+		require.Len(t, traces, 1)
+		require.Equal(t, 1, traces[0].Retried)
+		require.Equal(t, "failed", traces[0].Error)
+		require.Len(t, traces[0].Programs, 1)
+		require.True(t, traces[0].Programs[0].CacheHit)
+		require.Empty(t, traces[0].Registers)
+	})
+
+	t.Run("folded stacks", func(t *testing.T) {
+		trace := NewExecutionTracer().startTrace(TraceKindTransaction, id)
+
+		trace.program(location).Parsed = 2 * time.Millisecond
+		trace.program(location).Checked = 3 * time.Millisecond
+		trace.addRegister(location.Address.Bytes(), []byte("storage"), true, 10, time.Millisecond)
+		trace.addCall("GetCode", time.Millisecond)
+		trace.addCall("GetCode", time.Millisecond)
+
+		coverage := runtime.NewCoverageReport()
+		coverage.AddLineHit(location, 4)
+		coverage.AddLineHit(location, 4)
+		coverage.AddLineHit(location, 2)
+		trace.finish(nil, coverage)
+		trace.Duration = 10 * time.Millisecond
+
+		var stacks bytes.Buffer
+		require.NoError(t, trace.WriteFoldedStacks(&stacks))
+
+		root := "transaction " + id.String()
+		require.Equal(t, []string{
+			root + ";parse;A.0000000000000001.Token 2000",
+			root + ";check;A.0000000000000001.Token 3000",
+			root + ";interpret;storage;write 1000",
+			root + ";interpret;GetCode 2000",
+			root + ";interpret 2000",
+		}, strings.Split(strings.TrimSpace(stacks.String()), "\n"))
+
+		var statements bytes.Buffer
+		require.NoError(t, trace.WriteFoldedStatements(&statements))
+
+		require.Equal(t, []string{
+			root + ";A.0000000000000001.Token;line 2 1",
+			root + ";A.0000000000000001.Token;line 4 2",
+		}, strings.Split(strings.TrimSpace(statements.String()), "\n"))
+	})
+}
@@ -85,6 +85,12 @@ func (i *TransactionInvocator) Process(
 		blockHeight = ctx.BlockHeader.Height
 	}
 
+	trace := ctx.ExecutionTracer.startTrace(TraceKindTransaction, proc.ID)
+	var coverage *runtime.CoverageReport
+	if trace != nil {
+		defer vm.Runtime.SetCoverageReport(nil)
+	}
+
 	numberOfRetries := 0
 	for numberOfRetries = 0; numberOfRetries < int(ctx.MaxNumOfTxRetries); numberOfRetries++ {
 		env, err = newEnvironment(ctx, vm, st, programs)
@@ -93,6 +99,12 @@ func (i *TransactionInvocator) Process(
 			return err
 		}
 		env.setTransaction(proc.Transaction, proc.TxIndex)
+		env.setTrace(trace)
+
+		if trace != nil {
+			coverage = runtime.NewCoverageReport()
+			vm.Runtime.SetCoverageReport(coverage)
+		}
 
 		location := common.TransactionLocation(proc.ID[:])
 
@@ -131,6 +143,7 @@ func (i *TransactionInvocator) Process(
 		proc.Events = make([]flow.Event, 0)
 		proc.ServiceEvents = make([]flow.Event, 0)
 		proc.Retried++
+		trace.retry()
 	}
 
 	// (for future) panic if we tried several times and still failing
@@ -142,6 +155,7 @@ func (i *TransactionInvocator) Process(
 	if err != nil {
 		// if tx fails just do clean up
 		programs.Cleanup(nil)
+		trace.finish(err, coverage)
 		i.logger.Info().
 			Str("txHash", proc.ID.String()).
 			Uint64("blockHeight", blockHeight).
@@ -160,6 +174,8 @@ func (i *TransactionInvocator) Process(
 	// transaction without any deployed contracts
 	programs.Cleanup(updatedKeys)
 
+	trace.finish(err, coverage)
+
 	// tx failed at update contract step
 	if err != nil {
 		i.logger.Info().
@@ -140,8 +140,7 @@ func ReadShortData(input []byte) (data []byte, rest []byte, err error) {
 	if err != nil {
 		return nil, rest, err
 	}
-	data = rest[:size]
-	return
+	return ReadSlice(rest, int(size))
 }
 
 // ReadLongData read data shorter than 32MB and return the rest of bytes
@@ -151,8 +150,7 @@ func ReadLongData(input []byte) (data []byte, rest []byte, err error) {
 	if err != nil {
 		return nil, rest, err
 	}
-	data = rest[:size]
-	return
+	return ReadSlice(rest, int(size))
 }
 
 // ReadShortDataFromReader reads data shorter than 16kB from reader
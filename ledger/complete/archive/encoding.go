@@ -0,0 +1,125 @@
+package archive
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+const encodingDecodingVersion = uint16(0)
+
+// storedNode is the on-disk representation of a trie node. Children are
+// referenced by their hash, which is also the key they are stored under.
+type storedNode struct {
+	height     uint16
+	maxDepth   uint16
+	regCount   uint64
+	lHash      []byte // empty if there is no left child
+	rHash      []byte // empty if there is no right child
+	path       []byte // empty for interior nodes
+	encPayload []byte // empty for interior nodes
+}
+
+// encodeNode encodes the given node, referencing its children by hash.
+func encodeNode(n *node.Node) []byte {
+
+	var lHash, rHash []byte
+	if lChild := n.LeftChild(); lChild != nil {
+		lHash = lChild.Hash()
+	}
+	if rChild := n.RightChild(); rChild != nil {
+		rHash = rChild.Hash()
+	}
+	encPayload := encoding.EncodePayload(n.Payload())
+
+	length := 2 + 2 + 2 + 8 + 2 + len(lHash) + 2 + len(rHash) + 2 + len(n.Path()) + 4 + len(encPayload)
+	buf := make([]byte, 0, length)
+
+	// 2-bytes encoding version
+	buf = utils.AppendUint16(buf, encodingDecodingVersion)
+
+	// 2-bytes Big Endian uint16 height
+	buf = utils.AppendUint16(buf, uint16(n.Height()))
+
+	// 2-bytes Big Endian maxDepth
+	buf = utils.AppendUint16(buf, n.MaxDepth())
+
+	// 8-bytes Big Endian regCount
+	buf = utils.AppendUint64(buf, n.RegCount())
+
+	// 2-bytes Big Endian uint16 hash length and n-bytes hash of each child
+	buf = utils.AppendShortData(buf, lHash)
+	buf = utils.AppendShortData(buf, rHash)
+
+	// 2-bytes Big Endian uint16 path length and n-bytes path
+	buf = utils.AppendShortData(buf, n.Path())
+
+	// 4-bytes Big Endian uint32 encoded payload length and n-bytes encoded payload
+	buf = utils.AppendLongData(buf, encPayload)
+
+	return buf
+}
+
+// decodeNode decodes a stored node.
+func decodeNode(encoded []byte) (*storedNode, error) {
+
+	version, rest, err := utils.ReadUint16(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read version: %w", err)
+	}
+	if version > encodingDecodingVersion {
+		return nil, fmt.Errorf("unsupported version %d > %d", version, encodingDecodingVersion)
+	}
+
+	stored := &storedNode{}
+
+	stored.height, rest, err = utils.ReadUint16(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read height: %w", err)
+	}
+	stored.maxDepth, rest, err = utils.ReadUint16(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read max depth: %w", err)
+	}
+	stored.regCount, rest, err = utils.ReadUint64(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read register count: %w", err)
+	}
+	stored.lHash, rest, err = utils.ReadShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read left child hash: %w", err)
+	}
+	stored.rHash, rest, err = utils.ReadShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read right child hash: %w", err)
+	}
+	stored.path, rest, err = utils.ReadShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read path: %w", err)
+	}
+	stored.encPayload, _, err = utils.ReadLongData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read payload: %w", err)
+	}
+
+	return stored, nil
+}
+
+// toNode converts a stored node with the given hash into a trie node with the
+// given children.
+func (s *storedNode) toNode(hash []byte, lChild, rChild *node.Node) (*node.Node, error) {
+
+	payload, err := encoding.DecodePayload(s.encPayload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode payload: %w", err)
+	}
+	var path ledger.Path
+	if len(s.path) > 0 {
+		path = ledger.Path(s.path)
+	}
+
+	return node.NewNode(int(s.height), lChild, rChild, path, payload, hash, s.maxDepth, s.regCount), nil
+}
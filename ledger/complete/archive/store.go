@@ -0,0 +1,181 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// ErrNotArchived is returned when a trie node is not found in the archive.
+var ErrNotArchived = errors.New("trie node not archived")
+
+// NodeStore persists trie nodes to disk, content-addressed by their hash.
+// As tries are immutable and share unchanged subtries, each node is stored
+// only once, no matter how many tries it is part of.
+//
+// Nodes are always stored after all of their descendants, so the existence of
+// a node implies that its complete subtrie is stored.
+type NodeStore struct {
+	db *badger.DB
+}
+
+// NewNodeStore returns a new node store on top of the given database. The
+// store takes ownership of the database, which is closed with the store.
+func NewNodeStore(db *badger.DB) *NodeStore {
+	return &NodeStore{db: db}
+}
+
+// Close closes the database of the store.
+func (s *NodeStore) Close() error {
+	return s.db.Close()
+}
+
+// Store persists all nodes of the trie with the given root which are not yet
+// stored. Subtries shared with previously stored tries are skipped.
+func (s *NodeStore) Store(root *node.Node) error {
+
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	err := s.store(batch, root)
+	if err != nil {
+		return err
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush trie nodes: %w", err)
+	}
+
+	return nil
+}
+
+// store adds all missing nodes of the given subtrie to the batch, children
+// before their parents.
+func (s *NodeStore) store(batch *badger.WriteBatch, n *node.Node) error {
+
+	exists, err := s.Has(n.Hash())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if lChild := n.LeftChild(); lChild != nil {
+		err = s.store(batch, lChild)
+		if err != nil {
+			return err
+		}
+	}
+	if rChild := n.RightChild(); rChild != nil {
+		err = s.store(batch, rChild)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = batch.Set(n.Hash(), encodeNode(n))
+	if err != nil {
+		return fmt.Errorf("could not add trie node %x: %w", n.Hash(), err)
+	}
+
+	return nil
+}
+
+// Has returns true if the node with the given hash is stored.
+func (s *NodeStore) Has(hash []byte) (bool, error) {
+	err := s.db.View(func(tx *badger.Txn) error {
+		_, err := tx.Get(hash)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not check trie node %x: %w", hash, err)
+	}
+	return true, nil
+}
+
+// LoadTrie loads the parts of the trie with the given root hash needed to
+// read and prove the given paths. Nodes on the paths are loaded from disk,
+// while subtries not on any of the paths are represented by a node only
+// holding their hash. The returned trie must only be used for the given paths.
+func (s *NodeStore) LoadTrie(rootHash ledger.RootHash, paths []ledger.Path) (*trie.MTrie, error) {
+
+	sortedPaths := make([]ledger.Path, len(paths))
+	copy(sortedPaths, paths)
+	sort.Slice(sortedPaths, func(i, j int) bool {
+		return bytes.Compare(sortedPaths[i], sortedPaths[j]) < 0
+	})
+
+	var root *node.Node
+	err := s.db.View(func(tx *badger.Txn) error {
+		var err error
+		root, err = s.load(tx, rootHash, -1, sortedPaths)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load trie %x: %w", rootHash, err)
+	}
+
+	return trie.NewMTrie(root)
+}
+
+// load loads the node with the given hash and the nodes below it on the given
+// sorted paths. The tree height is derived from the root node.
+func (s *NodeStore) load(tx *badger.Txn, hash []byte, treeHeight int, paths []ledger.Path) (*node.Node, error) {
+
+	item, err := tx.Get(hash)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, fmt.Errorf("%w: %x", ErrNotArchived, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get trie node %x: %w", hash, err)
+	}
+	encoded, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not read trie node %x: %w", hash, err)
+	}
+	stored, err := decodeNode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode trie node %x: %w", hash, err)
+	}
+
+	if treeHeight < 0 {
+		treeHeight = int(stored.height)
+	}
+
+	// leaves are always loaded completely; subtries that are not on any path
+	// are only needed for their hash
+	if len(stored.path) > 0 || len(paths) == 0 {
+		return stored.toNode(hash, nil, nil)
+	}
+
+	lPaths, rPaths := utils.SplitSortedPaths(paths, treeHeight-int(stored.height))
+
+	var lChild, rChild *node.Node
+	if len(stored.lHash) > 0 {
+		lChild, err = s.load(tx, stored.lHash, treeHeight, lPaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(stored.rHash) > 0 {
+		rChild, err = s.load(tx, stored.rHash, treeHeight, rPaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stored.toNode(hash, lChild, rChild)
+}
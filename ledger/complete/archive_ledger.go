@@ -0,0 +1,192 @@
+package complete
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/archive"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// ArchiveLedger is a complete ledger which keeps every trie it has ever held.
+// In addition to the in-memory forest, all trie nodes are persisted to an
+// on-disk node store. Nodes are content-addressed by their hash, so subtries
+// shared between tries are only stored once.
+//
+// Reads and proofs at states still held in memory are served by the forest.
+// For states evicted from the forest, only the nodes on the requested paths
+// are loaded from disk, so arbitrarily old states can be queried without
+// loading complete tries into memory.
+type ArchiveLedger struct {
+	*Ledger
+	nodes *archive.NodeStore
+}
+
+// NewArchiveLedger creates a new complete ledger which archives all tries to
+// the given node store.
+func NewArchiveLedger(dbDir string,
+	capacity int,
+	metrics module.LedgerMetrics,
+	log zerolog.Logger,
+	reg prometheus.Registerer,
+	pathFinderVer uint8,
+	nodes *archive.NodeStore) (*ArchiveLedger, error) {
+
+	l, err := NewLedger(dbDir, capacity, metrics, log, reg, pathFinderVer)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveLedger := &ArchiveLedger{
+		Ledger: l,
+		nodes:  nodes,
+	}
+
+	// archive the tries restored from the write-ahead log, which covers the
+	// empty trie and any tries created before archiving was enabled
+	tries, err := l.forest.GetTries()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get restored tries: %w", err)
+	}
+	for _, t := range tries {
+		err = nodes.Store(t.RootNode())
+		if err != nil {
+			return nil, fmt.Errorf("cannot archive restored trie: %w", err)
+		}
+	}
+
+	return archiveLedger, nil
+}
+
+// Done closes the write-ahead log of the ledger, and then the node store.
+func (l *ArchiveLedger) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		<-l.Ledger.Done()
+		err := l.nodes.Close()
+		if err != nil {
+			l.logger.Error().Err(err).Msg("could not close archive node store")
+		}
+		close(done)
+	}()
+	return done
+}
+
+// Get reads the values of the given keys at the given state, which may be
+// any state ever held by the ledger.
+func (l *ArchiveLedger) Get(query *ledger.Query) ([]ledger.Value, error) {
+
+	_, err := l.forest.GetTrie(ledger.RootHash(query.State()))
+	if err == nil {
+		return l.Ledger.Get(query)
+	}
+
+	start := time.Now()
+	paths, err := pathfinder.KeysToPaths(query.Keys(), l.pathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+	forest, err := l.archivedForest(query.State(), paths)
+	if err != nil {
+		return nil, err
+	}
+	trieRead := &ledger.TrieRead{RootHash: ledger.RootHash(query.State()), Paths: paths}
+	payloads, err := forest.Read(trieRead)
+	if err != nil {
+		return nil, err
+	}
+	values, err := pathfinder.PayloadsToValues(payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	l.metrics.ReadValuesNumber(uint64(len(paths)))
+	l.metrics.ReadDuration(time.Since(start))
+
+	return values, nil
+}
+
+// Set updates the ledger given an update and archives the resulting trie.
+func (l *ArchiveLedger) Set(update *ledger.Update) (ledger.State, error) {
+
+	newState, err := l.Ledger.Set(update)
+	if err != nil {
+		return nil, err
+	}
+	if update.Size() == 0 {
+		return newState, nil
+	}
+
+	t, err := l.forest.GetTrie(ledger.RootHash(newState))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get updated trie: %w", err)
+	}
+	err = l.nodes.Store(t.RootNode())
+	if err != nil {
+		return nil, fmt.Errorf("cannot archive updated trie: %w", err)
+	}
+
+	return newState, nil
+}
+
+// Prove provides proofs for a ledger query at the given state, which may be
+// any state ever held by the ledger.
+func (l *ArchiveLedger) Prove(query *ledger.Query) (ledger.Proof, error) {
+
+	_, err := l.forest.GetTrie(ledger.RootHash(query.State()))
+	if err == nil {
+		return l.Ledger.Prove(query)
+	}
+
+	paths, err := pathfinder.KeysToPaths(query.Keys(), l.pathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+	forest, err := l.archivedForest(query.State(), paths)
+	if err != nil {
+		return nil, err
+	}
+	trieRead := &ledger.TrieRead{RootHash: ledger.RootHash(query.State()), Paths: paths}
+	batchProof, err := forest.Proofs(trieRead)
+	if err != nil {
+		return nil, fmt.Errorf("could not get proofs: %w", err)
+	}
+
+	proofToGo := encoding.EncodeTrieBatchProof(batchProof)
+
+	if len(paths) > 0 {
+		l.metrics.ProofSize(uint32(len(proofToGo) / len(paths)))
+	}
+
+	return ledger.Proof(proofToGo), nil
+}
+
+// archivedForest returns a forest holding the archived trie at the given
+// state, loaded from disk for the given paths only.
+func (l *ArchiveLedger) archivedForest(state ledger.State, paths []ledger.Path) (*mtrie.Forest, error) {
+
+	t, err := l.nodes.LoadTrie(ledger.RootHash(state), paths)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load archived trie: %w", err)
+	}
+
+	// the forest is only used for this query, so we don't report its metrics
+	forest, err := mtrie.NewForest(pathfinder.PathByteSize, "", 2, metrics.NewNoopCollector(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create forest: %w", err)
+	}
+	err = forest.AddTrie(t)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add archived trie to forest: %w", err)
+	}
+
+	return forest, nil
+}
@@ -0,0 +1,95 @@
+package complete_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/archive"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestArchiveLedger tests that values and proofs remain available for states
+// which have been evicted from memory, including after a restart.
+func TestArchiveLedger(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		walDir := filepath.Join(dir, "wal")
+		archiveDir := filepath.Join(dir, "archive")
+		nodes := archive.NewNodeStore(unittest.BadgerDB(t, archiveDir))
+
+		// a capacity of 2 means all but the latest tries are evicted from memory
+		led, err := complete.NewArchiveLedger(walDir, 2, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion, nodes)
+		require.NoError(t, err)
+
+		type snapshot struct {
+			state  ledger.State
+			keys   []ledger.Key
+			values []ledger.Value
+			proof  ledger.Proof
+		}
+
+		// the first key set is updated in every step, so it has a different value at each state
+		keys := utils.RandomUniqueKeys(10, 2, 1, 10)
+		unsetKeys := utils.RandomUniqueKeys(5, 2, 11, 20)
+
+		state := led.InitialState()
+		snapshots := make([]snapshot, 0)
+		for i := 0; i < 10; i++ {
+			values := utils.RandomValues(len(keys), 1, 32)
+			update, err := ledger.NewUpdate(state, keys, values)
+			require.NoError(t, err)
+			state, err = led.Set(update)
+			require.NoError(t, err)
+
+			// record a proof while the trie is still held in memory
+			query, err := ledger.NewQuery(state, append(keys, unsetKeys...))
+			require.NoError(t, err)
+			proof, err := led.Prove(query)
+			require.NoError(t, err)
+
+			snapshots = append(snapshots, snapshot{state: state, keys: keys, values: values, proof: proof})
+		}
+
+		check := func(led *complete.ArchiveLedger) {
+			for _, snap := range snapshots {
+				query, err := ledger.NewQuery(snap.state, snap.keys)
+				require.NoError(t, err)
+				values, err := led.Get(query)
+				require.NoError(t, err)
+				assert.Equal(t, snap.values, values)
+
+				query, err = ledger.NewQuery(snap.state, append(snap.keys, unsetKeys...))
+				require.NoError(t, err)
+				proof, err := led.Prove(query)
+				require.NoError(t, err)
+				assert.Equal(t, snap.proof, proof)
+			}
+		}
+
+		check(led)
+
+		// stopping the ledger closes the archive database, so it can be reopened
+		<-led.Done()
+
+		// restart the ledger; only the latest tries are restored to memory
+		nodes = archive.NewNodeStore(unittest.BadgerDB(t, archiveDir))
+		led, err = complete.NewArchiveLedger(walDir, 2, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion, nodes)
+		require.NoError(t, err)
+		check(led)
+
+		// unknown states are reported as errors
+		query, err := ledger.NewQuery(ledger.State(utils.RootHashFixture()), keys)
+		require.NoError(t, err)
+		_, err = led.Get(query)
+		assert.Error(t, err)
+
+		<-led.Done()
+	})
+}
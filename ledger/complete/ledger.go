@@ -338,6 +338,8 @@ func (l *Ledger) ExportCheckpointAt(state ledger.State,
 
 	l.logger.Info().Msg("storing the checkpoint to the file")
 
+	// root checkpoints are loaded by all nodes, so they are written in the
+	// version every node can read
 	err = wal.StoreCheckpoint(flatTrie.ToFlattenedForestWithASingleTrie(), writer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to store the checkpoint: %w", err)
@@ -86,7 +86,13 @@ func toStorableNode(node *node.Node, indexForNode node2indexMap) (*StorableNode,
 		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(node.RightChild().Hash()))
 	}
 
-	storableNode := &StorableNode{
+	return NewStorableNode(node, leftIndex, rightIndex), nil
+}
+
+// NewStorableNode returns the storable form of a node, given the indices of
+// its children.
+func NewStorableNode(node *node.Node, leftIndex uint64, rightIndex uint64) *StorableNode {
+	return &StorableNode{
 		LIndex:     leftIndex,
 		RIndex:     rightIndex,
 		Height:     uint16(node.Height()),
@@ -96,7 +102,6 @@ func toStorableNode(node *node.Node, indexForNode node2indexMap) (*StorableNode,
 		MaxDepth:   node.MaxDepth(),
 		RegCount:   node.RegCount(),
 	}
-	return storableNode, nil
 }
 
 func toStorableTrie(mtrie *trie.MTrie, indexForNode node2indexMap) (*StorableTrie, error) {
@@ -146,19 +151,24 @@ func RebuildNodes(storableNodes []*StorableNode) ([]*node.Node, error) {
 		if (snode.LIndex >= uint64(i)) || (snode.RIndex >= uint64(i)) {
 			return nil, fmt.Errorf("sequence of StorableNodes does not satisfy Descendents-First-Relationship")
 		}
-
-		if len(snode.Path) > 0 {
-			path := ledger.Path(snode.Path)
-			payload, err := encoding.DecodePayload(snode.EncPayload)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode a payload for an storableNode %w", err)
-			}
-			node := node.NewNode(int(snode.Height), nodes[snode.LIndex], nodes[snode.RIndex], path, payload, snode.HashValue, snode.MaxDepth, snode.RegCount)
-			nodes = append(nodes, node)
-			continue
+		node, err := RebuildNode(snode, nodes[snode.LIndex], nodes[snode.RIndex])
+		if err != nil {
+			return nil, err
 		}
-		node := node.NewNode(int(snode.Height), nodes[snode.LIndex], nodes[snode.RIndex], nil, nil, snode.HashValue, snode.MaxDepth, snode.RegCount)
 		nodes = append(nodes, node)
 	}
 	return nodes, nil
 }
+
+// RebuildNode generates a Node from a StorableNode, given its children.
+func RebuildNode(snode *StorableNode, leftChild *node.Node, rightChild *node.Node) (*node.Node, error) {
+	if len(snode.Path) > 0 {
+		path := ledger.Path(snode.Path)
+		payload, err := encoding.DecodePayload(snode.EncPayload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode a payload for an storableNode %w", err)
+		}
+		return node.NewNode(int(snode.Height), leftChild, rightChild, path, payload, snode.HashValue, snode.MaxDepth, snode.RegCount), nil
+	}
+	return node.NewNode(int(snode.Height), leftChild, rightChild, nil, nil, snode.HashValue, snode.MaxDepth, snode.RegCount), nil
+}
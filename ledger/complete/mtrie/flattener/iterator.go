@@ -65,12 +65,22 @@ type NodeIterator struct {
 // When re-building the Trie from the sequence of nodes, one can build the trie on the fly,
 // as for each node, the children have been previously encountered.
 func NewNodeIterator(mTrie *trie.MTrie) *NodeIterator {
-	// for a Trie with k := mTrie.KeyLength() [bytes], the longest possible path can contain at most 8k+1 vertices
-	stackSize := mTrie.PathLength()*8 + 1
+	return NewSubtrieNodeIterator(mTrie.RootNode())
+}
+
+// NewSubtrieNodeIterator returns a NodeIterator, which iterates through all
+// nodes of the subtrie with the given root, with the same
+// DESCENDANTS-FIRST-RELATIONSHIP as NewNodeIterator. The root may be nil.
+func NewSubtrieNodeIterator(root *node.Node) *NodeIterator {
+	// for a subtrie of height h, the longest possible path can contain at most h+1 vertices
+	stackSize := 1
+	if root != nil {
+		stackSize = root.Height() + 1
+	}
 	i := &NodeIterator{
 		stack: make([]*node.Node, 0, stackSize),
 	}
-	i.unprocessedRoot = mTrie.RootNode()
+	i.unprocessedRoot = root
 	return i
 }
 
@@ -0,0 +1,513 @@
+package wal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// Version 4 is written while iterating the tries, without flattening the forest
+// in memory first. The nodes of the subtries below subtrieLevel are stored in
+// separate sections, so that they can be loaded in parallel.
+//
+// Layout:
+//
+//	header:   magic bytes (2), version (2)
+//	sections: one section of nodes per subtrie, then one for the nodes above them
+//	tries:    the storable tries
+//	footer:   for each section: node count (8), byte size (8), CRC32 (4)
+//	          tries: count (2), byte size (8), CRC32 (4)
+//	          section count (2), CRC32 of the footer (4)
+const VersionV4 uint16 = 0x04
+
+// subtrieLevel is the depth of the roots of the subtries which are stored in
+// separate sections.
+const subtrieLevel = 4
+
+const subtrieCount = 1 << subtrieLevel
+
+const (
+	headerSizeV4         = 2 + 2
+	sectionFooterSize    = 8 + 8 + 4
+	triesFooterSize      = 2 + 8 + 4
+	footerTrailerSize    = 2 + 4
+	checkpointBufferSize = 64 * 1024
+)
+
+// checkpointSection is the footer entry of a section of a checkpoint.
+type checkpointSection struct {
+	nodes uint64
+	size  uint64
+	crc32 uint32
+}
+
+// sectionWriter writes a section of a checkpoint, and keeps track of its
+// footer entry.
+type sectionWriter struct {
+	writer  *Crc32Writer
+	section checkpointSection
+}
+
+func newSectionWriter(writer io.Writer) *sectionWriter {
+	return &sectionWriter{
+		writer: NewCRC32Writer(writer),
+	}
+}
+
+func (s *sectionWriter) write(data []byte) error {
+	_, err := s.writer.Write(data)
+	if err != nil {
+		return err
+	}
+	s.section.size += uint64(len(data))
+	return nil
+}
+
+func (s *sectionWriter) writeNode(n *node.Node, indices map[*node.Node]uint64) error {
+	leftIndex, ok := indices[n.LeftChild()]
+	if !ok {
+		return fmt.Errorf("missing left child of node %x", n.Hash())
+	}
+	rightIndex, ok := indices[n.RightChild()]
+	if !ok {
+		return fmt.Errorf("missing right child of node %x", n.Hash())
+	}
+
+	err := s.write(flattener.EncodeStorableNode(flattener.NewStorableNode(n, leftIndex, rightIndex)))
+	if err != nil {
+		return err
+	}
+	s.section.nodes++
+	return nil
+}
+
+func (s *sectionWriter) close() checkpointSection {
+	s.section.crc32 = s.writer.Crc32()
+	return s.section
+}
+
+// StoreCheckpointTries writes the given tries as a version 4 checkpoint.
+// Unlike StoreCheckpoint, the nodes are written as the tries are iterated, so
+// only the indices of the nodes of one subtrie are held in memory at a time.
+func StoreCheckpointTries(tries []*trie.MTrie, writer io.Writer) error {
+	if len(tries) > math.MaxUint16 {
+		return fmt.Errorf("cannot store %d tries in a checkpoint", len(tries))
+	}
+
+	header := make([]byte, headerSizeV4)
+	pos := writeUint16(header, 0, MagicBytes)
+	writeUint16(header, pos, VersionV4)
+	_, err := writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+
+	levels := make([][][]*node.Node, len(tries))
+	subtries := make([][]*node.Node, len(tries))
+	for i, t := range tries {
+		levels[i], subtries[i] = splitTrie(t.RootNode())
+	}
+
+	sections := make([]checkpointSection, 0, subtrieCount+1)
+	index := uint64(1) // 0 marks nil
+
+	// the indices of the nodes above the subtries, and of the subtrie roots
+	topIndices := map[*node.Node]uint64{nil: 0}
+
+	for s := 0; s < subtrieCount; s++ {
+		section := newSectionWriter(writer)
+		indices := map[*node.Node]uint64{nil: 0}
+
+		for i := range tries {
+			root := subtries[i][s]
+			if _, ok := indices[root]; ok {
+				topIndices[root] = indices[root]
+				continue
+			}
+
+			for itr := flattener.NewSubtrieNodeIterator(root); itr.Next(); {
+				n := itr.Value()
+				if _, ok := indices[n]; ok {
+					continue
+				}
+				indices[n] = index
+				index++
+
+				err := section.writeNode(n, indices)
+				if err != nil {
+					return fmt.Errorf("cannot write node of subtrie %d: %w", s, err)
+				}
+			}
+			topIndices[root] = indices[root]
+		}
+
+		sections = append(sections, section.close())
+	}
+
+	section := newSectionWriter(writer)
+	for level := subtrieLevel - 1; level >= 0; level-- {
+		for i := range tries {
+			for _, n := range levels[i][level] {
+				if _, ok := topIndices[n]; ok {
+					continue
+				}
+				topIndices[n] = index
+				index++
+
+				err := section.writeNode(n, topIndices)
+				if err != nil {
+					return fmt.Errorf("cannot write node at level %d: %w", level, err)
+				}
+			}
+		}
+	}
+	sections = append(sections, section.close())
+
+	section = newSectionWriter(writer)
+	for _, t := range tries {
+		storableTrie := &flattener.StorableTrie{
+			RootIndex: topIndices[t.RootNode()],
+			RootHash:  t.RootHash(),
+		}
+		err := section.write(flattener.EncodeStorableTrie(storableTrie))
+		if err != nil {
+			return fmt.Errorf("cannot write trie: %w", err)
+		}
+	}
+	triesSection := section.close()
+
+	footer := make([]byte, len(sections)*sectionFooterSize+triesFooterSize+footerTrailerSize)
+	pos = 0
+	for _, s := range sections {
+		pos = writeUint64(footer, pos, s.nodes)
+		pos = writeUint64(footer, pos, s.size)
+		pos = writeUint32(footer, pos, s.crc32)
+	}
+	pos = writeUint16(footer, pos, uint16(len(tries)))
+	pos = writeUint64(footer, pos, triesSection.size)
+	pos = writeUint32(footer, pos, triesSection.crc32)
+	pos = writeUint16(footer, pos, uint16(len(sections)))
+	writeUint32(footer, pos, crc32.Checksum(footer[:pos], crc32Table))
+
+	_, err = writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	return nil
+}
+
+// splitTrie returns the nodes of a trie above subtrieLevel, by level, and the
+// roots of its subtries, from left to right. Absent nodes are nil.
+func splitTrie(root *node.Node) ([][]*node.Node, []*node.Node) {
+	levels := make([][]*node.Node, 0, subtrieLevel)
+	current := []*node.Node{root}
+	for level := 0; level < subtrieLevel; level++ {
+		levels = append(levels, current)
+		next := make([]*node.Node, 0, 2*len(current))
+		for _, n := range current {
+			if n == nil {
+				next = append(next, nil, nil)
+				continue
+			}
+			next = append(next, n.LeftChild(), n.RightChild())
+		}
+		current = next
+	}
+	return levels, current
+}
+
+// LoadCheckpointTries loads the tries stored in a checkpoint file of any
+// version. The nodes are rebuilt as they are read, and the subtries of
+// version 4 checkpoints are loaded in parallel.
+func LoadCheckpointTries(filepath string) ([]*trie.MTrie, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	header := make([]byte, headerSizeV4)
+	_, err = io.ReadFull(file, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header bytes: %w", err)
+	}
+
+	magicBytes, pos := readUint16(header, 0)
+	version, _ := readUint16(header, pos)
+
+	if magicBytes != MagicBytes {
+		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	}
+
+	switch version {
+	case VersionV1, VersionV3:
+		_, err = file.Seek(0, io.SeekStart)
+		if err != nil {
+			return nil, fmt.Errorf("cannot seek checkpoint file: %w", err)
+		}
+		return readLegacyCheckpointTries(file)
+	case VersionV4:
+		info, err := file.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("cannot stat checkpoint file: %w", err)
+		}
+		return readCheckpointTriesV4(file, info.Size())
+	default:
+		return nil, fmt.Errorf("unsupported file version %x ", version)
+	}
+}
+
+// readLegacyCheckpointTries reads the tries of a version 1 or 3 checkpoint.
+func readLegacyCheckpointTries(r io.Reader) ([]*trie.MTrie, error) {
+
+	bufReader := bufio.NewReaderSize(r, checkpointBufferSize)
+	crcReader := NewCRC32Reader(bufReader)
+	var reader io.Reader = crcReader
+
+	header := make([]byte, 4+8+2)
+
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header bytes: %w", err)
+	}
+
+	_, pos := readUint16(header, 0)
+	version, pos := readUint16(header, pos)
+	nodesCount, pos := readUint64(header, pos)
+	triesCount, _ := readUint16(header, pos)
+
+	if version != VersionV3 {
+		reader = bufReader //switch back to plain reader
+	}
+
+	nodes := make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
+
+	err = readNodes(reader, nodes, 1, nodesCount+1, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	tries, err := readTries(reader, triesCount, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == VersionV3 {
+		crc32buf := make([]byte, 4)
+		_, err := io.ReadFull(bufReader, crc32buf)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading CRC32 checksum: %w", err)
+		}
+		readCrc32, _ := readUint32(crc32buf, 0)
+
+		calculatedCrc32 := crcReader.Crc32()
+
+		if calculatedCrc32 != readCrc32 {
+			return nil, fmt.Errorf("checkpoint checksum failed! File contains %x but read data checksums to %x", readCrc32, calculatedCrc32)
+		}
+	}
+
+	return tries, nil
+}
+
+// sectionRange is a section of a version 4 checkpoint, located in the file.
+type sectionRange struct {
+	checkpointSection
+	offset int64
+	// index of the first node of the section
+	start uint64
+}
+
+// readCheckpointTriesV4 reads the tries of a version 4 checkpoint of the
+// given size.
+func readCheckpointTriesV4(r io.ReaderAt, size int64) ([]*trie.MTrie, error) {
+
+	if size < headerSizeV4+footerTrailerSize {
+		return nil, fmt.Errorf("checkpoint file is too short: %d bytes", size)
+	}
+
+	trailer := make([]byte, footerTrailerSize)
+	_, err := r.ReadAt(trailer, size-footerTrailerSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer trailer: %w", err)
+	}
+	sectionCount, pos := readUint16(trailer, 0)
+	footerCrc32, _ := readUint32(trailer, pos)
+
+	footerSize := int64(sectionCount)*sectionFooterSize + triesFooterSize
+	footerOffset := size - footerTrailerSize - footerSize
+	if sectionCount == 0 || footerOffset < headerSizeV4 {
+		return nil, fmt.Errorf("invalid section count %d", sectionCount)
+	}
+
+	footer := make([]byte, footerSize+2)
+	_, err = r.ReadAt(footer, footerOffset)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+	calculatedCrc32 := crc32.Checksum(footer, crc32Table)
+	if calculatedCrc32 != footerCrc32 {
+		return nil, fmt.Errorf("checkpoint footer checksum failed! File contains %x but footer checksums to %x", footerCrc32, calculatedCrc32)
+	}
+
+	sections := make([]sectionRange, sectionCount)
+	offset := int64(headerSizeV4)
+	index := uint64(1)
+	pos = 0
+	for i := range sections {
+		s := &sections[i]
+		s.nodes, pos = readUint64(footer, pos)
+		s.size, pos = readUint64(footer, pos)
+		s.crc32, pos = readUint32(footer, pos)
+		s.offset = offset
+		s.start = index
+
+		// each node takes more than one byte
+		if s.size > uint64(footerOffset-offset) || s.nodes > s.size {
+			return nil, fmt.Errorf("section %d of %d bytes with %d nodes exceeds the checkpoint", i, s.size, s.nodes)
+		}
+		offset += int64(s.size)
+		index += s.nodes
+	}
+
+	var tries sectionRange
+	var triesCount uint16
+	triesCount, pos = readUint16(footer, pos)
+	tries.size, pos = readUint64(footer, pos)
+	tries.crc32, _ = readUint32(footer, pos)
+	tries.offset = offset
+	if offset+int64(tries.size) != footerOffset {
+		return nil, fmt.Errorf("tries of %d bytes at offset %d do not end at the footer", tries.size, offset)
+	}
+
+	nodes := make([]*node.Node, index)
+
+	// the nodes of a subtrie only refer to nodes of the same subtrie
+	subtries := sections[:len(sections)-1]
+	errs := make([]error, len(subtries))
+	workers := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i, s := range subtries {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, s sectionRange) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			errs[i] = readSection(r, s, func(reader io.Reader) error {
+				return readNodes(reader, nodes, s.start, s.start+s.nodes, s.start)
+			})
+		}(i, s)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("cannot read subtrie %d: %w", i, err)
+		}
+	}
+
+	top := sections[len(sections)-1]
+	err = readSection(r, top, func(reader io.Reader) error {
+		return readNodes(reader, nodes, top.start, top.start+top.nodes, 1)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read top nodes: %w", err)
+	}
+
+	var rebuiltTries []*trie.MTrie
+	err = readSection(r, tries, func(reader io.Reader) error {
+		var err error
+		rebuiltTries, err = readTries(reader, triesCount, nodes)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read tries: %w", err)
+	}
+
+	return rebuiltTries, nil
+}
+
+// readSection reads a section of a version 4 checkpoint with the given
+// function, and verifies its checksum.
+func readSection(r io.ReaderAt, s sectionRange, read func(io.Reader) error) error {
+	bufReader := bufio.NewReaderSize(io.NewSectionReader(r, s.offset, int64(s.size)), checkpointBufferSize)
+	crcReader := NewCRC32Reader(bufReader)
+
+	err := read(crcReader)
+	if err != nil {
+		return err
+	}
+
+	_, err = bufReader.ReadByte()
+	if err != io.EOF {
+		return fmt.Errorf("section has unread bytes")
+	}
+	calculatedCrc32 := crcReader.Crc32()
+	if calculatedCrc32 != s.crc32 {
+		return fmt.Errorf("section checksum failed! File contains %x but read data checksums to %x", s.crc32, calculatedCrc32)
+	}
+
+	return nil
+}
+
+// readNodes reads the nodes with indices from start to end (excluded), and
+// rebuilds them from their children, which must have indices from minChild
+// to the index of the node (excluded).
+func readNodes(reader io.Reader, nodes []*node.Node, start uint64, end uint64, minChild uint64) error {
+	for i := start; i < end; i++ {
+		storableNode, err := flattener.ReadStorableNode(reader)
+		if err != nil {
+			return fmt.Errorf("cannot read storable node %d: %w", i, err)
+		}
+
+		for _, child := range []uint64{storableNode.LIndex, storableNode.RIndex} {
+			if child != 0 && (child < minChild || child >= i) {
+				return fmt.Errorf("node %d refers to node %d out of range [%d, %d)", i, child, minChild, i)
+			}
+		}
+
+		nodes[i], err = flattener.RebuildNode(storableNode, nodes[storableNode.LIndex], nodes[storableNode.RIndex])
+		if err != nil {
+			return fmt.Errorf("cannot rebuild node %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// readTries reads the given number of storable tries, and rebuilds them from
+// the given nodes.
+func readTries(reader io.Reader, count uint16, nodes []*node.Node) ([]*trie.MTrie, error) {
+	tries := make([]*trie.MTrie, 0, count)
+	for i := uint16(0); i < count; i++ {
+		storableTrie, err := flattener.ReadStorableTrie(reader)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read storable trie %d: %w", i, err)
+		}
+		if storableTrie.RootIndex == 0 || storableTrie.RootIndex >= uint64(len(nodes)) {
+			return nil, fmt.Errorf("trie %d has invalid root index %d", i, storableTrie.RootIndex)
+		}
+
+		t, err := trie.NewMTrie(nodes[storableTrie.RootIndex])
+		if err != nil {
+			return nil, fmt.Errorf("restoring trie %d failed: %w", i, err)
+		}
+		if !bytes.Equal(storableTrie.RootHash, t.RootHash()) {
+			return nil, fmt.Errorf("restoring trie %d failed: roothash doesn't match", i)
+		}
+		tries = append(tries, t)
+	}
+	return tries, nil
+}
@@ -0,0 +1,132 @@
+package wal_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	realWAL "github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// randomForest returns a forest of tries sharing nodes, each trie updating
+// some registers of the previous one.
+func randomForest(t *testing.T, pathByteSize int, tries int) *mtrie.Forest {
+	forest, err := mtrie.NewForest(pathByteSize, "", tries+1, metricsCollector, nil)
+	require.NoError(t, err)
+
+	rootHash := ledger.RootHash(forest.GetEmptyRootHash())
+	for i := 0; i < tries; i++ {
+		paths := utils.RandomPaths(50, pathByteSize)
+		payloads := utils.RandomPayloads(len(paths), 1, 100)
+		rootHash, err = forest.Update(&ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads})
+		require.NoError(t, err)
+	}
+	return forest
+}
+
+func storeCheckpointTries(t *testing.T, dir string, filename string, tries []*trie.MTrie) string {
+	writer, err := realWAL.CreateCheckpointWriterForFile(dir, filename)
+	require.NoError(t, err)
+	require.NoError(t, realWAL.StoreCheckpointTries(tries, writer))
+	require.NoError(t, writer.Close())
+	return path.Join(dir, filename)
+}
+
+func requireSameTries(t *testing.T, expected []*trie.MTrie, actual []*trie.MTrie) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].RootHash(), actual[i].RootHash())
+		require.Equal(t, expected[i].AllPayloads(), actual[i].AllPayloads())
+		require.True(t, actual[i].IsAValidTrie())
+	}
+}
+
+func Test_StreamingCheckpoints(t *testing.T) {
+
+	t.Run("stores and loads tries", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			tries, err := randomForest(t, pathByteSize, 10).GetTries()
+			require.NoError(t, err)
+
+			filepath := storeCheckpointTries(t, dir, "checkpoint", tries)
+
+			loaded, err := realWAL.LoadCheckpointTries(filepath)
+			require.NoError(t, err)
+			requireSameTries(t, tries, loaded)
+
+			// the loaded tries can be added to a forest
+			forest, err := mtrie.NewForest(pathByteSize, "", len(loaded), metricsCollector, nil)
+			require.NoError(t, err)
+			require.NoError(t, forest.AddTries(loaded))
+		})
+	})
+
+	t.Run("stores and loads small and empty tries", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			tries, err := randomForest(t, 1, 3).GetTries()
+			require.NoError(t, err)
+			empty, err := trie.NewEmptyMTrie(1)
+			require.NoError(t, err)
+			tries = append(tries, empty)
+
+			filepath := storeCheckpointTries(t, dir, "checkpoint", tries)
+
+			loaded, err := realWAL.LoadCheckpointTries(filepath)
+			require.NoError(t, err)
+			requireSameTries(t, tries, loaded)
+		})
+	})
+
+	t.Run("loads version 3 checkpoints", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			forest := randomForest(t, pathByteSize, 5)
+			tries, err := forest.GetTries()
+			require.NoError(t, err)
+
+			flattenedForest, err := flattener.FlattenForest(forest)
+			require.NoError(t, err)
+
+			writer, err := realWAL.CreateCheckpointWriterForFile(dir, "checkpoint")
+			require.NoError(t, err)
+			require.NoError(t, realWAL.StoreCheckpoint(flattenedForest, writer))
+			require.NoError(t, writer.Close())
+
+			loaded, err := realWAL.LoadCheckpointTries(path.Join(dir, "checkpoint"))
+			require.NoError(t, err)
+			requireSameTries(t, tries, loaded)
+		})
+	})
+
+	t.Run("detects modified data", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			tries, err := randomForest(t, pathByteSize, 3).GetTries()
+			require.NoError(t, err)
+
+			filepath := storeCheckpointTries(t, dir, "checkpoint", tries)
+
+			// modify the max depth of the first node, which is not checked when rebuilding it
+			file, err := os.OpenFile(filepath, os.O_RDWR, 0644)
+			require.NoError(t, err)
+			buf := make([]byte, 1)
+			offset := int64(2 + 2 + 2 + 2 + 8 + 8 + 1)
+			_, err = file.ReadAt(buf, offset)
+			require.NoError(t, err)
+			buf[0]++
+			_, err = file.WriteAt(buf, offset)
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+
+			_, err = realWAL.LoadCheckpointTries(filepath)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "checksum")
+		})
+	})
+}
@@ -36,6 +36,7 @@ type Checkpointer struct {
 	wal            *LedgerWAL
 	keyByteSize    int
 	forestCapacity int
+	version        uint16
 }
 
 func NewCheckpointer(wal *LedgerWAL, keyByteSize int, forestCapacity int) *Checkpointer {
@@ -44,9 +45,22 @@ func NewCheckpointer(wal *LedgerWAL, keyByteSize int, forestCapacity int) *Check
 		wal:            wal,
 		keyByteSize:    keyByteSize,
 		forestCapacity: forestCapacity,
+		version:        VersionV3,
 	}
 }
 
+// SetVersion sets the version of the checkpoints created by the checkpointer,
+// VersionV3 by default. VersionV4 checkpoints are faster to create and load,
+// but can't be loaded by nodes which only read earlier versions, so they
+// should only be written once all nodes loading them support it.
+func (c *Checkpointer) SetVersion(version uint16) error {
+	if version != VersionV3 && version != VersionV4 {
+		return fmt.Errorf("cannot create checkpoints of version %x", version)
+	}
+	c.version = version
+	return nil
+}
+
 // listCheckpoints returns all the numbers (unsorted) of the checkpoint files, and the number of the last checkpoint.
 func (c *Checkpointer) listCheckpoints() ([]int, int, error) {
 
@@ -162,11 +176,7 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 	}
 
 	err = c.wal.replay(0, to,
-		func(forestSequencing *flattener.FlattenedForest) error {
-			tries, err := flattener.RebuildTries(forestSequencing)
-			if err != nil {
-				return err
-			}
+		func(tries []*trie.MTrie) error {
 			for _, t := range tries {
 				err := forest.AddTrie(t)
 				if err != nil {
@@ -186,6 +196,21 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 		return fmt.Errorf("cannot replay WAL: %w", err)
 	}
 
+	if c.version == VersionV4 {
+		tries, err := forest.GetTries()
+		if err != nil {
+			return fmt.Errorf("cannot get tries: %w", err)
+		}
+
+		writer, err := targetWriter()
+		if err != nil {
+			return fmt.Errorf("cannot generate writer: %w", err)
+		}
+		defer writer.Close()
+
+		return StoreCheckpointTries(tries, writer)
+	}
+
 	forestSequencing, err := flattener.FlattenForest(forest)
 	if err != nil {
 		return fmt.Errorf("cannot get storables: %w", err)
@@ -242,6 +267,7 @@ func CreateCheckpointWriterForFile(dir, filename string) (io.WriteCloser, error)
 }
 
 // StoreCheckpoint writes the given checkpoint to disk, and also append with a CRC32 file checksum for integrity check.
+// It writes a version 3 checkpoint, see StoreCheckpointTries to write one without flattening the forest.
 func StoreCheckpoint(forestSequencing *flattener.FlattenedForest, writer io.Writer) error {
 	storableNodes := forestSequencing.Nodes
 	storableTries := forestSequencing.Tries
@@ -288,14 +314,14 @@ func StoreCheckpoint(forestSequencing *flattener.FlattenedForest, writer io.Writ
 	return nil
 }
 
-func (c *Checkpointer) LoadCheckpoint(checkpoint int) (*flattener.FlattenedForest, error) {
+func (c *Checkpointer) LoadCheckpoint(checkpoint int) ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
-	return LoadCheckpoint(filepath)
+	return LoadCheckpointTries(filepath)
 }
 
-func (c *Checkpointer) LoadRootCheckpoint() (*flattener.FlattenedForest, error) {
+func (c *Checkpointer) LoadRootCheckpoint() ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, RootCheckpointFilename)
-	return LoadCheckpoint(filepath)
+	return LoadCheckpointTries(filepath)
 }
 
 func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
@@ -345,6 +371,9 @@ func ReadCheckpoint(r io.Reader) (*flattener.FlattenedForest, error) {
 	if magicBytes != MagicBytes {
 		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
 	}
+	if version == VersionV4 {
+		return nil, fmt.Errorf("version %x checkpoints can only be loaded as tries", version)
+	}
 	if version != VersionV1 && version != VersionV3 {
 		return nil, fmt.Errorf("unsupported file version %x ", version)
 	}
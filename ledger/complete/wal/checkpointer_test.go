@@ -173,7 +173,7 @@ func Test_Checkpointing(t *testing.T) {
 			require.NoError(t, err)
 
 			err = wal2.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
+				func(tries []*trie.MTrie) error {
 					return fmt.Errorf("I should fail as there should be no checkpoints")
 				},
 				func(update *ledger.TrieUpdate) error {
@@ -208,8 +208,8 @@ func Test_Checkpointing(t *testing.T) {
 			require.NoError(t, err)
 
 			err = wal3.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
-					return loadIntoForest(f3, forestSequencing)
+				func(tries []*trie.MTrie) error {
+					return loadIntoForest(f3, tries)
 				},
 				func(update *ledger.TrieUpdate) error {
 					return fmt.Errorf("I should fail as there should be no updates")
@@ -293,8 +293,8 @@ func Test_Checkpointing(t *testing.T) {
 			updatesLeft := 1 // there should be only one update
 
 			err = wal5.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
-					return loadIntoForest(f5, forestSequencing)
+				func(tries []*trie.MTrie) error {
+					return loadIntoForest(f5, tries)
 				},
 				func(update *ledger.TrieUpdate) error {
 					if updatesLeft == 0 {
@@ -490,11 +490,7 @@ func Test_StoringLoadingCheckpoints(t *testing.T) {
 
 }
 
-func loadIntoForest(forest *mtrie.Forest, forestSequencing *flattener.FlattenedForest) error {
-	tries, err := flattener.RebuildTries(forestSequencing)
-	if err != nil {
-		return err
-	}
+func loadIntoForest(forest *mtrie.Forest, tries []*trie.MTrie) error {
 	for _, t := range tries {
 		err := forest.AddTrie(t)
 		if err != nil {
@@ -1,11 +1,20 @@
 package wal
 
 import (
+	"io"
+	"os"
+	"path"
 	"testing"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
 )
 
 var v1Forest = &flattener.FlattenedForest{
@@ -51,6 +60,122 @@ func Test_LoadingV1Checkpoint(t *testing.T) {
 	require.Equal(t, v1Forest, forest)
 }
 
+func Test_LoadingV1CheckpointTries(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		forest, err := mtrie.NewForest(pathByteSize, "", 10, &metrics.NoopCollector{}, nil)
+		require.NoError(t, err)
+
+		rootHash := ledger.RootHash(forest.GetEmptyRootHash())
+		for i := 0; i < 5; i++ {
+			paths := utils.RandomPaths(20, pathByteSize)
+			payloads := utils.RandomPayloads(len(paths), 1, 100)
+			rootHash, err = forest.Update(&ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads})
+			require.NoError(t, err)
+		}
+
+		flattenedForest, err := flattener.FlattenForest(forest)
+		require.NoError(t, err)
+
+		filepath := path.Join(dir, "checkpoint.v1")
+		file, err := os.Create(filepath)
+		require.NoError(t, err)
+		storeCheckpointV1(t, flattenedForest, file)
+		require.NoError(t, file.Close())
+
+		expected, err := forest.GetTries()
+		require.NoError(t, err)
+
+		tries, err := LoadCheckpointTries(filepath)
+		require.NoError(t, err)
+		require.Len(t, tries, len(expected))
+		for i, trie := range tries {
+			require.Equal(t, expected[i].RootHash(), trie.RootHash())
+			require.Equal(t, expected[i].AllPayloads(), trie.AllPayloads())
+		}
+	})
+}
+
+func Test_CheckpointerVersion(t *testing.T) {
+
+	checkpointVersion := func(t *testing.T, version uint16) uint16 {
+		var written uint16
+		unittest.RunWithTempDir(t, func(dir string) {
+			w, err := NewWAL(zerolog.Nop(), nil, dir, 10, pathByteSize, segmentSize)
+			require.NoError(t, err)
+
+			paths := utils.RandomPaths(20, pathByteSize)
+			payloads := utils.RandomPayloads(len(paths), 1, 100)
+			forest, err := mtrie.NewForest(pathByteSize, "", 1, &metrics.NoopCollector{}, nil)
+			require.NoError(t, err)
+			err = w.RecordUpdate(&ledger.TrieUpdate{RootHash: forest.GetEmptyRootHash(), Paths: paths, Payloads: payloads})
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			w, err = NewWAL(zerolog.Nop(), nil, dir, 10, pathByteSize, segmentSize)
+			require.NoError(t, err)
+			defer w.Close()
+
+			checkpointer, err := w.NewCheckpointer()
+			require.NoError(t, err)
+			if version != 0 {
+				require.NoError(t, checkpointer.SetVersion(version))
+			}
+
+			err = checkpointer.Checkpoint(0, func() (io.WriteCloser, error) {
+				return checkpointer.CheckpointWriter(0)
+			})
+			require.NoError(t, err)
+
+			file, err := os.Open(path.Join(dir, NumberToFilename(0)))
+			require.NoError(t, err)
+			defer file.Close()
+			header := make([]byte, 4)
+			_, err = io.ReadFull(file, header)
+			require.NoError(t, err)
+			written, _ = readUint16(header, 2)
+
+			tries, err := checkpointer.LoadCheckpoint(0)
+			require.NoError(t, err)
+			require.Len(t, tries, 2)
+		})
+		return written
+	}
+
+	t.Run("writes version 3 by default", func(t *testing.T) {
+		require.Equal(t, VersionV3, checkpointVersion(t, 0))
+	})
+
+	t.Run("writes version 4 when set", func(t *testing.T) {
+		require.Equal(t, VersionV4, checkpointVersion(t, VersionV4))
+	})
+
+	t.Run("rejects other versions", func(t *testing.T) {
+		checkpointer := &Checkpointer{version: VersionV3}
+		require.Error(t, checkpointer.SetVersion(VersionV1))
+		require.Equal(t, VersionV3, checkpointer.version)
+	})
+}
+
+// storeCheckpointV1 writes a version 1 checkpoint, which has no checksum.
+func storeCheckpointV1(t *testing.T, forestSequencing *flattener.FlattenedForest, writer io.Writer) {
+	header := make([]byte, 4+8+2)
+	pos := writeUint16(header, 0, MagicBytes)
+	pos = writeUint16(header, pos, VersionV1)
+	pos = writeUint64(header, pos, uint64(len(forestSequencing.Nodes)-1))
+	writeUint16(header, pos, uint16(len(forestSequencing.Tries)))
+
+	_, err := writer.Write(header)
+	require.NoError(t, err)
+	for _, storableNode := range forestSequencing.Nodes[1:] {
+		_, err = writer.Write(flattener.EncodeStorableNode(storableNode))
+		require.NoError(t, err)
+	}
+	for _, storableTrie := range forestSequencing.Tries {
+		_, err = writer.Write(flattener.EncodeStorableTrie(storableTrie))
+		require.NoError(t, err)
+	}
+}
+
 func Test_CreateCheckpoint(t *testing.T) {
 
 	t.Skip("Used only to generate previous checkpoint version while upgrading")
@@ -14,7 +14,6 @@ import (
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/utils"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
-	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -135,8 +134,8 @@ func Test_Compactor(t *testing.T) {
 			require.NoError(t, err)
 
 			err = wal2.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
-					return loadIntoForest(f2, forestSequencing)
+				func(tries []*trie.MTrie) error {
+					return loadIntoForest(f2, tries)
 				},
 				func(update *ledger.TrieUpdate) error {
 					_, err := f2.Update(update)
@@ -299,11 +298,7 @@ func Test_Compactor_checkpointInterval(t *testing.T) {
 	})
 }
 
-func loadIntoForest(forest *mtrie.Forest, forestSequencing *flattener.FlattenedForest) error {
-	tries, err := flattener.RebuildTries(forestSequencing)
-	if err != nil {
-		return err
-	}
+func loadIntoForest(forest *mtrie.Forest, tries []*trie.MTrie) error {
 	for _, t := range tries {
 		err := forest.AddTrie(t)
 		if err != nil {
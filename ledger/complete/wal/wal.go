@@ -10,7 +10,7 @@ import (
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
-	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 )
 
 const SegmentSize = 32 * 1024 * 1024
@@ -78,12 +78,8 @@ func (w *LedgerWAL) RecordDelete(rootHash ledger.RootHash) error {
 
 func (w *LedgerWAL) ReplayOnForest(forest *mtrie.Forest) error {
 	return w.Replay(
-		func(forestSequencing *flattener.FlattenedForest) error {
-			rebuiltTries, err := flattener.RebuildTries(forestSequencing)
-			if err != nil {
-				return fmt.Errorf("rebuilding forest from sequenced nodes failed: %w", err)
-			}
-			err = forest.AddTries(rebuiltTries)
+		func(rebuiltTries []*trie.MTrie) error {
+			err := forest.AddTries(rebuiltTries)
 			if err != nil {
 				return fmt.Errorf("adding rebuilt tries to forest failed: %w", err)
 			}
@@ -105,7 +101,7 @@ func (w *LedgerWAL) Segments() (first, last int, err error) {
 }
 
 func (w *LedgerWAL) Replay(
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
+	checkpointFn func(tries []*trie.MTrie) error,
 	updateFn func(update *ledger.TrieUpdate) error,
 	deleteFn func(ledger.RootHash) error,
 ) error {
@@ -117,7 +113,7 @@ func (w *LedgerWAL) Replay(
 }
 
 func (w *LedgerWAL) ReplayLogsOnly(
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
+	checkpointFn func(tries []*trie.MTrie) error,
 	updateFn func(update *ledger.TrieUpdate) error,
 	deleteFn func(rootHash ledger.RootHash) error,
 ) error {
@@ -130,7 +126,7 @@ func (w *LedgerWAL) ReplayLogsOnly(
 
 func (w *LedgerWAL) replay(
 	from, to int,
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
+	checkpointFn func(tries []*trie.MTrie) error,
 	updateFn func(update *ledger.TrieUpdate) error,
 	deleteFn func(rootHash ledger.RootHash) error,
 	useCheckpoints bool,
@@ -169,7 +165,7 @@ func (w *LedgerWAL) replay(
 			// it allows us to load less segments.
 			latestCheckpoint := availableCheckpoints[len(availableCheckpoints)-1]
 
-			forestSequencing, err := checkpointer.LoadCheckpoint(latestCheckpoint)
+			tries, err := checkpointer.LoadCheckpoint(latestCheckpoint)
 			if err != nil {
 				w.log.Warn().Int("checkpoint", latestCheckpoint).Err(err).
 					Msg("checkpoint loading failed")
@@ -179,7 +175,7 @@ func (w *LedgerWAL) replay(
 			}
 			w.log.Info().Int("checkpoint", latestCheckpoint).
 				Msg("checkpoint loaded")
-			err = checkpointFn(forestSequencing)
+			err = checkpointFn(tries)
 			if err != nil {
 				return fmt.Errorf("error while handling checkpoint: %w", err)
 			}
@@ -202,11 +198,11 @@ func (w *LedgerWAL) replay(
 			return fmt.Errorf("cannot check root checkpoint existence: %w", err)
 		}
 		if hasRootCheckpoint {
-			flattenedForest, err := checkpointer.LoadRootCheckpoint()
+			tries, err := checkpointer.LoadRootCheckpoint()
 			if err != nil {
 				return fmt.Errorf("cannot load root checkpoint: %w", err)
 			}
-			err = checkpointFn(flattenedForest)
+			err = checkpointFn(tries)
 			if err != nil {
 				return fmt.Errorf("error while handling root checkpoint: %w", err)
 			}
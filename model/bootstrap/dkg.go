@@ -2,7 +2,6 @@ package bootstrap
 
 import (
 	"github.com/onflow/flow-go/crypto"
-	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 )
@@ -15,13 +14,6 @@ type DKGData struct {
 	PubKeyShares  []crypto.PublicKey
 }
 
-// bootstrap.DKGParticipantPriv is the canonical structure for encoding private node DKG information.
-type DKGParticipantPriv struct {
-	NodeID              flow.Identifier
-	RandomBeaconPrivKey encodable.RandomBeaconPrivKey
-	GroupIndex          int
-}
-
 func ToDKGLookup(dkg DKGData, identities flow.IdentityList) map[flow.Identifier]flow.DKGParticipant {
 
 	lookup := make(map[flow.Identifier]flow.DKGParticipant)
@@ -26,6 +26,7 @@ var (
 	PathRootQC                = filepath.Join(DirnamePublicBootstrap, "root-qc.json")
 	PathRootResult            = filepath.Join(DirnamePublicBootstrap, "root-execution-result.json")
 	PathRootSeal              = filepath.Join(DirnamePublicBootstrap, "root-block-seal.json")
+	PathRootSnapshot          = filepath.Join(DirnamePublicBootstrap, "root-protocol-state-snapshot.json")
 	PathRootCheckpoint        = filepath.Join(DirnameExecutionState, wal.RootCheckpointFilename) // only available on an execution node
 
 	// private genesis information
@@ -0,0 +1,13 @@
+package dkg
+
+import (
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DKGParticipantPriv is the canonical structure for encoding private node DKG information.
+type DKGParticipantPriv struct {
+	NodeID              flow.Identifier
+	RandomBeaconPrivKey encodable.RandomBeaconPrivKey
+	GroupIndex          int
+}
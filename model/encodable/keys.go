@@ -10,6 +10,7 @@ import (
 	"github.com/vmihailenco/msgpack"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 func toHex(bs []byte) string {
@@ -184,6 +185,24 @@ func (pub *RandomBeaconPubKey) UnmarshalMsgpack(b []byte) error {
 	return err
 }
 
+func (pub RandomBeaconPubKey) MarshalCBOR() ([]byte, error) {
+	if pub.PublicKey == nil {
+		return nil, fmt.Errorf("empty public key")
+	}
+	return cbor.Marshal(pub.PublicKey.Encode())
+}
+
+func (pub *RandomBeaconPubKey) UnmarshalCBOR(b []byte) error {
+	var bz []byte
+	err := cbor.Unmarshal(b, &bz)
+	if err != nil {
+		return err
+	}
+
+	pub.PublicKey, err = crypto.DecodePublicKey(crypto.BLSBLS12381, bz)
+	return err
+}
+
 func (pub *RandomBeaconPubKey) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, pub.PublicKey.Encode())
 }
@@ -215,3 +234,20 @@ func (priv *RandomBeaconPrivKey) UnmarshalJSON(b []byte) error {
 	priv.PrivateKey, err = crypto.DecodePrivateKey(crypto.BLSBLS12381, bz)
 	return err
 }
+
+func (priv RandomBeaconPrivKey) MarshalMsgpack() ([]byte, error) {
+	if priv.PrivateKey == nil {
+		return nil, fmt.Errorf("empty private key")
+	}
+	return msgpack.Marshal(toHex(priv.PrivateKey.Encode()))
+}
+
+func (priv *RandomBeaconPrivKey) UnmarshalMsgpack(b []byte) error {
+	bz, err := fromMsgPackHex(b)
+	if err != nil {
+		return err
+	}
+
+	priv.PrivateKey, err = crypto.DecodePrivateKey(crypto.BLSBLS12381, bz)
+	return err
+}
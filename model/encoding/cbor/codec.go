@@ -0,0 +1,88 @@
+package cbor
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// RawMessage is a raw encoded CBOR value, which can be used to delay decoding.
+type RawMessage = cbor.RawMessage
+
+// EncMode is the CBOR encoding mode used by Flow. It encodes time with nanosecond precision, and sorts map keys so
+// that the encoding of a value is deterministic.
+var EncMode = func() cbor.EncMode {
+	options := cbor.CoreDetEncOptions()
+	options.Time = cbor.TimeRFC3339Nano
+	mode, err := options.EncMode()
+	if err != nil {
+		panic(fmt.Sprintf("could not create CBOR encoding mode: %v", err))
+	}
+	return mode
+}()
+
+// DecMode is the CBOR decoding mode used by Flow. It rejects duplicate map keys and indefinite length items, which
+// are never produced by EncMode, as well as fields unknown to the decoded type.
+var DecMode = func() cbor.DecMode {
+	options := cbor.DecOptions{
+		DupMapKey:         cbor.DupMapKeyEnforcedAPF,
+		IndefLength:       cbor.IndefLengthForbidden,
+		ExtraReturnErrors: cbor.ExtraDecErrorUnknownField,
+	}
+	mode, err := options.DecMode()
+	if err != nil {
+		panic(fmt.Sprintf("could not create CBOR decoding mode: %v", err))
+	}
+	return mode
+}()
+
+// Marshal encodes the value with EncMode.
+func Marshal(val interface{}) ([]byte, error) {
+	return EncMode.Marshal(val)
+}
+
+// Unmarshal decodes the bytes into the value with DecMode.
+func Unmarshal(b []byte, val interface{}) error {
+	return DecMode.Unmarshal(b, val)
+}
+
+// NewStreamEncoder returns an encoder writing values encoded with EncMode to the writer.
+func NewStreamEncoder(w io.Writer) *cbor.Encoder {
+	return EncMode.NewEncoder(w)
+}
+
+// NewStreamDecoder returns a decoder reading values encoded with EncMode from the reader.
+func NewStreamDecoder(r io.Reader) *cbor.Decoder {
+	return DecMode.NewDecoder(r)
+}
+
+type Encoder struct{}
+
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+func (e *Encoder) Encode(val interface{}) ([]byte, error) {
+	return Marshal(val)
+}
+
+func (e *Encoder) Decode(b []byte, val interface{}) error {
+	return Unmarshal(b, val)
+}
+
+func (e *Encoder) MustEncode(val interface{}) []byte {
+	b, err := e.Encode(val)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (e *Encoder) MustDecode(b []byte, val interface{}) {
+	err := e.Decode(b, val)
+	if err != nil {
+		panic(err)
+	}
+}
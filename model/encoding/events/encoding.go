@@ -0,0 +1,243 @@
+// Package events implements the encodings of Cadence event payloads, and the
+// conversion of payloads between them.
+//
+// The CBOR encoding is a compact binary encoding of the JSON-CDC data model:
+// the JSON-CDC document of a value is encoded in CBOR, with the keys of its
+// objects replaced by small integers. Payloads in the CBOR encoding start with
+// the self-described CBOR tag, which can't start a JSON document, so that
+// payloads received without an encoding version can be told apart.
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// selfDescribedTag is the CBOR tag 55799, which marks a CBOR payload.
+var selfDescribedTag = []byte{0xd9, 0xd9, 0xf7}
+
+// keys are the keys of the objects in JSON-CDC documents, which are replaced
+// by their index in CBOR payloads. Keys must only be appended, so that
+// existing payloads can still be decoded.
+var keys = []string{
+	"type",
+	"value",
+	"id",
+	"fields",
+	"name",
+	"key",
+	"address",
+	"domain",
+	"identifier",
+	"path",
+	"targetPath",
+	"borrowType",
+	"staticType",
+}
+
+var keyIndexes = func() map[string]uint64 {
+	indexes := make(map[string]uint64, len(keys))
+	for i, key := range keys {
+		indexes[key] = uint64(i)
+	}
+	return indexes
+}()
+
+var encMode = func() cbor.EncMode {
+	mode, err := cbor.CanonicalEncOptions().EncMode()
+	if err != nil {
+		panic(fmt.Sprintf("could not create CBOR encoding mode: %v", err))
+	}
+	return mode
+}()
+
+// Detect returns the encoding of an event payload.
+func Detect(payload []byte) flow.EventEncodingVersion {
+	if bytes.HasPrefix(payload, selfDescribedTag) {
+		return flow.EventEncodingVersionCBOR
+	}
+	return flow.EventEncodingVersionJSONCDC
+}
+
+// Encode encodes a Cadence value with the given encoding.
+func Encode(value cadence.Value, version flow.EventEncodingVersion) ([]byte, error) {
+	payload, err := jsoncdc.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode value: %w", err)
+	}
+
+	return Convert(payload, flow.EventEncodingVersionJSONCDC, version)
+}
+
+// Decode decodes a Cadence value from a payload with the given encoding.
+func Decode(payload []byte, version flow.EventEncodingVersion) (cadence.Value, error) {
+	payload, err := Convert(payload, version, flow.EventEncodingVersionJSONCDC)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsoncdc.Decode(payload)
+}
+
+// Convert converts a payload between encodings.
+func Convert(payload []byte, from flow.EventEncodingVersion, to flow.EventEncodingVersion) ([]byte, error) {
+	if from == to {
+		return payload, nil
+	}
+
+	switch {
+	case from == flow.EventEncodingVersionJSONCDC && to == flow.EventEncodingVersionCBOR:
+		return jsonToCBOR(payload)
+	case from == flow.EventEncodingVersionCBOR && to == flow.EventEncodingVersionJSONCDC:
+		return cborToJSON(payload)
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %s to %s", from, to)
+	}
+}
+
+// ConvertEvents returns copies of the events with their payloads converted to
+// the given encoding.
+func ConvertEvents(events []flow.Event, to flow.EventEncodingVersion) ([]flow.Event, error) {
+	converted := make([]flow.Event, len(events))
+	for i, event := range events {
+		payload, err := Convert(event.Payload, event.EncodingVersion, to)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert payload of event %s: %w", event.ID(), err)
+		}
+		event.Payload = payload
+		event.EncodingVersion = to
+		converted[i] = event
+	}
+	return converted, nil
+}
+
+func jsonToCBOR(payload []byte) ([]byte, error) {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	err := decoder.Decode(&document)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode JSON-CDC payload: %w", err)
+	}
+
+	compacted, err := compactKeys(document)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := encMode.Marshal(compacted)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode CBOR payload: %w", err)
+	}
+
+	return append(append([]byte{}, selfDescribedTag...), encoded...), nil
+}
+
+func cborToJSON(payload []byte) ([]byte, error) {
+	if !bytes.HasPrefix(payload, selfDescribedTag) {
+		return nil, fmt.Errorf("CBOR payload is not tagged")
+	}
+
+	var document interface{}
+	err := cbor.Unmarshal(payload, &document)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode CBOR payload: %w", err)
+	}
+
+	expanded, err := expandKeys(document)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode JSON-CDC payload: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// compactKeys replaces the known keys of the objects in a JSON document by
+// their index.
+func compactKeys(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		compacted := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			item, err := compactKeys(item)
+			if err != nil {
+				return nil, err
+			}
+			index, ok := keyIndexes[key]
+			if ok {
+				compacted[index] = item
+			} else {
+				compacted[key] = item
+			}
+		}
+		return compacted, nil
+	case []interface{}:
+		compacted := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			compacted[i], err = compactKeys(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return compacted, nil
+	case json.Number:
+		// JSON-CDC encodes all numbers as strings
+		return nil, fmt.Errorf("unexpected number in JSON-CDC payload: %s", v)
+	default:
+		return v, nil
+	}
+}
+
+// expandKeys restores the keys of the objects in a decoded CBOR document.
+func expandKeys(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		expanded := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			var name string
+			switch k := key.(type) {
+			case uint64:
+				if k >= uint64(len(keys)) {
+					return nil, fmt.Errorf("unknown key index: %d", k)
+				}
+				name = keys[k]
+			case string:
+				name = k
+			default:
+				return nil, fmt.Errorf("invalid key type: %T", key)
+			}
+
+			var err error
+			expanded[name], err = expandKeys(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			expanded[i], err = expandKeys(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	default:
+		return v, nil
+	}
+}
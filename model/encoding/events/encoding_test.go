@@ -0,0 +1,94 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/encoding/events"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func eventFixture() cadence.Event {
+	eventType := &cadence.EventType{
+		Location:            common.AddressLocation{Address: common.BytesToAddress([]byte{1})},
+		QualifiedIdentifier: "Token.Deposited",
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+			{Identifier: "tags", Type: cadence.VariableSizedArrayType{ElementType: cadence.StringType{}}},
+			{Identifier: "memo", Type: cadence.OptionalType{Type: cadence.StringType{}}},
+		},
+	}
+
+	amount, _ := cadence.NewUFix64("12.5")
+
+	return cadence.NewEvent([]cadence.Value{
+		amount,
+		cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("01"))),
+		cadence.NewArray([]cadence.Value{cadence.NewString("a"), cadence.NewString("<b>")}),
+		cadence.NewOptional(nil),
+	}).WithType(eventType)
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	event := eventFixture()
+
+	jsonPayload, err := events.Encode(event, flow.EventEncodingVersionJSONCDC)
+	require.NoError(t, err)
+	assert.Equal(t, flow.EventEncodingVersionJSONCDC, events.Detect(jsonPayload))
+
+	cborPayload, err := events.Encode(event, flow.EventEncodingVersionCBOR)
+	require.NoError(t, err)
+	assert.Equal(t, flow.EventEncodingVersionCBOR, events.Detect(cborPayload))
+	assert.Less(t, len(cborPayload), len(jsonPayload))
+
+	decoded, err := events.Decode(cborPayload, flow.EventEncodingVersionCBOR)
+	require.NoError(t, err)
+	assert.Equal(t, event.Fields, decoded.(cadence.Event).Fields)
+
+	// payloads converted back to JSON-CDC can be decoded by existing clients
+	converted, err := events.Convert(cborPayload, flow.EventEncodingVersionCBOR, flow.EventEncodingVersionJSONCDC)
+	require.NoError(t, err)
+	decoded, err = jsoncdc.Decode(converted)
+	require.NoError(t, err)
+	assert.Equal(t, event.Fields, decoded.(cadence.Event).Fields)
+
+	t.Run("invalid payloads", func(t *testing.T) {
+		_, err := events.Convert(jsonPayload, flow.EventEncodingVersionCBOR, flow.EventEncodingVersionJSONCDC)
+		assert.Error(t, err)
+
+		_, err = events.Convert([]byte(`{"type":"Int","value":1}`), flow.EventEncodingVersionJSONCDC, flow.EventEncodingVersionCBOR)
+		assert.Error(t, err)
+
+		_, err = events.Convert(jsonPayload, flow.EventEncodingVersionJSONCDC, flow.EventEncodingVersion(7))
+		assert.Error(t, err)
+	})
+}
+
+func TestConvertEvents(t *testing.T) {
+	payload, err := events.Encode(eventFixture(), flow.EventEncodingVersionJSONCDC)
+	require.NoError(t, err)
+
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture())
+	event.Payload = payload
+
+	converted, err := events.ConvertEvents([]flow.Event{event}, flow.EventEncodingVersionCBOR)
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.Equal(t, flow.EventEncodingVersionCBOR, converted[0].EncodingVersion)
+	assert.Equal(t, event.ID(), converted[0].ID())
+
+	// the original events are not modified
+	assert.Equal(t, payload, event.Payload)
+	assert.Equal(t, flow.EventEncodingVersionJSONCDC, event.EncodingVersion)
+
+	restored, err := events.ConvertEvents(converted, flow.EventEncodingVersionJSONCDC)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(restored[0].Payload))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 // EpochPhase represents a phase of the Epoch Preparation Protocol. The phase
@@ -155,6 +156,20 @@ func (commit *EpochCommit) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+func (commit *EpochCommit) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(encodableFromCommit(commit))
+}
+
+func (commit *EpochCommit) UnmarshalCBOR(b []byte) error {
+	var enc encodableCommit
+	err := cbor.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+	*commit = commitFromEncodable(enc)
+	return nil
+}
+
 // EncodeRLP encodes the commit as RLP. The RLP encoding needs to be handled
 // differently from JSON/msgpack, because it does not handle custom encoders
 // within map types.
@@ -254,18 +269,33 @@ func (part *DKGParticipant) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+func (part DKGParticipant) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(encodableFromDKGParticipant(part))
+}
+
+func (part *DKGParticipant) UnmarshalCBOR(b []byte) error {
+	var enc encodableDKGParticipant
+	err := cbor.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+	*part = dkgParticipantFromEncodable(enc)
+	return nil
+}
+
 func (part DKGParticipant) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, encodableFromDKGParticipant(part))
 }
 
-// EpochStatus represents the status of the current and next epoch with respect
-// to a reference block. Concretely, it contains the IDs for all relevant
-// service events emitted as of the reference block. Events not yet emitted are
-// represented by ZeroID.
+// EpochStatus represents the status of the previous, current and next epoch
+// with respect to a reference block. Concretely, it contains the IDs for all
+// relevant service events emitted as of the reference block. Events not yet
+// emitted (or not known, for the previous epoch) are represented by ZeroID.
 type EpochStatus struct {
-	FirstBlockID Identifier // ID of the first block in current epoch
-	CurrentEpoch EventIDs   // EpochSetup and EpochCommit events for the current epoch
-	NextEpoch    EventIDs   // EpochSetup and EpochCommit events for the next epoch
+	FirstBlockID  Identifier // ID of the first block in current epoch
+	PreviousEpoch EventIDs   // EpochSetup and EpochCommit events for the previous epoch
+	CurrentEpoch  EventIDs   // EpochSetup and EpochCommit events for the current epoch
+	NextEpoch     EventIDs   // EpochSetup and EpochCommit events for the next epoch
 }
 
 // EventIDs is a container for IDs of epoch service events.
@@ -276,9 +306,13 @@ type EventIDs struct {
 	CommitID Identifier
 }
 
-func NewEpochStatus(firstBlockID, currentSetup, currentCommit, nextSetup, nextCommit Identifier) (*EpochStatus, error) {
+func NewEpochStatus(firstBlockID, previousSetup, previousCommit, currentSetup, currentCommit, nextSetup, nextCommit Identifier) (*EpochStatus, error) {
 	status := &EpochStatus{
 		FirstBlockID: firstBlockID,
+		PreviousEpoch: EventIDs{
+			SetupID:  previousSetup,
+			CommitID: previousCommit,
+		},
 		CurrentEpoch: EventIDs{
 			SetupID:  currentSetup,
 			CommitID: currentCommit,
@@ -306,6 +340,10 @@ func (es *EpochStatus) check() error {
 	if es.FirstBlockID == ZeroID {
 		return fmt.Errorf("epoch status with empty first block")
 	}
+	// must reference either both or neither event IDs for previous epoch
+	if (es.PreviousEpoch.SetupID == ZeroID) != (es.PreviousEpoch.CommitID == ZeroID) {
+		return fmt.Errorf("epoch status with only setup or only commit service event for previous epoch")
+	}
 	// must reference event IDs for current epoch
 	if es.CurrentEpoch.SetupID == ZeroID || es.CurrentEpoch.CommitID == ZeroID {
 		return fmt.Errorf("epoch status with empty current epoch service events")
@@ -20,6 +20,42 @@ const (
 
 type EventType string
 
+// EventEncodingVersion is the version of the encoding of an event payload.
+type EventEncodingVersion uint8
+
+const (
+	// EventEncodingVersionJSONCDC is the JSON-CDC encoding of Cadence values.
+	// It's the zero value, so that events stored before the encoding version
+	// was introduced are decoded as JSON-CDC.
+	EventEncodingVersionJSONCDC EventEncodingVersion = iota
+	// EventEncodingVersionCBOR is a compact binary encoding of the JSON-CDC
+	// data model in CBOR.
+	EventEncodingVersionCBOR
+)
+
+func (v EventEncodingVersion) String() string {
+	switch v {
+	case EventEncodingVersionJSONCDC:
+		return "json-cdc"
+	case EventEncodingVersionCBOR:
+		return "cbor"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(v))
+	}
+}
+
+// ParseEventEncodingVersion returns the event encoding version with the given name.
+func ParseEventEncodingVersion(name string) (EventEncodingVersion, error) {
+	switch name {
+	case EventEncodingVersionJSONCDC.String():
+		return EventEncodingVersionJSONCDC, nil
+	case EventEncodingVersionCBOR.String():
+		return EventEncodingVersionCBOR, nil
+	default:
+		return 0, fmt.Errorf("unknown event encoding: %s", name)
+	}
+}
+
 type Event struct {
 	// Type is the qualified event type.
 	Type EventType
@@ -33,6 +69,10 @@ type Event struct {
 	EventIndex uint32
 	// Payload contains the encoded event data.
 	Payload []byte
+	// EncodingVersion is the encoding of the payload. Events are emitted in
+	// JSON-CDC, and only converted when stored or served, so the encoding is
+	// not part of the fingerprint of the execution results including them.
+	EncodingVersion EventEncodingVersion `rlp:"-"`
 }
 
 // String returns the string representation of this event.
@@ -30,3 +30,15 @@ func TestEventFingerprint(t *testing.T) {
 	rlp.NewEncoder().MustDecode(data, &decoded)
 	assert.Equal(t, wrapEvent(evt), decoded)
 }
+
+// TestEventEncodingVersionFingerprint verifies that the encoding version of
+// service events doesn't change the ID of the execution result including them.
+func TestEventEncodingVersionFingerprint(t *testing.T) {
+	evt := unittest.EventFixture(flow.EventAccountCreated, 13, 12, unittest.IdentifierFixture())
+	result := unittest.ExecutionResultFixture()
+	result.ServiceEvents = []flow.Event{evt}
+	id := result.ID()
+
+	result.ServiceEvents[0].EncodingVersion = flow.EventEncodingVersionCBOR
+	assert.Equal(t, id, result.ID())
+}
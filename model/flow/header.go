@@ -7,6 +7,7 @@ import (
 	"github.com/vmihailenco/msgpack/v4"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 	"github.com/onflow/flow-go/model/fingerprint"
 )
 
@@ -140,3 +141,37 @@ func (h *Header) UnmarshalMsgpack(data []byte) error {
 
 	return err
 }
+
+// MarshalCBOR makes sure the timestamp is encoded in UTC.
+func (h Header) MarshalCBOR() ([]byte, error) {
+
+	// NOTE: this is just a sanity check to make sure that we don't get
+	// different encodings if someone forgets to use UTC timestamps
+	if h.Timestamp.Location() != time.UTC {
+		h.Timestamp = h.Timestamp.UTC()
+	}
+
+	// we use an alias to avoid endless recursion; the alias will not have the
+	// marshal function and encode like a raw header
+	type Encodable Header
+	return cbor.Marshal(Encodable(h))
+}
+
+// UnmarshalCBOR makes sure the timestamp is decoded in UTC.
+func (h *Header) UnmarshalCBOR(data []byte) error {
+
+	// we use an alias to avoid endless recursion; the alias will not have the
+	// unmarshal function and decode like a raw header
+	type Decodable Header
+	decodable := Decodable(*h)
+	err := cbor.Unmarshal(data, &decodable)
+	*h = Header(decodable)
+
+	// NOTE: CBOR decodes timestamps with the offset they were encoded with,
+	// which would change the block ID if it was not UTC
+	if h.Timestamp.Location() != time.UTC {
+		h.Timestamp = h.Timestamp.UTC()
+	}
+
+	return err
+}
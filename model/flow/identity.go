@@ -13,6 +13,7 @@ import (
 	"github.com/vmihailenco/msgpack"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 // rxid is the regex for parsing node identity entries.
@@ -133,6 +134,18 @@ func (iy Identity) MarshalMsgpack() ([]byte, error) {
 	return data, nil
 }
 
+func (iy Identity) MarshalCBOR() ([]byte, error) {
+	encodable, err := encodableFromIdentity(iy)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert to encodable: %w", err)
+	}
+	data, err := cbor.Marshal(encodable)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode cbor: %w", err)
+	}
+	return data, nil
+}
+
 func identityFromEncodable(ie encodableIdentity, identity *Identity) error {
 	identity.NodeID = ie.NodeID
 	identity.Address = ie.Address
@@ -178,6 +191,19 @@ func (iy *Identity) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+func (iy *Identity) UnmarshalCBOR(b []byte) error {
+	var encodable encodableIdentity
+	err := cbor.Unmarshal(b, &encodable)
+	if err != nil {
+		return fmt.Errorf("could not decode cbor: %w", err)
+	}
+	err = identityFromEncodable(encodable, iy)
+	if err != nil {
+		return fmt.Errorf("could not convert from encodable cbor: %w", err)
+	}
+	return nil
+}
+
 // IdentityFilter is a filter on identities.
 type IdentityFilter func(*Identity) bool
 
@@ -305,6 +331,16 @@ func (il IdentityList) ByNodeID(nodeID Identifier) (*Identity, bool) {
 	return nil, false
 }
 
+// GetIndex returns the index of the node with the given ID in the list.
+func (il IdentityList) GetIndex(nodeID Identifier) (uint, bool) {
+	for i, identity := range il {
+		if identity.NodeID == nodeID {
+			return uint(i), true
+		}
+	}
+	return 0, false
+}
+
 // Sample returns simple random sample from the `IdentityList`
 func (il IdentityList) Sample(size uint) IdentityList {
 	n := uint(len(il))
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 const (
@@ -123,3 +125,45 @@ func (se *ServiceEvent) UnmarshalMsgpack(b []byte) error {
 	}
 	return nil
 }
+
+func (se *ServiceEvent) UnmarshalCBOR(b []byte) error {
+
+	// decode the event lazily, we'll unmarshal it into the appropriate type
+	var enc struct {
+		Type  string
+		Event cbor.RawMessage
+	}
+	err := cbor.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+	if enc.Event == nil {
+		return fmt.Errorf("missing event key")
+	}
+
+	var event interface{}
+	switch enc.Type {
+	case ServiceEventSetup:
+		setup := new(EpochSetup)
+		err = cbor.Unmarshal(enc.Event, setup)
+		if err != nil {
+			return err
+		}
+		event = setup
+	case ServiceEventCommit:
+		commit := new(EpochCommit)
+		err = cbor.Unmarshal(enc.Event, commit)
+		if err != nil {
+			return err
+		}
+		event = commit
+	default:
+		return fmt.Errorf("invalid type: %s", enc.Type)
+	}
+
+	*se = ServiceEvent{
+		Type:  enc.Type,
+		Event: event,
+	}
+	return nil
+}
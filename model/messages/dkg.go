@@ -0,0 +1,29 @@
+package messages
+
+// DKGMessageType distinguishes between broadcast and private DKG messages.
+type DKGMessageType uint8
+
+const (
+	// DKGMessagePrivate is a message sent to a single DKG participant.
+	DKGMessagePrivate DKGMessageType = iota + 1
+	// DKGMessageBroadcast is a message sent to all DKG participants.
+	DKGMessageBroadcast
+)
+
+// DKGMessage is the type of message exchanged between DKG participants.
+type DKGMessage struct {
+	Orig          uint64         // index of the sender in the DKG committee
+	Type          DKGMessageType // whether the message is private or broadcast
+	Data          []byte         // opaque payload produced by the DKG protocol
+	DKGInstanceID string         // identifies the DKG instance, to avoid cross-talk between instances
+}
+
+// NewDKGMessage creates a new DKGMessage.
+func NewDKGMessage(orig int, typ DKGMessageType, data []byte, dkgInstanceID string) DKGMessage {
+	return DKGMessage{
+		Orig:          uint64(orig),
+		Type:          typ,
+		Data:          data,
+		DKGInstanceID: dkgInstanceID,
+	}
+}
@@ -0,0 +1,76 @@
+package module
+
+import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// DKGBroker connects a DKG instance to the network. It implements the output
+// actions of the DKG protocol (see crypto.DKGProcessor), and exposes a channel
+// through which the DKG controller receives incoming messages.
+type DKGBroker interface {
+
+	// PrivateSend sends a message to the participant with the given index
+	// over a private channel.
+	PrivateSend(dest int, data []byte)
+
+	// Broadcast sends a message to all other participants.
+	Broadcast(data []byte)
+
+	// Disqualify flags that the participant with the given index misbehaved
+	// and was disqualified from the protocol.
+	Disqualify(node int, log string)
+
+	// FlagMisbehavior warns that the participant with the given index
+	// misbehaved, without disqualifying it.
+	FlagMisbehavior(node int, log string)
+
+	// GetIndex returns the index of this node in the DKG committee.
+	GetIndex() int
+
+	// GetMsgCh returns the channel through which the broker forwards
+	// authenticated incoming DKG messages.
+	GetMsgCh() <-chan messages.DKGMessage
+
+	// Shutdown stops the broker from forwarding incoming messages.
+	Shutdown()
+}
+
+// DKGController controls the execution of a single DKG instance. The DKG runs
+// in three phases, which are ended externally by calling EndPhase1, EndPhase2
+// and End in that order, typically when reaching pre-defined block heights.
+type DKGController interface {
+
+	// Run starts the DKG and processes incoming messages until the DKG is
+	// ended or shut down. It blocks for the lifetime of the DKG.
+	Run() error
+
+	// EndPhase1 notifies the controller to end phase 1 and start phase 2.
+	EndPhase1() error
+
+	// EndPhase2 notifies the controller to end phase 2 and start phase 3.
+	EndPhase2() error
+
+	// End terminates the DKG and computes the resulting keys. It blocks until
+	// the keys are available or the DKG failed.
+	End() error
+
+	// Shutdown stops the controller without completing the DKG.
+	Shutdown()
+
+	// GetArtifacts returns our private key share, the group public key, and
+	// the list of all public key shares, once the DKG has ended successfully.
+	GetArtifacts() (crypto.PrivateKey, crypto.PublicKey, []crypto.PublicKey)
+
+	// GetIndex returns the index of this node in the DKG committee.
+	GetIndex() int
+}
+
+// DKGControllerFactory is used to create a DKGController for a DKG instance.
+type DKGControllerFactory interface {
+
+	// Create instantiates a new DKGController for the DKG instance with the
+	// given ID, between the given participants, using the given seed.
+	Create(dkgInstanceID string, participants flow.IdentityList, seed []byte) (DKGController, error)
+}
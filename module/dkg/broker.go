@@ -0,0 +1,156 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+)
+
+// DefaultMsgBufferSize is the default size of the buffered channels used to
+// relay DKG messages between the network and the DKG instance.
+const DefaultMsgBufferSize = 1000
+
+// Broker is an implementation of the DKGBroker interface which relays messages
+// between a DKG instance and the network, through a BrokerTunnel connected to
+// the DKG MessagingEngine.
+//
+// Private messages are sent directly to the recipient. Broadcast messages are
+// sent directly to all other committee members; unlike what the DKG protocol
+// assumes, the network layer does not guarantee that every participant
+// receives the same broadcast messages.
+type Broker struct {
+	log           zerolog.Logger
+	unit          *engine.Unit
+	dkgInstanceID string                   // unique identifier of the current DKG instance, to filter out messages from other instances
+	committee     flow.IdentityList        // identities of the DKG participants, ordered by DKG index
+	myIndex       int                      // index of this node in the committee
+	tunnel        *BrokerTunnel            // channels through which the broker communicates with the messaging engine
+	msgCh         chan messages.DKGMessage // channel to forward authenticated incoming messages to the controller
+}
+
+var _ module.DKGBroker = (*Broker)(nil)
+
+// NewBroker instantiates a new broker for the DKG instance with the given ID,
+// and starts forwarding incoming messages.
+func NewBroker(
+	log zerolog.Logger,
+	dkgInstanceID string,
+	committee flow.IdentityList,
+	myIndex int,
+	tunnel *BrokerTunnel) *Broker {
+
+	b := &Broker{
+		log:           log.With().Str("component", "dkg_broker").Str("dkg_instance_id", dkgInstanceID).Logger(),
+		unit:          engine.NewUnit(),
+		dkgInstanceID: dkgInstanceID,
+		committee:     committee,
+		myIndex:       myIndex,
+		tunnel:        tunnel,
+		msgCh:         make(chan messages.DKGMessage, DefaultMsgBufferSize),
+	}
+
+	b.unit.Launch(b.listen)
+
+	return b
+}
+
+// GetIndex returns the index of this node in the committee list.
+func (b *Broker) GetIndex() int {
+	return b.myIndex
+}
+
+// PrivateSend sends a DKGMessage to a destination over a private channel. It
+// relies on the network layer to encrypt and authenticate the connection.
+func (b *Broker) PrivateSend(dest int, data []byte) {
+	if dest < 0 || dest >= len(b.committee) {
+		b.log.Error().Msgf("can not send private message to invalid index %d", dest)
+		return
+	}
+	msg := messages.NewDKGMessage(b.myIndex, messages.DKGMessagePrivate, data, b.dkgInstanceID)
+	b.tunnel.SendOut(MessageOut{
+		DKGMessage: msg,
+		DestIDs:    []flow.Identifier{b.committee[dest].NodeID},
+	})
+}
+
+// Broadcast sends a DKGMessage to all other committee members.
+func (b *Broker) Broadcast(data []byte) {
+	msg := messages.NewDKGMessage(b.myIndex, messages.DKGMessageBroadcast, data, b.dkgInstanceID)
+	others := make([]flow.Identifier, 0, len(b.committee)-1)
+	for i, participant := range b.committee {
+		if i == b.myIndex {
+			continue
+		}
+		others = append(others, participant.NodeID)
+	}
+	b.tunnel.SendOut(MessageOut{
+		DKGMessage: msg,
+		DestIDs:    others,
+	})
+}
+
+// Disqualify flags that a node is misbehaving and got disqualified. The DKG
+// instance already excludes the disqualified participant; penalizing it
+// outside of the DKG, for example by slashing, is not implemented yet, so the
+// disqualification is only logged.
+func (b *Broker) Disqualify(node int, log string) {
+	b.log.Warn().Msgf("participant %d is disqualifying participant %d because: %s", b.myIndex, node, log)
+}
+
+// FlagMisbehavior warns that a node is misbehaving. Reporting misbehaving
+// participants is not implemented yet, so the misbehavior is only logged.
+func (b *Broker) FlagMisbehavior(node int, log string) {
+	b.log.Warn().Msgf("participant %d is flagging participant %d because: %s", b.myIndex, node, log)
+}
+
+// GetMsgCh returns the channel through which consumers can receive incoming
+// DKG messages.
+func (b *Broker) GetMsgCh() <-chan messages.DKGMessage {
+	return b.msgCh
+}
+
+// Shutdown stops the goroutine forwarding incoming messages.
+func (b *Broker) Shutdown() {
+	<-b.unit.Done()
+}
+
+// listen forwards incoming messages from the tunnel to the message channel,
+// until the broker is shut down.
+func (b *Broker) listen() {
+	for {
+		select {
+		case msg := <-b.tunnel.MsgChIn:
+			err := b.onMessage(msg)
+			if err != nil {
+				b.log.Error().Err(err).Msg("bad message")
+			}
+		case <-b.unit.Quit():
+			return
+		}
+	}
+}
+
+// onMessage verifies that an incoming message belongs to this DKG
+// instance and was sent by the committee member it claims to originate from,
+// before forwarding it to the message channel.
+func (b *Broker) onMessage(msg MessageIn) error {
+	if msg.DKGInstanceID != b.dkgInstanceID {
+		return fmt.Errorf("wrong DKG instance. Got %s, want %s", msg.DKGInstanceID, b.dkgInstanceID)
+	}
+	if msg.Orig >= uint64(len(b.committee)) {
+		return fmt.Errorf("origin index out of range: %d", msg.Orig)
+	}
+	if b.committee[msg.Orig].NodeID != msg.OriginID {
+		return fmt.Errorf("origin id (%x) does not match committee member at index %d", msg.OriginID, msg.Orig)
+	}
+	select {
+	case b.msgCh <- msg.DKGMessage:
+	case <-b.unit.Quit():
+	}
+	return nil
+}
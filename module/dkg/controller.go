@@ -0,0 +1,231 @@
+// +build relic
+
+package dkg
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+)
+
+// Controller implements the DKGController interface. It drives a Joint
+// Feldman DKG instance through its phases, and feeds it incoming messages
+// received through the broker. All interactions with the underlying DKG
+// instance happen on the goroutine executing Run, since it is not safe for
+// concurrent use.
+type Controller struct {
+	log    zerolog.Logger
+	dkg    crypto.DKGState
+	seed   []byte
+	broker module.DKGBroker
+
+	// channels used to signal the Run loop
+	phase1Ch     chan struct{}
+	phase2Ch     chan struct{}
+	endCh        chan struct{}
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+	doneCh       chan struct{} // closed when the Run loop exits
+
+	stateLock sync.Mutex
+	state     State
+
+	// artifacts of the DKG, only available after the DKG ended successfully
+	artifactsLock sync.Mutex
+	privateShare  crypto.PrivateKey
+	groupKey      crypto.PublicKey
+	publicShares  []crypto.PublicKey
+	endErr        error
+}
+
+var _ module.DKGController = (*Controller)(nil)
+
+// NewController instantiates a new Joint Feldman DKG controller.
+func NewController(
+	log zerolog.Logger,
+	dkg crypto.DKGState,
+	seed []byte,
+	broker module.DKGBroker,
+) *Controller {
+
+	return &Controller{
+		log:        log.With().Str("component", "dkg_controller").Logger(),
+		dkg:        dkg,
+		seed:       seed,
+		broker:     broker,
+		phase1Ch:   make(chan struct{}),
+		phase2Ch:   make(chan struct{}),
+		endCh:      make(chan struct{}),
+		shutdownCh: make(chan struct{}),
+		doneCh:     make(chan struct{}),
+		state:      Init,
+	}
+}
+
+// Run starts the DKG and processes incoming messages until the DKG is ended
+// or shut down.
+func (c *Controller) Run() error {
+	defer close(c.doneCh)
+
+	err := c.transition(Init, Phase1)
+	if err != nil {
+		return err
+	}
+
+	err = c.dkg.Start(c.seed)
+	if err != nil {
+		return fmt.Errorf("could not start dkg: %w", err)
+	}
+
+	for {
+		select {
+		case msg := <-c.broker.GetMsgCh():
+			c.processMessage(msg)
+		case <-c.phase1Ch:
+			err := c.dkg.NextTimeout()
+			if err != nil {
+				return fmt.Errorf("could not end dkg phase 1: %w", err)
+			}
+		case <-c.phase2Ch:
+			err := c.dkg.NextTimeout()
+			if err != nil {
+				return fmt.Errorf("could not end dkg phase 2: %w", err)
+			}
+		case <-c.endCh:
+			c.end()
+			return nil
+		case <-c.shutdownCh:
+			return nil
+		}
+	}
+}
+
+// EndPhase1 ends phase 1 of the DKG and starts phase 2.
+func (c *Controller) EndPhase1() error {
+	err := c.transition(Phase1, Phase2)
+	if err != nil {
+		return err
+	}
+	return c.signal(c.phase1Ch)
+}
+
+// EndPhase2 ends phase 2 of the DKG and starts phase 3.
+func (c *Controller) EndPhase2() error {
+	err := c.transition(Phase2, Phase3)
+	if err != nil {
+		return err
+	}
+	return c.signal(c.phase2Ch)
+}
+
+// End terminates the DKG and computes the resulting keys. It blocks until the
+// keys are available, and returns an error if the DKG failed.
+func (c *Controller) End() error {
+	err := c.transition(Phase3, End)
+	if err != nil {
+		return err
+	}
+	err = c.signal(c.endCh)
+	if err != nil {
+		return err
+	}
+	<-c.doneCh
+
+	c.artifactsLock.Lock()
+	defer c.artifactsLock.Unlock()
+	return c.endErr
+}
+
+// Shutdown stops the controller without completing the DKG, and shuts down
+// the broker. It is safe to call Shutdown more than once.
+func (c *Controller) Shutdown() {
+	c.shutdownOnce.Do(func() {
+		c.stateLock.Lock()
+		c.state = Shutdown
+		c.stateLock.Unlock()
+
+		close(c.shutdownCh)
+		c.broker.Shutdown()
+	})
+}
+
+// GetArtifacts returns our private key share, the group public key, and the
+// list of all public key shares. They are nil until the DKG ended successfully.
+func (c *Controller) GetArtifacts() (crypto.PrivateKey, crypto.PublicKey, []crypto.PublicKey) {
+	c.artifactsLock.Lock()
+	defer c.artifactsLock.Unlock()
+	return c.privateShare, c.groupKey, c.publicShares
+}
+
+// GetIndex returns the index of this node in the DKG committee.
+func (c *Controller) GetIndex() int {
+	return c.broker.GetIndex()
+}
+
+// GetState returns the current state of the DKG.
+func (c *Controller) GetState() State {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	return c.state
+}
+
+// transition atomically moves the controller from the expected state to the
+// given state.
+func (c *Controller) transition(from State, to State) error {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	if c.state != from {
+		return NewInvalidStateTransitionError(c.state, to)
+	}
+	c.state = to
+	return nil
+}
+
+// signal notifies the Run loop through the given channel, unless the loop has
+// already exited.
+func (c *Controller) signal(ch chan struct{}) error {
+	select {
+	case ch <- struct{}{}:
+		return nil
+	case <-c.doneCh:
+		return fmt.Errorf("dkg is no longer running")
+	}
+}
+
+// processMessage feeds an incoming message to the DKG instance. Invalid
+// messages are logged and dropped; the DKG protocol itself is responsible for
+// flagging or disqualifying misbehaving participants.
+func (c *Controller) processMessage(msg messages.DKGMessage) {
+	var err error
+	switch msg.Type {
+	case messages.DKGMessagePrivate:
+		err = c.dkg.HandlePrivateMsg(int(msg.Orig), msg.Data)
+	case messages.DKGMessageBroadcast:
+		err = c.dkg.HandleBroadcastMsg(int(msg.Orig), msg.Data)
+	default:
+		err = fmt.Errorf("unknown message type (%d)", msg.Type)
+	}
+	if err != nil {
+		c.log.Error().Err(err).Uint64("orig", msg.Orig).Msg("could not process dkg message")
+	}
+}
+
+// end terminates the DKG instance and stores the resulting keys.
+func (c *Controller) end() {
+	privateShare, groupKey, publicShares, err := c.dkg.End()
+
+	c.artifactsLock.Lock()
+	defer c.artifactsLock.Unlock()
+	if err != nil {
+		c.endErr = fmt.Errorf("could not end dkg: %w", err)
+		return
+	}
+	c.privateShare = privateShare
+	c.groupKey = groupKey
+	c.publicShares = publicShares
+}
@@ -0,0 +1,75 @@
+// +build relic
+
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/signature"
+)
+
+// ControllerFactory is a factory object that creates new Controllers for new
+// DKG instances. A new Controller must be created for every epoch.
+type ControllerFactory struct {
+	log    zerolog.Logger
+	me     module.Local
+	tunnel *BrokerTunnel
+}
+
+var _ module.DKGControllerFactory = (*ControllerFactory)(nil)
+
+// NewControllerFactory creates a new factory that generates Controllers with
+// the same underlying tunnel to the DKG messaging engine.
+func NewControllerFactory(
+	log zerolog.Logger,
+	me module.Local,
+	tunnel *BrokerTunnel) *ControllerFactory {
+
+	return &ControllerFactory{
+		log:    log,
+		me:     me,
+		tunnel: tunnel,
+	}
+}
+
+// Create creates a new Joint Feldman DKG controller for the DKG instance with
+// the given ID, between the given participants. The order of the participants
+// determines their DKG index.
+func (f *ControllerFactory) Create(
+	dkgInstanceID string,
+	participants flow.IdentityList,
+	seed []byte) (module.DKGController, error) {
+
+	myIndex, ok := participants.GetIndex(f.me.NodeID())
+	if !ok {
+		return nil, fmt.Errorf("failed to create controller factory, node %s is not part of DKG committee", f.me.NodeID())
+	}
+
+	broker := NewBroker(
+		f.log,
+		dkgInstanceID,
+		participants,
+		int(myIndex),
+		f.tunnel,
+	)
+
+	n := len(participants)
+	dkg, err := crypto.NewJointFeldman(n, signature.RandomBeaconThreshold(n), int(myIndex), broker)
+	if err != nil {
+		return nil, fmt.Errorf("could not create joint feldman dkg: %w", err)
+	}
+
+	controller := NewController(
+		f.log,
+		dkg,
+		seed,
+		broker,
+	)
+
+	return controller, nil
+}
@@ -0,0 +1,137 @@
+// +build relic
+
+package dkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// node is a test object that simulates a DKG participant with its own broker
+// tunnel and controller
+type node struct {
+	id         flow.Identifier
+	tunnel     *BrokerTunnel
+	controller *Controller
+}
+
+// route delivers the outgoing messages of the given node to the recipients
+func route(t *testing.T, from *node, nodes map[flow.Identifier]*node, quit <-chan struct{}) {
+	for {
+		select {
+		case msg := <-from.tunnel.MsgChOut:
+			for _, destID := range msg.DestIDs {
+				dest, ok := nodes[destID]
+				require.True(t, ok)
+				dest.tunnel.SendIn(MessageIn{
+					DKGMessage: msg.DKGMessage,
+					OriginID:   from.id,
+				})
+			}
+		case <-quit:
+			return
+		}
+	}
+}
+
+// TestDKGHappyPath runs a full DKG between honest participants and checks
+// that all participants obtain consistent keys.
+func TestDKGHappyPath(t *testing.T) {
+	n := 5
+	phaseDuration := time.Second
+	dkgInstanceID := "dkg-happy-path"
+
+	participants := unittest.IdentityListFixture(n)
+	nodes := make(map[flow.Identifier]*node, n)
+	ordered := make([]*node, 0, n)
+	for i, participant := range participants {
+		tunnel := NewBrokerTunnel()
+		broker := NewBroker(zerolog.Nop(), dkgInstanceID, participants, i, tunnel)
+		dkg, err := crypto.NewJointFeldman(n, signature.RandomBeaconThreshold(n), i, broker)
+		require.NoError(t, err)
+		seed := unittest.SeedFixture(crypto.SeedMinLenDKG)
+		controller := NewController(zerolog.Nop(), dkg, seed, broker)
+		nd := &node{id: participant.NodeID, tunnel: tunnel, controller: controller}
+		nodes[participant.NodeID] = nd
+		ordered = append(ordered, nd)
+	}
+
+	quit := make(chan struct{})
+	defer close(quit)
+	for _, nd := range ordered {
+		go route(t, nd, nodes, quit)
+	}
+
+	for _, nd := range ordered {
+		go func(nd *node) {
+			err := nd.controller.Run()
+			assert.NoError(t, err)
+		}(nd)
+	}
+
+	time.Sleep(phaseDuration)
+	for _, nd := range ordered {
+		require.NoError(t, nd.controller.EndPhase1())
+	}
+	time.Sleep(phaseDuration)
+	for _, nd := range ordered {
+		require.NoError(t, nd.controller.EndPhase2())
+	}
+	time.Sleep(phaseDuration)
+	for _, nd := range ordered {
+		require.NoError(t, nd.controller.End())
+	}
+
+	// all participants agree on the group key and the public key shares, and
+	// each public key share corresponds to the participant's private share
+	_, groupKey, publicShares := ordered[0].controller.GetArtifacts()
+	require.NotNil(t, groupKey)
+	for i, nd := range ordered {
+		priv, group, pubs := nd.controller.GetArtifacts()
+		require.NotNil(t, priv)
+		assert.True(t, groupKey.Equals(group))
+		require.Len(t, pubs, n)
+		for j := range pubs {
+			assert.True(t, publicShares[j].Equals(pubs[j]))
+		}
+		assert.True(t, priv.PublicKey().Equals(pubs[i]))
+		assert.Equal(t, End, nd.controller.GetState())
+	}
+}
+
+// TestInvalidStateTransition checks that phases can only be ended in order.
+func TestInvalidStateTransition(t *testing.T) {
+	participants := unittest.IdentityListFixture(2)
+	broker := NewBroker(zerolog.Nop(), "dkg-invalid-transition", participants, 0, NewBrokerTunnel())
+	dkg, err := crypto.NewJointFeldman(2, signature.RandomBeaconThreshold(2), 0, broker)
+	require.NoError(t, err)
+	controller := NewController(zerolog.Nop(), dkg, unittest.SeedFixture(crypto.SeedMinLenDKG), broker)
+
+	err = controller.EndPhase1()
+	assert.True(t, IsInvalidStateTransitionError(err))
+	err = controller.End()
+	assert.True(t, IsInvalidStateTransitionError(err))
+}
+
+// TestShutdownTwice checks that shutting down the controller more than once
+// does not panic.
+func TestShutdownTwice(t *testing.T) {
+	participants := unittest.IdentityListFixture(2)
+	broker := NewBroker(zerolog.Nop(), "dkg-shutdown-twice", participants, 0, NewBrokerTunnel())
+	dkg, err := crypto.NewJointFeldman(2, signature.RandomBeaconThreshold(2), 0, broker)
+	require.NoError(t, err)
+	controller := NewController(zerolog.Nop(), dkg, unittest.SeedFixture(crypto.SeedMinLenDKG), broker)
+
+	controller.Shutdown()
+	assert.NotPanics(t, controller.Shutdown)
+	assert.Equal(t, Shutdown, controller.GetState())
+}
@@ -0,0 +1,32 @@
+package dkg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InvalidStateTransitionError happens when an invalid DKG state transition is
+// attempted.
+type InvalidStateTransitionError struct {
+	From State
+	To   State
+}
+
+func (e InvalidStateTransitionError) Error() string {
+	return fmt.Sprintf("invalid DKG state transition from %s to %s", e.From, e.To)
+}
+
+// NewInvalidStateTransitionError creates a new InvalidStateTransitionError
+// between the given states.
+func NewInvalidStateTransitionError(from State, to State) error {
+	return InvalidStateTransitionError{
+		From: from,
+		To:   to,
+	}
+}
+
+// IsInvalidStateTransitionError returns whether an error is InvalidStateTransitionError
+func IsInvalidStateTransitionError(err error) bool {
+	var errInvalidStateTransition InvalidStateTransitionError
+	return errors.As(err, &errInvalidStateTransition)
+}
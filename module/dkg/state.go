@@ -0,0 +1,33 @@
+package dkg
+
+// State captures the state of an in-progress DKG.
+type State uint32
+
+const (
+	Init State = iota
+	Phase1
+	Phase2
+	Phase3
+	End
+	Shutdown
+)
+
+// String returns string representation of DKG state
+func (s State) String() string {
+	switch s {
+	case Init:
+		return "Init"
+	case Phase1:
+		return "Phase1"
+	case Phase2:
+		return "Phase2"
+	case Phase3:
+		return "Phase3"
+	case End:
+		return "End"
+	case Shutdown:
+		return "Shutdown"
+	default:
+		return "Unknown"
+	}
+}
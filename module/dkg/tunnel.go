@@ -0,0 +1,54 @@
+package dkg
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// BrokerTunnel allows the DKG MessagingEngine to relay messages to and from a
+// DKG Broker. The tunnel outlives individual brokers, so that the messaging
+// engine can be created once when the node starts, while a new broker is
+// created for every DKG instance.
+type BrokerTunnel struct {
+	MsgChIn  chan MessageIn  // from the messaging engine to the broker
+	MsgChOut chan MessageOut // from the broker to the messaging engine
+}
+
+// MessageIn is a DKG message received from the network, along with the ID of
+// the node that sent it.
+type MessageIn struct {
+	messages.DKGMessage
+	OriginID flow.Identifier
+}
+
+// MessageOut is a DKG message to be sent to the given recipients.
+type MessageOut struct {
+	messages.DKGMessage
+	DestIDs []flow.Identifier
+}
+
+// NewBrokerTunnel instantiates a new BrokerTunnel.
+func NewBrokerTunnel() *BrokerTunnel {
+	return &BrokerTunnel{
+		MsgChIn:  make(chan MessageIn, DefaultMsgBufferSize),
+		MsgChOut: make(chan MessageOut, DefaultMsgBufferSize),
+	}
+}
+
+// SendIn pushes a message received from the network to the broker. It does
+// not block, as the tunnel is only read while a broker is running, and returns
+// false if the message was dropped because the buffer is full.
+func (t *BrokerTunnel) SendIn(msg MessageIn) bool {
+	select {
+	case t.MsgChIn <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// SendOut pushes a message from the broker to the messaging engine, to be sent
+// over the network.
+func (t *BrokerTunnel) SendOut(msg MessageOut) {
+	t.MsgChOut <- msg
+}
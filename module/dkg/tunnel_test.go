@@ -0,0 +1,21 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSendIn checks that messages received while no broker reads them are
+// dropped once the buffer is full, rather than blocking the sender.
+func TestSendIn(t *testing.T) {
+	tunnel := NewBrokerTunnel()
+
+	for i := 0; i < DefaultMsgBufferSize; i++ {
+		assert.True(t, tunnel.SendIn(MessageIn{}))
+	}
+	assert.False(t, tunnel.SendIn(MessageIn{}))
+
+	<-tunnel.MsgChIn
+	assert.True(t, tunnel.SendIn(MessageIn{}))
+}
@@ -0,0 +1,226 @@
+package epochs
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// TransactionSubmissionTimeout is the time after which we return an error
+	// if a submitted vote transaction has not been sealed.
+	TransactionSubmissionTimeout = 5 * time.Minute
+
+	// TransactionStatusRetryTimeout is the time we wait between two queries
+	// of the status of a submitted vote transaction.
+	TransactionStatusRetryTimeout = 1 * time.Second
+
+	// SubmitVoteGasLimit is the gas limit of the vote transaction.
+	SubmitVoteGasLimit = 9999
+)
+
+// submitVoteTransaction is the transaction used to submit a vote to the QC
+// aggregator contract. The contract address is filled in when building the
+// transaction.
+const submitVoteTransaction = `
+import FlowEpochClusterQC from 0x%s
+
+transaction(voteSignature: String, voteMessage: String) {
+
+    prepare(signer: AuthAccount) {
+        let voterRef = signer.borrow<&FlowEpochClusterQC.Voter>(from: FlowEpochClusterQC.VoterStoragePath)
+            ?? panic("could not borrow reference to qc voter")
+
+        voterRef.vote(voteSignature: voteSignature, voteMessage: voteMessage)
+    }
+}
+`
+
+// nodeHasVotedScript is the script used to check whether a node has already
+// voted in the current epoch. The contract address is filled in when building
+// the script.
+const nodeHasVotedScript = `
+import FlowEpochClusterQC from 0x%s
+
+pub fun main(nodeID: String): Bool {
+    return FlowEpochClusterQC.nodeHasVoted(nodeID)
+}
+`
+
+// SDKClient is the subset of the Flow access API used to interact with the
+// epoch smart contracts. It is implemented by the Flow Go SDK client.
+type SDKClient interface {
+	GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*sdk.BlockHeader, error)
+	GetAccountAtLatestBlock(ctx context.Context, address sdk.Address, opts ...grpc.CallOption) (*sdk.Account, error)
+	SendTransaction(ctx context.Context, tx sdk.Transaction, opts ...grpc.CallOption) error
+	GetTransactionResult(ctx context.Context, txID sdk.Identifier, opts ...grpc.CallOption) (*sdk.TransactionResult, error)
+	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error)
+}
+
+// QCContractClient is a client to the cluster QC aggregator smart contract.
+// It submits votes as transactions signed with the node's account key, and
+// checks vote status with scripts, both through an access node.
+type QCContractClient struct {
+	log               zerolog.Logger
+	client            SDKClient        // client to the access API
+	nodeID            flow.Identifier  // ID of the voting node
+	accountAddress    sdk.Address      // address of the account used to vote
+	accountKeyIndex   uint             // index of the account key used to sign vote transactions
+	signer            sdkcrypto.Signer // signer for the account key
+	qcContractAddress sdk.Address      // address the QC aggregator contract is deployed to
+	submitTimeout     time.Duration    // how long we wait for a vote transaction to be sealed
+	retryTimeout      time.Duration    // how long we wait between two transaction status queries
+}
+
+// NewQCContractClient returns a new client to the QC aggregator contract.
+func NewQCContractClient(
+	log zerolog.Logger,
+	client SDKClient,
+	nodeID flow.Identifier,
+	accountAddress string,
+	accountKeyIndex uint,
+	qcContractAddress string,
+	signer sdkcrypto.Signer,
+) *QCContractClient {
+
+	return &QCContractClient{
+		log:               log.With().Str("module", "qc_contract_client").Logger(),
+		client:            client,
+		nodeID:            nodeID,
+		accountAddress:    sdk.HexToAddress(accountAddress),
+		accountKeyIndex:   accountKeyIndex,
+		signer:            signer,
+		qcContractAddress: sdk.HexToAddress(qcContractAddress),
+		submitTimeout:     TransactionSubmissionTimeout,
+		retryTimeout:      TransactionStatusRetryTimeout,
+	}
+}
+
+// SubmitVote submits the given vote to the cluster QC aggregator smart
+// contract. This function returns only once the transaction has been sealed.
+// An error is returned if the transaction has failed or expired, in which case
+// it should be re-submitted.
+func (c *QCContractClient) SubmitVote(ctx context.Context, vote *model.Vote) error {
+
+	// the reference block must be recent enough for the transaction to be valid
+	latestBlock, err := c.client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return fmt.Errorf("could not get latest block: %w", err)
+	}
+
+	// the sequence number of our account key is required to build the transaction
+	account, err := c.client.GetAccountAtLatestBlock(ctx, c.accountAddress)
+	if err != nil {
+		return fmt.Errorf("could not get account: %w", err)
+	}
+	if int(c.accountKeyIndex) >= len(account.Keys) {
+		return fmt.Errorf("account %s has no key at index %d", c.accountAddress, c.accountKeyIndex)
+	}
+	accountKey := account.Keys[c.accountKeyIndex]
+
+	tx := sdk.NewTransaction().
+		SetScript(c.submitVoteScript()).
+		SetGasLimit(SubmitVoteGasLimit).
+		SetReferenceBlockID(latestBlock.ID).
+		SetProposalKey(c.accountAddress, int(c.accountKeyIndex), accountKey.SequenceNumber).
+		SetPayer(c.accountAddress).
+		AddAuthorizer(c.accountAddress)
+
+	voteMessage := model.MakeVoteMessage(vote.View, vote.BlockID)
+	err = tx.AddArgument(cadence.NewString(hex.EncodeToString(vote.SigData)))
+	if err != nil {
+		return fmt.Errorf("could not add vote signature argument: %w", err)
+	}
+	err = tx.AddArgument(cadence.NewString(hex.EncodeToString(voteMessage)))
+	if err != nil {
+		return fmt.Errorf("could not add vote message argument: %w", err)
+	}
+
+	// the account is proposer, payer and authorizer, so a single envelope
+	// signature is sufficient
+	err = tx.SignEnvelope(c.accountAddress, int(c.accountKeyIndex), c.signer)
+	if err != nil {
+		return fmt.Errorf("could not sign vote transaction: %w", err)
+	}
+
+	err = c.client.SendTransaction(ctx, *tx)
+	if err != nil {
+		return fmt.Errorf("could not send vote transaction: %w", err)
+	}
+
+	c.log.Info().Str("tx_id", tx.ID().Hex()).Msg("vote transaction sent, waiting for it to be sealed")
+
+	return c.waitForSealed(ctx, tx.ID())
+}
+
+// Voted returns true if we have successfully submitted a vote to the cluster
+// QC aggregator smart contract for the current epoch.
+func (c *QCContractClient) Voted(ctx context.Context) (bool, error) {
+
+	arguments := []cadence.Value{cadence.NewString(c.nodeID.String())}
+	result, err := c.client.ExecuteScriptAtLatestBlock(ctx, c.nodeHasVotedScript(), arguments)
+	if err != nil {
+		return false, fmt.Errorf("could not execute vote status script: %w", err)
+	}
+
+	voted, ok := result.(cadence.Bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected vote status script result type (%T)", result)
+	}
+
+	return bool(voted), nil
+}
+
+// waitForSealed polls the status of the transaction with the given ID until
+// it is sealed, it has expired, or we time out. Errors querying the status are
+// logged and the query retried.
+func (c *QCContractClient) waitForSealed(ctx context.Context, txID sdk.Identifier) error {
+
+	ctx, cancel := context.WithTimeout(ctx, c.submitTimeout)
+	defer cancel()
+
+	for {
+		result, err := c.client.GetTransactionResult(ctx, txID)
+		if err != nil {
+			c.log.Warn().Err(err).Str("tx_id", txID.Hex()).Msg("could not get vote transaction result - retrying...")
+		} else {
+			switch result.Status {
+			case sdk.TransactionStatusSealed:
+				if result.Error != nil {
+					return fmt.Errorf("vote transaction failed: %w", result.Error)
+				}
+				return nil
+			case sdk.TransactionStatusExpired:
+				return fmt.Errorf("vote transaction expired")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("vote transaction not sealed: %w", ctx.Err())
+		case <-time.After(c.retryTimeout):
+		}
+	}
+}
+
+// submitVoteScript returns the vote transaction script for the configured
+// contract address.
+func (c *QCContractClient) submitVoteScript() []byte {
+	return []byte(fmt.Sprintf(submitVoteTransaction, c.qcContractAddress.Hex()))
+}
+
+// nodeHasVotedScript returns the vote status script for the configured
+// contract address.
+func (c *QCContractClient) nodeHasVotedScript() []byte {
+	return []byte(fmt.Sprintf(nodeHasVotedScript, c.qcContractAddress.Hex()))
+}
@@ -0,0 +1,335 @@
+package epochs
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testQCContract is a minimal version of the FlowEpochClusterQC contract,
+// exposing the interface used by the vote transaction and the vote status
+// script.
+const testQCContract = `
+pub contract FlowEpochClusterQC {
+
+    pub let VoterStoragePath: StoragePath
+
+    access(contract) var votes: {String: String}
+
+    pub resource Voter {
+
+        pub let nodeID: String
+
+        pub fun vote(voteSignature: String, voteMessage: String) {
+            pre {
+                !FlowEpochClusterQC.nodeHasVoted(self.nodeID): "node has already voted"
+            }
+            FlowEpochClusterQC.votes[self.nodeID] = voteSignature
+        }
+
+        init(nodeID: String) {
+            self.nodeID = nodeID
+        }
+    }
+
+    pub fun createVoter(nodeID: String): @Voter {
+        return <-create Voter(nodeID: nodeID)
+    }
+
+    pub fun nodeHasVoted(_ nodeID: String): Bool {
+        return self.votes[nodeID] != nil
+    }
+
+    init() {
+        self.VoterStoragePath = /storage/flowEpochClusterQCVoter
+        self.votes = {}
+    }
+}
+`
+
+// fvmAccessAPI is an in-process access API backed by the FVM. Transactions
+// are executed as soon as they are sent, with signature and sequence number
+// checks, and are reported as sealed.
+type fvmAccessAPI struct {
+	sync.Mutex
+	vm       *fvm.VirtualMachine
+	ctx      fvm.Context
+	ledger   state.Ledger
+	programs *fvm.Programs
+	height   uint64
+	results  map[sdk.Identifier]*sdk.TransactionResult
+}
+
+func newFVMAccessAPI(chain flow.Chain) *fvmAccessAPI {
+	return &fvmAccessAPI{
+		vm: fvm.New(runtime.NewInterpreterRuntime()),
+		ctx: fvm.NewContext(
+			zerolog.Nop(),
+			fvm.WithChain(chain),
+			fvm.WithTransactionProcessors(
+				fvm.NewTransactionSignatureVerifier(fvm.AccountKeyWeightThreshold),
+				fvm.NewTransactionSequenceNumberChecker(),
+				fvm.NewTransactionInvocator(zerolog.Nop()),
+			),
+		),
+		ledger:   state.NewMapLedger(),
+		programs: fvm.NewEmptyPrograms(),
+		results:  make(map[sdk.Identifier]*sdk.TransactionResult),
+	}
+}
+
+func (f *fvmAccessAPI) GetLatestBlockHeader(_ context.Context, _ bool, _ ...grpc.CallOption) (*sdk.BlockHeader, error) {
+	f.Lock()
+	defer f.Unlock()
+	return &sdk.BlockHeader{ID: sdk.Identifier(unittest.IdentifierFixture()), Height: f.height}, nil
+}
+
+func (f *fvmAccessAPI) GetAccountAtLatestBlock(_ context.Context, address sdk.Address, _ ...grpc.CallOption) (*sdk.Account, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	account, err := f.vm.GetAccount(f.ctx, flow.Address(address), f.ledger, f.programs)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*sdk.AccountKey, 0, len(account.Keys))
+	for _, key := range account.Keys {
+		keys = append(keys, &sdk.AccountKey{
+			Index:          key.Index,
+			Weight:         key.Weight,
+			SequenceNumber: key.SeqNumber,
+			Revoked:        key.Revoked,
+		})
+	}
+	return &sdk.Account{Address: address, Keys: keys}, nil
+}
+
+func (f *fvmAccessAPI) SendTransaction(_ context.Context, tx sdk.Transaction, _ ...grpc.CallOption) error {
+	f.Lock()
+	defer f.Unlock()
+
+	txBody := flow.NewTransactionBody().
+		SetScript(tx.Script).
+		SetArguments(tx.Arguments).
+		SetReferenceBlockID(flow.Identifier(tx.ReferenceBlockID)).
+		SetGasLimit(tx.GasLimit).
+		SetProposalKey(flow.Address(tx.ProposalKey.Address), uint64(tx.ProposalKey.KeyIndex), tx.ProposalKey.SequenceNumber).
+		SetPayer(flow.Address(tx.Payer))
+	for _, authorizer := range tx.Authorizers {
+		txBody.AddAuthorizer(flow.Address(authorizer))
+	}
+	for _, sig := range tx.PayloadSignatures {
+		txBody.AddPayloadSignature(flow.Address(sig.Address), uint64(sig.KeyIndex), sig.Signature)
+	}
+	for _, sig := range tx.EnvelopeSignatures {
+		txBody.AddEnvelopeSignature(flow.Address(sig.Address), uint64(sig.KeyIndex), sig.Signature)
+	}
+
+	proc := fvm.Transaction(txBody, 0)
+	err := f.vm.Run(f.ctx, proc, f.ledger, f.programs)
+	if err != nil {
+		return err
+	}
+	f.height++
+
+	result := &sdk.TransactionResult{Status: sdk.TransactionStatusSealed}
+	if proc.Err != nil {
+		result.Error = proc.Err
+	}
+	f.results[tx.ID()] = result
+
+	return nil
+}
+
+func (f *fvmAccessAPI) GetTransactionResult(_ context.Context, txID sdk.Identifier, _ ...grpc.CallOption) (*sdk.TransactionResult, error) {
+	f.Lock()
+	defer f.Unlock()
+	result, ok := f.results[txID]
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction %s", txID)
+	}
+	return result, nil
+}
+
+func (f *fvmAccessAPI) ExecuteScriptAtLatestBlock(_ context.Context, script []byte, arguments []cadence.Value, _ ...grpc.CallOption) (cadence.Value, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	args := make([][]byte, 0, len(arguments))
+	for _, arg := range arguments {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, encoded)
+	}
+
+	proc := fvm.Script(script).WithArguments(args...)
+	err := f.vm.Run(f.ctx, proc, f.ledger, f.programs)
+	if err != nil {
+		return nil, err
+	}
+	if proc.Err != nil {
+		return nil, proc.Err
+	}
+	return proc.Value, nil
+}
+
+// setup executes the given transaction without signature and sequence number
+// checks, and returns its events.
+func (f *fvmAccessAPI) setup(t *testing.T, txBody *flow.TransactionBody) []flow.Event {
+	ctx := fvm.NewContextFromParent(f.ctx, fvm.WithTransactionProcessors(fvm.NewTransactionInvocator(zerolog.Nop())))
+	proc := fvm.Transaction(txBody, 0)
+	err := f.vm.Run(ctx, proc, f.ledger, f.programs)
+	require.NoError(t, err)
+	require.NoError(t, proc.Err)
+	return proc.Events
+}
+
+// setupQCContract bootstraps the chain, deploys the QC contract to the service
+// account and creates an account holding a QC voter resource for the given
+// node. It returns the voter account address and a signer for its key.
+func setupQCContract(t *testing.T, api *fvmAccessAPI, chain flow.Chain, nodeID flow.Identifier) (flow.Address, sdkcrypto.Signer) {
+
+	err := api.vm.Run(
+		api.ctx,
+		fvm.Bootstrap(unittest.ServiceAccountPublicKey, fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply)),
+		api.ledger,
+		api.programs,
+	)
+	require.NoError(t, err)
+
+	serviceAddress := chain.ServiceAddress()
+
+	// deploy the QC contract to the service account
+	deploy := flow.NewTransactionBody().
+		SetScript([]byte(fmt.Sprintf(`
+			transaction {
+				prepare(signer: AuthAccount) {
+					signer.contracts.add(name: "FlowEpochClusterQC", code: "%s".decodeHex())
+				}
+			}`, hex.EncodeToString([]byte(testQCContract))))).
+		AddAuthorizer(serviceAddress)
+	api.setup(t, deploy)
+
+	// create the voter account with an ECDSA key
+	seed := make([]byte, sdkcrypto.MinSeedLength)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	sk, err := sdkcrypto.GeneratePrivateKey(sdkcrypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+	pk, err := crypto.DecodePublicKey(crypto.ECDSAP256, sk.PublicKey().Encode())
+	require.NoError(t, err)
+	accountKey := flow.AccountPublicKey{
+		PublicKey: pk,
+		SignAlgo:  crypto.ECDSAP256,
+		HashAlgo:  hash.SHA3_256,
+		Weight:    fvm.AccountKeyWeightThreshold,
+	}
+	encodedKey, err := flow.EncodeRuntimeAccountPublicKey(accountKey)
+	require.NoError(t, err)
+	keyBytes := make([]cadence.Value, 0, len(encodedKey))
+	for _, b := range encodedKey {
+		keyBytes = append(keyBytes, cadence.NewUInt8(b))
+	}
+	keyArg, err := jsoncdc.Encode(cadence.NewArray(keyBytes))
+	require.NoError(t, err)
+
+	create := flow.NewTransactionBody().
+		SetScript([]byte(`
+			transaction(publicKey: [UInt8]) {
+				prepare(signer: AuthAccount) {
+					let account = AuthAccount(payer: signer)
+					account.addPublicKey(publicKey)
+				}
+			}`)).
+		AddArgument(keyArg).
+		AddAuthorizer(serviceAddress)
+	events := api.setup(t, create)
+
+	var voterAddress flow.Address
+	for _, event := range events {
+		if event.Type == flow.EventAccountCreated {
+			data, err := jsoncdc.Decode(event.Payload)
+			require.NoError(t, err)
+			voterAddress = flow.Address(data.(cadence.Event).Fields[0].(cadence.Address))
+		}
+	}
+	require.NotEqual(t, flow.EmptyAddress, voterAddress)
+
+	// store a voter resource for the node in the voter account
+	nodeIDArg, err := jsoncdc.Encode(cadence.NewString(nodeID.String()))
+	require.NoError(t, err)
+	voter := flow.NewTransactionBody().
+		SetScript([]byte(fmt.Sprintf(`
+			import FlowEpochClusterQC from 0x%s
+
+			transaction(nodeID: String) {
+				prepare(signer: AuthAccount) {
+					signer.save(<-FlowEpochClusterQC.createVoter(nodeID: nodeID), to: FlowEpochClusterQC.VoterStoragePath)
+				}
+			}`, serviceAddress.Hex()))).
+		AddArgument(nodeIDArg).
+		AddAuthorizer(voterAddress)
+	api.setup(t, voter)
+
+	return voterAddress, sdkcrypto.NewInMemorySigner(sk, sdkcrypto.SHA3_256)
+}
+
+// TestSubmitVote_FVM tests the vote transaction and the vote status script
+// against the QC contract deployed on an in-process FVM.
+func TestSubmitVote_FVM(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	api := newFVMAccessAPI(chain)
+	nodeID := unittest.IdentifierFixture()
+
+	voterAddress, signer := setupQCContract(t, api, chain, nodeID)
+
+	client := NewQCContractClient(zerolog.Nop(), api, nodeID, voterAddress.Hex(), 0, chain.ServiceAddress().Hex(), signer)
+	client.retryTimeout = 10 * time.Millisecond
+	ctx := context.Background()
+
+	voted, err := client.Voted(ctx)
+	require.NoError(t, err)
+	assert.False(t, voted)
+
+	vote := &model.Vote{
+		View:    10,
+		BlockID: unittest.IdentifierFixture(),
+		SigData: unittest.SignatureFixture(),
+	}
+	err = client.SubmitVote(ctx, vote)
+	require.NoError(t, err)
+
+	voted, err = client.Voted(ctx)
+	require.NoError(t, err)
+	assert.True(t, voted)
+
+	// the contract rejects a second vote, and the failed transaction must
+	// be reported as such
+	err = client.SubmitVote(ctx, vote)
+	assert.Error(t, err)
+}
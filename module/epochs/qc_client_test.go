@@ -0,0 +1,187 @@
+package epochs
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fakeAccessAPI is an in-process stand-in for an access node with the QC
+// aggregator contract deployed. It verifies the envelope signature of vote
+// transactions and records the submitted vote.
+type fakeAccessAPI struct {
+	sync.Mutex
+	address   sdk.Address
+	key       *sdk.AccountKey
+	status    sdk.TransactionStatus // status of submitted transactions
+	txErr     error                 // execution error of submitted transactions
+	votes     map[string]string     // vote signature -> vote message
+	submitted map[sdk.Identifier]struct{}
+}
+
+func (f *fakeAccessAPI) GetLatestBlockHeader(_ context.Context, _ bool, _ ...grpc.CallOption) (*sdk.BlockHeader, error) {
+	return &sdk.BlockHeader{ID: sdk.Identifier(unittest.IdentifierFixture()), Height: 100}, nil
+}
+
+func (f *fakeAccessAPI) GetAccountAtLatestBlock(_ context.Context, address sdk.Address, _ ...grpc.CallOption) (*sdk.Account, error) {
+	f.Lock()
+	defer f.Unlock()
+	if address != f.address {
+		return nil, fmt.Errorf("unknown account %s", address)
+	}
+	key := *f.key
+	return &sdk.Account{Address: f.address, Keys: []*sdk.AccountKey{&key}}, nil
+}
+
+func (f *fakeAccessAPI) SendTransaction(_ context.Context, tx sdk.Transaction, _ ...grpc.CallOption) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if tx.ProposalKey.SequenceNumber != f.key.SequenceNumber {
+		return fmt.Errorf("invalid sequence number")
+	}
+	if len(tx.EnvelopeSignatures) != 1 {
+		return fmt.Errorf("expected one envelope signature")
+	}
+	hasher, err := sdkcrypto.NewHasher(f.key.HashAlgo)
+	if err != nil {
+		return err
+	}
+	valid, err := f.key.PublicKey.Verify(tx.EnvelopeSignatures[0].Signature, tx.EnvelopeMessage(), hasher)
+	if err != nil || !valid {
+		return fmt.Errorf("invalid envelope signature")
+	}
+
+	sig, err := tx.Argument(0)
+	if err != nil {
+		return err
+	}
+	msg, err := tx.Argument(1)
+	if err != nil {
+		return err
+	}
+
+	f.key.SequenceNumber++
+	f.submitted[tx.ID()] = struct{}{}
+	if f.status == sdk.TransactionStatusSealed && f.txErr == nil {
+		f.votes[string(sig.(cadence.String))] = string(msg.(cadence.String))
+	}
+	return nil
+}
+
+func (f *fakeAccessAPI) GetTransactionResult(_ context.Context, txID sdk.Identifier, _ ...grpc.CallOption) (*sdk.TransactionResult, error) {
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.submitted[txID]; !ok {
+		return nil, fmt.Errorf("unknown transaction %s", txID)
+	}
+	return &sdk.TransactionResult{Status: f.status, Error: f.txErr}, nil
+}
+
+func (f *fakeAccessAPI) ExecuteScriptAtLatestBlock(_ context.Context, _ []byte, _ []cadence.Value, _ ...grpc.CallOption) (cadence.Value, error) {
+	f.Lock()
+	defer f.Unlock()
+	return cadence.NewBool(len(f.votes) > 0), nil
+}
+
+// setupQCClient returns a QC contract client connected to a fake access API.
+func setupQCClient(t *testing.T) (*QCContractClient, *fakeAccessAPI) {
+
+	seed := make([]byte, sdkcrypto.MinSeedLength)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	sk, err := sdkcrypto.GeneratePrivateKey(sdkcrypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+
+	api := &fakeAccessAPI{
+		address: sdk.HexToAddress("01"),
+		key: &sdk.AccountKey{
+			PublicKey: sk.PublicKey(),
+			SigAlgo:   sdkcrypto.ECDSA_P256,
+			HashAlgo:  sdkcrypto.SHA3_256,
+			Weight:    sdk.AccountKeyWeightThreshold,
+		},
+		status:    sdk.TransactionStatusSealed,
+		votes:     make(map[string]string),
+		submitted: make(map[sdk.Identifier]struct{}),
+	}
+
+	signer := sdkcrypto.NewInMemorySigner(sk, sdkcrypto.SHA3_256)
+	client := NewQCContractClient(zerolog.Nop(), api, unittest.IdentifierFixture(), api.address.Hex(), 0, "02", signer)
+	client.retryTimeout = 10 * time.Millisecond
+
+	return client, api
+}
+
+// TestSubmitVote tests that a sealed vote transaction is reported as
+// successful, and that the contract records the expected vote.
+func TestSubmitVote(t *testing.T) {
+	client, api := setupQCClient(t)
+	ctx := context.Background()
+
+	voted, err := client.Voted(ctx)
+	require.NoError(t, err)
+	assert.False(t, voted)
+
+	vote := &model.Vote{
+		View:    10,
+		BlockID: unittest.IdentifierFixture(),
+		SigData: unittest.SignatureFixture(),
+	}
+	err = client.SubmitVote(ctx, vote)
+	require.NoError(t, err)
+
+	voted, err = client.Voted(ctx)
+	require.NoError(t, err)
+	assert.True(t, voted)
+
+	msg, ok := api.votes[hex.EncodeToString(vote.SigData)]
+	require.True(t, ok)
+	assert.Equal(t, hex.EncodeToString(model.MakeVoteMessage(vote.View, vote.BlockID)), msg)
+}
+
+// TestSubmitVote_Expired tests that an expired vote transaction results in
+// an error.
+func TestSubmitVote_Expired(t *testing.T) {
+	client, api := setupQCClient(t)
+	api.status = sdk.TransactionStatusExpired
+
+	err := client.SubmitVote(context.Background(), &model.Vote{BlockID: unittest.IdentifierFixture()})
+	assert.Error(t, err)
+}
+
+// TestSubmitVote_Failed tests that a vote transaction which failed execution
+// results in an error.
+func TestSubmitVote_Failed(t *testing.T) {
+	client, api := setupQCClient(t)
+	api.txErr = fmt.Errorf("execution failed")
+
+	err := client.SubmitVote(context.Background(), &model.Vote{BlockID: unittest.IdentifierFixture()})
+	assert.Error(t, err)
+}
+
+// TestSubmitVote_Timeout tests that a vote transaction that is never sealed
+// results in an error once the submission timeout elapses.
+func TestSubmitVote_Timeout(t *testing.T) {
+	client, api := setupQCClient(t)
+	api.status = sdk.TransactionStatusPending
+	client.submitTimeout = 50 * time.Millisecond
+
+	err := client.SubmitVote(context.Background(), &model.Vote{BlockID: unittest.IdentifierFixture()})
+	assert.Error(t, err)
+}
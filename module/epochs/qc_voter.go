@@ -55,6 +55,10 @@ func NewRootQCVoter(
 // It is safe to run multiple times within a single setup phase.
 func (voter *RootQCVoter) Vote(ctx context.Context, epoch protocol.Epoch) error {
 
+	if voter.client == nil {
+		return fmt.Errorf("could not vote - no qc contract client configured")
+	}
+
 	counter, err := epoch.Counter()
 	if err != nil {
 		return fmt.Errorf("could not get epoch counter: %w", err)
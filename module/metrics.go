@@ -20,6 +20,27 @@ type NetworkMetrics interface {
 	// NetworkDuplicateMessagesDropped counts number of messages dropped due to duplicate detection
 	NetworkDuplicateMessagesDropped(topic string, messageType string)
 
+	// NetworkUnauthenticatedMessagesDropped counts number of messages dropped because their origin could not be authenticated
+	NetworkUnauthenticatedMessagesDropped(topic string, messageType string)
+
+	// NetworkUnauthorizedMessagesDropped counts number of messages dropped because their origin may not send them on their channel
+	NetworkUnauthorizedMessagesDropped(topic string, messageType string)
+
+	// NetworkRateLimitedMessagesDropped counts number of messages dropped because they exceeded a rate limit of their peer
+	NetworkRateLimitedMessagesDropped(topic string, messageType string, limit string)
+
+	// NetworkMessageCompressed tracks the compression ratio of an outgoing unicast message, and the time spent compressing it
+	NetworkMessageCompressed(messageType string, compression string, ratio float64, duration time.Duration)
+
+	// PeerMisbehaviorReported counts misbehavior of another node reported by engines on a topic, with a severity
+	PeerMisbehaviorReported(nodeID string, topic string, severity string)
+
+	// PeerScore tracks the score of another node, which is lowered by its misbehavior and decays over time
+	PeerScore(nodeID string, score float64)
+
+	// PeerPenalized counts the penalties imposed on another node because of its low score
+	PeerPenalized(nodeID string, penalty string)
+
 	// Message receive queue metrics
 	// MessageAdded increments the metric tracking the number of messages in the queue with the given priority
 	MessageAdded(priority int)
@@ -58,6 +79,12 @@ type CleanerMetrics interface {
 	RanGC(took time.Duration)
 }
 
+type PrunerMetrics interface {
+	// BlocksPruned records the number of blocks removed from storage by a
+	// pruning run, and the root height of the protocol state after the run.
+	BlocksPruned(count uint, rootHeight uint64)
+}
+
 type CacheMetrics interface {
 	CacheEntries(resource string, entries uint)
 	CacheHit(resource string)
@@ -308,6 +335,21 @@ type ExecutionMetrics interface {
 
 	// ExecutionSync reports when the state syncing is triggered or stopped.
 	ExecutionSync(syncing bool)
+
+	// ExecutionSyncTargetHeight reports the sealed height the state syncing is catching up to
+	ExecutionSyncTargetHeight(height uint64)
+
+	// ExecutionSyncDeltaApplied reports when a state delta received from a peer was applied
+	ExecutionSyncDeltaApplied()
+
+	// ExecutionSyncDeltaRejected reports when a state delta received from a peer was rejected as invalid
+	ExecutionSyncDeltaRejected()
+
+	// ExecutionStopHeight reports the height of the last block to execute before stopping, or 0 if none is set
+	ExecutionStopHeight(height uint64)
+
+	// ExecutionStopped reports when the execution stopped at the stop height, or was resumed
+	ExecutionStopped(stopped bool)
 }
 
 type TransactionMetrics interface {
@@ -329,6 +371,28 @@ type TransactionMetrics interface {
 	TransactionSubmissionFailed()
 }
 
+type ConnectionPoolMetrics interface {
+	// ConnectionFromPoolReused tracks requests served by a cached connection.
+	ConnectionFromPoolReused()
+
+	// NewConnectionEstablished tracks requests for which a new connection had to be created.
+	NewConnectionEstablished()
+
+	// ConnectionFromPoolEvicted tracks cached connections closed for being idle or unhealthy.
+	ConnectionFromPoolEvicted()
+
+	// ConnectionRejected tracks requests rejected because the circuit breaker of the node is open.
+	ConnectionRejected()
+
+	// TotalConnectionsInPool tracks the number of cached connections.
+	TotalConnectionsInPool(count uint)
+}
+
+type ExecutionQueryMetrics interface {
+	// ExecutionResultMismatch tracks requests for which execution nodes returned diverging results.
+	ExecutionResultMismatch(method string)
+}
+
 type PingMetrics interface {
 	// NodeReachable tracks the round trip time in milliseconds taken to ping a node
 	// The nodeInfo provides additional information about the node such as the name of the node operator
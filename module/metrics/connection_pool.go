@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type ConnectionPoolCollector struct {
+	connectionReused      prometheus.Counter
+	connectionEstablished prometheus.Counter
+	connectionEvicted     prometheus.Counter
+	connectionRejected    prometheus.Counter
+	connectionsInPool     prometheus.Gauge
+}
+
+func NewConnectionPoolCollector() *ConnectionPoolCollector {
+	cc := &ConnectionPoolCollector{
+		connectionReused: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Name:      "connection_reused_total",
+			Help:      "the number of requests served by a cached connection",
+		}),
+		connectionEstablished: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Name:      "connection_established_total",
+			Help:      "the number of new connections created because no cached connection was available",
+		}),
+		connectionEvicted: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Name:      "connection_evicted_total",
+			Help:      "the number of cached connections closed for being idle or unhealthy",
+		}),
+		connectionRejected: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Name:      "connection_rejected_total",
+			Help:      "the number of requests rejected by the circuit breaker of a failing node",
+		}),
+		connectionsInPool: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Name:      "connections_in_pool",
+			Help:      "the number of cached connections",
+		}),
+	}
+	return cc
+}
+
+func (cc *ConnectionPoolCollector) ConnectionFromPoolReused() {
+	cc.connectionReused.Inc()
+}
+
+func (cc *ConnectionPoolCollector) NewConnectionEstablished() {
+	cc.connectionEstablished.Inc()
+}
+
+func (cc *ConnectionPoolCollector) ConnectionFromPoolEvicted() {
+	cc.connectionEvicted.Inc()
+}
+
+func (cc *ConnectionPoolCollector) ConnectionRejected() {
+	cc.connectionRejected.Inc()
+}
+
+func (cc *ConnectionPoolCollector) TotalConnectionsInPool(count uint) {
+	cc.connectionsInPool.Set(float64(count))
+}
@@ -48,6 +48,11 @@ type ExecutionCollector struct {
 	transactionInterpretTime         prometheus.Histogram
 	totalChunkDataPackRequests       prometheus.Counter
 	stateSyncActive                  prometheus.Gauge
+	stateSyncTargetHeight            prometheus.Gauge
+	stateSyncDeltasApplied           prometheus.Counter
+	stateSyncDeltasRejected          prometheus.Counter
+	stopHeight                       prometheus.Gauge
+	stopped                          prometheus.Gauge
 	executionStateDiskUsage          prometheus.Gauge
 }
 
@@ -311,6 +316,41 @@ func NewExecutionCollector(tracer module.Tracer, registerer prometheus.Registere
 			Help:      "indicates if the state sync is active",
 		}),
 
+		stateSyncTargetHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "state_sync_target_height",
+			Help:      "the sealed height the state sync is catching up to",
+		}),
+
+		stateSyncDeltasApplied: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "state_sync_deltas_applied_total",
+			Help:      "the number of state deltas received from peers which were applied",
+		}),
+
+		stateSyncDeltasRejected: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "state_sync_deltas_rejected_total",
+			Help:      "the number of state deltas received from peers which were rejected as invalid",
+		}),
+
+		stopHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "stop_height",
+			Help:      "the height of the last block to execute before stopping, 0 if not set",
+		}),
+
+		stopped: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "stopped",
+			Help:      "indicates if the execution stopped at the stop height",
+		}),
+
 		executionStateDiskUsage: promauto.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemMTrie,
@@ -481,6 +521,30 @@ func (ec *ExecutionCollector) ExecutionSync(syncing bool) {
 	ec.stateSyncActive.Set(float64(0))
 }
 
+func (ec *ExecutionCollector) ExecutionSyncTargetHeight(height uint64) {
+	ec.stateSyncTargetHeight.Set(float64(height))
+}
+
+func (ec *ExecutionCollector) ExecutionSyncDeltaApplied() {
+	ec.stateSyncDeltasApplied.Inc()
+}
+
+func (ec *ExecutionCollector) ExecutionSyncDeltaRejected() {
+	ec.stateSyncDeltasRejected.Inc()
+}
+
+func (ec *ExecutionCollector) ExecutionStopHeight(height uint64) {
+	ec.stopHeight.Set(float64(height))
+}
+
+func (ec *ExecutionCollector) ExecutionStopped(stopped bool) {
+	if stopped {
+		ec.stopped.Set(float64(1))
+		return
+	}
+	ec.stopped.Set(float64(0))
+}
+
 func (ec *ExecutionCollector) DiskSize(bytes uint64) {
 	ec.executionStateDiskUsage.Set(float64(bytes))
 }
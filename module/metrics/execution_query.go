@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type ExecutionQueryCollector struct {
+	resultMismatch *prometheus.CounterVec
+}
+
+func NewExecutionQueryCollector() *ExecutionQueryCollector {
+	ec := &ExecutionQueryCollector{
+		resultMismatch: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemExecutionQuery,
+			Name:      "result_mismatch_total",
+			Help:      "the number of requests for which execution nodes returned diverging results",
+		}, []string{"method"}),
+	}
+	return ec
+}
+
+func (ec *ExecutionQueryCollector) ExecutionResultMismatch(method string) {
+	ec.resultMismatch.WithLabelValues(method).Inc()
+}
@@ -11,6 +11,10 @@ const (
 	LabelNodeRole    = "noderole"
 	LabelNodeInfo    = "nodeinfo"
 	LabelPriority    = "priority"
+	LabelSeverity    = "severity"
+	LabelPenalty     = "penalty"
+	LabelLimit       = "limit"
+	LabelCompression = "compression"
 )
 
 const (
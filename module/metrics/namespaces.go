@@ -18,6 +18,7 @@ const (
 	subsystemGossip = "gossip"
 	subsystemEngine = "engine"
 	subsystemQueue  = "queue"
+	subsystemScore  = "peer_score"
 )
 
 // Storage subsystems represent the various components of the storage layer.
@@ -31,6 +32,8 @@ const (
 const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
+	subsystemConnectionPool        = "connection_pool"
+	subsystemExecutionQuery        = "execution_query"
 )
 
 // Collection subsystem
@@ -19,6 +19,14 @@ type NetworkCollector struct {
 	outboundMessageSize      *prometheus.HistogramVec
 	inboundMessageSize       *prometheus.HistogramVec
 	duplicateMessagesDropped *prometheus.CounterVec
+	unauthenticatedDropped   *prometheus.CounterVec
+	unauthorizedDropped      *prometheus.CounterVec
+	rateLimitedDropped       *prometheus.CounterVec
+	compressionRatio         *prometheus.HistogramVec
+	compressionDuration      *prometheus.HistogramVec
+	misbehaviorReported      *prometheus.CounterVec
+	peerScore                *prometheus.GaugeVec
+	peerPenalties            *prometheus.CounterVec
 	queueSize                *prometheus.GaugeVec
 	queueDuration            *prometheus.HistogramVec
 	inboundProcessTime       *prometheus.CounterVec
@@ -53,6 +61,64 @@ func NewNetworkCollector() *NetworkCollector {
 			Help:      "number of duplicate messages dropped",
 		}, []string{LabelChannel, LabelMessage}),
 
+		unauthenticatedDropped: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "unauthenticated_messages_dropped",
+			Help:      "number of messages dropped because their origin could not be authenticated",
+		}, []string{LabelChannel, LabelMessage}),
+
+		unauthorizedDropped: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "unauthorized_messages_dropped",
+			Help:      "number of messages dropped because their origin may not send them on their channel",
+		}, []string{LabelChannel, LabelMessage}),
+
+		rateLimitedDropped: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "rate_limited_messages_dropped",
+			Help:      "number of messages dropped because they exceeded a rate limit of their peer",
+		}, []string{LabelChannel, LabelMessage, LabelLimit}),
+
+		compressionRatio: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "outbound_message_compression_ratio",
+			Help:      "ratio of the size of outbound unicast messages to their compressed size",
+			Buckets:   []float64{1, 1.5, 2, 3, 5, 10},
+		}, []string{LabelMessage, LabelCompression}),
+
+		compressionDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "outbound_message_compression_duration_seconds",
+			Help:      "duration [seconds; measured with float64 precision] of the compression of outbound unicast messages",
+			Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 5}, // 1ms, 10ms, 100ms, 500ms, 1s, 5s
+		}, []string{LabelMessage, LabelCompression}),
+
+		misbehaviorReported: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemScore,
+			Name:      "misbehavior_reported_total",
+			Help:      "number of misbehaviors of other nodes reported by engines",
+		}, []string{LabelNodeID, LabelChannel, LabelSeverity}),
+
+		peerScore: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemScore,
+			Name:      "score",
+			Help:      "the score of other nodes, which is lowered by their misbehavior and decays back to zero over time",
+		}, []string{LabelNodeID}),
+
+		peerPenalties: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemScore,
+			Name:      "penalties_total",
+			Help:      "number of penalties imposed on other nodes because of their low score",
+		}, []string{LabelNodeID, LabelPenalty}),
+
 		queueSize: promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespaceNetwork,
 			Subsystem: subsystemQueue,
@@ -110,6 +176,46 @@ func (nc *NetworkCollector) NetworkDuplicateMessagesDropped(topic, messageType s
 	nc.duplicateMessagesDropped.WithLabelValues(topic, messageType).Add(1)
 }
 
+// NetworkUnauthenticatedMessagesDropped tracks the number of messages dropped by the network layer because
+// they were not delivered by the node they claim to originate from
+func (nc *NetworkCollector) NetworkUnauthenticatedMessagesDropped(topic, messageType string) {
+	nc.unauthenticatedDropped.WithLabelValues(topic, messageType).Add(1)
+}
+
+// NetworkUnauthorizedMessagesDropped tracks the number of messages dropped by the network layer because
+// the role of their origin is not involved in their channel
+func (nc *NetworkCollector) NetworkUnauthorizedMessagesDropped(topic, messageType string) {
+	nc.unauthorizedDropped.WithLabelValues(topic, messageType).Add(1)
+}
+
+// NetworkRateLimitedMessagesDropped tracks the number of messages dropped by the network layer because
+// they exceeded the given rate limit of the peer they were received from
+func (nc *NetworkCollector) NetworkRateLimitedMessagesDropped(topic, messageType, limit string) {
+	nc.rateLimitedDropped.WithLabelValues(topic, messageType, limit).Add(1)
+}
+
+// NetworkMessageCompressed tracks the compression ratio of an outbound unicast message, and the time spent
+// compressing it with the given algorithm
+func (nc *NetworkCollector) NetworkMessageCompressed(messageType, compression string, ratio float64, duration time.Duration) {
+	nc.compressionRatio.WithLabelValues(messageType, compression).Observe(ratio)
+	nc.compressionDuration.WithLabelValues(messageType, compression).Observe(duration.Seconds())
+}
+
+// PeerMisbehaviorReported tracks the number of misbehaviors of another node reported by engines
+func (nc *NetworkCollector) PeerMisbehaviorReported(nodeID string, topic string, severity string) {
+	nc.misbehaviorReported.WithLabelValues(nodeID, topic, severity).Inc()
+}
+
+// PeerScore tracks the score of another node
+func (nc *NetworkCollector) PeerScore(nodeID string, score float64) {
+	nc.peerScore.WithLabelValues(nodeID).Set(score)
+}
+
+// PeerPenalized tracks the number of penalties imposed on another node
+func (nc *NetworkCollector) PeerPenalized(nodeID string, penalty string) {
+	nc.peerPenalties.WithLabelValues(nodeID, penalty).Inc()
+}
+
 func (nc *NetworkCollector) MessageAdded(priority int) {
 	nc.queueSize.WithLabelValues(strconv.Itoa(priority)).Inc()
 }
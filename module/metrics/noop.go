@@ -18,6 +18,13 @@ func NewNoopCollector() *NoopCollector {
 func (nc *NoopCollector) NetworkMessageSent(sizeBytes int, topic string, messageType string)     {}
 func (nc *NoopCollector) NetworkMessageReceived(sizeBytes int, topic string, messageType string) {}
 func (nc *NoopCollector) NetworkDuplicateMessagesDropped(topic string, messageType string)       {}
+func (nc *NoopCollector) NetworkUnauthenticatedMessagesDropped(topic string, messageType string) {}
+func (nc *NoopCollector) NetworkUnauthorizedMessagesDropped(topic string, messageType string)    {}
+func (nc *NoopCollector) NetworkRateLimitedMessagesDropped(topic, messageType, limit string)     {}
+func (nc *NoopCollector) NetworkMessageCompressed(_, _ string, _ float64, _ time.Duration)       {}
+func (nc *NoopCollector) PeerMisbehaviorReported(nodeID string, topic string, severity string)   {}
+func (nc *NoopCollector) PeerScore(nodeID string, score float64)                                 {}
+func (nc *NoopCollector) PeerPenalized(nodeID string, penalty string)                            {}
 func (nc *NoopCollector) MessageAdded(priority int)                                              {}
 func (nc *NoopCollector) MessageRemoved(priority int)                                            {}
 func (nc *NoopCollector) QueueDuration(duration time.Duration, priority int)                     {}
@@ -28,6 +35,7 @@ func (nc *NoopCollector) MessageHandled(engine string, message string)
 func (nc *NoopCollector) OutboundConnections(_ uint)                                             {}
 func (nc *NoopCollector) InboundConnections(_ uint)                                              {}
 func (nc *NoopCollector) RanGC(duration time.Duration)                                           {}
+func (nc *NoopCollector) BlocksPruned(count uint, rootHeight uint64)                             {}
 func (nc *NoopCollector) BadgerLSMSize(sizeBytes int64)                                          {}
 func (nc *NoopCollector) BadgerVLogSize(sizeBytes int64)                                         {}
 func (nc *NoopCollector) BadgerNumReads(n int64)                                                 {}
@@ -115,6 +123,17 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)               {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                                {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                                           {}
+func (nc *NoopCollector) ConnectionFromPoolReused()                                              {}
+func (nc *NoopCollector) NewConnectionEstablished()                                              {}
+func (nc *NoopCollector) ConnectionFromPoolEvicted()                                             {}
+func (nc *NoopCollector) ConnectionRejected()                                                    {}
+func (nc *NoopCollector) TotalConnectionsInPool(count uint)                                      {}
+func (nc *NoopCollector) ExecutionResultMismatch(method string)                                  {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                                {}
 func (nc *NoopCollector) ExecutionSync(syncing bool)                                             {}
+func (nc *NoopCollector) ExecutionSyncTargetHeight(height uint64)                                {}
+func (nc *NoopCollector) ExecutionSyncDeltaApplied()                                             {}
+func (nc *NoopCollector) ExecutionSyncDeltaRejected()                                            {}
+func (nc *NoopCollector) ExecutionStopHeight(height uint64)                                      {}
+func (nc *NoopCollector) ExecutionStopped(stopped bool)                                          {}
 func (nc *NoopCollector) DiskSize(uint64)                                                        {}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type PrunerCollector struct {
+	prunedBlocks prometheus.Counter
+	rootHeight   prometheus.Gauge
+}
+
+func NewPrunerCollector() *PrunerCollector {
+	pc := &PrunerCollector{
+		prunedBlocks: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceStorage,
+			Subsystem: subsystemBadger,
+			Name:      "pruned_blocks_total",
+			Help:      "the number of blocks removed from storage by pruning",
+		}),
+		rootHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceStorage,
+			Subsystem: subsystemBadger,
+			Name:      "pruned_root_height",
+			Help:      "the root height of the protocol state after pruning",
+		}),
+	}
+	return pc
+}
+
+// BlocksPruned records a successful pruning run.
+func (pc *PrunerCollector) BlocksPruned(count uint, rootHeight uint64) {
+	pc.prunedBlocks.Add(float64(count))
+	pc.rootHeight.Set(float64(rootHeight))
+}
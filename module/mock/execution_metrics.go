@@ -49,6 +49,16 @@ func (_m *ExecutionMetrics) ExecutionStateReadsPerBlock(reads uint64) {
 	_m.Called(reads)
 }
 
+// ExecutionStopHeight provides a mock function with given fields: height
+func (_m *ExecutionMetrics) ExecutionStopHeight(height uint64) {
+	_m.Called(height)
+}
+
+// ExecutionStopped provides a mock function with given fields: stopped
+func (_m *ExecutionMetrics) ExecutionStopped(stopped bool) {
+	_m.Called(stopped)
+}
+
 // ExecutionStorageStateCommitment provides a mock function with given fields: bytes
 func (_m *ExecutionMetrics) ExecutionStorageStateCommitment(bytes int64) {
 	_m.Called(bytes)
@@ -59,6 +69,21 @@ func (_m *ExecutionMetrics) ExecutionSync(syncing bool) {
 	_m.Called(syncing)
 }
 
+// ExecutionSyncDeltaApplied provides a mock function with given fields:
+func (_m *ExecutionMetrics) ExecutionSyncDeltaApplied() {
+	_m.Called()
+}
+
+// ExecutionSyncDeltaRejected provides a mock function with given fields:
+func (_m *ExecutionMetrics) ExecutionSyncDeltaRejected() {
+	_m.Called()
+}
+
+// ExecutionSyncTargetHeight provides a mock function with given fields: height
+func (_m *ExecutionMetrics) ExecutionSyncTargetHeight(height uint64) {
+	_m.Called(height)
+}
+
 // ExecutionTotalExecutedTransactions provides a mock function with given fields: numExecuted
 func (_m *ExecutionMetrics) ExecutionTotalExecutedTransactions(numExecuted int) {
 	_m.Called(numExecuted)
@@ -38,6 +38,11 @@ func (_m *NetworkMetrics) NetworkDuplicateMessagesDropped(topic string, messageT
 	_m.Called(topic, messageType)
 }
 
+// NetworkMessageCompressed provides a mock function with given fields: messageType, compression, ratio, duration
+func (_m *NetworkMetrics) NetworkMessageCompressed(messageType string, compression string, ratio float64, duration time.Duration) {
+	_m.Called(messageType, compression, ratio, duration)
+}
+
 // NetworkMessageReceived provides a mock function with given fields: sizeBytes, topic, messageType
 func (_m *NetworkMetrics) NetworkMessageReceived(sizeBytes int, topic string, messageType string) {
 	_m.Called(sizeBytes, topic, messageType)
@@ -48,11 +53,41 @@ func (_m *NetworkMetrics) NetworkMessageSent(sizeBytes int, topic string, messag
 	_m.Called(sizeBytes, topic, messageType)
 }
 
+// NetworkRateLimitedMessagesDropped provides a mock function with given fields: topic, messageType, limit
+func (_m *NetworkMetrics) NetworkRateLimitedMessagesDropped(topic string, messageType string, limit string) {
+	_m.Called(topic, messageType, limit)
+}
+
+// NetworkUnauthenticatedMessagesDropped provides a mock function with given fields: topic, messageType
+func (_m *NetworkMetrics) NetworkUnauthenticatedMessagesDropped(topic string, messageType string) {
+	_m.Called(topic, messageType)
+}
+
+// NetworkUnauthorizedMessagesDropped provides a mock function with given fields: topic, messageType
+func (_m *NetworkMetrics) NetworkUnauthorizedMessagesDropped(topic string, messageType string) {
+	_m.Called(topic, messageType)
+}
+
 // OutboundConnections provides a mock function with given fields: connectionCount
 func (_m *NetworkMetrics) OutboundConnections(connectionCount uint) {
 	_m.Called(connectionCount)
 }
 
+// PeerMisbehaviorReported provides a mock function with given fields: nodeID, topic, severity
+func (_m *NetworkMetrics) PeerMisbehaviorReported(nodeID string, topic string, severity string) {
+	_m.Called(nodeID, topic, severity)
+}
+
+// PeerPenalized provides a mock function with given fields: nodeID, penalty
+func (_m *NetworkMetrics) PeerPenalized(nodeID string, penalty string) {
+	_m.Called(nodeID, penalty)
+}
+
+// PeerScore provides a mock function with given fields: nodeID, score
+func (_m *NetworkMetrics) PeerScore(nodeID string, score float64) {
+	_m.Called(nodeID, score)
+}
+
 // QueueDuration provides a mock function with given fields: duration, priority
 func (_m *NetworkMetrics) QueueDuration(duration time.Duration, priority int) {
 	_m.Called(duration, priority)
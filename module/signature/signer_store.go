@@ -0,0 +1,105 @@
+// +build relic
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// SingleSignerStore is a threshold signer store which returns the same signer
+// for every view. It is used where the random beacon key can't change, such as
+// for the root block of a spork.
+type SingleSignerStore struct {
+	signer module.ThresholdSigner
+}
+
+// NewSingleSignerStore creates a store which always returns the given signer.
+func NewSingleSignerStore(signer module.ThresholdSigner) *SingleSignerStore {
+	return &SingleSignerStore{signer: signer}
+}
+
+func (s *SingleSignerStore) GetThresholdSigner(_ uint64) (module.ThresholdSigner, error) {
+	return s.signer, nil
+}
+
+// EpochAwareSignerStore is a threshold signer store which returns a signer
+// using the random beacon key share of the epoch the view belongs to. The key
+// shares are loaded from the DKG key storage when an epoch is first signed
+// for, so that the new key share is used from the first view of a new epoch.
+type EpochAwareSignerStore struct {
+	tag     string
+	state   protocol.State
+	keys    storage.DKGKeys
+	mu      sync.Mutex
+	signers map[uint64]module.ThresholdSigner // signers by epoch counter
+}
+
+// NewEpochAwareSignerStore creates a store for threshold signers in the context
+// of the given KMAC tag, with the key shares from the given DKG key storage.
+func NewEpochAwareSignerStore(tag string, state protocol.State, keys storage.DKGKeys) *EpochAwareSignerStore {
+	return &EpochAwareSignerStore{
+		tag:     tag,
+		state:   state,
+		keys:    keys,
+		signers: make(map[uint64]module.ThresholdSigner),
+	}
+}
+
+// GetThresholdSigner returns the threshold signer for the epoch of the given
+// view, which is either the previous, current or next epoch as of the latest
+// finalized block.
+func (s *EpochAwareSignerStore) GetThresholdSigner(view uint64) (module.ThresholdSigner, error) {
+	counter, err := s.epochForView(view)
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch for view %d: %w", view, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	signer, ok := s.signers[counter]
+	if ok {
+		return signer, nil
+	}
+
+	info, err := s.keys.RetrieveMyDKGPrivateInfo(counter)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve random beacon key for epoch %d: %w", counter, err)
+	}
+	signer = NewThresholdProvider(s.tag, info.RandomBeaconPrivKey)
+	s.signers[counter] = signer
+
+	return signer, nil
+}
+
+// epochForView returns the counter of the epoch containing the given view.
+func (s *EpochAwareSignerStore) epochForView(view uint64) (uint64, error) {
+	epochs := s.state.Final().Epochs()
+	for _, epoch := range []protocol.Epoch{epochs.Current(), epochs.Next(), epochs.Previous()} {
+		counter, err := epoch.Counter()
+		if errors.Is(err, protocol.ErrNoPreviousEpoch) || errors.Is(err, protocol.ErrNextEpochNotSetup) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("could not get epoch counter: %w", err)
+		}
+		first, err := epoch.FirstView()
+		if err != nil {
+			return 0, fmt.Errorf("could not get first view of epoch %d: %w", counter, err)
+		}
+		final, err := epoch.FinalView()
+		if err != nil {
+			return 0, fmt.Errorf("could not get final view of epoch %d: %w", counter, err)
+		}
+		if first <= view && view <= final {
+			return counter, nil
+		}
+	}
+	return 0, fmt.Errorf("view is not within the previous, current or next epoch")
+}
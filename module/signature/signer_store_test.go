@@ -0,0 +1,76 @@
+// +build relic
+
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/state/protocol"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func epochFixture(counter uint64, firstView uint64, finalView uint64) *mockprotocol.Epoch {
+	epoch := new(mockprotocol.Epoch)
+	epoch.On("Counter").Return(counter, nil)
+	epoch.On("FirstView").Return(firstView, nil)
+	epoch.On("FinalView").Return(finalView, nil)
+	return epoch
+}
+
+// TestEpochAwareSignerStore checks that the signer store signs with the
+// random beacon key of the epoch of each view.
+func TestEpochAwareSignerStore(t *testing.T) {
+	currentKey := unittest.KeyFixture(crypto.BLSBLS12381)
+	nextKey := unittest.KeyFixture(crypto.BLSBLS12381)
+
+	previous := new(mockprotocol.Epoch)
+	previous.On("Counter").Return(uint64(0), protocol.ErrNoPreviousEpoch)
+	epochs := new(mockprotocol.EpochQuery)
+	epochs.On("Previous").Return(previous)
+	epochs.On("Current").Return(epochFixture(1, 0, 99))
+	epochs.On("Next").Return(epochFixture(2, 100, 199))
+	snapshot := new(mockprotocol.Snapshot)
+	snapshot.On("Epochs").Return(epochs)
+	state := new(mockprotocol.State)
+	state.On("Final").Return(snapshot)
+
+	keys := new(mockstorage.DKGKeys)
+	keys.On("RetrieveMyDKGPrivateInfo", uint64(1)).Return(&dkg.DKGParticipantPriv{RandomBeaconPrivKey: encodable.RandomBeaconPrivKey{PrivateKey: currentKey}}, nil).Once()
+	keys.On("RetrieveMyDKGPrivateInfo", uint64(2)).Return(&dkg.DKGParticipantPriv{RandomBeaconPrivKey: encodable.RandomBeaconPrivKey{PrivateKey: nextKey}}, nil).Once()
+
+	store := NewEpochAwareSignerStore("test_beacon", state, keys)
+	msg := createMSGT(t)
+
+	for _, view := range []uint64{0, 50, 99} {
+		signer, err := store.GetThresholdSigner(view)
+		require.NoError(t, err)
+		sig, err := signer.Sign(msg)
+		require.NoError(t, err)
+		valid, err := signer.Verify(msg, sig, currentKey.PublicKey())
+		require.NoError(t, err)
+		assert.True(t, valid, "view %d should be signed with the current epoch key", view)
+	}
+
+	signer, err := store.GetThresholdSigner(100)
+	require.NoError(t, err)
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+	valid, err := signer.Verify(msg, sig, nextKey.PublicKey())
+	require.NoError(t, err)
+	assert.True(t, valid, "view 100 should be signed with the next epoch key")
+
+	// views outside of the known epochs can't be signed
+	_, err = store.GetThresholdSigner(200)
+	assert.Error(t, err)
+
+	// the keys are only loaded once per epoch
+	keys.AssertExpectations(t)
+}
@@ -26,3 +26,9 @@ type ThresholdSigner interface {
 	Sign(msg []byte) (crypto.Signature, error)
 	Combine(size uint, shares []crypto.Signature, indices []uint) (crypto.Signature, error)
 }
+
+// ThresholdSignerStore provides the threshold signer to use for a given view,
+// as the random beacon key share changes with each epoch.
+type ThresholdSignerStore interface {
+	GetThresholdSigner(view uint64) (ThresholdSigner, error)
+}
@@ -0,0 +1,74 @@
+package cbor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// benchmarkMessages returns the messages which make up most of the network traffic, by name.
+func benchmarkMessages() map[string]interface{} {
+	block := unittest.BlockFixture()
+	chunkID := unittest.IdentifierFixture()
+	collection := unittest.CollectionFixture(100)
+	return map[string]interface{}{
+		"block_proposal":      unittest.ProposalFromBlock(&block),
+		"chunk_data_response": &messages.ChunkDataResponse{ChunkDataPack: *unittest.ChunkDataPackFixture(chunkID), Collection: collection, Nonce: 1},
+		"entity_response":     &messages.EntityResponse{Nonce: 1, EntityIDs: unittest.IdentifierListFixture(10), Blobs: [][]byte{unittest.RandomBytes(1000)}},
+		"execution_receipt":   unittest.ExecutionReceiptFixture(),
+		"block_vote":          &messages.BlockVote{BlockID: block.ID(), View: 42, SigData: unittest.SignatureFixture()},
+	}
+}
+
+// benchmarkCodecs returns the codecs to compare.
+func benchmarkCodecs() map[string]network.Codec {
+	return map[string]network.Codec{
+		"json": json.NewCodec(),
+		"cbor": cbor.NewCodec(),
+	}
+}
+
+// BenchmarkEncode measures the throughput of encoding messages, and reports the size of the encoded messages.
+func BenchmarkEncode(b *testing.B) {
+	for msgName, msg := range benchmarkMessages() {
+		for codecName, codec := range benchmarkCodecs() {
+			b.Run(fmt.Sprintf("%s/%s", msgName, codecName), func(b *testing.B) {
+				data, err := codec.Encode(msg)
+				require.NoError(b, err)
+				b.SetBytes(int64(len(data)))
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, _ = codec.Encode(msg)
+				}
+				b.ReportMetric(float64(len(data)), "bytes/msg")
+			})
+		}
+	}
+}
+
+// BenchmarkDecode measures the throughput of decoding messages, and reports the size of the encoded messages.
+func BenchmarkDecode(b *testing.B) {
+	for msgName, msg := range benchmarkMessages() {
+		for codecName, codec := range benchmarkCodecs() {
+			b.Run(fmt.Sprintf("%s/%s", msgName, codecName), func(b *testing.B) {
+				data, err := codec.Encode(msg)
+				require.NoError(b, err)
+				b.SetBytes(int64(len(data)))
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, _ = codec.Decode(data)
+				}
+				b.ReportMetric(float64(len(data)), "bytes/msg")
+			})
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package cbor_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/cluster"
+	encoding "github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/model/messages"
+	codecs "github.com/onflow/flow-go/network/codec"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// messageFixtures returns a message of each type supported by the network codecs.
+func messageFixtures(t testing.TB) []interface{} {
+	block := unittest.BlockFixture()
+	clusterBlock := unittest.ClusterBlockFixture()
+	collection := unittest.CollectionFixture(10)
+	tx := unittest.TransactionFixture()
+	txBody := unittest.TransactionBodyFixture()
+	chunkID := unittest.IdentifierFixture()
+	stateDelta := unittest.StateDeltaFixture()
+	stateDelta.StateInteractions = []*delta.Snapshot{{
+		Delta: delta.Delta{Data: map[string]flow.RegisterEntry{
+			"key": {Key: flow.RegisterID{Owner: "owner", Controller: "controller", Key: "key"}, Value: []byte{1, 2, 3}},
+		}},
+		Reads: []flow.RegisterID{{Owner: "owner", Controller: "controller", Key: "key"}},
+	}}
+	stateDelta.EndState = unittest.StateCommitmentFixture()
+	stateDelta.Events = []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID())}
+
+	return []interface{}{
+		unittest.ProposalFromBlock(&block),
+		&messages.BlockVote{BlockID: block.ID(), View: 42, SigData: unittest.SignatureFixture()},
+		&messages.ClusterBlockProposal{Header: clusterBlock.Header, Payload: clusterBlock.Payload},
+		&messages.ClusterBlockVote{BlockID: clusterBlock.ID(), View: 42, SigData: unittest.SignatureFixture()},
+		&messages.ClusterBlockResponse{Nonce: 1, Blocks: []*cluster.Block{&clusterBlock}},
+		&messages.SyncRequest{Nonce: 1, Height: 100},
+		&messages.SyncResponse{Nonce: 1, Height: 100},
+		&messages.RangeRequest{Nonce: 1, FromHeight: 10, ToHeight: 100},
+		&messages.BatchRequest{Nonce: 1, BlockIDs: unittest.IdentifierListFixture(10)},
+		&messages.BlockResponse{Nonce: 1, Blocks: []*flow.Block{&block}},
+		unittest.CollectionGuaranteeFixture(),
+		&txBody,
+		&tx,
+		unittest.ExecutionReceiptFixture(),
+		unittest.ResultApprovalFixture(),
+		&messages.ExecutionStateSyncRequest{FromHeight: 10, ToHeight: 100},
+		stateDelta,
+		&messages.ChunkDataRequest{ChunkID: chunkID, Nonce: 1},
+		&messages.ChunkDataResponse{ChunkDataPack: *unittest.ChunkDataPackFixture(chunkID), Collection: collection, Nonce: 1},
+		&messages.ApprovalRequest{Nonce: 1, ResultID: unittest.IdentifierFixture(), ChunkIndex: 3},
+		&messages.ApprovalResponse{Nonce: 1, Approval: *unittest.ResultApprovalFixture()},
+		&messages.EntityRequest{Nonce: 1, EntityIDs: unittest.IdentifierListFixture(10)},
+		&messages.EntityResponse{Nonce: 1, EntityIDs: unittest.IdentifierListFixture(2), Blobs: [][]byte{unittest.RandomBytes(100), unittest.RandomBytes(100)}},
+		&messages.DKGMessage{Orig: 1, Type: messages.DKGMessageBroadcast, Data: unittest.RandomBytes(100), DKGInstanceID: "dkg"},
+		&message.TestMessage{Text: "hello"},
+	}
+}
+
+// TestCodec_RoundTrip evaluates that all messages supported by the network codecs are decoded by the CBOR codec
+// into the message they were encoded from.
+func TestCodec_RoundTrip(t *testing.T) {
+	codec := cbor.NewCodec()
+
+	for _, msg := range messageFixtures(t) {
+		data, err := codec.Encode(msg)
+		require.NoError(t, err, "%T", msg)
+
+		decoded, err := codec.Decode(data)
+		require.NoError(t, err, "%T", msg)
+		assert.Equal(t, msg, decoded, "%T", msg)
+	}
+}
+
+// TestCodec_Stream evaluates that messages written to a stream by the encoder are read back by the decoder.
+func TestCodec_Stream(t *testing.T) {
+	codec := cbor.NewCodec()
+	msgs := messageFixtures(t)
+
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf)
+	for _, msg := range msgs {
+		require.NoError(t, enc.Encode(msg), "%T", msg)
+	}
+
+	dec := codec.NewDecoder(&buf)
+	for _, msg := range msgs {
+		decoded, err := dec.Decode()
+		require.NoError(t, err, "%T", msg)
+		assert.Equal(t, msg, decoded, "%T", msg)
+	}
+}
+
+// TestCodec_Header evaluates that block headers are decoded with UTC timestamps, so that their ID does not change.
+func TestCodec_Header(t *testing.T) {
+	codec := cbor.NewCodec()
+	block := unittest.BlockFixture()
+	block.Header.Timestamp = time.Now().In(time.FixedZone("test", 3600))
+	proposal := unittest.ProposalFromBlock(&block)
+
+	data, err := codec.Encode(proposal)
+	require.NoError(t, err)
+	decoded, err := codec.Decode(data)
+	require.NoError(t, err)
+
+	decodedHeader := decoded.(*messages.BlockProposal).Header
+	assert.Equal(t, time.UTC, decodedHeader.Timestamp.Location())
+	assert.Equal(t, block.Header.ID(), decodedHeader.ID())
+}
+
+// TestCodec_Invalid evaluates that envelopes with an unknown version or code, or a payload which does not match
+// their code, are rejected.
+func TestCodec_Invalid(t *testing.T) {
+	codec := cbor.NewCodec()
+
+	data, err := codec.Encode(&messages.SyncRequest{Nonce: 1, Height: 100})
+	require.NoError(t, err)
+
+	// the envelope is an array of the version, the code and the payload
+	require.Equal(t, byte(0x83), data[0])
+	require.Equal(t, byte(cbor.Version1), data[1])
+
+	t.Run("unknown version", func(t *testing.T) {
+		invalid := append([]byte{}, data...)
+		invalid[1] = cbor.Version1 + 1
+		_, err := codec.Decode(invalid)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		invalid, err := encoding.Marshal(cbor.Envelope{Version: cbor.Version1, Code: 0xff, Data: data[3:]})
+		require.NoError(t, err)
+		_, err = codec.Decode(invalid)
+		assert.Error(t, err)
+	})
+
+	t.Run("mismatching payload", func(t *testing.T) {
+		invalid := append([]byte{}, data...)
+		invalid[2] = codecs.CodeBlockProposal
+		_, err := codec.Decode(invalid)
+		assert.Error(t, err)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		jsonData, err := json.NewCodec().Encode(&messages.SyncRequest{Nonce: 1, Height: 100})
+		require.NoError(t, err)
+		_, err = codec.Decode(jsonData)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := codec.Encode(&struct{}{})
+		assert.Error(t, err)
+	})
+}
+
+// TestCodec_Fuzz evaluates that decoding random bytes, and random mutations of valid encodings, never panics, and
+// that every value decoded from them is encoded again without error.
+func TestCodec_Fuzz(t *testing.T) {
+	codec := cbor.NewCodec()
+	seed := time.Now().UnixNano()
+	r := rand.New(rand.NewSource(seed))
+	t.Logf("fuzzing with seed %d", seed)
+
+	var corpus [][]byte
+	for _, msg := range messageFixtures(t) {
+		data, err := codec.Encode(msg)
+		require.NoError(t, err)
+		corpus = append(corpus, data)
+	}
+
+	fuzz := func(data []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("decoding panicked on input %x: %v", data, r)
+			}
+		}()
+
+		v, err := codec.Decode(data)
+		if err != nil {
+			return
+		}
+		_, err = codec.Encode(v)
+		assert.NoError(t, err, "could not re-encode decoded %T from input %x", v, data)
+	}
+
+	for i := 0; i < 10000; i++ {
+
+		// random bytes, which mostly fail to decode the envelope
+		random := make([]byte, r.Intn(64))
+		_, _ = r.Read(random)
+		fuzz(random)
+
+		// valid encodings with random bytes flipped, truncated or extended
+		mutated := append([]byte{}, corpus[r.Intn(len(corpus))]...)
+		switch r.Intn(3) {
+		case 0:
+			for j := 0; j < 1+r.Intn(4); j++ {
+				mutated[r.Intn(len(mutated))] = byte(r.Intn(256))
+			}
+		case 1:
+			mutated = mutated[:r.Intn(len(mutated))]
+		case 2:
+			extra := make([]byte, 1+r.Intn(16))
+			_, _ = r.Read(extra)
+			mutated = append(mutated, extra...)
+		}
+		fuzz(mutated)
+	}
+}
@@ -0,0 +1,33 @@
+// (c) 2019 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/network/codec"
+)
+
+// decode will decode the envelope into an entity.
+func decode(env Envelope) (interface{}, error) {
+
+	// reject envelopes encoded by other versions of the codec
+	if env.Version != Version1 {
+		return nil, fmt.Errorf("unsupported envelope version (%d)", env.Version)
+	}
+
+	// create the desired message
+	v, err := codec.InterfaceFromMessageCode(env.Code)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine interface from code: %w", err)
+	}
+
+	// unmarshal the payload
+	err = cbor.Unmarshal(env.Data, v)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode payload: %w", err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,33 @@
+// (c) 2019 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Decoder implements a stream decoder for CBOR.
+type Decoder struct {
+	dec *cbor.Decoder
+}
+
+// Decode will decode the next CBOR value from the stream.
+func (d *Decoder) Decode() (interface{}, error) {
+
+	// decode the next envelope
+	var env Envelope
+	err := d.dec.Decode(&env)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode envelope: %w", err)
+	}
+
+	// decode the embedded value
+	v, err := decode(env)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode value: %w", err)
+	}
+
+	return v, nil
+}
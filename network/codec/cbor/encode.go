@@ -0,0 +1,33 @@
+// (c) 2019 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/network/codec"
+)
+
+func encode(v interface{}) (*Envelope, error) {
+
+	// determine the message type
+	code, err := codec.MessageCodeFromInterface(v)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine envelope code: %w", err)
+	}
+
+	// encode the payload
+	data, err := cbor.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode payload: %w", err)
+	}
+
+	env := Envelope{
+		Version: Version1,
+		Code:    code,
+		Data:    data,
+	}
+
+	return &env, nil
+}
@@ -0,0 +1,21 @@
+// (c) 2019 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"github.com/onflow/flow-go/model/encoding/cbor"
+)
+
+// Version1 is the current version of the binary encoding. It is the first element of each envelope, so that the
+// encoding can be changed at a spork while messages of older versions are rejected explicitly.
+const Version1 = 1
+
+// Envelope is a wrapper to convey the encoding version and type information with
+// CBOR encoding. It is encoded as a CBOR array, which adds only a few bytes to the
+// encoded payload, and which can't be mistaken for a JSON envelope.
+type Envelope struct {
+	_       struct{} `cbor:",toarray"`
+	Version uint8
+	Code    uint8
+	Data    cbor.RawMessage
+}
@@ -0,0 +1,228 @@
+// (c) 2019 Dapper Labs - ALL RIGHTS RESERVED
+
+// Package codec defines the codes used by the network codecs to convey the type of the messages they encode,
+// which are shared by all codecs.
+package codec
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+const (
+
+	// consensus
+	CodeBlockProposal = iota + 1
+	CodeBlockVote
+
+	// protocol state sync
+	CodeSyncRequest
+	CodeSyncResponse
+	CodeRangeRequest
+	CodeBatchRequest
+	CodeBlockResponse
+
+	// cluster consensus
+	CodeClusterBlockProposal
+	CodeClusterBlockVote
+	CodeClusterBlockResponse
+
+	// collections, guarantees & transactions
+	CodeCollectionGuarantee
+	CodeTransaction
+	CodeTransactionBody
+
+	// core messages for execution & verification
+	CodeExecutionReceipt
+	CodeResultApproval
+
+	// execution state synchronization
+	CodeExecutionStateSyncRequest
+	CodeExecutionStateDelta
+
+	// data exchange for execution of blocks
+	CodeChunkDataRequest
+	CodeChunkDataResponse
+
+	// result approvals
+	CodeApprovalRequest
+	CodeApprovalResponse
+
+	// generic entity exchange engines
+	CodeEntityRequest
+	CodeEntityResponse
+
+	// distributed key generation
+	CodeDKGMessage
+
+	// testing
+	CodeEcho
+)
+
+// MessageCodeFromInterface returns the code of the given message.
+func MessageCodeFromInterface(v interface{}) (uint8, error) {
+
+	// determine the message type
+	switch v.(type) {
+
+	// consensus
+	case *messages.BlockProposal:
+		return CodeBlockProposal, nil
+	case *messages.BlockVote:
+		return CodeBlockVote, nil
+
+	// protocol state sync
+	case *messages.SyncRequest:
+		return CodeSyncRequest, nil
+	case *messages.SyncResponse:
+		return CodeSyncResponse, nil
+	case *messages.RangeRequest:
+		return CodeRangeRequest, nil
+	case *messages.BatchRequest:
+		return CodeBatchRequest, nil
+	case *messages.BlockResponse:
+		return CodeBlockResponse, nil
+
+	// cluster consensus
+	case *messages.ClusterBlockProposal:
+		return CodeClusterBlockProposal, nil
+	case *messages.ClusterBlockVote:
+		return CodeClusterBlockVote, nil
+	case *messages.ClusterBlockResponse:
+		return CodeClusterBlockResponse, nil
+
+	// collections, guarantees & transactions
+	case *flow.CollectionGuarantee:
+		return CodeCollectionGuarantee, nil
+	case *flow.TransactionBody:
+		return CodeTransactionBody, nil
+	case *flow.Transaction:
+		return CodeTransaction, nil
+
+	// core messages for execution & verification
+	case *flow.ExecutionReceipt:
+		return CodeExecutionReceipt, nil
+	case *flow.ResultApproval:
+		return CodeResultApproval, nil
+
+	// execution state synchronization
+	case *messages.ExecutionStateSyncRequest:
+		return CodeExecutionStateSyncRequest, nil
+	case *messages.ExecutionStateDelta:
+		return CodeExecutionStateDelta, nil
+
+	// data exchange for execution of blocks
+	case *messages.ChunkDataRequest:
+		return CodeChunkDataRequest, nil
+	case *messages.ChunkDataResponse:
+		return CodeChunkDataResponse, nil
+
+	// result approvals
+	case *messages.ApprovalRequest:
+		return CodeApprovalRequest, nil
+	case *messages.ApprovalResponse:
+		return CodeApprovalResponse, nil
+
+	// generic entity exchange engines
+	case *messages.EntityRequest:
+		return CodeEntityRequest, nil
+	case *messages.EntityResponse:
+		return CodeEntityResponse, nil
+
+	// distributed key generation
+	case *messages.DKGMessage:
+		return CodeDKGMessage, nil
+
+	// testing
+	case *message.TestMessage:
+		return CodeEcho, nil
+
+	default:
+		return 0, fmt.Errorf("invalid encode type (%T)", v)
+	}
+}
+
+// InterfaceFromMessageCode returns an empty message of the type with the given code, to decode a message into.
+func InterfaceFromMessageCode(code uint8) (interface{}, error) {
+
+	// create the desired message
+	switch code {
+
+	// consensus
+	case CodeBlockProposal:
+		return &messages.BlockProposal{}, nil
+	case CodeBlockVote:
+		return &messages.BlockVote{}, nil
+
+	// cluster consensus
+	case CodeClusterBlockProposal:
+		return &messages.ClusterBlockProposal{}, nil
+	case CodeClusterBlockVote:
+		return &messages.ClusterBlockVote{}, nil
+	case CodeClusterBlockResponse:
+		return &messages.ClusterBlockResponse{}, nil
+
+	// protocol state sync
+	case CodeSyncRequest:
+		return &messages.SyncRequest{}, nil
+	case CodeSyncResponse:
+		return &messages.SyncResponse{}, nil
+	case CodeRangeRequest:
+		return &messages.RangeRequest{}, nil
+	case CodeBatchRequest:
+		return &messages.BatchRequest{}, nil
+	case CodeBlockResponse:
+		return &messages.BlockResponse{}, nil
+
+	// collections, guarantees & transactions
+	case CodeCollectionGuarantee:
+		return &flow.CollectionGuarantee{}, nil
+	case CodeTransactionBody:
+		return &flow.TransactionBody{}, nil
+	case CodeTransaction:
+		return &flow.Transaction{}, nil
+
+	// core messages for execution & verification
+	case CodeExecutionReceipt:
+		return &flow.ExecutionReceipt{}, nil
+	case CodeResultApproval:
+		return &flow.ResultApproval{}, nil
+
+	// execution state synchronization
+	case CodeExecutionStateSyncRequest:
+		return &messages.ExecutionStateSyncRequest{}, nil
+	case CodeExecutionStateDelta:
+		return &messages.ExecutionStateDelta{}, nil
+
+	// data exchange for execution of blocks
+	case CodeChunkDataRequest:
+		return &messages.ChunkDataRequest{}, nil
+	case CodeChunkDataResponse:
+		return &messages.ChunkDataResponse{}, nil
+
+	case CodeApprovalRequest:
+		return &messages.ApprovalRequest{}, nil
+	case CodeApprovalResponse:
+		return &messages.ApprovalResponse{}, nil
+
+	// generic entity exchange engines
+	case CodeEntityRequest:
+		return &messages.EntityRequest{}, nil
+	case CodeEntityResponse:
+		return &messages.EntityResponse{}, nil
+
+	// distributed key generation
+	case CodeDKGMessage:
+		return &messages.DKGMessage{}, nil
+
+	// testing
+	case CodeEcho:
+		return &message.TestMessage{}, nil
+
+	default:
+		return nil, fmt.Errorf("invalid message code (%d)", code)
+	}
+}
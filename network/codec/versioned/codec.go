@@ -0,0 +1,108 @@
+// Package versioned implements a network codec which decodes messages of all the network encodings, so that the
+// encoding used by a network can be switched without a spork: all nodes are first upgraded to decode every encoding
+// while still encoding their messages with the previous one, and are then switched to encode with the new one.
+package versioned
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/json"
+)
+
+const (
+	// jsonPrefix is the first byte of JSON envelopes, which are JSON objects
+	jsonPrefix = '{'
+
+	// cborPrefix is the first byte of CBOR envelopes, which are CBOR arrays of three elements
+	cborPrefix = 0x83
+)
+
+// Codec encodes messages with the configured codec, and decodes messages encoded by any of the network codecs,
+// which are told apart by the first byte of their envelope.
+type Codec struct {
+	encoder network.Codec
+	json    *json.Codec
+	cbor    *cbor.Codec
+}
+
+// NewCodec creates a new versioned codec, which encodes messages with the given codec.
+func NewCodec(encoder network.Codec) *Codec {
+	c := &Codec{
+		encoder: encoder,
+		json:    json.NewCodec(),
+		cbor:    cbor.NewCodec(),
+	}
+	return c
+}
+
+// NewEncoder creates a new encoder of the configured codec with the given underlying writer.
+func (c *Codec) NewEncoder(w io.Writer) network.Encoder {
+	return c.encoder.NewEncoder(w)
+}
+
+// NewDecoder creates a new decoder with the given underlying reader, which determines the encoding of the stream
+// from its first byte.
+func (c *Codec) NewDecoder(r io.Reader) network.Decoder {
+	return &Decoder{codec: c, r: bufio.NewReader(r)}
+}
+
+// Encode will encode the given entity with the configured codec and return the bytes.
+func (c *Codec) Encode(v interface{}) ([]byte, error) {
+	return c.encoder.Encode(v)
+}
+
+// Decode will attempt to decode the given entity from bytes of any encoding.
+func (c *Codec) Decode(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("could not decode empty message")
+	}
+
+	codec, err := c.codecOf(data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Decode(data)
+}
+
+// codecOf returns the codec of envelopes starting with the given byte.
+func (c *Codec) codecOf(prefix byte) (network.Codec, error) {
+	switch prefix {
+	case jsonPrefix:
+		return c.json, nil
+	case cborPrefix:
+		return c.cbor, nil
+	default:
+		return nil, fmt.Errorf("unknown envelope encoding (prefix: %x)", prefix)
+	}
+}
+
+// Decoder implements a stream decoder for streams of any encoding.
+type Decoder struct {
+	codec *Codec
+	r     *bufio.Reader
+	dec   network.Decoder
+}
+
+// Decode will decode the next value from the stream.
+func (d *Decoder) Decode() (interface{}, error) {
+
+	// determine the encoding of the stream from its first byte
+	if d.dec == nil {
+		prefix, err := d.r.Peek(1)
+		if err != nil {
+			return nil, fmt.Errorf("could not read envelope: %w", err)
+		}
+		codec, err := d.codec.codecOf(prefix[0])
+		if err != nil {
+			return nil, err
+		}
+		d.dec = codec.NewDecoder(d.r)
+	}
+
+	return d.dec.Decode()
+}
@@ -0,0 +1,87 @@
+package versioned_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/network/codec/versioned"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestCodec_MixedNetwork evaluates that nodes encoding messages with different codecs can decode the messages of
+// each other.
+func TestCodec_MixedNetwork(t *testing.T) {
+	jsonNode := versioned.NewCodec(json.NewCodec())
+	cborNode := versioned.NewCodec(cbor.NewCodec())
+
+	msgs := []interface{}{
+		&messages.SyncRequest{Nonce: 1, Height: 100},
+		&messages.EntityRequest{Nonce: 1, EntityIDs: unittest.IdentifierListFixture(10)},
+		&message.TestMessage{Text: "hello"},
+	}
+
+	for _, sender := range []*versioned.Codec{jsonNode, cborNode} {
+		for _, receiver := range []*versioned.Codec{jsonNode, cborNode} {
+			for _, msg := range msgs {
+				data, err := sender.Encode(msg)
+				require.NoError(t, err)
+
+				decoded, err := receiver.Decode(data)
+				require.NoError(t, err)
+				assert.Equal(t, msg, decoded)
+			}
+		}
+	}
+
+	// the configured codec is used to encode messages
+	data, err := cborNode.Encode(msgs[0])
+	require.NoError(t, err)
+	expected, err := cbor.NewCodec().Encode(msgs[0])
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
+
+// TestCodec_Stream evaluates that the encoding of streams is determined from their first byte.
+func TestCodec_Stream(t *testing.T) {
+	msgs := []interface{}{
+		&messages.SyncRequest{Nonce: 1, Height: 100},
+		&message.TestMessage{Text: "hello"},
+	}
+
+	for _, encoder := range []network.Codec{json.NewCodec(), cbor.NewCodec()} {
+		var buf bytes.Buffer
+		enc := encoder.NewEncoder(&buf)
+		for _, msg := range msgs {
+			require.NoError(t, enc.Encode(msg))
+		}
+
+		dec := versioned.NewCodec(json.NewCodec()).NewDecoder(&buf)
+		for _, msg := range msgs {
+			decoded, err := dec.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, msg, decoded)
+		}
+	}
+}
+
+// TestCodec_UnknownEncoding evaluates that messages of unknown encodings are rejected.
+func TestCodec_UnknownEncoding(t *testing.T) {
+	codec := versioned.NewCodec(cbor.NewCodec())
+
+	_, err := codec.Decode(nil)
+	assert.Error(t, err)
+
+	_, err = codec.Decode([]byte{0x00, 0x01, 0x02})
+	assert.Error(t, err)
+
+	_, err = codec.NewDecoder(bytes.NewReader([]byte{0x00})).Decode()
+	assert.Error(t, err)
+}
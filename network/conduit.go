@@ -53,6 +53,10 @@ func (cl ChannelList) ID() flow.Identifier {
 // engines with the same ID over a shared bus, accessible through the conduit.
 type Conduit interface {
 
+	// MisbehaviorReporter allows the engine to report misbehavior of nodes which sent it messages on the
+	// channel of this Conduit.
+	MisbehaviorReporter
+
 	// Publish submits an event to the network layer for unreliable delivery
 	// to subscribers of the given event on the network layer. It uses a
 	// publish-subscribe layer and can thus not guarantee that the specified
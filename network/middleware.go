@@ -61,6 +61,10 @@ type Middleware interface {
 	// UpdateAllowList fetches the most recent identity of the nodes from overlay
 	// and updates the underlying libp2p node.
 	UpdateAllowList() error
+
+	// ReportMisbehavior reports that the node with the given ID misbehaved on the given channel, which lowers
+	// its score and eventually penalizes it.
+	ReportMisbehavior(originID flow.Identifier, channel Channel, severity Severity, reason string)
 }
 
 // Overlay represents the interface that middleware uses to interact with the
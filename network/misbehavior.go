@@ -0,0 +1,46 @@
+package network
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Severity specifies how severe a misbehavior of a node is. The more severe a misbehavior, the more it lowers the
+// score of the misbehaving node in the network layer, which eventually throttles it and disconnects from it.
+type Severity int
+
+const (
+	// SeverityLow is used for misbehavior which may be caused by an honest node, such as a response to a request
+	// which already timed out.
+	SeverityLow Severity = iota + 1
+	// SeverityMedium is used for misbehavior which is unlikely to be caused by an honest node, such as an
+	// unsolicited response.
+	SeverityMedium
+	// SeverityHigh is used for misbehavior which can't be caused by an honest node, such as a malformed message.
+	SeverityHigh
+	// SeverityCritical is used for misbehavior which is a proven attack on the protocol, such as a block proposal
+	// whose payload does not match its header.
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// MisbehaviorReporter allows engines to report misbehavior of other nodes to the network layer, which penalizes
+// nodes that misbehave repeatedly.
+type MisbehaviorReporter interface {
+	// ReportMisbehavior reports that the node with the given ID misbehaved with the given severity. The reason
+	// is logged by the network layer, so that operators can see why a node was penalized.
+	ReportMisbehavior(originID flow.Identifier, severity Severity, reason string)
+}
@@ -5,6 +5,8 @@ package mocknetwork
 import (
 	flow "github.com/onflow/flow-go/model/flow"
 	mock "github.com/stretchr/testify/mock"
+
+	network "github.com/onflow/flow-go/network"
 )
 
 // Conduit is an autogenerated mock type for the Conduit type
@@ -68,6 +70,11 @@ func (_m *Conduit) Publish(event interface{}, targetIDs ...flow.Identifier) erro
 	return r0
 }
 
+// ReportMisbehavior provides a mock function with given fields: originID, severity, reason
+func (_m *Conduit) ReportMisbehavior(originID flow.Identifier, severity network.Severity, reason string) {
+	_m.Called(originID, severity, reason)
+}
+
 // Unicast provides a mock function with given fields: event, targetID
 func (_m *Conduit) Unicast(event interface{}, targetID flow.Identifier) error {
 	ret := _m.Called(event, targetID)
@@ -53,6 +53,11 @@ func (_m *Middleware) Publish(msg *message.Message, channel network.Channel) err
 	return r0
 }
 
+// ReportMisbehavior provides a mock function with given fields: originID, channel, severity, reason
+func (_m *Middleware) ReportMisbehavior(originID flow.Identifier, channel network.Channel, severity network.Severity, reason string) {
+	_m.Called(originID, channel, severity, reason)
+}
+
 // Send provides a mock function with given fields: channel, msg, targetIDs
 func (_m *Middleware) Send(channel network.Channel, msg *message.Message, targetIDs ...flow.Identifier) error {
 	_va := make([]interface{}, len(targetIDs))
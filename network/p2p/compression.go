@@ -0,0 +1,133 @@
+package p2p
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	"github.com/onflow/flow-go/network/message"
+)
+
+const (
+	// CompressionNone disables the compression of unicast messages
+	CompressionNone = "none"
+
+	// CompressionSnappy compresses unicast messages with the snappy framing format
+	CompressionSnappy = "snappy"
+
+	// DefaultCompressionThreshold is the size from which unicast messages are compressed, smaller messages are
+	// not worth the time spent compressing them
+	DefaultCompressionThreshold = 1 * mb // 1 mb
+)
+
+// compressor wraps the streams of a compression algorithm.
+type compressor struct {
+	newWriter func(w io.Writer) io.WriteCloser
+	newReader func(r io.Reader) io.Reader
+}
+
+// compressors holds the supported compression algorithms, by name. Every node accepts streams compressed
+// with any of them, so that senders may enable compression without coordinating with their receivers.
+var compressors = map[string]compressor{
+	CompressionSnappy: {
+		newWriter: func(w io.Writer) io.WriteCloser { return snappy.NewBufferedWriter(w) },
+		newReader: func(r io.Reader) io.Reader { return snappy.NewReader(r) },
+	},
+}
+
+// UnicastCompression configures the compression of outgoing unicast messages. Messages of at least Threshold
+// bytes are sent on a stream compressed with Algorithm, if the receiver supports it, and uncompressed otherwise.
+type UnicastCompression struct {
+	Algorithm string
+	Threshold int
+}
+
+// NoCompression returns the configuration which sends all unicast messages uncompressed.
+func NoCompression() UnicastCompression {
+	return UnicastCompression{Algorithm: CompressionNone}
+}
+
+// NewUnicastCompression returns the configuration compressing unicast messages of at least threshold bytes
+// with the given algorithm, or an error if the algorithm is not supported.
+func NewUnicastCompression(algorithm string, threshold int) (UnicastCompression, error) {
+	if algorithm == CompressionNone {
+		return NoCompression(), nil
+	}
+	if _, ok := compressors[algorithm]; !ok {
+		return UnicastCompression{}, fmt.Errorf("unsupported compression algorithm: %s", algorithm)
+	}
+	if threshold < 0 {
+		return UnicastCompression{}, fmt.Errorf("invalid compression threshold: %d", threshold)
+	}
+	return UnicastCompression{Algorithm: algorithm, Threshold: threshold}, nil
+}
+
+// applies returns true if the given message should be compressed.
+func (c UnicastCompression) applies(msg *message.Message) bool {
+	_, ok := compressors[c.Algorithm]
+	return ok && msg.Size() >= c.Threshold
+}
+
+// compressedProtocolID returns the protocol ID of the streams compressed with the given algorithm.
+func compressedProtocolID(protocolID protocol.ID, algorithm string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("%s/%s", protocolID, algorithm))
+}
+
+// streamCompression returns the compression algorithm of a stream negotiated for the given protocol ID, which
+// is CompressionNone for the uncompressed protocol.
+func streamCompression(protocolID protocol.ID, negotiated protocol.ID) (string, error) {
+	if negotiated == protocolID {
+		return CompressionNone, nil
+	}
+	algorithm := strings.TrimPrefix(string(negotiated), string(protocolID)+"/")
+	if _, ok := compressors[algorithm]; !ok {
+		return "", fmt.Errorf("unknown stream protocol: %s", negotiated)
+	}
+	return algorithm, nil
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// compressMessage writes the message to the given writer compressed with the given algorithm, delimited the same
+// way as on an uncompressed stream. It returns the size of the compressed message, together with the time spent
+// compressing and writing it.
+func compressMessage(w io.Writer, msg *message.Message, algorithm string) (int, time.Duration, error) {
+	start := time.Now()
+
+	counter := &countingWriter{w: w}
+	cw := compressors[algorithm].newWriter(counter)
+	err := ggio.NewDelimitedWriter(cw).WriteMsg(msg)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not compress message: %w", err)
+	}
+	err = cw.Close()
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not flush compressed message: %w", err)
+	}
+
+	return counter.n, time.Since(start), nil
+}
+
+// decompressingReader returns a reader of the messages of a stream compressed with the given algorithm.
+func decompressingReader(r io.Reader, algorithm string) io.Reader {
+	c, ok := compressors[algorithm]
+	if !ok {
+		return r
+	}
+	return c.newReader(r)
+}
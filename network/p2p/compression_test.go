@@ -0,0 +1,78 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/network/message"
+)
+
+// TestUnicastCompression_Applies evaluates that only messages of at least the threshold size are compressed, and
+// that no message is compressed without a compression algorithm.
+func TestUnicastCompression_Applies(t *testing.T) {
+	msg := &message.Message{Payload: bytes.Repeat([]byte{1}, 1000)}
+
+	compression, err := NewUnicastCompression(CompressionSnappy, msg.Size())
+	require.NoError(t, err)
+	assert.True(t, compression.applies(msg))
+
+	compression, err = NewUnicastCompression(CompressionSnappy, msg.Size()+1)
+	require.NoError(t, err)
+	assert.False(t, compression.applies(msg))
+
+	compression, err = NewUnicastCompression(CompressionNone, 0)
+	require.NoError(t, err)
+	assert.False(t, compression.applies(msg))
+
+	_, err = NewUnicastCompression("unknown", 0)
+	assert.Error(t, err)
+	_, err = NewUnicastCompression(CompressionSnappy, -1)
+	assert.Error(t, err)
+}
+
+// TestCompressMessage evaluates that compressed messages are read back from a decompressing reader, that their
+// compressed size is reported, and that the maximum message size applies to the decompressed messages.
+func TestCompressMessage(t *testing.T) {
+	msg := &message.Message{
+		ChannelID: "test",
+		Type:      "messages.ChunkDataResponse",
+		Payload:   bytes.Repeat([]byte("chunk data "), 10000),
+	}
+
+	var buf bytes.Buffer
+	size, _, err := compressMessage(&buf, msg, CompressionSnappy)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Len(), size)
+	assert.Less(t, size, msg.Size()/10)
+	compressed := buf.Bytes()
+
+	var decompressed message.Message
+	r := ggio.NewDelimitedReader(decompressingReader(bytes.NewReader(compressed), CompressionSnappy), msg.Size()+100)
+	require.NoError(t, r.ReadMsg(&decompressed))
+	assert.Equal(t, msg, &decompressed)
+
+	r = ggio.NewDelimitedReader(decompressingReader(bytes.NewReader(compressed), CompressionSnappy), len(compressed))
+	assert.Error(t, r.ReadMsg(&decompressed))
+}
+
+// TestStreamCompression evaluates that the compression of streams is given by their protocol ID.
+func TestStreamCompression(t *testing.T) {
+	protocolID := generateProtocolID(rootBlockID)
+
+	compression, err := streamCompression(protocolID, protocolID)
+	require.NoError(t, err)
+	assert.Equal(t, CompressionNone, compression)
+
+	compression, err = streamCompression(protocolID, compressedProtocolID(protocolID, CompressionSnappy))
+	require.NoError(t, err)
+	assert.Equal(t, CompressionSnappy, compression)
+
+	_, err = streamCompression(protocolID, compressedProtocolID(protocolID, "unknown"))
+	assert.Error(t, err)
+	_, err = streamCompression(protocolID, generateProtocolID("other"))
+	assert.Error(t, err)
+}
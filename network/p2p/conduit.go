@@ -23,6 +23,10 @@ type MulticastFunc func(channel network.Channel, event interface{}, num uint, ta
 // CloseFunc is a function that unsubscribes the conduit from the channel
 type CloseFunc func(channel network.Channel) error
 
+// ReportMisbehaviorFunc is a function that reports the misbehavior of a node on the channel
+// to the network layer
+type ReportMisbehaviorFunc func(channel network.Channel, originID flow.Identifier, severity network.Severity, reason string)
+
 // Conduit is a helper of the overlay layer which functions as an accessor for
 // sending messages within a single engine process. It sends all messages to
 // what can be considered a bus reserved for that specific engine.
@@ -34,6 +38,7 @@ type Conduit struct {
 	unicast   UnicastFunc
 	multicast MulticastFunc
 	close     CloseFunc
+	report    ReportMisbehaviorFunc
 }
 
 // Publish sends an event to the network layer for unreliable delivery
@@ -66,6 +71,12 @@ func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Ident
 	return c.multicast(c.channel, event, num, targetIDs...)
 }
 
+// ReportMisbehavior reports that the node with the given ID misbehaved on the channel of the conduit,
+// which lowers its score in the network layer.
+func (c *Conduit) ReportMisbehavior(originID flow.Identifier, severity network.Severity, reason string) {
+	c.report(c.channel, originID, severity, reason)
+}
+
 func (c *Conduit) Close() error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit for channel %s already closed", c.channel)
@@ -52,15 +52,16 @@ type LibP2PFactoryFunc func() (*Node, error)
 // DefaultLibP2PNodeFactory is a factory function that receives a middleware instance and generates a libp2p Node by invoking its factory with
 // proper parameters.
 func DefaultLibP2PNodeFactory(log zerolog.Logger, me flow.Identifier, address string, flowKey fcrypto.PrivateKey, rootBlockID string,
-	maxPubSubMsgSize int, metrics module.NetworkMetrics) (LibP2PFactoryFunc, error) {
+	maxPubSubMsgSize int, metrics module.NetworkMetrics, scorer *PeerScorer) (LibP2PFactoryFunc, error) {
 	// create PubSub options for libp2p to use
 	psOptions := []pubsub.Option{
-		// skip message signing
-		pubsub.WithMessageSigning(false),
-		// skip message signature
-		pubsub.WithStrictSignatureVerification(false),
+		// sign messages with the networking key and drop unsigned messages, so that the author of
+		// a message can be authenticated
+		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
 		// set max message size limit for 1-k PubSub messaging
 		pubsub.WithMaxMessageSize(maxPubSubMsgSize),
+		// score gossipsub peers with the score of their node, lowered by their misbehavior
+		scorer.gossipSubOption(),
 	}
 
 	return func() (*Node, error) {
@@ -231,7 +232,7 @@ func (n *Node) RemovePeer(ctx context.Context, identity flow.Identity) error {
 // CreateStream returns an existing stream connected to identity, if it exists or adds one to identity as a peer and creates a new stream with it.
 func (n *Node) CreateStream(ctx context.Context, identity flow.Identity) (libp2pnet.Stream, error) {
 	// Open libp2p Stream with the remote peer (will use an existing TCP connection underneath if it exists)
-	stream, err := n.tryCreateNewStream(ctx, identity, maxConnectAttempt)
+	stream, err := n.tryCreateNewStream(ctx, identity, maxConnectAttempt, n.flowLibP2PProtocolID)
 	if err != nil {
 		return nil, flownet.NewPeerUnreachableError(fmt.Errorf("could not create stream (node_id: %s, address: %s): %w", identity.NodeID.String(),
 			identity.Address, err))
@@ -239,10 +240,33 @@ func (n *Node) CreateStream(ctx context.Context, identity flow.Identity) (libp2p
 	return stream, nil
 }
 
-// tryCreateNewStream makes at most maxAttempts to create a stream with the identity.
+// CreateCompressedStream creates a new stream to identity like CreateStream, which is compressed with the given
+// algorithm if the remote node supports it, and uncompressed otherwise. The compression of the returned stream
+// is given by StreamCompression.
+func (n *Node) CreateCompressedStream(ctx context.Context, identity flow.Identity, algorithm string) (libp2pnet.Stream, error) {
+	// the protocols are negotiated in order of preference, so that nodes which don't support the compressed
+	// protocol fall back to the uncompressed one
+	stream, err := n.tryCreateNewStream(ctx, identity, maxConnectAttempt,
+		compressedProtocolID(n.flowLibP2PProtocolID, algorithm),
+		n.flowLibP2PProtocolID)
+	if err != nil {
+		return nil, flownet.NewPeerUnreachableError(fmt.Errorf("could not create compressed stream (node_id: %s, address: %s): %w", identity.NodeID.String(),
+			identity.Address, err))
+	}
+	return stream, nil
+}
+
+// StreamCompression returns the compression algorithm negotiated for the given stream, which is CompressionNone
+// for uncompressed streams.
+func (n *Node) StreamCompression(stream libp2pnet.Stream) (string, error) {
+	return streamCompression(n.flowLibP2PProtocolID, stream.Protocol())
+}
+
+// tryCreateNewStream makes at most maxAttempts to create a stream with the identity, for the first of the given
+// protocols supported by the identity.
 // This was put in as a fix for #2416. PubSub and 1-1 communication compete with each other when trying to connect to
 // remote nodes and once in a while NewStream returns an error 'both yamux endpoints are clients'
-func (n *Node) tryCreateNewStream(ctx context.Context, identity flow.Identity, maxAttempts int) (libp2pnet.Stream, error) {
+func (n *Node) tryCreateNewStream(ctx context.Context, identity flow.Identity, maxAttempts int, protocolIDs ...protocol.ID) (libp2pnet.Stream, error) {
 	_, _, key, err := networkingInfo(identity)
 	if err != nil {
 		return nil, fmt.Errorf("could not get translate identity to networking info %s: %w", identity.NodeID.String(), err)
@@ -296,7 +320,7 @@ func (n *Node) tryCreateNewStream(ctx context.Context, identity flow.Identity, m
 			continue
 		}
 
-		s, err = n.host.NewStream(ctx, peerID, n.flowLibP2PProtocolID)
+		s, err = n.host.NewStream(ctx, peerID, protocolIDs...)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
@@ -455,9 +479,13 @@ func (n *Node) Host() host.Host {
 	return n.host
 }
 
-// SetStreamHandler sets the stream handler of libp2p host of the node.
+// SetStreamHandler sets the stream handler of libp2p host of the node, for uncompressed streams as well as
+// streams compressed with any of the supported algorithms.
 func (n *Node) SetStreamHandler(handler libp2pnet.StreamHandler) {
 	n.host.SetStreamHandler(n.flowLibP2PProtocolID, handler)
+	for algorithm := range compressors {
+		n.host.SetStreamHandler(compressedProtocolID(n.flowLibP2PProtocolID, algorithm), handler)
+	}
 }
 
 // IsConnected returns true is address is a direct peer of this node else false
@@ -240,6 +240,27 @@ func (suite *LibP2PNodeTestSuite) TestCreateStream() {
 	}
 }
 
+// TestCreateCompressedStream checks that compressed streams are negotiated with nodes supporting the compression
+// algorithm, and that uncompressed streams are negotiated with the other nodes
+func (suite *LibP2PNodeTestSuite) TestCreateCompressedStream() {
+
+	// Creates nodes
+	nodes, identities := suite.NodesFixture(3, nil, false)
+	defer StopNodes(suite.T(), nodes)
+
+	// the last node does not support the compressed protocol, like nodes of earlier versions
+	nodes[2].host.RemoveStreamHandler(compressedProtocolID(nodes[2].flowLibP2PProtocolID, CompressionSnappy))
+
+	for i, expected := range map[int]string{1: CompressionSnappy, 2: CompressionNone} {
+		s, err := nodes[0].CreateCompressedStream(context.Background(), *identities[i], CompressionSnappy)
+		require.NoError(suite.T(), err)
+		compression, err := nodes[0].StreamCompression(s)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), expected, compression)
+		assert.NoError(suite.T(), s.Close())
+	}
+}
+
 // TestOneToOneComm sends a message from node 1 to node 2 and then from node 2 to node 1
 func (suite *LibP2PNodeTestSuite) TestOneToOneComm() {
 
@@ -14,6 +14,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/multiformats/go-multiaddr"
 
+	fcrypto "github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -112,6 +113,21 @@ func networkingInfo(identity flow.Identity) (string, string, crypto.PubKey, erro
 	return ip, port, lkey, nil
 }
 
+// peerIDFromFlowKey returns the libp2p peer ID of the node with the given networking key.
+func peerIDFromFlowKey(key fcrypto.PublicKey) (peer.ID, error) {
+	lkey, err := publicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("could not convert flow key to libp2p key: %w", err)
+	}
+
+	id, err := peer.IDFromPublicKey(lkey)
+	if err != nil {
+		return "", fmt.Errorf("could not extract libp2p id from key: %w", err)
+	}
+
+	return id, nil
+}
+
 // MultiAddressStr receives a node ip and port and returns
 // its corresponding Libp2p MultiAddressStr in string format
 // in current implementation IP part of the node address is
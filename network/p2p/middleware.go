@@ -6,11 +6,13 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	ggio "github.com/gogo/protobuf/io"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/engine"
@@ -69,9 +71,15 @@ type Middleware struct {
 	libP2PNodeFactory LibP2PFactoryFunc
 	me                flow.Identifier
 	metrics           module.NetworkMetrics
+	scorer            *PeerScorer
+	limiter           *RateLimiter
+	compression       UnicastCompression
 	rootBlockID       string
 	validators        []network.MessageValidator
 	peerManager       *PeerManager
+	idsLock           sync.RWMutex
+	identities        map[flow.Identifier]flow.Identity // identities of the other nodes, used to authorize incoming messages
+	peerIDs           map[flow.Identifier]peer.ID       // libp2p peer IDs of the other nodes, used to authenticate incoming messages
 }
 
 // NewMiddleware creates a new middleware instance with the given config and using the
@@ -80,18 +88,16 @@ func NewMiddleware(log zerolog.Logger,
 	libP2PNodeFactory LibP2PFactoryFunc,
 	flowID flow.Identifier,
 	metrics module.NetworkMetrics,
+	scorer *PeerScorer,
+	limiter *RateLimiter,
+	compression UnicastCompression,
 	rootBlockID string,
 	validators ...network.MessageValidator) *Middleware {
 
-	if len(validators) == 0 {
-		// add default validators to filter out unwanted messages received by this node
-		validators = defaultValidators(log, flowID)
-	}
-
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// create the node entity and inject dependencies & config
-	return &Middleware{
+	m := &Middleware{
 		ctx:               ctx,
 		cancel:            cancel,
 		log:               log,
@@ -99,15 +105,26 @@ func NewMiddleware(log zerolog.Logger,
 		me:                flowID,
 		libP2PNodeFactory: libP2PNodeFactory,
 		metrics:           metrics,
+		scorer:            scorer,
+		limiter:           limiter,
+		compression:       compression,
 		rootBlockID:       rootBlockID,
 		validators:        validators,
 	}
+
+	if len(validators) == 0 {
+		// add default validators to filter out unwanted messages received by this node
+		m.validators = defaultValidators(log, flowID, m.originIdentity, metrics)
+	}
+
+	return m
 }
 
-func defaultValidators(log zerolog.Logger, flowID flow.Identifier) []network.MessageValidator {
+func defaultValidators(log zerolog.Logger, flowID flow.Identifier, identity validator.IdentityFunc, metrics module.NetworkMetrics) []network.MessageValidator {
 	return []network.MessageValidator{
-		validator.NewSenderValidator(flowID),      // validator to filter out messages sent by this node itself
-		validator.NewTargetValidator(log, flowID), // validator to filter out messages not intended for this node
+		validator.NewSenderValidator(flowID),                      // validator to filter out messages sent by this node itself
+		validator.NewTargetValidator(log, flowID),                 // validator to filter out messages not intended for this node
+		validator.NewChannelRoleValidator(log, identity, metrics), // validator to filter out messages from roles which do not send on their channel
 	}
 }
 
@@ -138,17 +155,19 @@ func (m *Middleware) Start(ov network.Overlay) error {
 		return fmt.Errorf("could not get identities: %w", err)
 	}
 
-	err = m.libP2PNode.UpdateAllowList(identityList(idsMap))
+	err = m.libP2PNode.UpdateAllowList(identityList(idsMap).Filter(m.scorer.NotDisconnected()))
 	if err != nil {
 		return fmt.Errorf("could not update approved peer list: %w", err)
 	}
 
+	m.updateIdentities(idsMap)
+
 	libp2pConnector, err := newLibp2pConnector(m.libP2PNode.Host(), m.log)
 	if err != nil {
 		return fmt.Errorf("failed to create libp2pConnector: %w", err)
 	}
 
-	m.peerManager = NewPeerManager(m.log, m.ov.Topology, libp2pConnector)
+	m.peerManager = NewPeerManager(m.log, m.topology, libp2pConnector)
 	select {
 	case <-m.peerManager.Ready():
 		m.log.Debug().Msg("peer manager successfully started")
@@ -260,16 +279,31 @@ func (m *Middleware) SendDirect(msg *message.Message, targetID flow.Identifier)
 	// (streams don't need to be reused and are fairly inexpensive to be created for each send.
 	// A stream creation does NOT incur an RTT as stream negotiation happens as part of the first message
 	// sent out the the receiver
-	stream, err := m.libP2PNode.CreateStream(ctx, targetIdentity)
+	var stream libp2pnetwork.Stream
+	if m.compression.applies(msg) {
+		stream, err = m.libP2PNode.CreateCompressedStream(ctx, targetIdentity, m.compression.Algorithm)
+	} else {
+		stream, err = m.libP2PNode.CreateStream(ctx, targetIdentity)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create stream for %s :%w", targetID.String(), err)
 	}
 
-	// create a gogo protobuf writer
-	bufw := bufio.NewWriter(stream)
-	writer := ggio.NewDelimitedWriter(bufw)
+	// the stream is uncompressed if the target does not support the compression algorithm
+	compression, err := m.libP2PNode.StreamCompression(stream)
+	if err != nil {
+		_ = stream.Reset()
+		return fmt.Errorf("failed to get stream compression for %s: %w", targetID.String(), err)
+	}
 
-	err = writer.WriteMsg(msg)
+	bufw := bufio.NewWriter(stream)
+	if compression == CompressionNone {
+		// create a gogo protobuf writer
+		writer := ggio.NewDelimitedWriter(bufw)
+		err = writer.WriteMsg(msg)
+	} else {
+		err = m.writeCompressed(bufw, msg, compression)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to send message to %s: %w", targetID.String(), err)
 	}
@@ -292,6 +326,18 @@ func (m *Middleware) SendDirect(msg *message.Message, targetID flow.Identifier)
 	return nil
 }
 
+// writeCompressed writes the message compressed with the given algorithm.
+func (m *Middleware) writeCompressed(w io.Writer, msg *message.Message, compression string) error {
+	size, duration, err := compressMessage(w, msg, compression)
+	if err != nil {
+		return err
+	}
+
+	m.metrics.NetworkMessageCompressed(msg.Type, compression, float64(msg.Size())/float64(size), duration)
+
+	return nil
+}
+
 // identity returns corresponding identity of an identifier based on overlay identity list.
 func (m *Middleware) identity(identifier flow.Identifier) (flow.Identity, error) {
 	// get the node identity map from the overlay
@@ -309,6 +355,48 @@ func (m *Middleware) identity(identifier flow.Identifier) (flow.Identity, error)
 	return flowIdentity, nil
 }
 
+// updateIdentities updates the identities and peer IDs of the other nodes, used to authenticate and authorize
+// incoming messages.
+func (m *Middleware) updateIdentities(idsMap map[flow.Identifier]flow.Identity) {
+	peerIDs := make(map[flow.Identifier]peer.ID, len(idsMap))
+	for nodeID, identity := range idsMap {
+		peerID, err := peerIDFromFlowKey(identity.NetworkPubKey)
+		if err != nil {
+			// messages from this node can't be authenticated, and are dropped
+			m.log.Warn().Err(err).Hex("node_id", nodeID[:]).Msg("could not get peer ID of node")
+			continue
+		}
+		peerIDs[nodeID] = peerID
+	}
+
+	m.scorer.updatePeerIDs(peerIDs)
+	m.limiter.prune(peerIDs)
+
+	m.idsLock.Lock()
+	defer m.idsLock.Unlock()
+	m.identities = idsMap
+	m.peerIDs = peerIDs
+}
+
+// originIdentity returns the identity of another node, and false if the node is unknown.
+func (m *Middleware) originIdentity(nodeID flow.Identifier) (*flow.Identity, bool) {
+	m.idsLock.RLock()
+	defer m.idsLock.RUnlock()
+	identity, ok := m.identities[nodeID]
+	if !ok {
+		return nil, false
+	}
+	return &identity, true
+}
+
+// authenticate returns true if the given peer is the peer of the node with the given origin ID.
+func (m *Middleware) authenticate(originID flow.Identifier, peerID peer.ID) bool {
+	m.idsLock.RLock()
+	defer m.idsLock.RUnlock()
+	expected, ok := m.peerIDs[originID]
+	return ok && expected == peerID
+}
+
 // identityList translates an identity map into an identity list.
 func identityList(identityMap map[flow.Identifier]flow.Identity) flow.IdentityList {
 	var identities flow.IdentityList
@@ -334,8 +422,16 @@ func (m *Middleware) handleIncomingStream(s libp2pnetwork.Stream) {
 
 	log.Info().Msg("incoming connection established")
 
+	// streams are compressed if they were negotiated for a compressed protocol
+	compression, err := m.libP2PNode.StreamCompression(s)
+	if err != nil {
+		log.Error().Err(err).Msg("could not get stream compression")
+		_ = s.Reset()
+		return
+	}
+
 	//create a new readConnection with the context of the middleware
-	conn := newReadConnection(m.ctx, s, m.processMessage, log, m.metrics, LargeMsgMaxUnicastMsgSize)
+	conn := newReadConnection(m.ctx, s, m.processMessage, log, m.metrics, LargeMsgMaxUnicastMsgSize, compression)
 
 	// kick off the receive loop to continuously receive messages
 	m.wg.Add(1)
@@ -378,8 +474,21 @@ func (m *Middleware) Unsubscribe(channel network.Channel) error {
 	return nil
 }
 
-// processMessage processes a message and eventually passes it to the overlay
-func (m *Middleware) processMessage(msg *message.Message) {
+// processMessage processes a message delivered by the given peer and eventually passes it to the overlay
+func (m *Middleware) processMessage(msg *message.Message, peerID peer.ID) {
+
+	// drop the message if its peer exceeded a rate limit, before spending any more resources on it
+	if ok, limit := m.limiter.Allow(peerID, network.Channel(msg.ChannelID)); !ok {
+		m.log.Debug().
+			Str("peer_id", peerID.String()).
+			Hex("event_id", msg.EventID).
+			Str("channel", msg.ChannelID).
+			Str("type", msg.Type).
+			Str("limit", limit).
+			Msg("dropping message exceeding rate limit")
+		m.metrics.NetworkRateLimitedMessagesDropped(msg.ChannelID, msg.Type, limit)
+		return
+	}
 
 	// run through all the message validators
 	for _, v := range m.validators {
@@ -389,8 +498,33 @@ func (m *Middleware) processMessage(msg *message.Message) {
 		}
 	}
 
+	// drop the message if it was not delivered by the node it claims to originate from
+	originID := flow.HashToID(msg.OriginID)
+	if !m.authenticate(originID, peerID) {
+		m.log.Warn().
+			Hex("origin_id", msg.OriginID).
+			Str("peer_id", peerID.String()).
+			Hex("event_id", msg.EventID).
+			Str("channel", msg.ChannelID).
+			Str("type", msg.Type).
+			Msg("dropping message with unauthenticated origin")
+		m.metrics.NetworkUnauthenticatedMessagesDropped(msg.ChannelID, msg.Type)
+		return
+	}
+
+	// drop the message if its origin is disconnected or throttled because of its misbehavior
+	if !m.scorer.Allow(originID) {
+		m.log.Debug().
+			Hex("origin_id", msg.OriginID).
+			Hex("event_id", msg.EventID).
+			Str("channel", msg.ChannelID).
+			Str("type", msg.Type).
+			Msg("dropping message from penalized origin")
+		return
+	}
+
 	// if validation passed, send the message to the overlay
-	err := m.ov.Receive(flow.HashToID(msg.OriginID), msg)
+	err := m.ov.Receive(originID, msg)
 	if err != nil {
 		m.log.Error().Err(err).Msg("could not deliver payload")
 	}
@@ -446,18 +580,54 @@ func (m *Middleware) UpdateAllowList() error {
 		return fmt.Errorf("could not get identities: %w", err)
 	}
 
-	// update libp2pNode's approve lists
-	err = m.libP2PNode.UpdateAllowList(identityList(idsMap))
+	// update libp2pNode's approve lists, leaving out the nodes disconnected because of their misbehavior
+	err = m.libP2PNode.UpdateAllowList(identityList(idsMap).Filter(m.scorer.NotDisconnected()))
 	if err != nil {
 		return fmt.Errorf("failed to update approved peer list: %w", err)
 	}
 
+	m.updateIdentities(idsMap)
+
 	// update peer connections
 	m.peerManager.RequestPeerUpdate()
 
 	return nil
 }
 
+// ReportMisbehavior lowers the score of the node with the given ID according to the severity of its misbehavior.
+// Once its score is low enough, the node is disconnected for DisconnectDuration.
+func (m *Middleware) ReportMisbehavior(originID flow.Identifier, channel network.Channel, severity network.Severity, reason string) {
+	_, disconnected := m.scorer.Report(originID, channel, severity, reason)
+	if !disconnected {
+		return
+	}
+
+	// remove the node from the allow list and the peers of this node, and add it back once the penalty expired
+	m.refreshPeers()
+	time.AfterFunc(DisconnectDuration, m.refreshPeers)
+}
+
+// refreshPeers updates the allow list and the peer connections according to the current penalties of the nodes.
+func (m *Middleware) refreshPeers() {
+	if m.ctx.Err() != nil {
+		return
+	}
+	err := m.UpdateAllowList()
+	if err != nil {
+		m.log.Error().Err(err).Msg("could not update allow list after penalty change")
+	}
+}
+
+// topology returns the nodes this node should be connected to, leaving out the nodes disconnected because of
+// their misbehavior.
+func (m *Middleware) topology() (flow.IdentityList, error) {
+	ids, err := m.ov.Topology()
+	if err != nil {
+		return nil, err
+	}
+	return ids.Filter(m.scorer.NotDisconnected()), nil
+}
+
 // IsConnected returns true if this node is connected to the node with id nodeID.
 func (m *Middleware) IsConnected(identity flow.Identity) (bool, error) {
 	return m.libP2PNode.IsConnected(identity)
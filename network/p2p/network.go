@@ -31,7 +31,8 @@ type Network struct {
 	mw      network.Middleware
 	top     network.Topology // used to determine fanout connections
 	metrics module.NetworkMetrics
-	rcache  *RcvCache // used to deduplicate incoming messages
+	rcache  *RcvCache    // used to deduplicate incoming messages
+	limiter *RateLimiter // used to limit the rate of incoming messages of each type
 	queue   network.MessageQueue
 	ctx     context.Context
 	cancel  context.CancelFunc
@@ -52,6 +53,7 @@ func NewNetwork(
 	csize int,
 	top network.Topology,
 	sm network.SubscriptionManager,
+	limiter *RateLimiter,
 	metrics module.NetworkMetrics,
 ) (*Network, error) {
 
@@ -69,6 +71,7 @@ func NewNetwork(
 		top:     top,
 		metrics: metrics,
 		subMngr: sm,
+		limiter: limiter,
 	}
 	o.ctx, o.cancel = context.WithCancel(context.Background())
 	o.ids = ids
@@ -136,6 +139,7 @@ func (n *Network) Register(channel network.Channel, engine network.Engine) (netw
 		unicast:   n.unicast,
 		multicast: n.multicast,
 		close:     n.unregister,
+		report:    n.reportMisbehavior,
 	}
 
 	return conduit, nil
@@ -151,6 +155,16 @@ func (n *Network) unregister(channel network.Channel) error {
 	return nil
 }
 
+// reportMisbehavior forwards the misbehavior of a node reported by an engine to the middleware
+func (n *Network) reportMisbehavior(channel network.Channel, originID flow.Identifier, severity network.Severity, reason string) {
+	if originID == n.me.NodeID() {
+		// messages submitted locally, e.g. synced blocks, are reported with this node as origin
+		n.logger.Debug().Str("reason", reason).Msg("network skips self misbehavior report")
+		return
+	}
+	n.mw.ReportMisbehavior(originID, channel, severity, reason)
+}
+
 // Identity returns a map of all flow.Identifier to flow identity by querying the flow state
 func (n *Network) Identity() (map[flow.Identifier]flow.Identity, error) {
 	n.RLock()
@@ -227,6 +241,19 @@ func (n *Network) processNetworkMessage(senderID flow.Identifier, message *messa
 		return fmt.Errorf("could not decode event: %w", err)
 	}
 
+	// drops the message if its origin exceeded the limit on its type, which is only known once it is decoded
+	msgType := messageType(decodedMessage)
+	if !n.limiter.AllowMessage(senderID, msgType) {
+		n.logger.Debug().
+			Hex("sender_id", senderID[:]).
+			Hex("event_id", message.EventID).
+			Str("channel", message.ChannelID).
+			Str("type", msgType).
+			Msg("dropping message exceeding rate limit")
+		n.metrics.NetworkRateLimitedMessagesDropped(message.ChannelID, msgType, LimitMessage)
+		return nil
+	}
+
 	// create queue message
 	qm := queue.QMessage{
 		Payload:  decodedMessage,
@@ -276,9 +303,6 @@ func (n *Network) genNetworkMessage(channel network.Channel, event interface{},
 	selfID := n.me.NodeID()
 	originID := selfID[:]
 
-	// get message type from event type and remove the asterisk prefix if present
-	msgType := strings.TrimLeft(fmt.Sprintf("%T", event), "*")
-
 	// cast event to a libp2p.Message
 	msg := &message.Message{
 		ChannelID: channel.String(),
@@ -286,12 +310,17 @@ func (n *Network) genNetworkMessage(channel network.Channel, event interface{},
 		OriginID:  originID,
 		TargetIDs: emTargets,
 		Payload:   payload,
-		Type:      msgType,
+		Type:      messageType(event),
 	}
 
 	return msg, nil
 }
 
+// messageType returns the type of the given event, without the asterisk prefix if present.
+func messageType(event interface{}) string {
+	return strings.TrimLeft(fmt.Sprintf("%T", event), "*")
+}
+
 // unicast sends the message in a reliable way to the given recipient.
 // It uses 1-1 direct messaging over the underlying network to deliver the message.
 // It returns an error if unicasting fails.
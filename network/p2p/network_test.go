@@ -0,0 +1,60 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/network/message"
+	"github.com/onflow/flow-go/network/queue"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestNetwork_RateLimitsDecodedType evaluates that the limits on message types are enforced on the type of the
+// decoded payload, so that they can not be evaded by claiming another type in the message.
+func TestNetwork_RateLimitsDecodedType(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rcache, err := newRcvCache(100)
+	require.NoError(t, err)
+
+	codec := json.NewCodec()
+	collector := metrics.NewNoopCollector()
+	net := &Network{
+		logger:  zerolog.Nop(),
+		codec:   codec,
+		metrics: collector,
+		rcache:  rcache,
+		limiter: NewRateLimiter(RateLimits{
+			Messages: map[string]RateLimit{"messages.SyncRequest": {Rate: 1, Burst: 2}},
+		}),
+		queue: queue.NewMessageQueue(ctx, queue.GetEventPriority, collector),
+	}
+	originID := unittest.IdentifierFixture()
+
+	for i := 0; i < 5; i++ {
+		payload, err := codec.Encode(&messages.SyncRequest{Nonce: uint64(i)})
+		require.NoError(t, err)
+		eventID := unittest.IdentifierFixture()
+
+		err = net.Receive(originID, &message.Message{
+			ChannelID: engine.SyncCommittee.String(),
+			EventID:   eventID[:],
+			OriginID:  originID[:],
+			Payload:   payload,
+			Type:      "messages.BlockProposal",
+		})
+		require.NoError(t, err)
+	}
+
+	// only the burst of the limit on sync requests was queued, despite the type claimed by the messages
+	assert.Equal(t, 2, net.queue.Len())
+}
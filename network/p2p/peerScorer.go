@@ -0,0 +1,288 @@
+package p2p
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+)
+
+// Penalty is imposed by the network layer on nodes with a low score.
+type Penalty int
+
+const (
+	// PenaltyNone is the penalty of nodes with a score above ThrottleThreshold.
+	PenaltyNone Penalty = iota
+	// PenaltyThrottled is the penalty of nodes with a score below ThrottleThreshold: at most one of their messages
+	// is accepted per ThrottleInterval, and gossipsub does not exchange gossip with them.
+	PenaltyThrottled
+	// PenaltyDisconnected is the penalty of nodes whose score fell below DisconnectThreshold: they are removed from
+	// the allow list and the peers of this node for DisconnectDuration.
+	PenaltyDisconnected
+)
+
+func (p Penalty) String() string {
+	switch p {
+	case PenaltyNone:
+		return "none"
+	case PenaltyThrottled:
+		return "throttled"
+	case PenaltyDisconnected:
+		return "disconnected"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// ThrottleThreshold is the score below which a node is throttled
+	ThrottleThreshold = -25.0
+
+	// DisconnectThreshold is the score below which a node is disconnected
+	DisconnectThreshold = -100.0
+
+	// ThrottleInterval is the minimum interval between two messages accepted from a throttled node
+	ThrottleInterval = time.Second
+
+	// DisconnectDuration is how long a node is disconnected once its score fell below DisconnectThreshold
+	DisconnectDuration = 10 * time.Minute
+
+	// ScoreHalfLife is the time after which the score of a node decays to half of its value
+	ScoreHalfLife = 10 * time.Minute
+
+	// minScore is the magnitude under which a decayed score is considered zero, and no longer tracked
+	minScore = 0.1
+)
+
+// severityScores maps the severity of a misbehavior to the amount by which it lowers the score of a node.
+var severityScores = map[network.Severity]float64{
+	network.SeverityLow:      1,
+	network.SeverityMedium:   5,
+	network.SeverityHigh:     25,
+	network.SeverityCritical: 100,
+}
+
+// peerScore is the score of a single node.
+type peerScore struct {
+	value             float64   // the score at the time of the last update, not decayed since
+	updated           time.Time // the time of the last update
+	disconnectedUntil time.Time // the time until which the node is disconnected
+	lastAccepted      time.Time // the time the last message of the node was accepted while it was throttled
+}
+
+// PeerScorer tracks the score of other nodes, which is lowered by their misbehavior reported by engines, and
+// decays back to zero over time. Nodes with a low score are penalized by the network layer.
+type PeerScorer struct {
+	sync.Mutex
+	log     zerolog.Logger
+	metrics module.NetworkMetrics
+	scores  map[flow.Identifier]*peerScore
+	nodeIDs map[peer.ID]flow.Identifier // node IDs of the libp2p peers, used to score gossipsub peers
+	now     func() time.Time
+}
+
+// NewPeerScorer creates a new peer scorer, in which all nodes have a score of zero.
+func NewPeerScorer(log zerolog.Logger, metrics module.NetworkMetrics) *PeerScorer {
+	return &PeerScorer{
+		log:     log.With().Str("component", "peer_scorer").Logger(),
+		metrics: metrics,
+		scores:  make(map[flow.Identifier]*peerScore),
+		nodeIDs: make(map[peer.ID]flow.Identifier),
+		now:     time.Now,
+	}
+}
+
+// Report lowers the score of the node according to the severity of its misbehavior. It returns the penalty of
+// the node after the report, and true if the node was disconnected because of it.
+func (s *PeerScorer) Report(nodeID flow.Identifier, channel network.Channel, severity network.Severity, reason string) (Penalty, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	score, ok := s.scores[nodeID]
+	if !ok {
+		score = &peerScore{updated: now}
+		s.scores[nodeID] = score
+	}
+
+	before := s.penalty(score, now)
+	score.value = s.decay(score, now) - severityScores[severity]
+	score.updated = now
+
+	if score.value < DisconnectThreshold && before != PenaltyDisconnected {
+		score.disconnectedUntil = now.Add(DisconnectDuration)
+	}
+	after := s.penalty(score, now)
+
+	s.metrics.PeerMisbehaviorReported(nodeID.String(), channel.String(), severity.String())
+	s.metrics.PeerScore(nodeID.String(), score.value)
+
+	log := s.log.With().
+		Hex("node_id", nodeID[:]).
+		Str("channel", channel.String()).
+		Str("severity", severity.String()).
+		Str("reason", reason).
+		Float64("score", score.value).
+		Logger()
+
+	if after == before {
+		log.Info().Msg("misbehavior reported")
+		return after, false
+	}
+
+	s.metrics.PeerPenalized(nodeID.String(), after.String())
+	log.Warn().Str("penalty", after.String()).Msg("node penalized after misbehavior report")
+
+	return after, after == PenaltyDisconnected
+}
+
+// Score returns the current score of the node, which is zero for nodes that never misbehaved.
+func (s *PeerScorer) Score(nodeID flow.Identifier) float64 {
+	s.Lock()
+	defer s.Unlock()
+
+	score, ok := s.scores[nodeID]
+	if !ok {
+		return 0
+	}
+	return s.refresh(nodeID, score, s.now())
+}
+
+// Penalty returns the current penalty of the node.
+func (s *PeerScorer) Penalty(nodeID flow.Identifier) Penalty {
+	s.Lock()
+	defer s.Unlock()
+
+	score, ok := s.scores[nodeID]
+	if !ok {
+		return PenaltyNone
+	}
+	return s.penalty(score, s.now())
+}
+
+// Allow returns true if a message of the node should be accepted. Messages of disconnected nodes are never
+// accepted, and only one message per ThrottleInterval is accepted from throttled nodes.
+func (s *PeerScorer) Allow(nodeID flow.Identifier) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	score, ok := s.scores[nodeID]
+	if !ok {
+		return true
+	}
+
+	now := s.now()
+	switch s.penalty(score, now) {
+	case PenaltyDisconnected:
+		return false
+	case PenaltyThrottled:
+		if now.Sub(score.lastAccepted) < ThrottleInterval {
+			return false
+		}
+		score.lastAccepted = now
+		return true
+	default:
+		return true
+	}
+}
+
+// NotDisconnected returns an identity filter which removes the currently disconnected nodes.
+func (s *PeerScorer) NotDisconnected() flow.IdentityFilter {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	disconnected := make(map[flow.Identifier]struct{})
+	for nodeID, score := range s.scores {
+		if s.penalty(score, now) == PenaltyDisconnected {
+			disconnected[nodeID] = struct{}{}
+		}
+	}
+
+	return func(identity *flow.Identity) bool {
+		_, ok := disconnected[identity.NodeID]
+		return !ok
+	}
+}
+
+// updatePeerIDs updates the libp2p peer IDs of the nodes, used to look up the score of gossipsub peers.
+func (s *PeerScorer) updatePeerIDs(peerIDs map[flow.Identifier]peer.ID) {
+	nodeIDs := make(map[peer.ID]flow.Identifier, len(peerIDs))
+	for nodeID, peerID := range peerIDs {
+		nodeIDs[peerID] = nodeID
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.nodeIDs = nodeIDs
+}
+
+// gossipSubScore returns the score of a gossipsub peer, which is the score of its node.
+func (s *PeerScorer) gossipSubScore(peerID peer.ID) float64 {
+	s.Lock()
+	nodeID, ok := s.nodeIDs[peerID]
+	s.Unlock()
+	if !ok {
+		return 0
+	}
+	return s.Score(nodeID)
+}
+
+// gossipSubOption returns the pubsub option that makes gossipsub score peers with the score of their node, so
+// that it stops exchanging gossip with throttled nodes and ignores disconnected nodes.
+func (s *PeerScorer) gossipSubOption() pubsub.Option {
+	params := &pubsub.PeerScoreParams{
+		AppSpecificScore:  s.gossipSubScore,
+		AppSpecificWeight: 1,
+		DecayInterval:     time.Second,
+		DecayToZero:       0.01,
+	}
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:   ThrottleThreshold,
+		PublishThreshold:  ThrottleThreshold,
+		GraylistThreshold: DisconnectThreshold,
+	}
+	return pubsub.WithPeerScore(params, thresholds)
+}
+
+// refresh decays the score of the node to the given time, and stops tracking it once it decayed to zero.
+func (s *PeerScorer) refresh(nodeID flow.Identifier, score *peerScore, now time.Time) float64 {
+	score.value = s.decay(score, now)
+	score.updated = now
+
+	if math.Abs(score.value) < minScore && s.penalty(score, now) == PenaltyNone {
+		delete(s.scores, nodeID)
+		s.metrics.PeerScore(nodeID.String(), 0)
+		return 0
+	}
+
+	s.metrics.PeerScore(nodeID.String(), score.value)
+	return score.value
+}
+
+// decay returns the score of the node decayed to the given time.
+func (s *PeerScorer) decay(score *peerScore, now time.Time) float64 {
+	elapsed := now.Sub(score.updated)
+	if elapsed <= 0 {
+		return score.value
+	}
+	return score.value * math.Pow(0.5, float64(elapsed)/float64(ScoreHalfLife))
+}
+
+// penalty returns the penalty of the node at the given time.
+func (s *PeerScorer) penalty(score *peerScore, now time.Time) Penalty {
+	if now.Before(score.disconnectedUntil) {
+		return PenaltyDisconnected
+	}
+	if s.decay(score, now) < ThrottleThreshold {
+		return PenaltyThrottled
+	}
+	return PenaltyNone
+}
@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// newTestPeerScorer returns a peer scorer using a clock which is only advanced by the returned function.
+func newTestPeerScorer() (*PeerScorer, func(time.Duration)) {
+	scorer := NewPeerScorer(zerolog.Nop(), metrics.NewNoopCollector())
+	now := time.Now()
+	scorer.now = func() time.Time { return now }
+	return scorer, func(d time.Duration) { now = now.Add(d) }
+}
+
+// TestPeerScorer_Throttle evaluates that a node is throttled once its score falls below the throttle threshold,
+// and that only one of its messages is accepted per throttle interval.
+func TestPeerScorer_Throttle(t *testing.T) {
+	scorer, advance := newTestPeerScorer()
+	nodeID := unittest.IdentifierFixture()
+
+	// nodes that never misbehaved have no penalty
+	assert.Equal(t, 0.0, scorer.Score(nodeID))
+	assert.Equal(t, PenaltyNone, scorer.Penalty(nodeID))
+	assert.True(t, scorer.Allow(nodeID))
+
+	penalty, disconnected := scorer.Report(nodeID, engine.TestNetwork, network.SeverityMedium, "test")
+	assert.Equal(t, PenaltyNone, penalty)
+	assert.False(t, disconnected)
+	assert.Equal(t, -5.0, scorer.Score(nodeID))
+	assert.True(t, scorer.Allow(nodeID))
+	assert.True(t, scorer.Allow(nodeID))
+
+	penalty, disconnected = scorer.Report(nodeID, engine.TestNetwork, network.SeverityHigh, "test")
+	assert.Equal(t, PenaltyThrottled, penalty)
+	assert.False(t, disconnected)
+
+	// only one message is accepted per throttle interval
+	assert.True(t, scorer.Allow(nodeID))
+	assert.False(t, scorer.Allow(nodeID))
+	advance(ThrottleInterval)
+	assert.True(t, scorer.Allow(nodeID))
+
+	// other nodes are not affected
+	assert.True(t, scorer.Allow(unittest.IdentifierFixture()))
+}
+
+// TestPeerScorer_Disconnect evaluates that a node is disconnected for the disconnect duration once its score
+// falls below the disconnect threshold.
+func TestPeerScorer_Disconnect(t *testing.T) {
+	scorer, advance := newTestPeerScorer()
+	identities := unittest.IdentityListFixture(3)
+	nodeID := identities[0].NodeID
+
+	penalty, disconnected := scorer.Report(nodeID, engine.TestNetwork, network.SeverityCritical, "test")
+	assert.Equal(t, PenaltyThrottled, penalty)
+	assert.False(t, disconnected)
+
+	penalty, disconnected = scorer.Report(nodeID, engine.TestNetwork, network.SeverityLow, "test")
+	assert.Equal(t, PenaltyDisconnected, penalty)
+	assert.True(t, disconnected)
+
+	// further reports don't disconnect the node again
+	penalty, disconnected = scorer.Report(nodeID, engine.TestNetwork, network.SeverityLow, "test")
+	assert.Equal(t, PenaltyDisconnected, penalty)
+	assert.False(t, disconnected)
+
+	// messages of the disconnected node are dropped, and it is filtered out of identity lists
+	assert.False(t, scorer.Allow(nodeID))
+	assert.Equal(t, identities[1:], identities.Filter(scorer.NotDisconnected()))
+
+	// once the penalty expired, the node is only throttled, as its score has not decayed enough
+	advance(DisconnectDuration)
+	assert.Equal(t, PenaltyThrottled, scorer.Penalty(nodeID))
+	assert.Equal(t, identities, identities.Filter(scorer.NotDisconnected()))
+}
+
+// TestPeerScorer_Decay evaluates that the score of a node decays back to zero over time.
+func TestPeerScorer_Decay(t *testing.T) {
+	scorer, advance := newTestPeerScorer()
+	nodeID := unittest.IdentifierFixture()
+
+	scorer.Report(nodeID, engine.TestNetwork, network.SeverityHigh, "test")
+	scorer.Report(nodeID, engine.TestNetwork, network.SeverityMedium, "test")
+	assert.Equal(t, PenaltyThrottled, scorer.Penalty(nodeID))
+
+	advance(ScoreHalfLife)
+	assert.InDelta(t, -15.0, scorer.Score(nodeID), 0.001)
+	assert.Equal(t, PenaltyNone, scorer.Penalty(nodeID))
+
+	// once decayed to zero, the node is no longer tracked
+	advance(10 * ScoreHalfLife)
+	assert.Equal(t, 0.0, scorer.Score(nodeID))
+	scorer.Lock()
+	assert.NotContains(t, scorer.scores, nodeID)
+	scorer.Unlock()
+}
+
+// TestPeerScorer_GossipSubScore evaluates that gossipsub peers are scored with the score of their node.
+func TestPeerScorer_GossipSubScore(t *testing.T) {
+	scorer, _ := newTestPeerScorer()
+
+	key := generateNetworkingKey(t)
+	peerID, err := peerIDFromFlowKey(key.PublicKey())
+	require.NoError(t, err)
+
+	nodeID := unittest.IdentifierFixture()
+	scorer.updatePeerIDs(map[flow.Identifier]peer.ID{nodeID: peerID})
+
+	scorer.Report(nodeID, engine.TestNetwork, network.SeverityHigh, "test")
+	assert.Equal(t, -25.0, scorer.gossipSubScore(peerID))
+
+	// unknown peers have a neutral score
+	assert.Equal(t, 0.0, scorer.gossipSubScore(peer.ID("unknown")))
+}
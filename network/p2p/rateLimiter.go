@@ -0,0 +1,268 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+)
+
+const (
+	// LimitPeer is reported when a message exceeds the limit on all messages of its peer
+	LimitPeer = "peer"
+
+	// LimitChannel is reported when a message exceeds the limit on the messages of its peer on its channel
+	LimitChannel = "channel"
+
+	// LimitMessage is reported when a message exceeds the limit on the messages of its peer of its type
+	LimitMessage = "message"
+)
+
+// RateLimit configures a token bucket, which accepts bursts of up to Burst messages and refills at Rate messages
+// per second. A rate of zero disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits configures the limits on inbound messages, which are enforced separately for each peer, so that a
+// single noisy peer can not starve the traffic of the others.
+type RateLimits struct {
+	Peer     RateLimit                     // limit on all messages of a peer
+	Channels map[network.Channel]RateLimit // limits on the messages of a peer on a channel
+	Messages map[string]RateLimit          // limits on the messages of a peer of a decoded type
+}
+
+// Scale returns the limits with all rates and bursts multiplied by the given factor.
+func (l RateLimits) Scale(factor float64) RateLimits {
+	scale := func(limit RateLimit) RateLimit {
+		return RateLimit{Rate: limit.Rate * factor, Burst: int(float64(limit.Burst) * factor)}
+	}
+
+	scaled := RateLimits{
+		Peer:     scale(l.Peer),
+		Channels: make(map[network.Channel]RateLimit, len(l.Channels)),
+		Messages: make(map[string]RateLimit, len(l.Messages)),
+	}
+	for channel, limit := range l.Channels {
+		scaled.Channels[channel] = scale(limit)
+	}
+	for msgType, limit := range l.Messages {
+		scaled.Messages[msgType] = scale(limit)
+	}
+	return scaled
+}
+
+// DefaultRateLimits returns the default limits on the inbound messages of a node with the given role. They are
+// well above the traffic of honest peers, and only meant to protect the node from noisy or broken ones.
+func DefaultRateLimits(role flow.Role) RateLimits {
+	limits := RateLimits{
+		Peer:     RateLimit{Rate: 1000, Burst: 2000},
+		Channels: make(map[network.Channel]RateLimit),
+		Messages: map[string]RateLimit{
+			// requests which cause this node to look up and send entities
+			"messages.SyncRequest":      {Rate: 10, Burst: 20},
+			"messages.RangeRequest":     {Rate: 10, Burst: 50},
+			"messages.BatchRequest":     {Rate: 10, Burst: 50},
+			"messages.EntityRequest":    {Rate: 50, Burst: 200},
+			"messages.ApprovalRequest":  {Rate: 50, Burst: 200},
+			"messages.ChunkDataRequest": {Rate: 100, Burst: 500},
+		},
+	}
+
+	// blocks are only pushed once per view by consensus nodes
+	limits.Channels[engine.PushBlocks] = RateLimit{Rate: 50, Burst: 100}
+
+	switch role {
+	case flow.RoleCollection:
+		limits.Channels[engine.PushTransactions] = RateLimit{Rate: 500, Burst: 1000}
+	case flow.RoleConsensus:
+		limits.Channels[engine.ConsensusCommittee] = RateLimit{Rate: 200, Burst: 500}
+		limits.Channels[engine.SyncCommittee] = RateLimit{Rate: 50, Burst: 100}
+		limits.Channels[engine.DKGCommittee] = RateLimit{Rate: 50, Burst: 100}
+		limits.Channels[engine.PushGuarantees] = RateLimit{Rate: 200, Burst: 500}
+		limits.Channels[engine.PushReceipts] = RateLimit{Rate: 200, Burst: 500}
+		limits.Channels[engine.PushApprovals] = RateLimit{Rate: 500, Burst: 1000}
+	case flow.RoleExecution:
+		limits.Channels[engine.SyncExecution] = RateLimit{Rate: 100, Burst: 200}
+		limits.Channels[engine.RequestCollections] = RateLimit{Rate: 200, Burst: 500}
+	case flow.RoleVerification:
+		limits.Channels[engine.PushReceipts] = RateLimit{Rate: 200, Burst: 500}
+		limits.Channels[engine.RequestChunks] = RateLimit{Rate: 500, Burst: 1000}
+	case flow.RoleAccess:
+		limits.Channels[engine.PushReceipts] = RateLimit{Rate: 200, Burst: 500}
+	}
+
+	return limits
+}
+
+// tokenBucket holds up to burst tokens and refills at rate tokens per second. Each accepted message takes a token.
+type tokenBucket struct {
+	rate    float64
+	burst   float64
+	tokens  float64
+	updated time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	// a bucket must hold at least one token, otherwise it would never accept a message
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:    limit.Rate,
+		burst:   burst,
+		tokens:  burst,
+		updated: now,
+	}
+}
+
+// available refills the bucket to the given time, and returns true if it holds a token.
+func (b *tokenBucket) available(now time.Time) bool {
+	elapsed := now.Sub(b.updated)
+	if elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.updated = now
+	}
+	return b.tokens >= 1
+}
+
+// peerBuckets holds the token buckets of a single peer.
+type peerBuckets struct {
+	peer     *tokenBucket
+	channels map[network.Channel]*tokenBucket
+}
+
+// RateLimiter enforces the limits on inbound messages. The limits on peers and channels are checked for every
+// message received from a peer before its payload is decoded, while the limits on message types are checked once
+// the payload is decoded by the overlay, as the type claimed by the sender of a message can not be trusted.
+type RateLimiter struct {
+	sync.Mutex
+	limits   RateLimits
+	buckets  map[peer.ID]*peerBuckets
+	messages map[flow.Identifier]map[string]*tokenBucket
+	now      func() time.Time
+}
+
+// NewRateLimiter creates a new rate limiter enforcing the given limits.
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits:   limits,
+		buckets:  make(map[peer.ID]*peerBuckets),
+		messages: make(map[flow.Identifier]map[string]*tokenBucket),
+		now:      time.Now,
+	}
+}
+
+// Allow returns true if a message received from the peer on the channel is within the limits on the peer and
+// the channel. Otherwise, it returns false and the limit exceeded by the message, and the message does not count
+// towards any of the limits.
+func (r *RateLimiter) Allow(peerID peer.ID, channel network.Channel) (bool, string) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+	buckets, ok := r.buckets[peerID]
+	if !ok {
+		buckets = &peerBuckets{
+			channels: make(map[network.Channel]*tokenBucket),
+		}
+		if r.limits.Peer.Rate > 0 {
+			buckets.peer = newTokenBucket(r.limits.Peer, now)
+		}
+		r.buckets[peerID] = buckets
+	}
+
+	// the buckets are checked from the narrowest to the widest limit
+	checked := make([]*tokenBucket, 0, 2)
+
+	// buckets are only created for configured limits, so that arbitrary channels sent by a peer do not grow the
+	// state of the limiter
+	channelBucket, ok := buckets.channels[channel]
+	if limit := r.limits.Channels[channel]; !ok && limit.Rate > 0 {
+		channelBucket = newTokenBucket(limit, now)
+		buckets.channels[channel] = channelBucket
+	}
+	if channelBucket != nil {
+		if !channelBucket.available(now) {
+			return false, LimitChannel
+		}
+		checked = append(checked, channelBucket)
+	}
+
+	if buckets.peer != nil {
+		if !buckets.peer.available(now) {
+			return false, LimitPeer
+		}
+		checked = append(checked, buckets.peer)
+	}
+
+	// only take tokens once the message is within all limits
+	for _, bucket := range checked {
+		bucket.tokens--
+	}
+
+	return true, ""
+}
+
+// AllowMessage returns true if a decoded message of the given type, originating from the node, is within the
+// limit on its type. The origin of a message is authenticated to be the peer which delivered it before it is
+// decoded, so that the limits on message types are enforced separately for each peer as well.
+func (r *RateLimiter) AllowMessage(originID flow.Identifier, msgType string) bool {
+	limit := r.limits.Messages[msgType]
+	if limit.Rate <= 0 {
+		return true
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+	buckets, ok := r.messages[originID]
+	if !ok {
+		buckets = make(map[string]*tokenBucket)
+		r.messages[originID] = buckets
+	}
+
+	bucket, ok := buckets[msgType]
+	if !ok {
+		bucket = newTokenBucket(limit, now)
+		buckets[msgType] = bucket
+	}
+	if !bucket.available(now) {
+		return false
+	}
+	bucket.tokens--
+
+	return true
+}
+
+// prune removes the buckets of the peers which are not in the given set, once they are no longer allowed to
+// connect to this node.
+func (r *RateLimiter) prune(peerIDs map[flow.Identifier]peer.ID) {
+	allowed := make(map[peer.ID]struct{}, len(peerIDs))
+	for _, peerID := range peerIDs {
+		allowed[peerID] = struct{}{}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	for peerID := range r.buckets {
+		if _, ok := allowed[peerID]; !ok {
+			delete(r.buckets, peerID)
+		}
+	}
+	for originID := range r.messages {
+		if _, ok := peerIDs[originID]; !ok {
+			delete(r.messages, originID)
+		}
+	}
+}
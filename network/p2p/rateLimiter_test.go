@@ -0,0 +1,147 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// newTestRateLimiter returns a rate limiter using a clock which is only advanced by the returned function.
+func newTestRateLimiter(limits RateLimits) (*RateLimiter, func(time.Duration)) {
+	limiter := NewRateLimiter(limits)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	return limiter, func(d time.Duration) { now = now.Add(d) }
+}
+
+// assertAllowed asserts that the given number of messages is accepted, and that the next one exceeds the given limit.
+func assertAllowed(t *testing.T, limiter *RateLimiter, peerID peer.ID, channel network.Channel, count int, limit string) {
+	for i := 0; i < count; i++ {
+		ok, _ := limiter.Allow(peerID, channel)
+		assert.True(t, ok, "message %d should be accepted", i)
+	}
+	ok, exceeded := limiter.Allow(peerID, channel)
+	assert.False(t, ok)
+	assert.Equal(t, limit, exceeded)
+}
+
+// assertAllowedMessages asserts that the given number of decoded messages is accepted, and that the next one
+// exceeds the limit on their type.
+func assertAllowedMessages(t *testing.T, limiter *RateLimiter, originID flow.Identifier, msgType string, count int) {
+	for i := 0; i < count; i++ {
+		assert.True(t, limiter.AllowMessage(originID, msgType), "message %d should be accepted", i)
+	}
+	assert.False(t, limiter.AllowMessage(originID, msgType))
+}
+
+// TestRateLimiter_Peer evaluates that the limit on all messages of a peer refills over time, and is enforced
+// separately for each peer.
+func TestRateLimiter_Peer(t *testing.T) {
+	limiter, advance := newTestRateLimiter(RateLimits{
+		Peer: RateLimit{Rate: 10, Burst: 5},
+	})
+
+	assertAllowed(t, limiter, "peer-1", engine.TestNetwork, 5, LimitPeer)
+
+	// other peers are not affected
+	assertAllowed(t, limiter, "peer-2", engine.TestNetwork, 5, LimitPeer)
+
+	// the bucket refills at the configured rate, up to the burst
+	advance(200 * time.Millisecond)
+	assertAllowed(t, limiter, "peer-1", engine.PushBlocks, 2, LimitPeer)
+	advance(time.Minute)
+	assertAllowed(t, limiter, "peer-1", engine.TestNetwork, 5, LimitPeer)
+}
+
+// TestRateLimiter_Channel evaluates that the limits on channels are enforced separately, and that a message
+// exceeding one limit does not count towards the others.
+func TestRateLimiter_Channel(t *testing.T) {
+	limiter, advance := newTestRateLimiter(RateLimits{
+		Peer:     RateLimit{Rate: 1, Burst: 10},
+		Channels: map[network.Channel]RateLimit{engine.PushBlocks: {Rate: 1, Burst: 3}},
+	})
+	peerID := peer.ID("peer")
+
+	assertAllowed(t, limiter, peerID, engine.PushBlocks, 3, LimitChannel)
+
+	// messages dropped by the channel limit did not take tokens from the peer limit
+	assertAllowed(t, limiter, peerID, engine.TestNetwork, 7, LimitPeer)
+
+	// unlimited channels do not grow the state of the limiter
+	limiter.Lock()
+	assert.Len(t, limiter.buckets[peerID].channels, 1)
+	limiter.Unlock()
+
+	advance(time.Second)
+	ok, _ := limiter.Allow(peerID, engine.PushBlocks)
+	assert.True(t, ok)
+}
+
+// TestRateLimiter_Message evaluates that the limits on decoded message types are enforced separately for each
+// origin, independently of the channel and peer limits.
+func TestRateLimiter_Message(t *testing.T) {
+	limiter, advance := newTestRateLimiter(RateLimits{
+		Peer:     RateLimit{Rate: 1, Burst: 1},
+		Messages: map[string]RateLimit{"messages.SyncRequest": {Rate: 1, Burst: 2}},
+	})
+	originID := unittest.IdentifierFixture()
+
+	assertAllowedMessages(t, limiter, originID, "messages.SyncRequest", 2)
+
+	// other origins are not affected
+	assertAllowedMessages(t, limiter, unittest.IdentifierFixture(), "messages.SyncRequest", 2)
+
+	// unlimited types are always accepted, and do not grow the state of the limiter
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.AllowMessage(originID, "messages.BlockProposal"))
+	}
+	limiter.Lock()
+	assert.Len(t, limiter.messages[originID], 1)
+	limiter.Unlock()
+
+	advance(time.Second)
+	assert.True(t, limiter.AllowMessage(originID, "messages.SyncRequest"))
+}
+
+// TestRateLimiter_Unlimited evaluates that limits with a rate of zero are not enforced, which allows disabling
+// rate limiting by scaling the limits to zero.
+func TestRateLimiter_Unlimited(t *testing.T) {
+	limiter, _ := newTestRateLimiter(DefaultRateLimits(flow.RoleConsensus).Scale(0))
+
+	for i := 0; i < 10000; i++ {
+		ok, _ := limiter.Allow("peer", engine.SyncCommittee)
+		assert.True(t, ok)
+		assert.True(t, limiter.AllowMessage(flow.ZeroID, "messages.SyncRequest"))
+	}
+}
+
+// TestRateLimiter_Prune evaluates that the buckets of peers and origins which are no longer allowed are removed.
+func TestRateLimiter_Prune(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimits{
+		Peer:     RateLimit{Rate: 1, Burst: 1},
+		Messages: map[string]RateLimit{"type": {Rate: 1, Burst: 1}},
+	})
+	allowedID := unittest.IdentifierFixture()
+	removedID := unittest.IdentifierFixture()
+
+	limiter.Allow("peer-1", engine.TestNetwork)
+	limiter.Allow("peer-2", engine.TestNetwork)
+	limiter.AllowMessage(allowedID, "type")
+	limiter.AllowMessage(removedID, "type")
+
+	limiter.prune(map[flow.Identifier]peer.ID{allowedID: "peer-1"})
+
+	limiter.Lock()
+	assert.Contains(t, limiter.buckets, peer.ID("peer-1"))
+	assert.NotContains(t, limiter.buckets, peer.ID("peer-2"))
+	assert.Contains(t, limiter.messages, allowedID)
+	assert.NotContains(t, limiter.messages, removedID)
+	limiter.Unlock()
+}
@@ -7,6 +7,7 @@ import (
 
 	ggio "github.com/gogo/protobuf/io"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/module"
@@ -17,21 +18,23 @@ import (
 // readConnection reads the incoming stream and calls the callback until the remote closes the stream or the context is
 // cancelled
 type readConnection struct {
-	ctx        context.Context
-	stream     libp2pnetwork.Stream
-	log        zerolog.Logger
-	metrics    module.NetworkMetrics
-	maxMsgSize int
-	callback   func(msg *message.Message)
+	ctx         context.Context
+	stream      libp2pnetwork.Stream
+	log         zerolog.Logger
+	metrics     module.NetworkMetrics
+	maxMsgSize  int
+	compression string
+	callback    func(msg *message.Message, peerID peer.ID)
 }
 
 // newReadConnection creates a new readConnection
 func newReadConnection(ctx context.Context,
 	stream libp2pnetwork.Stream,
-	callback func(msg *message.Message),
+	callback func(msg *message.Message, peerID peer.ID),
 	log zerolog.Logger,
 	metrics module.NetworkMetrics,
-	maxMsgSize int) *readConnection {
+	maxMsgSize int,
+	compression string) *readConnection {
 
 	if maxMsgSize <= 0 {
 		maxMsgSize = DefaultMaxUnicastMsgSize
@@ -40,12 +43,13 @@ func newReadConnection(ctx context.Context,
 	streamLogger := streamLogger(log, stream)
 
 	c := readConnection{
-		ctx:        ctx,
-		stream:     stream,
-		callback:   callback,
-		log:        streamLogger,
-		metrics:    metrics,
-		maxMsgSize: maxMsgSize,
+		ctx:         ctx,
+		stream:      stream,
+		callback:    callback,
+		log:         streamLogger,
+		metrics:     metrics,
+		maxMsgSize:  maxMsgSize,
+		compression: compression,
 	}
 	return &c
 }
@@ -57,8 +61,8 @@ func (rc *readConnection) receiveLoop(wg *sync.WaitGroup) {
 	defer wg.Done()
 	defer rc.log.Trace().Msg("exiting receive routine")
 
-	// create the reader
-	r := ggio.NewDelimitedReader(rc.stream, rc.maxMsgSize)
+	// create the reader, the maximum message size applies to the decompressed messages
+	r := ggio.NewDelimitedReader(decompressingReader(rc.stream, rc.compression), rc.maxMsgSize)
 
 	for {
 		// check if we should stop
@@ -102,8 +106,9 @@ func (rc *readConnection) receiveLoop(wg *sync.WaitGroup) {
 		// log metrics with the channel name as OneToOne
 		rc.metrics.NetworkMessageReceived(msg.Size(), metrics.ChannelOneToOne, msg.Type)
 
-		// call the callback
-		rc.callback(&msg)
+		// call the callback with the peer at the other end of the stream, which is authenticated
+		// by the secure channel of the connection
+		rc.callback(&msg, rc.stream.Conn().RemotePeer())
 	}
 }
 
@@ -103,6 +103,10 @@ func getPriorityByType(message interface{}) Priority {
 	case *messages.EntityResponse:
 		return LowPriority
 
+	// distributed key generation
+	case *messages.DKGMessage:
+		return HighPriority
+
 	// test message
 	case *libp2pmessage.TestMessage:
 		return LowPriority
@@ -17,6 +17,7 @@ type Conduit struct {
 	unicast   p2p.UnicastFunc
 	multicast p2p.MulticastFunc
 	close     p2p.CloseFunc
+	report    p2p.ReportMisbehaviorFunc
 }
 
 func (c *Conduit) Publish(event interface{}, targetIDs ...flow.Identifier) error {
@@ -40,6 +41,10 @@ func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Ident
 	return c.multicast(c.channel, event, num, targetIDs...)
 }
 
+func (c *Conduit) ReportMisbehavior(originID flow.Identifier, severity network.Severity, reason string) {
+	c.report(c.channel, originID, severity, reason)
+}
+
 func (c *Conduit) Close() error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit for channel %s closed", c.channel)
@@ -69,6 +69,7 @@ func (n *Network) Register(channel network.Channel, engine network.Engine) (netw
 		publish:   n.publish,
 		unicast:   n.unicast,
 		multicast: n.multicast,
+		report:    n.reportMisbehavior,
 	}
 	n.engines[channel] = engine
 	return conduit, nil
@@ -96,6 +97,11 @@ func (n *Network) submit(channel network.Channel, event interface{}, targetIDs .
 	return nil
 }
 
+// reportMisbehavior is called when the attached Engine to the channel reports the misbehavior of another
+// node. The stub network does not penalize nodes, so the report is ignored.
+func (n *Network) reportMisbehavior(channel network.Channel, originID flow.Identifier, severity network.Severity, reason string) {
+}
+
 // unicast is called when the attached Engine to the channel is sending an event to a single target
 // Engine attached to the same channel on another node.
 func (n *Network) unicast(channel network.Channel, event interface{}, targetID flow.Identifier) error {
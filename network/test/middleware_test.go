@@ -14,9 +14,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	libp2pmessage "github.com/onflow/flow-go/model/libp2p/message"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/codec/json"
 	"github.com/onflow/flow-go/network/message"
 	"github.com/onflow/flow-go/network/mocknetwork"
@@ -24,7 +26,7 @@ import (
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
-const testChannel = "test-channel"
+const testChannel = engine.TestNetwork
 
 type MiddlewareTestSuite struct {
 	suite.Suite
@@ -45,7 +47,7 @@ func (m *MiddlewareTestSuite) SetupTest() {
 	logger := zerolog.New(os.Stderr).Level(zerolog.ErrorLevel)
 	log.SetAllLoggers(log.LevelError)
 
-	m.size = 2 // operates on two middlewares
+	m.size = 3 // operates on three middlewares
 	m.metrics = metrics.NewNoopCollector()
 	// create and start the middlewares
 	m.ids, m.mws = GenerateIDsAndMiddlewares(m.T(), m.size, !DryRun, logger)
@@ -58,11 +60,14 @@ func (m *MiddlewareTestSuite) SetupTest() {
 		overlay := &mocknetwork.Overlay{}
 		m.ov = append(m.ov, overlay)
 
-		identifierToID := make(map[flow.Identifier]flow.Identity)
-		for _, id := range m.ids {
-			identifierToID[id.NodeID] = *id
-		}
-		overlay.On("Identity").Maybe().Return(identifierToID, nil)
+		// the identities are read on each call, so that tests can change them
+		overlay.On("Identity").Maybe().Return(func() map[flow.Identifier]flow.Identity {
+			identifierToID := make(map[flow.Identifier]flow.Identity)
+			for _, id := range m.ids {
+				identifierToID[id.NodeID] = *id
+			}
+			return identifierToID
+		}, nil)
 		overlay.On("Topology").Maybe().Return(flow.IdentityList(m.ids), nil)
 	}
 	for i, mw := range m.mws {
@@ -95,14 +100,14 @@ func (m *MiddlewareTestSuite) TestPingTypeReception() {
 // and content of the payload of the event upon reception at the receiver side
 // it does not evaluate the actual value of the sender ID
 func (m *MiddlewareTestSuite) TestPingIDType() {
-	msg := createMessage(m.ids[0].NodeID, m.ids[1].NodeID)
+	msg := createMessage(m.ids[0].NodeID, m.ids[m.size-1].NodeID)
 	m.Ping(mockery.AnythingOfType("flow.Identifier"), msg)
 }
 
 // TestPingContentReception tests the middleware against both
 // the payload and sender ID of the event upon reception at the receiver side
 func (m *MiddlewareTestSuite) TestPingContentReception() {
-	msg := createMessage(m.ids[0].NodeID, m.ids[1].NodeID)
+	msg := createMessage(m.ids[0].NodeID, m.ids[m.size-1].NodeID)
 	m.Ping(m.ids[0].NodeID, msg)
 }
 
@@ -339,6 +344,127 @@ func (m *MiddlewareTestSuite) TestMaxMessageSize_Publish() {
 	require.Error(m.Suite.T(), err)
 }
 
+// TestUnauthenticatedOrigin evaluates that a message is dropped by the target node when its origin ID
+// belongs to another node than the one sending it.
+func (m *MiddlewareTestSuite) TestUnauthenticatedOrigin() {
+	sender := 0
+	spoofed := 1
+	target := m.size - 1
+
+	// the message claims to originate from another node than its sender
+	msg := createMessage(m.ids[spoofed].NodeID, m.ids[target].NodeID, "spoofed")
+
+	received := make(chan struct{})
+	m.ov[target].On("Receive", mockery.Anything, mockery.Anything).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(received)
+		})
+
+	err := m.mws[sender].SendDirect(msg, m.ids[target].NodeID)
+	require.NoError(m.T(), err)
+
+	// assert that the message is not received by the target node
+	unittest.RequireNeverClosedWithin(m.T(), received, 2*time.Second, "target node received the message")
+}
+
+// TestUnauthorizedChannel evaluates that a message is dropped by the target node when its channel
+// is not known to the target.
+func (m *MiddlewareTestSuite) TestUnauthorizedChannel() {
+	sender := 0
+	target := m.size - 1
+
+	msg := createMessage(m.ids[sender].NodeID, m.ids[target].NodeID, "unknown channel")
+	msg.ChannelID = "unknown-channel"
+
+	received := make(chan struct{})
+	m.ov[target].On("Receive", mockery.Anything, mockery.Anything).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(received)
+		})
+
+	err := m.mws[sender].SendDirect(msg, m.ids[target].NodeID)
+	require.NoError(m.T(), err)
+
+	// assert that the message is not received by the target node
+	unittest.RequireNeverClosedWithin(m.T(), received, 2*time.Second, "target node received the message")
+}
+
+// TestMisbehavingOriginDisconnected evaluates that the messages of a node are dropped by the target node once
+// it reported enough misbehavior of the node to disconnect from it.
+func (m *MiddlewareTestSuite) TestMisbehavingOriginDisconnected() {
+	sender := 0
+	target := m.size - 1
+
+	m.mws[target].ReportMisbehavior(m.ids[sender].NodeID, testChannel, network.SeverityCritical, "test")
+	m.mws[target].ReportMisbehavior(m.ids[sender].NodeID, testChannel, network.SeverityCritical, "test")
+
+	received := make(chan struct{})
+	m.ov[target].On("Receive", mockery.Anything, mockery.Anything).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(received)
+		})
+
+	// the sender may fail to send the message, as the target no longer accepts its connections
+	msg := createMessage(m.ids[sender].NodeID, m.ids[target].NodeID, "disconnected")
+	_ = m.mws[sender].SendDirect(msg, m.ids[target].NodeID)
+
+	// assert that the message is not received by the target node
+	unittest.RequireNeverClosedWithin(m.T(), received, 2*time.Second, "target node received the message")
+}
+
+// TestAuthorizedSenderRole evaluates that a message is received by the target node when it is sent
+// on a channel by a node of a role which sends on it, even though the role does not subscribe to it.
+func (m *MiddlewareTestSuite) TestAuthorizedSenderRole() {
+	sender := 0
+	target := m.size - 1
+
+	// an access node synchronizes blocks with a consensus node
+	m.ids[sender].Role = flow.RoleAccess
+	m.ids[target].Role = flow.RoleConsensus
+	require.NoError(m.T(), m.mws[target].UpdateAllowList())
+
+	msg := createMessage(m.ids[sender].NodeID, m.ids[target].NodeID, "sync request")
+	msg.ChannelID = engine.SyncCommittee.String()
+
+	received := make(chan struct{})
+	m.ov[target].On("Receive", m.ids[sender].NodeID, mockery.Anything).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(received)
+		})
+
+	err := m.mws[sender].SendDirect(msg, m.ids[target].NodeID)
+	require.NoError(m.T(), err)
+
+	unittest.RequireCloseBefore(m.T(), received, 2*time.Second, "target node failed to receive the message")
+}
+
+// TestUnauthorizedSenderRole evaluates that a message is dropped by the target node when it is sent
+// on a channel by a node of a role which does not send on it.
+func (m *MiddlewareTestSuite) TestUnauthorizedSenderRole() {
+	sender := 0
+	target := m.size - 1
+
+	// only consensus nodes send on the consensus committee channel
+	m.ids[sender].Role = flow.RoleAccess
+	m.ids[target].Role = flow.RoleConsensus
+	require.NoError(m.T(), m.mws[target].UpdateAllowList())
+
+	msg := createMessage(m.ids[sender].NodeID, m.ids[target].NodeID, "proposal")
+	msg.ChannelID = engine.ConsensusCommittee.String()
+
+	received := make(chan struct{})
+	m.ov[target].On("Receive", mockery.Anything, mockery.Anything).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(received)
+		})
+
+	err := m.mws[sender].SendDirect(msg, m.ids[target].NodeID)
+	require.NoError(m.T(), err)
+
+	// assert that the message is not received by the target node
+	unittest.RequireNeverClosedWithin(m.T(), received, 2*time.Second, "target node received the message")
+}
+
 // TestUnsubscribe tests that an engine can unsubscribe from a topic it was earlier subscribed to and stop receiving
 // messages.
 func (m *MiddlewareTestSuite) TestUnsubscribe() {
@@ -363,15 +489,17 @@ func (m *MiddlewareTestSuite) TestUnsubscribe() {
 	originID := m.ids[origin].NodeID
 	message1 := createMessage(firstNode, lastNode, "hello1")
 
-	m.ov[target].On("Receive", originID, mockery.Anything).Return(nil).Once()
+	received := make(chan struct{})
+	m.ov[target].On("Receive", originID, mockery.Anything).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(received)
+		})
 
 	// first test that when both nodes are subscribed to the channel, the target node receives the message
 	err := m.mws[origin].Publish(message1, testChannel)
 	assert.NoError(m.T(), err)
 
-	assert.Eventually(m.T(), func() bool {
-		return m.ov[target].AssertCalled(m.T(), "Receive", originID, mockery.Anything)
-	}, 2*time.Second, time.Millisecond)
+	unittest.RequireCloseBefore(m.T(), received, 2*time.Second, "target node failed to receive the message")
 
 	// now unsubscribe the target node from the channel
 	err = m.mws[target].Unsubscribe(testChannel)
@@ -396,7 +524,7 @@ func createMessage(originID flow.Identifier, targetID flow.Identifier, msg ...st
 	}
 
 	return &message.Message{
-		ChannelID: testChannel,
+		ChannelID: testChannel.String(),
 		EventID:   []byte("1"),
 		OriginID:  originID[:],
 		TargetIDs: [][]byte{targetID[:]},
@@ -65,6 +65,10 @@ func GenerateMiddlewares(t *testing.T, logger zerolog.Logger, identities flow.Id
 	metrics := metrics.NewNoopCollector()
 	mws := make([]*p2p.Middleware, len(identities))
 
+	// large messages are compressed, so that compression is exercised by the tests sending them
+	compression, err := p2p.NewUnicastCompression(p2p.CompressionSnappy, p2p.DefaultCompressionThreshold)
+	require.NoError(t, err)
+
 	for i, id := range identities {
 		// casts libP2PNode instance to a local variable to avoid closure
 		node := libP2PNodes[i]
@@ -79,6 +83,9 @@ func GenerateMiddlewares(t *testing.T, logger zerolog.Logger, identities flow.Id
 			factory,
 			id.NodeID,
 			metrics,
+			p2p.NewPeerScorer(logger, metrics),
+			p2p.NewRateLimiter(p2p.DefaultRateLimits(id.Role)),
+			compression,
 			rootBlockID)
 	}
 	return mws
@@ -121,7 +128,8 @@ func GenerateNetworks(t *testing.T,
 		me.On("Address").Return(ids[i].Address)
 
 		// create the network
-		net, err := p2p.NewNetwork(log, json.NewCodec(), ids, me, mws[i], csize, tops[i], sms[i], metrics)
+		limiter := p2p.NewRateLimiter(p2p.DefaultRateLimits(ids[i].Role))
+		net, err := p2p.NewNetwork(log, json.NewCodec(), ids, me, mws[i], csize, tops[i], sms[i], limiter, metrics)
 		require.NoError(t, err)
 
 		nets = append(nets, net)
@@ -182,10 +190,9 @@ func generateLibP2PNode(t *testing.T,
 
 	// create PubSub options for libp2p to use
 	psOptions := []pubsub.Option{
-		// skip message signing
-		pubsub.WithMessageSigning(false),
-		// skip message signature
-		pubsub.WithStrictSignatureVerification(false),
+		// sign messages with the networking key and drop unsigned messages, so that the author of
+		// a message can be authenticated
+		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
 		// set max message size limit for 1-k PubSub messaging
 		pubsub.WithMaxMessageSize(p2p.DefaultMaxPubSubMsgSize),
 	}
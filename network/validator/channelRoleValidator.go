@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/message"
+)
+
+var _ network.MessageValidator = &ChannelRoleValidator{}
+
+// IdentityFunc returns the identity of the node with the given ID, and false if the node is unknown.
+type IdentityFunc func(nodeID flow.Identifier) (*flow.Identity, bool)
+
+// ChannelRoleValidator filters out messages whose origin is unknown, ejected, or has a role which does
+// not send messages on the channel of the message
+type ChannelRoleValidator struct {
+	log      zerolog.Logger
+	identity IdentityFunc
+	metrics  module.NetworkMetrics
+}
+
+// NewChannelRoleValidator returns a new ChannelRoleValidator, which looks up the origin of messages with the given function
+func NewChannelRoleValidator(log zerolog.Logger, identity IdentityFunc, metrics module.NetworkMetrics) *ChannelRoleValidator {
+	return &ChannelRoleValidator{
+		log:      log,
+		identity: identity,
+		metrics:  metrics,
+	}
+}
+
+// Validate returns true if the origin of the message may send messages on its channel, else it returns false
+func (cv *ChannelRoleValidator) Validate(msg message.Message) bool {
+	log := cv.log.With().
+		Hex("origin_id", msg.OriginID).
+		Hex("event_id", msg.EventID).
+		Str("channel", msg.ChannelID).
+		Str("type", msg.Type).
+		Logger()
+
+	roles, ok := engine.SenderRolesByChannel(network.Channel(msg.ChannelID))
+	if !ok {
+		log.Warn().Msg("dropping message on unknown channel")
+		cv.metrics.NetworkUnauthorizedMessagesDropped(msg.ChannelID, msg.Type)
+		return false
+	}
+
+	origin, ok := cv.identity(flow.HashToID(msg.OriginID))
+	if !ok || origin.Ejected {
+		log.Warn().Msg("dropping message from unknown or ejected origin")
+		cv.metrics.NetworkUnauthorizedMessagesDropped(msg.ChannelID, msg.Type)
+		return false
+	}
+
+	if !roles.Contains(origin.Role) {
+		log.Warn().Str("role", origin.Role.String()).Msg("dropping message from origin with unauthorized role")
+		cv.metrics.NetworkUnauthorizedMessagesDropped(msg.ChannelID, msg.Type)
+		return false
+	}
+
+	return true
+}
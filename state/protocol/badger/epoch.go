@@ -53,11 +53,15 @@ func (es *SetupEpoch) Clustering() (flow.ClusterList, error) {
 }
 
 func (es *SetupEpoch) Cluster(_ uint) (protocol.Cluster, error) {
-	return nil, fmt.Errorf("EpochCommit event not yet received in fork")
+	return nil, protocol.ErrEpochNotCommitted
 }
 
 func (es *SetupEpoch) DKG() (protocol.DKG, error) {
-	return nil, fmt.Errorf("EpochCommit event not yet received in fork")
+	return nil, protocol.ErrEpochNotCommitted
+}
+
+func (es *SetupEpoch) RandomSource() ([]byte, error) {
+	return es.setupEvent.RandomSource, nil
 }
 
 func (es *SetupEpoch) Seed(indices ...uint32) ([]byte, error) {
@@ -160,6 +164,10 @@ func (u *InvalidEpoch) DKG() (protocol.DKG, error) {
 	return nil, u.err
 }
 
+func (u *InvalidEpoch) RandomSource() ([]byte, error) {
+	return nil, u.err
+}
+
 func (u *InvalidEpoch) Seed(...uint32) ([]byte, error) {
 	return nil, u.err
 }
@@ -0,0 +1,64 @@
+package badger
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestMigrateEpochStatuses verifies that the epoch statuses persisted without
+// the service events of the previous epoch are migrated once, taking them from
+// the parent of the first block of their epoch.
+func TestMigrateEpochStatuses(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		first := flow.EventIDs{SetupID: unittest.IdentifierFixture(), CommitID: unittest.IdentifierFixture()}
+		second := flow.EventIDs{SetupID: unittest.IdentifierFixture(), CommitID: unittest.IdentifierFixture()}
+
+		// the root block starts the first epoch, which is followed by the second
+		// epoch starting with the transition block
+		root := unittest.BlockHeaderFixture()
+		parent := unittest.BlockHeaderWithParentFixture(&root)
+		transition := unittest.BlockHeaderWithParentFixture(&parent)
+		child := unittest.BlockHeaderWithParentFixture(&transition)
+
+		statuses := map[flow.Identifier]*flow.EpochStatus{
+			root.ID():       {FirstBlockID: root.ID(), CurrentEpoch: first},
+			parent.ID():     {FirstBlockID: root.ID(), CurrentEpoch: first, NextEpoch: second},
+			transition.ID(): {FirstBlockID: transition.ID(), CurrentEpoch: second},
+			child.ID():      {FirstBlockID: transition.ID(), CurrentEpoch: second},
+		}
+		for _, header := range []*flow.Header{&root, &parent, &transition, &child} {
+			require.NoError(t, db.Update(operation.InsertHeader(header.ID(), header)))
+			require.NoError(t, db.Update(operation.InsertEpochStatus(header.ID(), statuses[header.ID()])))
+		}
+
+		err := migrateEpochStatuses(db)
+		require.NoError(t, err)
+
+		previous := func(blockID flow.Identifier) flow.EventIDs {
+			var status flow.EpochStatus
+			require.NoError(t, db.View(operation.RetrieveEpochStatus(blockID, &status)))
+			return status.PreviousEpoch
+		}
+		assert.Equal(t, flow.EventIDs{}, previous(root.ID()))
+		assert.Equal(t, flow.EventIDs{}, previous(parent.ID()))
+		assert.Equal(t, first, previous(transition.ID()))
+		assert.Equal(t, first, previous(child.ID()))
+
+		var version uint
+		require.NoError(t, db.View(operation.RetrieveEpochStatusVersion(&version)))
+		assert.Equal(t, uint(epochStatusVersion), version)
+
+		// statuses are not migrated again once the version is persisted
+		require.NoError(t, db.Update(operation.UpdateEpochStatus(child.ID(), statuses[child.ID()])))
+		err = migrateEpochStatuses(db)
+		require.NoError(t, err)
+		assert.Equal(t, flow.EventIDs{}, previous(child.ID()))
+	})
+}
@@ -605,7 +605,7 @@ func (m *FollowerState) Finalize(blockID flow.Identifier) error {
 	}
 
 	// FINALLY: any block that is finalized is already a valid extension;
-	// in order to make it final, we need to do just three things:
+	// in order to make it final, we need to do just four things:
 	// 1) Map its height to its index; there can no longer be other blocks at
 	// this height, as it becomes immutable.
 	// 2) Forward the last finalized height to its height as well. We now have
@@ -613,6 +613,8 @@ func (m *FollowerState) Finalize(blockID flow.Identifier) error {
 	// 3) Forward the last sealed height to the height of the block its last
 	// seal sealed. This could actually stay the same if it has no seals in its
 	// payload, in which case the parent's seal is the same.
+	// 4) Index the seals in its payload by the blocks they seal, as each block
+	// is sealed by exactly one finalized seal.
 
 	err = operation.RetryOnConflict(m.db.Update, func(tx *badger.Txn) error {
 		err = operation.IndexBlockHeight(header.Height, blockID)(tx)
@@ -627,6 +629,12 @@ func (m *FollowerState) Finalize(blockID flow.Identifier) error {
 		if err != nil {
 			return fmt.Errorf("could not update sealed height: %w", err)
 		}
+		for _, seal := range payload.Seals {
+			err = operation.IndexFinalizedSealByBlockID(seal.BlockID, seal.ID())(tx)
+			if err != nil {
+				return fmt.Errorf("could not index finalized seal (%x): %w", seal.ID(), err)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -668,6 +676,7 @@ func (m *FollowerState) Finalize(blockID flow.Identifier) error {
 //           the parent's EpochStatus.CurrentEpoch also applies for the current block
 // case (b): block starts new Epoch in its respective fork.
 //           the parent's EpochStatus.NextEpoch is the current block's EpochStatus.CurrentEpoch
+//           and the parent's EpochStatus.CurrentEpoch is the current block's EpochStatus.PreviousEpoch
 // As the parent was a valid extension of the chain, by induction, the parent satisfies all
 // consistency requirements of the protocol.
 func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, error) {
@@ -693,6 +702,7 @@ func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, erro
 		}
 		status, err := flow.NewEpochStatus(
 			block.ID(),
+			parentStatus.CurrentEpoch.SetupID, parentStatus.CurrentEpoch.CommitID,
 			parentStatus.NextEpoch.SetupID, parentStatus.NextEpoch.CommitID,
 			flow.ZeroID, flow.ZeroID,
 		)
@@ -703,6 +713,7 @@ func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, erro
 	// IMPORTANT: copy the status to avoid modifying the parent status in the cache
 	status, err := flow.NewEpochStatus(
 		parentStatus.FirstBlockID,
+		parentStatus.PreviousEpoch.SetupID, parentStatus.PreviousEpoch.CommitID,
 		parentStatus.CurrentEpoch.SetupID, parentStatus.CurrentEpoch.CommitID,
 		parentStatus.NextEpoch.SetupID, parentStatus.NextEpoch.CommitID,
 	)
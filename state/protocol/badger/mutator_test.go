@@ -387,12 +387,20 @@ func TestExtendSealedBoundary(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, rootSeal.FinalState, finalCommit, "commit should not change after finalizing non-sealing block")
 
+		var sealID flow.Identifier
+		err = db.View(operation.LookupBySealedBlockID(block1.ID(), &sealID))
+		require.True(t, errors.Is(err, stoerr.ErrNotFound), "seal should not be indexed before finalizing sealing block")
+
 		err = state.Finalize(block3.ID())
 		require.NoError(t, err)
 
 		finalCommit, err = state.Final().Commit()
 		require.NoError(t, err)
 		require.Equal(t, block1Seal.FinalState, finalCommit, "commit should change after finalizing sealing block")
+
+		err = db.View(operation.LookupBySealedBlockID(block1.ID(), &sealID))
+		require.NoError(t, err)
+		require.Equal(t, block1Seal.ID(), sealID, "seal should be indexed by sealed block after finalizing sealing block")
 	})
 }
 
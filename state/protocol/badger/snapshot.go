@@ -82,25 +82,13 @@ func (s *Snapshot) Identities(selector flow.IdentityFilter) (flow.IdentityList,
 	// from the previous epoch that are now un-staking
 	case flow.EpochPhaseStaking:
 
-		first, err := s.state.AtBlockID(status.FirstBlockID).Head()
-		if err != nil {
-			return nil, fmt.Errorf("could not get first block of epoch: %w", err)
-		}
-		// check whether this is the first epoch after the root block - in this
-		// case there are no previous epoch identities to check anyway
-		root, err := s.state.Params().Root()
-		if err != nil {
-			return nil, fmt.Errorf("could not get root block: %w", err)
-		}
-		if first.Height == root.Height {
+		// check whether there is a previous epoch - this is not the case for
+		// the first epoch after the root block
+		if status.PreviousEpoch.SetupID == flow.ZeroID {
 			break
 		}
 
-		lastStatus, err := s.state.epoch.statuses.ByBlockID(first.ParentID)
-		if err != nil {
-			return nil, fmt.Errorf("could not get last epoch status: %w", err)
-		}
-		lastSetup, err := s.state.epoch.setups.ByID(lastStatus.CurrentEpoch.SetupID)
+		lastSetup, err := s.state.epoch.setups.ByID(status.PreviousEpoch.SetupID)
 		if err != nil {
 			return nil, fmt.Errorf("could not get last epoch setup event: %w", err)
 		}
@@ -173,6 +161,69 @@ func (s *Snapshot) Commit() (flow.StateCommitment, error) {
 	return seal.FinalState, nil
 }
 
+// LatestSeal returns the most recent seal as of the current block snapshot.
+func (s *Snapshot) LatestSeal() (*flow.Seal, error) {
+	seal, err := s.state.seals.ByBlockID(s.blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not look up latest seal: %w", err)
+	}
+	return seal, nil
+}
+
+// LatestResult returns the execution result sealed by the most recent seal as
+// of the current block snapshot.
+func (s *Snapshot) LatestResult() (*flow.ExecutionResult, error) {
+	seal, err := s.LatestSeal()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest seal: %w", err)
+	}
+	var result flow.ExecutionResult
+	err = s.state.db.View(operation.RetrieveExecutionResult(seal.ResultID, &result))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve latest sealed result: %w", err)
+	}
+	return &result, nil
+}
+
+// SealingSegment returns the blocks from the latest sealed block (as of the
+// current block snapshot) up to and including the head of the snapshot,
+// ordered by ascending height.
+func (s *Snapshot) SealingSegment() ([]*flow.Block, error) {
+	seal, err := s.LatestSeal()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest seal: %w", err)
+	}
+	sealed, err := s.state.headers.ByBlockID(seal.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest sealed block: %w", err)
+	}
+
+	// walk backwards from the head to the sealed block
+	var segment []*flow.Block
+	blockID := s.blockID
+	for {
+		block, err := s.state.blocks.ByID(blockID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get segment block (%x): %w", blockID, err)
+		}
+		segment = append(segment, block)
+		if block.Header.Height <= sealed.Height {
+			break
+		}
+		blockID = block.Header.ParentID
+	}
+	if segment[len(segment)-1].ID() != seal.BlockID {
+		return nil, fmt.Errorf("sealed block (%x) is not an ancestor of head (%x)", seal.BlockID, s.blockID)
+	}
+
+	// reverse the segment, so that it is ordered by ascending height
+	for i, j := 0, len(segment)-1; i < j; i, j = i+1, j-1 {
+		segment[i], segment[j] = segment[j], segment[i]
+	}
+
+	return segment, nil
+}
+
 func (s *Snapshot) Pending() ([]flow.Identifier, error) {
 	return s.pending(s.blockID)
 }
@@ -194,8 +245,10 @@ func (s *Snapshot) pending(blockID flow.Identifier) ([]flow.Identifier, error) {
 	return pendingIDs, nil
 }
 
-// Seed returns the random seed at the given indices for the current block snapshot.
-func (s *Snapshot) Seed(indices ...uint32) ([]byte, error) {
+// QuorumCertificate returns a valid quorum certificate for the block at the
+// current block snapshot. As the QC for a block is only included in its
+// children, we reconstruct it from the first child that has been validated.
+func (s *Snapshot) QuorumCertificate() (*flow.QuorumCertificate, error) {
 
 	// get the current state snapshot head
 	var childrenIDs []flow.Identifier
@@ -232,12 +285,34 @@ func (s *Snapshot) Seed(indices ...uint32) ([]byte, error) {
 	}
 
 	// get the header of the first child (they all have the same threshold sig)
-	head, err := s.state.headers.ByBlockID(validChildID)
+	child, err := s.state.headers.ByBlockID(validChildID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get child: %w", err)
+	}
+	head, err := s.state.headers.ByBlockID(s.blockID)
 	if err != nil {
 		return nil, fmt.Errorf("could not get head: %w", err)
 	}
 
-	seed, err := seed.FromParentSignature(indices, head.ParentVoterSig)
+	qc := &flow.QuorumCertificate{
+		View:      head.View,
+		BlockID:   s.blockID,
+		SignerIDs: child.ParentVoterIDs,
+		SigData:   child.ParentVoterSig,
+	}
+
+	return qc, nil
+}
+
+// Seed returns the random seed at the given indices for the current block snapshot.
+func (s *Snapshot) Seed(indices ...uint32) ([]byte, error) {
+
+	qc, err := s.QuorumCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("could not get quorum certificate: %w", err)
+	}
+
+	seed, err := seed.FromParentSignature(indices, qc.SigData)
 	if err != nil {
 		return nil, fmt.Errorf("could not create seed from header's signature: %w", err)
 	}
@@ -317,25 +392,25 @@ func (q *EpochQuery) Previous() protocol.Epoch {
 	if err != nil {
 		return NewInvalidEpoch(err)
 	}
-	first, err := q.snap.state.headers.ByBlockID(status.FirstBlockID)
-	if err != nil {
-		return NewInvalidEpoch(err)
+
+	// CASE 1: there is no previous epoch as of this block - this is the case
+	// in the first epoch after the root block
+	if status.PreviousEpoch.SetupID == flow.ZeroID {
+		return NewInvalidEpoch(protocol.ErrNoPreviousEpoch)
 	}
 
-	// CASE 1: we are in the first epoch after the root block, in which case
-	// we return a sentinel error
-	root, err := q.snap.state.Params().Root()
+	// CASE 2: we are in any other epoch, return the previous epoch, which is
+	// necessarily committed
+	setup, err := q.snap.state.epoch.setups.ByID(status.PreviousEpoch.SetupID)
 	if err != nil {
-		return NewInvalidEpoch(err)
+		return NewInvalidEpoch(fmt.Errorf("failed to retrieve setup event for previous epoch: %w", err))
 	}
-	if first.Height == root.Height {
-		return NewInvalidEpoch(protocol.ErrNoPreviousEpoch)
+	commit, err := q.snap.state.epoch.commits.ByID(status.PreviousEpoch.CommitID)
+	if err != nil {
+		return NewInvalidEpoch(fmt.Errorf("failed to retrieve commit event for previous epoch: %w", err))
 	}
 
-	// CASE 2: we are in any other epoch, return the current epoch w.r.t. the
-	// parent block of the first block in this epoch, which must be in the
-	// previous epoch
-	return q.snap.state.AtBlockID(first.ParentID).Epochs().Current()
+	return NewCommittedEpoch(setup, commit)
 }
 
 // InvalidSnapshot represents a snapshot referencing an invalid block, or for
@@ -368,10 +443,26 @@ func (u *InvalidSnapshot) Commit() (flow.StateCommitment, error) {
 	return nil, u.err
 }
 
+func (u *InvalidSnapshot) LatestSeal() (*flow.Seal, error) {
+	return nil, u.err
+}
+
+func (u *InvalidSnapshot) LatestResult() (*flow.ExecutionResult, error) {
+	return nil, u.err
+}
+
+func (u *InvalidSnapshot) SealingSegment() ([]*flow.Block, error) {
+	return nil, u.err
+}
+
 func (u *InvalidSnapshot) Pending() ([]flow.Identifier, error) {
 	return nil, u.err
 }
 
+func (u *InvalidSnapshot) QuorumCertificate() (*flow.QuorumCertificate, error) {
+	return nil, u.err
+}
+
 func (u *InvalidSnapshot) Seed(_ ...uint32) ([]byte, error) {
 	return nil, u.err
 }
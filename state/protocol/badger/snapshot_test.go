@@ -162,6 +162,40 @@ func TestSeed(t *testing.T) {
 	})
 }
 
+func TestQuorumCertificate(t *testing.T) {
+	identities := unittest.IdentityListFixture(5, unittest.WithAllRoles())
+	stateRoot := fixtureStateRootWithParticipants(t, identities)
+
+	// should not be able to get QC from a block with no children
+	t.Run("no children", func(t *testing.T) {
+		util.RunWithBootstrapState(t, stateRoot, func(db *badger.DB, state *bprotocol.State) {
+			_, err := state.Final().QuorumCertificate()
+			assert.Error(t, err)
+		})
+	})
+
+	// should be able to get QC from a block with a valid child
+	t.Run("valid child", func(t *testing.T) {
+		util.RunWithFollowerProtocolState(t, stateRoot, func(db *badger.DB, state *bprotocol.FollowerState) {
+			head := stateRoot.Block().Header
+			child := unittest.BlockWithParentFixture(head)
+			child.Payload.Guarantees = nil
+			child.Header.PayloadHash = child.Payload.Hash()
+			err := state.Extend(&child)
+			require.NoError(t, err)
+			err = state.MarkValid(child.ID())
+			require.NoError(t, err)
+
+			qc, err := state.Final().QuorumCertificate()
+			require.NoError(t, err)
+			assert.Equal(t, head.ID(), qc.BlockID)
+			assert.Equal(t, head.View, qc.View)
+			assert.Equal(t, child.Header.ParentVoterIDs, qc.SignerIDs)
+			assert.Equal(t, []byte(child.Header.ParentVoterSig), qc.SigData)
+		})
+	})
+}
+
 // test that we can query current/next/previous epochs from a snapshot
 func TestSnapshot_EpochQuery(t *testing.T) {
 	identities := unittest.CompleteIdentitySet()
@@ -15,6 +15,10 @@ import (
 	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
+// epochStatusVersion is the version of the layout of the epoch statuses written
+// by the protocol state. Version 1 added the service events of the previous epoch.
+const epochStatusVersion = 1
+
 type State struct {
 	metrics module.ComplianceMetrics
 	db      *badger.DB
@@ -28,6 +32,8 @@ type State struct {
 	}
 }
 
+// Bootstrap initializes the persistent protocol state from the root
+// information generated for the root block of a spork.
 func Bootstrap(
 	metrics module.ComplianceMetrics,
 	db *badger.DB,
@@ -39,6 +45,102 @@ func Bootstrap(
 	statuses storage.EpochStatuses,
 	stateRoot *StateRoot,
 ) (*State, error) {
+
+	root := stateRoot.Block()
+	setup := stateRoot.EpochSetupEvent()
+	commit := stateRoot.EpochCommitEvent()
+
+	// the root block is the first block of the first epoch
+	status, err := flow.NewEpochStatus(root.ID(), flow.ZeroID, flow.ZeroID, setup.ID(), commit.ID(), flow.ZeroID, flow.ZeroID)
+	if err != nil {
+		return nil, fmt.Errorf("could not construct root epoch status: %w", err)
+	}
+
+	return bootstrap(
+		metrics, db, headers, seals, blocks, setups, commits, statuses,
+		[]*flow.Block{root},
+		stateRoot.Result(),
+		stateRoot.Seal(),
+		[]*flow.EpochSetup{setup},
+		[]*flow.EpochCommit{commit},
+		[]*flow.EpochStatus{status},
+	)
+}
+
+// BootstrapFromSnapshot initializes the persistent protocol state from an
+// arbitrary sealed protocol state snapshot, for example one that was obtained
+// from an access node. The head of the snapshot becomes the root block of the
+// protocol state, and all blocks of the snapshot's sealing segment are stored,
+// so that subsequent blocks can be validated against them.
+//
+// The sealing segment may span an epoch transition, in which case the
+// snapshot must include the previous epoch.
+func BootstrapFromSnapshot(
+	metrics module.ComplianceMetrics,
+	db *badger.DB,
+	headers storage.Headers,
+	seals storage.Seals,
+	blocks storage.Blocks,
+	setups storage.EpochSetups,
+	commits storage.EpochCommits,
+	statuses storage.EpochStatuses,
+	root protocol.Snapshot,
+) (*State, error) {
+
+	segment, err := root.SealingSegment()
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealing segment: %w", err)
+	}
+	result, err := root.LatestResult()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest result: %w", err)
+	}
+	seal, err := root.LatestSeal()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest seal: %w", err)
+	}
+	err = validSealingSegment(segment, result, seal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root snapshot: %w", err)
+	}
+
+	epochSetups, epochCommits, epochStatuses, err := epochsFromSnapshot(root, segment)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert root snapshot epochs: %w", err)
+	}
+
+	return bootstrap(
+		metrics, db, headers, seals, blocks, setups, commits, statuses,
+		segment,
+		result,
+		seal,
+		epochSetups,
+		epochCommits,
+		epochStatuses,
+	)
+}
+
+// bootstrap inserts the given sealing segment, the latest sealed result and
+// seal, and the given epoch service events into an empty database, along with
+// the epoch status of each block of the segment. The last block of the segment
+// becomes the root block of the protocol state, and the first block of the
+// segment is considered sealed.
+func bootstrap(
+	metrics module.ComplianceMetrics,
+	db *badger.DB,
+	headers storage.Headers,
+	seals storage.Seals,
+	blocks storage.Blocks,
+	setups storage.EpochSetups,
+	commits storage.EpochCommits,
+	statuses storage.EpochStatuses,
+	segment []*flow.Block,
+	result *flow.ExecutionResult,
+	seal *flow.Seal,
+	epochSetups []*flow.EpochSetup,
+	epochCommits []*flow.EpochCommit,
+	epochStatuses []*flow.EpochStatus,
+) (*State, error) {
 	isBootstrapped, err := IsBootstrapped(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine whether database contains bootstrapped state: %w", err)
@@ -48,93 +150,124 @@ func Bootstrap(
 	}
 	state := newState(metrics, db, headers, seals, blocks, setups, commits, statuses)
 
-	err = operation.RetryOnConflict(db.Update, func(tx *badger.Txn) error {
-		// 1) insert the root block with its payload into the state and index it
-		err = state.blocks.StoreTx(stateRoot.Block())(tx)
-		if err != nil {
-			return fmt.Errorf("could not insert root block: %w", err)
-		}
-		err = operation.InsertBlockValidity(stateRoot.Block().ID(), true)(tx)
-		if err != nil {
-			return fmt.Errorf("could not mark root block as valid: %w", err)
-		}
+	head := segment[len(segment)-1]
+	lowest := segment[0]
 
-		err = operation.IndexBlockHeight(stateRoot.Block().Header.Height, stateRoot.Block().ID())(tx)
-		if err != nil {
-			return fmt.Errorf("could not index root block: %w", err)
+	err = operation.RetryOnConflict(db.Update, func(tx *badger.Txn) error {
+		// 1) insert the blocks of the sealing segment with their payloads into
+		// the state and index them
+		for i, block := range segment {
+			blockID := block.ID()
+			err := state.blocks.StoreTx(block)(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert root block: %w", err)
+			}
+			err = operation.InsertBlockValidity(blockID, true)(tx)
+			if err != nil {
+				return fmt.Errorf("could not mark root block as valid: %w", err)
+			}
+			err = operation.IndexBlockHeight(block.Header.Height, blockID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not index root block: %w", err)
+			}
+			// for all but the first block in the segment, index the
+			// parent->child relationship
+			if i > 0 {
+				err = operation.InsertBlockChildren(block.Header.ParentID, []flow.Identifier{blockID})(tx)
+				if err != nil {
+					return fmt.Errorf("could not insert child index for block (%x): %w", blockID, err)
+				}
+			}
 		}
-		// root block has no parent, so only needs to add one index
+		// the root block has no child yet, so only needs to add one index
 		// to indicate the root block has no child yet
-		err = operation.InsertBlockChildren(stateRoot.Block().ID(), nil)(tx)
+		err = operation.InsertBlockChildren(head.ID(), nil)(tx)
 		if err != nil {
 			return fmt.Errorf("could not initialize root child index: %w", err)
 		}
 
-		// 2) insert the root execution result into the database and index it
-		err = operation.InsertExecutionResult(stateRoot.Result())(tx)
+		// 2) insert the latest sealed execution result into the database and
+		// index it; it might already be included in a segment block payload
+		err = operation.SkipDuplicates(operation.InsertExecutionResult(result))(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert root result: %w", err)
 		}
-		err = operation.IndexExecutionResult(stateRoot.Block().ID(), stateRoot.Result().ID())(tx)
+		err = operation.IndexExecutionResult(lowest.ID(), result.ID())(tx)
 		if err != nil {
 			return fmt.Errorf("could not index root result: %w", err)
 		}
 
-		// 3) insert the root block seal into the database and index it
-		err = operation.InsertSeal(stateRoot.Seal().ID(), stateRoot.Seal())(tx)
+		// 3) insert the latest seal into the database and index it as the
+		// latest seal for the block including it and all its descendants, and
+		// as the finalized seal of the block it seals; the blocks below it are
+		// sealed by seals which are not part of the segment, so we can't index
+		// their latest seal
+		err = operation.SkipDuplicates(operation.InsertSeal(seal.ID(), seal))(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert root seal: %w", err)
 		}
-		err = operation.IndexBlockSeal(stateRoot.Block().ID(), stateRoot.Seal().ID())(tx)
+		err = operation.IndexFinalizedSealByBlockID(seal.BlockID, seal.ID())(tx)
 		if err != nil {
-			return fmt.Errorf("could not index root block seal: %w", err)
+			return fmt.Errorf("could not index root seal: %w", err)
+		}
+		for _, block := range segment[sealIncluded(segment, seal):] {
+			err = operation.IndexBlockSeal(block.ID(), seal.ID())(tx)
+			if err != nil {
+				return fmt.Errorf("could not index root block seal: %w", err)
+			}
 		}
 
 		// 4) initialize the current protocol state values
-		err = operation.InsertStartedView(stateRoot.Block().Header.ChainID, stateRoot.Block().Header.View)(tx)
+		err = operation.InsertStartedView(head.Header.ChainID, head.Header.View)(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert started view: %w", err)
 		}
-		err = operation.InsertVotedView(stateRoot.Block().Header.ChainID, stateRoot.Block().Header.View)(tx)
+		err = operation.InsertVotedView(head.Header.ChainID, head.Header.View)(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert started view: %w", err)
 		}
-		err = operation.InsertRootHeight(stateRoot.Block().Header.Height)(tx)
+		err = operation.InsertRootHeight(head.Header.Height)(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert root height: %w", err)
 		}
-		err = operation.InsertFinalizedHeight(stateRoot.Block().Header.Height)(tx)
+		err = operation.InsertFinalizedHeight(head.Header.Height)(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert finalized height: %w", err)
 		}
-		err = operation.InsertSealedHeight(stateRoot.Block().Header.Height)(tx)
+		err = operation.InsertSealedHeight(lowest.Header.Height)(tx)
 		if err != nil {
 			return fmt.Errorf("could not insert sealed height: %w", err)
 		}
 
 		// 5) initialize values related to the epoch logic
-		err = state.epoch.setups.StoreTx(stateRoot.EpochSetupEvent())(tx)
-		if err != nil {
-			return fmt.Errorf("could not insert EpochSetup event: %w", err)
+		for _, setup := range epochSetups {
+			err = state.epoch.setups.StoreTx(setup)(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert EpochSetup event: %w", err)
+			}
 		}
-		err = state.epoch.commits.StoreTx(stateRoot.EpochCommitEvent())(tx)
-		if err != nil {
-			return fmt.Errorf("could not insert EpochCommit event: %w", err)
+		for _, commit := range epochCommits {
+			err = state.epoch.commits.StoreTx(commit)(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert EpochCommit event: %w", err)
+			}
 		}
-		status, err := flow.NewEpochStatus(stateRoot.Block().ID(), stateRoot.EpochSetupEvent().ID(), stateRoot.EpochCommitEvent().ID(), flow.ZeroID, flow.ZeroID)
-		if err != nil {
-			return fmt.Errorf("could not construct root epoch status: %w", err)
+		for i, block := range segment {
+			err = state.epoch.statuses.StoreTx(block.ID(), epochStatuses[i])(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert EpochStatus: %w", err)
+			}
 		}
-		err = state.epoch.statuses.StoreTx(stateRoot.Block().ID(), status)(tx)
+		err = operation.InsertEpochStatusVersion(epochStatusVersion)(tx)
 		if err != nil {
-			return fmt.Errorf("could not insert EpochStatus: %w", err)
+			return fmt.Errorf("could not insert epoch status version: %w", err)
 		}
 
-		state.metrics.FinalizedHeight(stateRoot.Block().Header.Height)
-		state.metrics.BlockFinalized(stateRoot.Block())
+		state.metrics.FinalizedHeight(head.Header.Height)
+		state.metrics.BlockFinalized(head)
 
-		state.metrics.SealedHeight(stateRoot.Block().Header.Height)
-		state.metrics.BlockSealed(stateRoot.Block())
+		state.metrics.SealedHeight(lowest.Header.Height)
+		state.metrics.BlockSealed(lowest)
 
 		return nil
 	})
@@ -145,6 +278,194 @@ func Bootstrap(
 	return state, nil
 }
 
+// sealIncluded returns the index of the segment block whose payload includes
+// the given seal. If no block of the segment includes it, as for the root seal
+// of a spork, it is the latest seal of the head only.
+func sealIncluded(segment []*flow.Block, seal *flow.Seal) int {
+	for i, block := range segment {
+		for _, included := range block.Payload.Seals {
+			if included.ID() == seal.ID() {
+				return i
+			}
+		}
+	}
+	return len(segment) - 1
+}
+
+// epochsFromSnapshot converts the epochs of the given root snapshot into the
+// corresponding service events, and constructs the epoch status of each block
+// of the sealing segment.
+//
+// The first block of an epoch is the first block with a view of at least the
+// epoch's first view. If an epoch started before the sealing segment, its
+// first block is not part of the snapshot, and the lowest block of the segment
+// is used instead.
+func epochsFromSnapshot(root protocol.Snapshot, segment []*flow.Block) ([]*flow.EpochSetup, []*flow.EpochCommit, []*flow.EpochStatus, error) {
+
+	var setups []*flow.EpochSetup
+	var commits []*flow.EpochCommit
+	var previousSetupID, previousCommitID flow.Identifier
+	var nextSetupID, nextCommitID flow.Identifier
+	var nextCounter uint64
+
+	// the previous epoch, if it exists, must have been committed
+	previous := root.Epochs().Previous()
+	_, err := previous.Counter()
+	if err == nil {
+		setup, err := protocol.ToEpochSetup(previous)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not convert previous epoch setup: %w", err)
+		}
+		commit, err := protocol.ToEpochCommit(previous)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not convert previous epoch commit: %w", err)
+		}
+		setups = append(setups, setup)
+		commits = append(commits, commit)
+		previousSetupID, previousCommitID = setup.ID(), commit.ID()
+	} else if !errors.Is(err, protocol.ErrNoPreviousEpoch) {
+		return nil, nil, nil, fmt.Errorf("could not get previous epoch: %w", err)
+	}
+
+	// the current epoch must always have been committed
+	current := root.Epochs().Current()
+	currentSetup, err := protocol.ToEpochSetup(current)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not convert current epoch setup: %w", err)
+	}
+	currentCommit, err := protocol.ToEpochCommit(current)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not convert current epoch commit: %w", err)
+	}
+	setups = append(setups, currentSetup)
+	commits = append(commits, currentCommit)
+
+	// the next epoch, if it exists, must have been set up and might have been
+	// committed, depending on the epoch phase
+	next := root.Epochs().Next()
+	_, err = next.Counter()
+	if err == nil {
+		setup, err := protocol.ToEpochSetup(next)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not convert next epoch setup: %w", err)
+		}
+		setups = append(setups, setup)
+		nextSetupID = setup.ID()
+		nextCounter = setup.Counter
+
+		commit, err := protocol.ToEpochCommit(next)
+		if err == nil {
+			commits = append(commits, commit)
+			nextCommitID = commit.ID()
+		} else if !errors.Is(err, protocol.ErrEpochNotCommitted) {
+			return nil, nil, nil, fmt.Errorf("could not convert next epoch commit: %w", err)
+		}
+	} else if !errors.Is(err, protocol.ErrNextEpochNotSetup) {
+		return nil, nil, nil, fmt.Errorf("could not get next epoch: %w", err)
+	}
+
+	// find the first block of the current epoch within the segment; the blocks
+	// below it belong to the previous epoch
+	first := 0
+	for first < len(segment) && segment[first].Header.View < currentSetup.FirstView {
+		first++
+	}
+	if first == len(segment) {
+		return nil, nil, nil, fmt.Errorf("head of sealing segment is not in the current epoch")
+	}
+	if first > 0 && previousSetupID == flow.ZeroID {
+		return nil, nil, nil, fmt.Errorf("sealing segment spans an epoch transition without previous epoch")
+	}
+
+	// the epoch service events take effect from the block whose payload seals
+	// include them; those emitted before the segment apply to all its blocks
+	setupIncluded, commitIncluded := serviceEventsIncluded(segment)
+	emitted := func(included map[uint64]int, counter uint64, i int) bool {
+		index, ok := included[counter]
+		return !ok || index <= i
+	}
+
+	statuses := make([]*flow.EpochStatus, 0, len(segment))
+	for i := range segment {
+
+		// blocks of the previous epoch, for which the current epoch is the next
+		// epoch; the epoch before the previous epoch is not part of the snapshot
+		if i < first {
+			var setupID, commitID flow.Identifier
+			if emitted(setupIncluded, currentSetup.Counter, i) {
+				setupID = currentSetup.ID()
+				if emitted(commitIncluded, currentSetup.Counter, i) {
+					commitID = currentCommit.ID()
+				}
+			}
+			status, err := flow.NewEpochStatus(
+				segment[0].ID(),
+				flow.ZeroID, flow.ZeroID,
+				previousSetupID, previousCommitID,
+				setupID, commitID,
+			)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("could not construct epoch status for block %d of segment: %w", i, err)
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+
+		var setupID, commitID flow.Identifier
+		if nextSetupID != flow.ZeroID && emitted(setupIncluded, nextCounter, i) {
+			setupID = nextSetupID
+			if nextCommitID != flow.ZeroID && emitted(commitIncluded, nextCounter, i) {
+				commitID = nextCommitID
+			}
+		}
+		status, err := flow.NewEpochStatus(
+			segment[first].ID(),
+			previousSetupID, previousCommitID,
+			currentSetup.ID(), currentCommit.ID(),
+			setupID, commitID,
+		)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not construct epoch status for block %d of segment: %w", i, err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	// sanity check: the epoch phase of the head must match the snapshot
+	phase, err := root.Phase()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not get root phase: %w", err)
+	}
+	statusPhase, err := statuses[len(statuses)-1].Phase()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not get epoch status phase: %w", err)
+	}
+	if phase != statusPhase {
+		return nil, nil, nil, fmt.Errorf("inconsistent epoch phase (snapshot: %s, epochs: %s)", phase, statusPhase)
+	}
+
+	return setups, commits, statuses, nil
+}
+
+// serviceEventsIncluded returns the index of the segment block whose payload
+// seals include the epoch setup and epoch commit events, by epoch counter.
+func serviceEventsIncluded(segment []*flow.Block) (map[uint64]int, map[uint64]int) {
+	setups := make(map[uint64]int)
+	commits := make(map[uint64]int)
+	for i, block := range segment {
+		for _, seal := range block.Payload.Seals {
+			for _, event := range seal.ServiceEvents {
+				switch ev := event.Event.(type) {
+				case *flow.EpochSetup:
+					setups[ev.Counter] = i
+				case *flow.EpochCommit:
+					commits[ev.Counter] = i
+				}
+			}
+		}
+	}
+	return setups, commits
+}
+
 func OpenState(
 	metrics module.ComplianceMetrics,
 	db *badger.DB,
@@ -162,6 +483,10 @@ func OpenState(
 	if !isBootstrapped {
 		return nil, nil, fmt.Errorf("expected database to contain bootstrapped state")
 	}
+	err = migrateEpochStatuses(db)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not migrate epoch statuses: %w", err)
+	}
 	state := newState(metrics, db, headers, seals, blocks, setups, commits, statuses)
 
 	// read root block from database:
@@ -175,25 +500,21 @@ func OpenState(
 		return nil, nil, fmt.Errorf("failed retrieve root block: %w", err)
 	}
 
-	// read root execution result
-	var resultID flow.Identifier
-	err = db.View(operation.LookupExecutionResult(rootBlock.ID(), &resultID))
+	// read the latest seal as of the root block; for a state bootstrapped
+	// from a root block, this is the root seal
+	seal, err := seals.ByBlockID(rootBlock.ID())
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed retrieve root block's execution result ID: %w", err)
+		return nil, nil, fmt.Errorf("failed retrieve root block's seal: %w", err)
 	}
+
+	// read the execution result sealed by the root seal
 	var result flow.ExecutionResult
-	err = db.View(operation.RetrieveExecutionResult(resultID, &result))
+	err = db.View(operation.RetrieveExecutionResult(seal.ResultID, &result))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed retrieve root block's execution result: %w", err)
 	}
 
-	// read root seal
-	seal, err := seals.ByBlockID(rootBlock.ID())
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed retrieve root block's seal: %w", err)
-	}
-
-	// read root seal
+	// read root epoch events
 	epochStatus, err := statuses.ByBlockID(rootBlock.ID())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed retrieve root block's epoch status: %w", err)
@@ -202,16 +523,110 @@ func OpenState(
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed retrieve root epochs's setup event: %w", err)
 	}
-
-	// construct state Root
-	stateRoot, err := NewStateRoot(rootBlock, &result, seal, epochSetup.FirstView)
+	epochCommit, err := commits.ByID(epochStatus.CurrentEpoch.CommitID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("constructing state root failed: %w", err)
+		return nil, nil, fmt.Errorf("failed retrieve root epochs's commit event: %w", err)
+	}
+
+	// construct state root; it has been validated when bootstrapping the state
+	stateRoot := &StateRoot{
+		block:       rootBlock,
+		result:      &result,
+		seal:        seal,
+		epochSetup:  epochSetup,
+		epochCommit: epochCommit,
 	}
 
 	return state, stateRoot, nil
 }
 
+// migrateEpochStatuses adds the service events of the previous epoch to the
+// epoch statuses persisted before they were part of the epoch status. These are
+// decoded without previous epoch events, which would otherwise be taken for the
+// absence of a previous epoch.
+func migrateEpochStatuses(db *badger.DB) error {
+	var version uint
+	err := db.View(operation.RetrieveEpochStatusVersion(&version))
+	if err == nil && version >= epochStatusVersion {
+		return nil
+	}
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not retrieve epoch status version: %w", err)
+	}
+
+	var blockIDs []flow.Identifier
+	err = db.View(operation.LookupEpochStatusBlocks(&blockIDs))
+	if err != nil {
+		return fmt.Errorf("could not look up epoch statuses: %w", err)
+	}
+
+	// all blocks of an epoch share its previous epoch, so it is only looked up
+	// once per epoch, by the first block of the epoch
+	previousEpochs := make(map[flow.Identifier]flow.EventIDs)
+	for _, blockID := range blockIDs {
+		err = operation.RetryOnConflict(db.Update, func(tx *badger.Txn) error {
+			var status flow.EpochStatus
+			err := operation.RetrieveEpochStatus(blockID, &status)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve epoch status: %w", err)
+			}
+			if status.PreviousEpoch.SetupID != flow.ZeroID {
+				return nil
+			}
+
+			previous, ok := previousEpochs[status.FirstBlockID]
+			if !ok {
+				previous, err = previousEpochEvents(tx, status.FirstBlockID)
+				if err != nil {
+					return fmt.Errorf("could not look up previous epoch: %w", err)
+				}
+				previousEpochs[status.FirstBlockID] = previous
+			}
+			if previous.SetupID == flow.ZeroID {
+				return nil
+			}
+
+			status.PreviousEpoch = previous
+			return operation.UpdateEpochStatus(blockID, &status)(tx)
+		})
+		if err != nil {
+			return fmt.Errorf("could not migrate epoch status of block %x: %w", blockID, err)
+		}
+	}
+
+	err = db.Update(operation.InsertEpochStatusVersion(epochStatusVersion))
+	if err != nil {
+		return fmt.Errorf("could not insert epoch status version: %w", err)
+	}
+	return nil
+}
+
+// previousEpochEvents returns the service events of the epoch preceding the
+// epoch starting with the given block, which is the current epoch of the parent
+// of that block. They are empty if the parent is not known, as for the first
+// epoch of a spork.
+func previousEpochEvents(tx *badger.Txn, firstBlockID flow.Identifier) (flow.EventIDs, error) {
+	var first flow.Header
+	err := operation.RetrieveHeader(firstBlockID, &first)(tx)
+	if errors.Is(err, storage.ErrNotFound) {
+		return flow.EventIDs{}, nil
+	}
+	if err != nil {
+		return flow.EventIDs{}, fmt.Errorf("could not retrieve first block of epoch: %w", err)
+	}
+
+	var parent flow.EpochStatus
+	err = operation.RetrieveEpochStatus(first.ParentID, &parent)(tx)
+	if errors.Is(err, storage.ErrNotFound) {
+		return flow.EventIDs{}, nil
+	}
+	if err != nil {
+		return flow.EventIDs{}, fmt.Errorf("could not retrieve epoch status of parent: %w", err)
+	}
+
+	return parent.CurrentEpoch, nil
+}
+
 func (s *State) Params() protocol.Params {
 	return &Params{state: s}
 }
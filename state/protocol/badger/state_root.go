@@ -7,11 +7,13 @@ import (
 )
 
 // StateRoot is the root information required to bootstrap the protocol state
+// from the root block of a spork.
 type StateRoot struct {
-	block          *flow.Block
-	result         *flow.ExecutionResult
-	seal           *flow.Seal
-	epochFirstView uint64
+	block       *flow.Block
+	result      *flow.ExecutionResult
+	seal        *flow.Seal
+	epochSetup  *flow.EpochSetup
+	epochCommit *flow.EpochCommit
 }
 
 func NewStateRoot(block *flow.Block, result *flow.ExecutionResult, seal *flow.Seal, epochFirstView uint64) (*StateRoot, error) {
@@ -19,11 +21,22 @@ func NewStateRoot(block *flow.Block, result *flow.ExecutionResult, seal *flow.Se
 	if err != nil {
 		return nil, fmt.Errorf("inconsistent state root: %w", err)
 	}
+
+	// CAUTION: the epoch setup event as emitted by the epoch smart contract does
+	// NOT include the Epoch's first view. Instead, this information is added
+	// locally (trusted) when stored to simplify epoch queries.
+
+	// copy service event from seal (to not modify seal in-place)
+	// and set Epoch's first view on the copy
+	setup := *seal.ServiceEvents[0].Event.(*flow.EpochSetup)
+	setup.FirstView = epochFirstView
+
 	return &StateRoot{
-		block:          block,
-		result:         result,
-		seal:           seal,
-		epochFirstView: epochFirstView,
+		block:       block,
+		result:      result,
+		seal:        seal,
+		epochSetup:  &setup,
+		epochCommit: seal.ServiceEvents[1].Event.(*flow.EpochCommit),
 	}, nil
 }
 
@@ -95,21 +108,11 @@ func validate(block *flow.Block, result *flow.ExecutionResult, seal *flow.Seal,
 }
 
 func (s StateRoot) EpochSetupEvent() *flow.EpochSetup {
-	// CAUTION: the epoch setup event as emitted by the epoch smart contract does
-	// NOT include the Epoch's first view. Instead, this information is added
-	// locally (trusted) when stored to simplify epoch queries.
-
-	// copy service event from seal (to not modify seal in-place)
-	// and set Epoch's first view on the copy
-	es := s.seal.ServiceEvents[0].Event.(*flow.EpochSetup)
-	var event flow.EpochSetup = *es
-	event.FirstView = s.epochFirstView
-
-	return &event
+	return s.epochSetup
 }
 
 func (s StateRoot) EpochCommitEvent() *flow.EpochCommit {
-	return s.seal.ServiceEvents[1].Event.(*flow.EpochCommit)
+	return s.epochCommit
 }
 
 func (s StateRoot) Block() *flow.Block {
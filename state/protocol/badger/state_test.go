@@ -4,13 +4,17 @@ import (
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module/metrics"
 	bprotocol "github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/inmem"
 	"github.com/onflow/flow-go/state/protocol/util"
 	storagebadger "github.com/onflow/flow-go/storage/badger"
+	storageutil "github.com/onflow/flow-go/storage/util"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -50,3 +54,202 @@ func TestBootstrapAndOpen(t *testing.T) {
 	})
 
 }
+
+// TestBootstrapFromSnapshot verifies that a protocol state bootstrapped from
+// a snapshot of another protocol state is consistent with the original.
+func TestBootstrapFromSnapshot(t *testing.T) {
+	identities := unittest.CompleteIdentitySet()
+	stateRoot := fixtureStateRootWithParticipants(t, identities)
+
+	util.RunWithFollowerProtocolState(t, stateRoot, func(db *badger.DB, state *bprotocol.FollowerState) {
+
+		// add a valid child to the root block, so that the root QC is available
+		head := stateRoot.Block().Header
+		child := unittest.BlockWithParentFixture(head)
+		child.Payload.Guarantees = nil
+		child.Header.PayloadHash = child.Payload.Hash()
+		err := state.Extend(&child)
+		require.NoError(t, err)
+		err = state.MarkValid(child.ID())
+		require.NoError(t, err)
+
+		expected, err := inmem.FromSnapshot(state.Final())
+		require.NoError(t, err)
+
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := metrics.NewNoopCollector()
+			headers, _, seals, _, _, blocks, setups, commits, statuses, _ := storageutil.StorageLayer(t, db)
+			bootstrapped, err := bprotocol.BootstrapFromSnapshot(metrics, db, headers, seals, blocks, setups, commits, statuses, expected)
+			require.NoError(t, err)
+
+			final := bootstrapped.Final()
+			actualHead, err := final.Head()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Encodable().Head, actualHead)
+
+			actualIdentities, err := final.Identities(filter.Any)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, expected.Encodable().Identities, actualIdentities)
+
+			actualSeal, err := final.LatestSeal()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Encodable().LatestSeal, actualSeal)
+
+			actualResult, err := final.LatestResult()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Encodable().LatestResult.ID(), actualResult.ID())
+
+			actualPhase, err := final.Phase()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Encodable().Phase, actualPhase)
+
+			actualCounter, err := final.Epochs().Current().Counter()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Encodable().Epochs.Current.Counter, actualCounter)
+
+			// the root of the new state is the head of the sealing segment
+			root, err := bootstrapped.Params().Root()
+			require.NoError(t, err)
+			assert.Equal(t, head.ID(), root.ID())
+		})
+	})
+}
+
+// TestBootstrapFromSnapshot_SealingSegment verifies that a protocol state
+// bootstrapped from a snapshot with a sealing segment of several blocks, which
+// spans the setup and commit phases and an epoch transition, has the same
+// epoch status and latest seals for the segment blocks as the original state.
+func TestBootstrapFromSnapshot_SealingSegment(t *testing.T) {
+	stateRoot := fixtureStateRoot(t)
+	util.RunWithFullProtocolState(t, stateRoot, func(db *badger.DB, state *bprotocol.MutableState) {
+		root, rootSeal := stateRoot.Block(), stateRoot.Seal()
+		epoch1Setup := rootSeal.ServiceEvents[0].Event.(*flow.EpochSetup)
+
+		extend := func(parent *flow.Block, payload flow.Payload) *flow.Block {
+			block := unittest.BlockWithParentFixture(parent.Header)
+			block.SetPayload(payload)
+			err := state.Extend(&block)
+			require.NoError(t, err)
+			err = state.MarkValid(block.ID())
+			require.NoError(t, err)
+			return &block
+		}
+
+		block1 := extend(root, flow.Payload{})
+		block1Receipt := unittest.ReceiptForBlockFixture(block1)
+		block2 := extend(block1, flow.Payload{Receipts: []*flow.ExecutionReceipt{block1Receipt}})
+		block2Receipt := unittest.ReceiptForBlockFixture(block2)
+
+		// block 3 seals block 1 with the setup event for epoch 2
+		epoch2Setup := unittest.EpochSetupFixture(
+			unittest.WithParticipants(participants),
+			unittest.SetupWithCounter(epoch1Setup.Counter+1),
+			unittest.WithFinalView(epoch1Setup.FinalView+1000),
+		)
+		seal1 := unittest.Seal.Fixture(
+			unittest.Seal.WithResult(&block1Receipt.ExecutionResult),
+			unittest.Seal.WithServiceEvents(epoch2Setup.ServiceEvent()),
+		)
+		block3 := extend(block2, flow.Payload{Receipts: []*flow.ExecutionReceipt{block2Receipt}, Seals: []*flow.Seal{seal1}})
+		block3Receipt := unittest.ReceiptForBlockFixture(block3)
+
+		// block 4 seals block 2 with the commit event for epoch 2
+		epoch2Commit := unittest.EpochCommitFixture(
+			unittest.CommitWithCounter(epoch2Setup.Counter),
+			unittest.WithDKGFromParticipants(participants),
+		)
+		seal2 := unittest.Seal.Fixture(
+			unittest.Seal.WithResult(&block2Receipt.ExecutionResult),
+			unittest.Seal.WithServiceEvents(epoch2Commit.ServiceEvent()),
+		)
+		block4 := extend(block3, flow.Payload{Receipts: []*flow.ExecutionReceipt{block3Receipt}, Seals: []*flow.Seal{seal2}})
+
+		// block 5 is the last block of epoch 1, and block 6 the first of epoch 2
+		block5 := unittest.BlockWithParentFixture(block4.Header)
+		block5.SetPayload(flow.Payload{})
+		block5.Header.View = epoch1Setup.FinalView
+		err := state.Extend(&block5)
+		require.NoError(t, err)
+		err = state.MarkValid(block5.ID())
+		require.NoError(t, err)
+		block6 := extend(&block5, flow.Payload{})
+
+		// block 7 seals block 3, so that the segment spans the epoch transition
+		seal3 := unittest.Seal.Fixture(unittest.Seal.WithResult(&block3Receipt.ExecutionResult))
+		block7 := extend(block6, flow.Payload{Seals: []*flow.Seal{seal3}})
+		extend(block7, flow.Payload{})
+
+		for _, block := range []*flow.Block{block1, block2, block3, block4, &block5, block6, block7} {
+			err = state.Finalize(block.ID())
+			require.NoError(t, err)
+		}
+		original := storagebadger.NewEpochStatuses(metrics.NewNoopCollector(), db)
+
+		// bootstrapFrom bootstraps a protocol state from the snapshot at the
+		// given block and checks it against the original state
+		bootstrapFrom := func(t *testing.T, head *flow.Block, segment []*flow.Block, seal *flow.Seal) {
+			expected, err := inmem.FromSnapshot(state.AtBlockID(head.ID()))
+			require.NoError(t, err)
+			require.Len(t, expected.Encodable().SealingSegment, len(segment))
+
+			unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+				metrics := metrics.NewNoopCollector()
+				headers, _, seals, _, _, blocks, setups, commits, statuses, _ := storageutil.StorageLayer(t, db)
+				bootstrapped, err := bprotocol.BootstrapFromSnapshot(metrics, db, headers, seals, blocks, setups, commits, statuses, expected)
+				require.NoError(t, err)
+
+				sealed, err := bootstrapped.Sealed().Head()
+				require.NoError(t, err)
+				assert.Equal(t, segment[0].ID(), sealed.ID())
+
+				for _, block := range segment {
+					blockID := block.ID()
+
+					expectedPhase, err := state.AtBlockID(blockID).Phase()
+					require.NoError(t, err)
+					actualPhase, err := bootstrapped.AtBlockID(blockID).Phase()
+					require.NoError(t, err)
+					assert.Equal(t, expectedPhase, actualPhase)
+
+					expectedCounter, err := state.AtBlockID(blockID).Epochs().Current().Counter()
+					require.NoError(t, err)
+					actualCounter, err := bootstrapped.AtBlockID(blockID).Epochs().Current().Counter()
+					require.NoError(t, err)
+					assert.Equal(t, expectedCounter, actualCounter)
+
+					// the first block of epoch 2 is part of the segment
+					if block.Header.View > epoch1Setup.FinalView {
+						expectedStatus, err := original.ByBlockID(blockID)
+						require.NoError(t, err)
+						actualStatus, err := statuses.ByBlockID(blockID)
+						require.NoError(t, err)
+						assert.Equal(t, expectedStatus.FirstBlockID, actualStatus.FirstBlockID)
+					}
+				}
+
+				// the block including the latest seal and its descendants are
+				// indexed with it
+				included := false
+				for _, block := range segment {
+					for _, payloadSeal := range block.Payload.Seals {
+						included = included || payloadSeal.ID() == seal.ID()
+					}
+					if !included {
+						continue
+					}
+					actualSeal, err := bootstrapped.AtBlockID(block.ID()).LatestSeal()
+					require.NoError(t, err)
+					assert.Equal(t, seal.ID(), actualSeal.ID())
+				}
+				assert.True(t, included)
+			})
+		}
+
+		t.Run("with next epoch", func(t *testing.T) {
+			bootstrapFrom(t, block4, []*flow.Block{block2, block3, block4}, seal2)
+		})
+		t.Run("with previous epoch", func(t *testing.T) {
+			bootstrapFrom(t, block7, []*flow.Block{block3, block4, &block5, block6, block7}, seal3)
+		})
+	})
+}
@@ -110,3 +110,36 @@ func validCommit(commit *flow.EpochCommit, setup *flow.EpochSetup) error {
 
 	return nil
 }
+
+// validSealingSegment checks that the given sealing segment forms a chain of
+// blocks, and that the given seal and result pertain to its lowest block.
+func validSealingSegment(segment []*flow.Block, result *flow.ExecutionResult, seal *flow.Seal) error {
+	if len(segment) == 0 {
+		return fmt.Errorf("empty sealing segment")
+	}
+
+	// the blocks of the segment must form a chain ordered by height
+	for i := 1; i < len(segment); i++ {
+		parent, child := segment[i-1], segment[i]
+		if child.Header.ParentID != parent.ID() {
+			return fmt.Errorf("sealing segment is not a chain (block %x is not the parent of %x)", parent.ID(), child.ID())
+		}
+		if child.Header.Height != parent.Header.Height+1 {
+			return fmt.Errorf("sealing segment has non-consecutive heights (%d => %d)", parent.Header.Height, child.Header.Height)
+		}
+	}
+
+	// the latest seal and result must be for the lowest block of the segment
+	lowest := segment[0]
+	if result.BlockID != lowest.ID() {
+		return fmt.Errorf("latest execution result for wrong block (%x != %x)", result.BlockID, lowest.ID())
+	}
+	if seal.BlockID != lowest.ID() {
+		return fmt.Errorf("latest seal for wrong block (%x != %x)", seal.BlockID, lowest.ID())
+	}
+	if seal.ResultID != result.ID() {
+		return fmt.Errorf("latest seal for wrong execution result (%x != %x)", seal.ResultID, result.ID())
+	}
+
+	return nil
+}
@@ -52,6 +52,10 @@ type Epoch interface {
 	// FinalView returns the largest view number which still belongs to this epoch.
 	FinalView() (uint64, error)
 
+	// RandomSource returns the underlying source of randomness for this epoch,
+	// as specified in the EpochSetup service event.
+	RandomSource() ([]byte, error)
+
 	// Seed generates a random seed using the source of randomness for this
 	// epoch, specified in the EpochSetup service event.
 	Seed(indices ...uint32) ([]byte, error)
@@ -14,6 +14,11 @@ var (
 
 	// ErrNextEpochNotSetup is a sentinal error returned when
 	ErrNextEpochNotSetup = fmt.Errorf("next epoch has not yet been set up")
+
+	// ErrEpochNotCommitted is a sentinel error returned when the epoch has
+	// been set up, but the EpochCommit event has not yet been received, so
+	// commit-specific information (clusters, DKG) is not yet available.
+	ErrEpochNotCommitted = fmt.Errorf("queried info from EpochCommit event before it was emitted")
 )
 
 type IdentityNotFoundError struct {
@@ -0,0 +1,26 @@
+package gadgets
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+// Finalization is a protocol events consumer that invokes a callback for
+// every finalized block.
+type Finalization struct {
+	events.Noop
+	callback func(*flow.Header)
+}
+
+// NewFinalization returns a new Finalization events gadget.
+func NewFinalization(callback func(*flow.Header)) *Finalization {
+	finalization := &Finalization{
+		callback: callback,
+	}
+	return finalization
+}
+
+// BlockFinalized handles block finalized protocol events, invoking the callback.
+func (g *Finalization) BlockFinalized(block *flow.Header) {
+	g.callback(block)
+}
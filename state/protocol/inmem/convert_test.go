@@ -0,0 +1,132 @@
+package inmem_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+	bprotocol "github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	"github.com/onflow/flow-go/state/protocol/util"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestFromSnapshot tests that we are able to convert a database-backed snapshot
+// to a memory-backed snapshot, and that the result survives an encoding round-trip.
+func TestFromSnapshot(t *testing.T) {
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	root, result, seal := unittest.BootstrapFixture(identities)
+	stateRoot, err := bprotocol.NewStateRoot(root, result, seal, 0)
+	require.NoError(t, err)
+
+	util.RunWithFollowerProtocolState(t, stateRoot, func(db *badger.DB, state *bprotocol.FollowerState) {
+
+		// add a valid child, so that the QC for the root block is available
+		child := unittest.BlockWithParentFixture(root.Header)
+		child.Payload.Guarantees = nil
+		child.Header.PayloadHash = child.Payload.Hash()
+		err := state.Extend(&child)
+		require.NoError(t, err)
+		err = state.MarkValid(child.ID())
+		require.NoError(t, err)
+
+		expected := state.AtBlockID(root.ID())
+		actual, err := inmem.FromSnapshot(expected)
+		require.NoError(t, err)
+
+		assertSnapshotsEqual(t, expected, actual)
+
+		t.Run("encoding round-trip", func(t *testing.T) {
+			data, err := json.Marshal(actual.Encodable())
+			require.NoError(t, err)
+
+			var enc inmem.EncodableSnapshot
+			err = json.Unmarshal(data, &enc)
+			require.NoError(t, err)
+			decoded := inmem.SnapshotFromEncodable(enc)
+
+			reencoded, err := json.Marshal(decoded.Encodable())
+			require.NoError(t, err)
+			assert.JSONEq(t, string(data), string(reencoded))
+			assertSnapshotsEqual(t, expected, decoded)
+		})
+	})
+}
+
+// assertSnapshotsEqual checks that the relevant fields of the two snapshots match.
+func assertSnapshotsEqual(t *testing.T, expected, actual protocol.Snapshot) {
+
+	expectedHead, err := expected.Head()
+	require.NoError(t, err)
+	actualHead, err := actual.Head()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHead.ID(), actualHead.ID())
+
+	expectedIdentities, err := expected.Identities(filter.Any)
+	require.NoError(t, err)
+	actualIdentities, err := actual.Identities(filter.Any)
+	require.NoError(t, err)
+	assert.Equal(t, expectedIdentities.NodeIDs(), actualIdentities.NodeIDs())
+
+	expectedCommit, err := expected.Commit()
+	require.NoError(t, err)
+	actualCommit, err := actual.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, expectedCommit, actualCommit)
+
+	expectedPhase, err := expected.Phase()
+	require.NoError(t, err)
+	actualPhase, err := actual.Phase()
+	require.NoError(t, err)
+	assert.Equal(t, expectedPhase, actualPhase)
+
+	expectedCounter, err := expected.Epochs().Current().Counter()
+	require.NoError(t, err)
+	actualCounter, err := actual.Epochs().Current().Counter()
+	require.NoError(t, err)
+	assert.Equal(t, expectedCounter, actualCounter)
+
+	expectedSource, err := expected.Epochs().Current().RandomSource()
+	require.NoError(t, err)
+	actualSource, err := actual.Epochs().Current().RandomSource()
+	require.NoError(t, err)
+	assert.Equal(t, expectedSource, actualSource)
+
+	expectedClustering, err := expected.Epochs().Current().Clustering()
+	require.NoError(t, err)
+	actualClustering, err := actual.Epochs().Current().Clustering()
+	require.NoError(t, err)
+	require.Equal(t, len(expectedClustering), len(actualClustering))
+	for index := range expectedClustering {
+		expectedCluster, err := expected.Epochs().Current().Cluster(uint(index))
+		require.NoError(t, err)
+		actualCluster, err := actual.Epochs().Current().Cluster(uint(index))
+		require.NoError(t, err)
+		assert.Equal(t, expectedCluster.ChainID(), actualCluster.ChainID())
+		assert.Equal(t, expectedCluster.Members().NodeIDs(), actualCluster.Members().NodeIDs())
+	}
+
+	expectedDKG, err := expected.Epochs().Current().DKG()
+	require.NoError(t, err)
+	actualDKG, err := actual.Epochs().Current().DKG()
+	require.NoError(t, err)
+	assert.Equal(t, expectedDKG.Size(), actualDKG.Size())
+	for _, identity := range expectedIdentities.Filter(filter.HasRole(flow.RoleConsensus)) {
+		expectedIndex, err := expectedDKG.Index(identity.NodeID)
+		require.NoError(t, err)
+		actualIndex, err := actualDKG.Index(identity.NodeID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedIndex, actualIndex)
+	}
+
+	_, err = actual.Epochs().Previous().Counter()
+	assert.ErrorIs(t, err, protocol.ErrNoPreviousEpoch)
+	_, err = actual.Epochs().Next().Counter()
+	assert.ErrorIs(t, err, protocol.ErrNextEpochNotSetup)
+}
@@ -0,0 +1,32 @@
+package inmem
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DKG is a memory-backed implementation of protocol.DKG.
+type DKG struct {
+	enc EncodableDKG
+}
+
+func (d DKG) Size() uint                 { return uint(len(d.enc.Participants)) }
+func (d DKG) GroupKey() crypto.PublicKey { return d.enc.GroupKey.PublicKey }
+
+func (d DKG) Index(nodeID flow.Identifier) (uint, error) {
+	part, exists := d.enc.Participants[nodeID]
+	if !exists {
+		return 0, fmt.Errorf("could not find DKG participant data (%x)", nodeID)
+	}
+	return part.Index, nil
+}
+
+func (d DKG) KeyShare(nodeID flow.Identifier) (crypto.PublicKey, error) {
+	part, exists := d.enc.Participants[nodeID]
+	if !exists {
+		return nil, fmt.Errorf("could not find DKG participant data (%x)", nodeID)
+	}
+	return part.KeyShare, nil
+}
@@ -0,0 +1,98 @@
+package inmem
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/seed"
+)
+
+// Epochs is a memory-backed implementation of protocol.EpochQuery.
+type Epochs struct {
+	enc EncodableEpochs
+}
+
+func (eq Epochs) Previous() protocol.Epoch {
+	if eq.enc.Previous != nil {
+		return Epoch{*eq.enc.Previous}
+	}
+	return invalidEpoch{err: protocol.ErrNoPreviousEpoch}
+}
+
+func (eq Epochs) Current() protocol.Epoch {
+	return Epoch{eq.enc.Current}
+}
+
+func (eq Epochs) Next() protocol.Epoch {
+	if eq.enc.Next != nil {
+		return Epoch{*eq.enc.Next}
+	}
+	return invalidEpoch{err: protocol.ErrNextEpochNotSetup}
+}
+
+// Epoch is a memory-backed implementation of protocol.Epoch.
+type Epoch struct {
+	enc EncodableEpoch
+}
+
+func (e Epoch) Counter() (uint64, error) {
+	return e.enc.Counter, nil
+}
+
+func (e Epoch) FirstView() (uint64, error) {
+	return e.enc.FirstView, nil
+}
+
+func (e Epoch) FinalView() (uint64, error) {
+	return e.enc.FinalView, nil
+}
+
+func (e Epoch) InitialIdentities() (flow.IdentityList, error) {
+	return e.enc.InitialIdentities, nil
+}
+
+func (e Epoch) Clustering() (flow.ClusterList, error) {
+	return e.enc.Clustering, nil
+}
+
+func (e Epoch) Cluster(index uint) (protocol.Cluster, error) {
+	if e.enc.DKG == nil {
+		return nil, protocol.ErrEpochNotCommitted
+	}
+	if index >= uint(len(e.enc.Clusters)) {
+		return nil, fmt.Errorf("no cluster with index %d", index)
+	}
+	return Cluster{e.enc.Clusters[index]}, nil
+}
+
+func (e Epoch) DKG() (protocol.DKG, error) {
+	if e.enc.DKG == nil {
+		return nil, protocol.ErrEpochNotCommitted
+	}
+	return DKG{*e.enc.DKG}, nil
+}
+
+func (e Epoch) RandomSource() ([]byte, error) {
+	return e.enc.RandomSource, nil
+}
+
+func (e Epoch) Seed(indices ...uint32) ([]byte, error) {
+	return seed.FromRandomSource(indices, e.enc.RandomSource)
+}
+
+// invalidEpoch represents an epoch which is not included in the snapshot.
+// All methods return the error describing why the epoch is unavailable.
+type invalidEpoch struct {
+	err error
+}
+
+func (u invalidEpoch) Counter() (uint64, error)                      { return 0, u.err }
+func (u invalidEpoch) FirstView() (uint64, error)                    { return 0, u.err }
+func (u invalidEpoch) FinalView() (uint64, error)                    { return 0, u.err }
+func (u invalidEpoch) InitialIdentities() (flow.IdentityList, error) { return nil, u.err }
+func (u invalidEpoch) Clustering() (flow.ClusterList, error)         { return nil, u.err }
+func (u invalidEpoch) Cluster(uint) (protocol.Cluster, error)        { return nil, u.err }
+func (u invalidEpoch) DKG() (protocol.DKG, error)                    { return nil, u.err }
+func (u invalidEpoch) RandomSource() ([]byte, error)                 { return nil, u.err }
+func (u invalidEpoch) Seed(...uint32) ([]byte, error)                { return nil, u.err }
@@ -0,0 +1,98 @@
+package inmem
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/seed"
+)
+
+// Snapshot is a memory-backed implementation of protocol.Snapshot. The snapshot
+// data is stored in the embedded encodable snapshot model, which defines the
+// canonical structure of an encoded snapshot for the purposes of serialization.
+type Snapshot struct {
+	enc EncodableSnapshot
+}
+
+func (s Snapshot) Head() (*flow.Header, error) {
+	return s.enc.Head, nil
+}
+
+func (s Snapshot) QuorumCertificate() (*flow.QuorumCertificate, error) {
+	if s.enc.QuorumCertificate == nil {
+		return nil, fmt.Errorf("snapshot does not contain a quorum certificate for head")
+	}
+	return s.enc.QuorumCertificate, nil
+}
+
+func (s Snapshot) Identities(selector flow.IdentityFilter) (flow.IdentityList, error) {
+	identities := s.enc.Identities.Filter(selector)
+	// apply a deterministic sort to the participants
+	identities = identities.Order(order.ByNodeIDAsc)
+	return identities, nil
+}
+
+func (s Snapshot) Identity(nodeID flow.Identifier) (*flow.Identity, error) {
+	identities := s.enc.Identities.Filter(filter.HasNodeID(nodeID))
+	if len(identities) == 0 {
+		return nil, protocol.IdentityNotFoundError{NodeID: nodeID}
+	}
+	return identities[0], nil
+}
+
+func (s Snapshot) Commit() (flow.StateCommitment, error) {
+	return s.enc.LatestSeal.FinalState, nil
+}
+
+func (s Snapshot) LatestSeal() (*flow.Seal, error) {
+	return s.enc.LatestSeal, nil
+}
+
+func (s Snapshot) LatestResult() (*flow.ExecutionResult, error) {
+	return s.enc.LatestResult, nil
+}
+
+func (s Snapshot) SealingSegment() ([]*flow.Block, error) {
+	return s.enc.SealingSegment, nil
+}
+
+// Pending returns an empty list, as an in-memory snapshot does not include
+// any blocks beyond its head.
+func (s Snapshot) Pending() ([]flow.Identifier, error) {
+	return nil, nil
+}
+
+func (s Snapshot) Phase() (flow.EpochPhase, error) {
+	return s.enc.Phase, nil
+}
+
+func (s Snapshot) Seed(indices ...uint32) ([]byte, error) {
+	qc, err := s.QuorumCertificate()
+	if err != nil {
+		return nil, err
+	}
+	return seed.FromParentSignature(indices, qc.SigData)
+}
+
+func (s Snapshot) Epochs() protocol.EpochQuery {
+	return Epochs{
+		enc: s.enc.Epochs,
+	}
+}
+
+// Encodable returns the encodable model of the snapshot, which can be used
+// for serialization.
+func (s Snapshot) Encodable() EncodableSnapshot {
+	return s.enc
+}
+
+// SnapshotFromEncodable returns an in-memory snapshot backed by the given
+// encodable model, for example after it has been decoded.
+func SnapshotFromEncodable(enc EncodableSnapshot) *Snapshot {
+	return &Snapshot{
+		enc: enc,
+	}
+}
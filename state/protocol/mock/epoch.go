@@ -169,6 +169,29 @@ func (_m *Epoch) InitialIdentities() (flow.IdentityList, error) {
 	return r0, r1
 }
 
+// RandomSource provides a mock function with given fields:
+func (_m *Epoch) RandomSource() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Seed provides a mock function with given fields: indices
 func (_m *Epoch) Seed(indices ...uint32) ([]byte, error) {
 	_va := make([]interface{}, len(indices))
@@ -122,6 +122,52 @@ func (_m *Snapshot) Identity(nodeID flow.Identifier) (*flow.Identity, error) {
 	return r0, r1
 }
 
+// LatestResult provides a mock function with given fields:
+func (_m *Snapshot) LatestResult() (*flow.ExecutionResult, error) {
+	ret := _m.Called()
+
+	var r0 *flow.ExecutionResult
+	if rf, ok := ret.Get(0).(func() *flow.ExecutionResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ExecutionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LatestSeal provides a mock function with given fields:
+func (_m *Snapshot) LatestSeal() (*flow.Seal, error) {
+	ret := _m.Called()
+
+	var r0 *flow.Seal
+	if rf, ok := ret.Get(0).(func() *flow.Seal); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Seal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Pending provides a mock function with given fields:
 func (_m *Snapshot) Pending() ([]flow.Identifier, error) {
 	ret := _m.Called()
@@ -166,6 +212,52 @@ func (_m *Snapshot) Phase() (flow.EpochPhase, error) {
 	return r0, r1
 }
 
+// QuorumCertificate provides a mock function with given fields:
+func (_m *Snapshot) QuorumCertificate() (*flow.QuorumCertificate, error) {
+	ret := _m.Called()
+
+	var r0 *flow.QuorumCertificate
+	if rf, ok := ret.Get(0).(func() *flow.QuorumCertificate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.QuorumCertificate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SealingSegment provides a mock function with given fields:
+func (_m *Snapshot) SealingSegment() ([]*flow.Block, error) {
+	ret := _m.Called()
+
+	var r0 []*flow.Block
+	if rf, ok := ret.Get(0).(func() []*flow.Block); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Seed provides a mock function with given fields: indices
 func (_m *Snapshot) Seed(indices ...uint32) ([]byte, error) {
 	_va := make([]interface{}, len(indices))
@@ -36,11 +36,29 @@ type Snapshot interface {
 	// Commit return the sealed execution state commitment at this block.
 	Commit() (flow.StateCommitment, error)
 
+	// LatestSeal returns the most recent seal as of the Head block, which
+	// seals the latest sealed block in this fork.
+	LatestSeal() (*flow.Seal, error)
+
+	// LatestResult returns the execution result referenced by the most recent
+	// seal as of the Head block.
+	LatestResult() (*flow.ExecutionResult, error)
+
+	// SealingSegment returns the chain segment such that the head (greatest
+	// height) is this snapshot's Head block and the tail (least height) is the
+	// block sealed by LatestSeal. The segment is ordered by ascending height.
+	// For the root block of the protocol state, this is the root block itself.
+	SealingSegment() ([]*flow.Block, error)
+
 	// Pending returns the IDs of all descendants of the Head block. The IDs
 	// are ordered such that parents are included before their children. These
 	// are NOT guaranteed to have been validated by HotStuff.
 	Pending() ([]flow.Identifier, error)
 
+	// QuorumCertificate returns a valid quorum certificate for the Head block,
+	// reconstructed from the parent voter signatures of its first valid child.
+	QuorumCertificate() (*flow.QuorumCertificate, error)
+
 	// Seed returns a deterministic seed for a pseudo random number generator.
 	// The seed is derived from the source of randomness for the Head block.
 	// In order to deterministically derive task specific seeds, indices must
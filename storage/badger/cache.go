@@ -123,3 +123,10 @@ func (c *Cache) Put(key interface{}, resource interface{}) func(*badger.Txn) err
 		return nil
 	}
 }
+
+// Remove removes the resource with the given key from the cache. It does not
+// remove the resource from the database.
+func (c *Cache) Remove(key interface{}) {
+	c.cache.Remove(key)
+	c.metrics.CacheEntries(c.resource, uint(c.cache.Len()))
+}
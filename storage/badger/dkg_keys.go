@@ -0,0 +1,41 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// DKGKeys stores the private information resulting from running the DKG,
+// keyed by the counter of the epoch the keys are used in.
+type DKGKeys struct {
+	db *badger.DB
+}
+
+func NewDKGKeys(db *badger.DB) *DKGKeys {
+	return &DKGKeys{
+		db: db,
+	}
+}
+
+// InsertMyDKGPrivateInfo stores the DKG private information for the given epoch.
+func (k *DKGKeys) InsertMyDKGPrivateInfo(epochCounter uint64, info *dkg.DKGParticipantPriv) error {
+	err := operation.RetryOnConflict(k.db.Update, operation.InsertMyDKGPrivateInfo(epochCounter, info))
+	if err != nil {
+		return fmt.Errorf("could not insert dkg private info: %w", err)
+	}
+	return nil
+}
+
+// RetrieveMyDKGPrivateInfo retrieves the DKG private information for the given epoch.
+func (k *DKGKeys) RetrieveMyDKGPrivateInfo(epochCounter uint64) (*dkg.DKGParticipantPriv, error) {
+	var info dkg.DKGParticipantPriv
+	err := k.db.View(operation.RetrieveMyDKGPrivateInfo(epochCounter, &info))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg private info: %w", err)
+	}
+	return &info, nil
+}
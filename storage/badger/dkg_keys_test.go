@@ -0,0 +1,47 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// TestDKGKeysInsertAndRetrieve tests that DKG private info can be stored and retrieved by epoch counter
+func TestDKGKeysInsertAndRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewDKGKeys(db)
+
+		// attempt to get info for an epoch with no stored info
+		_, err := store.RetrieveMyDKGPrivateInfo(1)
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		expected := &dkg.DKGParticipantPriv{
+			NodeID:              unittest.IdentifierFixture(),
+			RandomBeaconPrivKey: encodable.RandomBeaconPrivKey{PrivateKey: unittest.KeyFixture(crypto.BLSBLS12381)},
+			GroupIndex:          2,
+		}
+		err = store.InsertMyDKGPrivateInfo(1, expected)
+		require.NoError(t, err)
+
+		actual, err := store.RetrieveMyDKGPrivateInfo(1)
+		require.NoError(t, err)
+		assert.Equal(t, expected.NodeID, actual.NodeID)
+		assert.Equal(t, expected.GroupIndex, actual.GroupIndex)
+		assert.True(t, expected.RandomBeaconPrivKey.Equals(actual.RandomBeaconPrivKey.PrivateKey))
+
+		// storing info for the same epoch again should fail
+		err = store.InsertMyDKGPrivateInfo(1, expected)
+		assert.True(t, errors.Is(err, storage.ErrAlreadyExists))
+	})
+}
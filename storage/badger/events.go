@@ -5,22 +5,38 @@ import (
 
 	"github.com/dgraph-io/badger/v2"
 
+	eventEncoding "github.com/onflow/flow-go/model/encoding/events"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
 type Events struct {
-	db *badger.DB
+	db       *badger.DB
+	encoding flow.EventEncodingVersion
 }
 
 func NewEvents(db *badger.DB) *Events {
+	return NewEventsWithEncoding(db, flow.EventEncodingVersionJSONCDC)
+}
+
+// NewEventsWithEncoding returns events storage which stores the payloads of
+// events in the given encoding. Service events are stored by themselves in
+// JSON-CDC, as they are part of execution results.
+func NewEventsWithEncoding(db *badger.DB, encoding flow.EventEncodingVersion) *Events {
 	return &Events{
-		db: db,
+		db:       db,
+		encoding: encoding,
 	}
 }
 
-// Store will store events for the given block ID
+// Store will store events for the given block ID, with their payloads
+// converted to the encoding of the storage
 func (e *Events) Store(blockID flow.Identifier, events []flow.Event) error {
+	events, err := eventEncoding.ConvertEvents(events, e.encoding)
+	if err != nil {
+		return fmt.Errorf("could not encode events: %w", err)
+	}
+
 	return operation.RetryOnConflict(e.db.Update, func(btx *badger.Txn) error {
 		for _, event := range events {
 			err := operation.SkipDuplicates(operation.InsertEvent(blockID, event))(btx)
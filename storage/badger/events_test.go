@@ -4,8 +4,12 @@ import (
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/stretchr/testify/require"
 
+	eventEncoding "github.com/onflow/flow-go/model/encoding/events"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 
@@ -69,3 +73,34 @@ func TestEventRetrieveWithoutStore(t *testing.T) {
 
 	})
 }
+
+func TestEventStoreWithEncoding(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewEventsWithEncoding(db, flow.EventEncodingVersionCBOR)
+
+		blockID := unittest.IdentifierFixture()
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture())
+		payload, err := jsoncdc.Encode(cadence.NewEvent([]cadence.Value{cadence.NewString("created")}).
+			WithType(&cadence.EventType{
+				Location:            common.AddressLocation{Address: common.BytesToAddress([]byte{1})},
+				QualifiedIdentifier: "Test.Created",
+				Fields:              []cadence.Field{{Identifier: "name", Type: cadence.StringType{}}},
+			}))
+		require.NoError(t, err)
+		event.Payload = payload
+
+		err = store.Store(blockID, []flow.Event{event})
+		require.NoError(t, err)
+
+		// payloads are stored in the encoding of the storage
+		actual, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		require.Equal(t, flow.EventEncodingVersionCBOR, actual[0].EncodingVersion)
+		require.Equal(t, flow.EventEncodingVersionCBOR, eventEncoding.Detect(actual[0].Payload))
+
+		converted, err := eventEncoding.Convert(actual[0].Payload, flow.EventEncodingVersionCBOR, flow.EventEncodingVersionJSONCDC)
+		require.NoError(t, err)
+		require.JSONEq(t, string(payload), string(converted))
+	})
+}
@@ -14,3 +14,7 @@ func InsertChunkLocator(locator *chunks.Locator) func(*badger.Txn) error {
 func RetrieveChunkLocator(locatorID flow.Identifier, locator *chunks.Locator) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeChunk, locatorID), locator)
 }
+
+func RemoveChunkLocator(locatorID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeChunk, locatorID))
+}
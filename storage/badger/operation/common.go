@@ -102,6 +102,37 @@ func remove(key []byte) func(*badger.Txn) error {
 	}
 }
 
+// removeByPrefix removes all keys with the given prefix from the badger DB.
+// It is not an error if no key has the prefix.
+func removeByPrefix(prefix []byte) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if len(prefix) == 0 {
+			return fmt.Errorf("prefix must not be empty")
+		}
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		opts.PrefetchValues = false
+
+		// collect the keys first, as we can't delete keys while iterating
+		var keys [][]byte
+		it := tx.NewIterator(opts)
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+		it.Close()
+
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if err != nil {
+				return fmt.Errorf("could not delete key: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
 // retrieve will retrieve the binary data under the given key from the badger DB
 // and decode it into the given entity. The provided entity needs to be a
 // pointer to an initialized entity of the correct type.
@@ -0,0 +1,17 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/dkg"
+)
+
+// InsertMyDKGPrivateInfo stores the DKG private information for the given epoch.
+func InsertMyDKGPrivateInfo(epochCounter uint64, info *dkg.DKGParticipantPriv) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGPrivateInfo, epochCounter), info)
+}
+
+// RetrieveMyDKGPrivateInfo retrieves the DKG private information for the given epoch.
+func RetrieveMyDKGPrivateInfo(epochCounter uint64, info *dkg.DKGParticipantPriv) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDKGPrivateInfo, epochCounter), info)
+}
@@ -29,3 +29,43 @@ func InsertEpochStatus(blockID flow.Identifier, status *flow.EpochStatus) func(*
 func RetrieveEpochStatus(blockID flow.Identifier, status *flow.EpochStatus) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeBlockEpochStatus, blockID), status)
 }
+
+func RemoveEpochStatus(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeBlockEpochStatus, blockID))
+}
+
+func UpdateEpochStatus(blockID flow.Identifier, status *flow.EpochStatus) func(*badger.Txn) error {
+	return update(makePrefix(codeBlockEpochStatus, blockID), status)
+}
+
+// LookupEpochStatusBlocks retrieves the IDs of all blocks with an epoch status.
+func LookupEpochStatusBlocks(blockIDs *[]flow.Identifier) func(*badger.Txn) error {
+	*blockIDs = make([]flow.Identifier, 0, len(*blockIDs))
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		var blockID flow.Identifier
+		check := func(key []byte) bool {
+			copy(blockID[:], key[1:])
+			return true
+		}
+		var status flow.EpochStatus
+		create := func() interface{} {
+			return &status
+		}
+		handle := func() error {
+			*blockIDs = append(*blockIDs, blockID)
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeBlockEpochStatus), iterationFunc)
+}
+
+// InsertEpochStatusVersion inserts the version of the layout of the persisted epoch statuses.
+func InsertEpochStatusVersion(version uint) func(*badger.Txn) error {
+	return insert(makePrefix(codeEpochStatusVersion), version)
+}
+
+// RetrieveEpochStatusVersion retrieves the version of the layout of the persisted epoch statuses.
+func RetrieveEpochStatusVersion(version *uint) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEpochStatusVersion), version)
+}
@@ -21,3 +21,11 @@ func IndexPayloadGuarantees(blockID flow.Identifier, guarIDs []flow.Identifier)
 func LookupPayloadGuarantees(blockID flow.Identifier, guarIDs *[]flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codePayloadGuarantees, blockID), guarIDs)
 }
+
+func RemoveGuarantee(collID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeGuarantee, collID))
+}
+
+func RemovePayloadGuarantees(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadGuarantees, blockID))
+}
@@ -27,6 +27,21 @@ func LookupBlockHeight(height uint64, blockID *flow.Identifier) func(*badger.Txn
 	return retrieve(makePrefix(codeHeightToBlock, height), blockID)
 }
 
+// RemoveHeader removes the header of a block.
+func RemoveHeader(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeHeader, blockID))
+}
+
+// InsertPrunedRoot records the ID of the bootstrap root block when it is pruned.
+func InsertPrunedRoot(blockID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codePrunedRoot), blockID)
+}
+
+// RetrievePrunedRoot retrieves the ID of the pruned bootstrap root block.
+func RetrievePrunedRoot(blockID *flow.Identifier) func(*badger.Txn) error {
+	return retrieve(makePrefix(codePrunedRoot), blockID)
+}
+
 // InsertBlockValidity marks a block as valid or invalid, defined by the consensus algorithm.
 func InsertBlockValidity(blockID flow.Identifier, valid bool) func(*badger.Txn) error {
 	return insert(makePrefix(codeBlockValidity, blockID), valid)
@@ -37,6 +52,11 @@ func RetrieveBlockValidity(blockID flow.Identifier, valid *bool) func(*badger.Tx
 	return retrieve(makePrefix(codeBlockValidity, blockID), valid)
 }
 
+// RemoveBlockValidity removes the validity marker of a block.
+func RemoveBlockValidity(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeBlockValidity, blockID))
+}
+
 func InsertExecutedBlock(blockID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeExecutedBlock), blockID)
 }
@@ -54,10 +74,20 @@ func IndexCollectionBlock(collID flow.Identifier, blockID flow.Identifier) func(
 	return insert(makePrefix(codeCollectionBlock, collID), blockID)
 }
 
+// RemoveCollectionBlock removes the index of a block by a collection within that block.
+func RemoveCollectionBlock(collID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeCollectionBlock, collID))
+}
+
 func IndexBlockIDByChunkID(chunkID, blockID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeIndexBlockByChunkID, chunkID), blockID)
 }
 
+// RemoveBlockIDByChunkID removes the index of a block by a chunk of its execution result.
+func RemoveBlockIDByChunkID(chunkID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeIndexBlockByChunkID, chunkID))
+}
+
 // LookupCollectionBlock looks up a block by a collection within that block.
 func LookupCollectionBlock(collID flow.Identifier, blockID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeCollectionBlock, collID), blockID)
@@ -49,3 +49,47 @@ func UpdateLastCompleteBlockHeight(height uint64) func(*badger.Txn) error {
 func RetrieveLastCompleteBlockHeight(height *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeLastCompleteBlockHeight), height)
 }
+
+func UpdateRootHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeRootHeight), height)
+}
+
+func InsertExecutionStopHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutionStopHeight), height)
+}
+
+func UpdateExecutionStopHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeExecutionStopHeight), height)
+}
+
+func RetrieveExecutionStopHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeExecutionStopHeight), height)
+}
+
+func RemoveExecutionStopHeight() func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionStopHeight))
+}
+
+func InsertRegisterFirstHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisterFirstHeight), height)
+}
+
+func UpdateRegisterFirstHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeRegisterFirstHeight), height)
+}
+
+func RetrieveRegisterFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeRegisterFirstHeight), height)
+}
+
+func InsertRegisterLatestHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisterLatestHeight), height)
+}
+
+func UpdateRegisterLatestHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeRegisterLatestHeight), height)
+}
+
+func RetrieveRegisterLatestHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeRegisterLatestHeight), height)
+}
@@ -12,7 +12,10 @@ import (
 const (
 
 	// codes for special database markers
-	codeMax = 1 // keeps track of the maximum key size
+	codeMax        = 1 // keeps track of the maximum key size
+	codePrunedRoot = 2 // ID of the bootstrap root block, once it has been pruned
+
+	codeEpochStatusVersion = 3 // version of the layout of the persisted epoch statuses
 
 	// codes for views with special meaning
 	codeStartedView = 10 // latest view hotstuff started
@@ -25,6 +28,9 @@ const (
 	codeExecutedBlock           = 23 // latest executed block with max height
 	codeRootHeight              = 24 // the height of the first loaded block
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codeExecutionStopHeight     = 26 // the height of the last block the execution node executes before stopping
+	codeRegisterFirstHeight     = 27 // the first height registers can be read at from the register index
+	codeRegisterLatestHeight    = 28 // the latest height indexed by the register index
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -43,6 +49,7 @@ const (
 	codeBlockToSeal         = 41 // index mapping a block its last payload seal
 	codeCollectionReference = 42 // index reference block ID for collection
 	codeBlockValidity       = 43 // validity of block per HotStuff
+	codeBlockToFinalSeal    = 44 // index mapping a sealed block to its seal in a finalized block
 
 	// codes for indexing multiple identifiers by identifier
 	// NOTE: 51 was used for identity indexes before epochs
@@ -57,14 +64,18 @@ const (
 	codeIndexBlockByChunkID         = 59 // index mapping chunk ID to block ID
 
 	// codes related to epoch information
-	codeEpochSetup  = 60 // EpochSetup service event, keyed by ID
-	codeEpochCommit = 61 // EpochCommit service event, keyed by ID
+	codeEpochSetup     = 60 // EpochSetup service event, keyed by ID
+	codeEpochCommit    = 61 // EpochCommit service event, keyed by ID
+	codeDKGPrivateInfo = 62 // DKG private information, keyed by epoch counter
 
 	// job queue consumers and producers
 	codeJobConsumerProcessed = 70
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes for the register index
+	codeRegister = 80 // register values, keyed by register ID, height and block ID
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -16,6 +16,11 @@ func RetrieveExecutionReceiptMeta(receiptID flow.Identifier, meta *flow.Executio
 	return retrieve(makePrefix(codeExecutionReceiptMeta, receiptID), meta)
 }
 
+// RemoveExecutionReceiptMeta removes an execution receipt meta by ID.
+func RemoveExecutionReceiptMeta(receiptID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionReceiptMeta, receiptID))
+}
+
 // IndexExecutionReceipt inserts an execution receipt ID keyed by block ID
 func IndexExecutionReceipt(blockID flow.Identifier, receiptID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeBlockExecutionReceipt, blockID), receiptID)
@@ -26,6 +31,11 @@ func LookupExecutionReceipt(blockID flow.Identifier, receiptID *flow.Identifier)
 	return retrieve(makePrefix(codeBlockExecutionReceipt, blockID), receiptID)
 }
 
+// RemoveExecutionReceiptIndex removes the index of an execution receipt by block
+func RemoveExecutionReceiptIndex(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeBlockExecutionReceipt, blockID))
+}
+
 // IndexExecutionReceipt inserts an execution receipt ID keyed by block ID and execution ID
 func IndexExecutionReceiptByBlockIDExecutionID(blockID, executorID, receiptID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeExecutionIDExecutionReceipt, blockID, executorID), receiptID)
@@ -42,6 +52,12 @@ func LookupExecutionReceiptByBlockIDAllExecutionIDs(blockID flow.Identifier, rec
 	return traverse(makePrefix(codeExecutionIDExecutionReceipt, blockID), iterationFunc)
 }
 
+// RemoveExecutionReceiptsByBlockID removes the index of execution receipts by
+// block ID and execution ID for all execution IDs
+func RemoveExecutionReceiptsByBlockID(blockID flow.Identifier) func(*badger.Txn) error {
+	return removeByPrefix(makePrefix(codeExecutionIDExecutionReceipt, blockID))
+}
+
 // receiptIterationFunc returns an in iteration function which returns all receipt IDs found during traversal
 func receiptIterationFunc(receiptIDs *[]flow.Identifier) func() (checkFunc, createFunc, handleFunc) {
 	return func() (checkFunc, createFunc, handleFunc) {
@@ -0,0 +1,98 @@
+package operation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// IndexRegister indexes the value of a register updated by the block with the
+// given ID at the given height.
+//
+// Registers are keyed by the hash of the register ID, the height and the block
+// ID, so that the updates of a register are sorted by height, and updates by
+// conflicting blocks at the same height don't overwrite each other.
+func IndexRegister(id flow.RegisterID, height uint64, blockID flow.Identifier, value flow.RegisterValue) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegister, flow.MakeID(id), height, blockID), value)
+}
+
+// LookupRegisterAtHeight retrieves the value of a register at the given
+// height, which is the value of its latest update by a finalized block at or
+// below the height. Updates by blocks which conflict with the finalized chain
+// are skipped. Updates at or below the root height are accepted even if the
+// height is not indexed anymore, as the blocks below the root may have been
+// pruned. It returns storage.ErrNotFound if there is no such update.
+func LookupRegisterAtHeight(id flow.RegisterID, height uint64, value *flow.RegisterValue) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+
+		var rootHeight uint64
+		hasRoot := true
+		err := RetrieveRootHeight(&rootHeight)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			hasRoot = false
+		} else if err != nil {
+			return fmt.Errorf("could not retrieve root height: %w", err)
+		}
+
+		prefix := makePrefix(codeRegister, flow.MakeID(id))
+
+		// seek to the last key at the given height, whatever its block ID
+		start := makePrefix(codeRegister, flow.MakeID(id), height)
+		for i := 0; i < len(flow.ZeroID); i++ {
+			start = append(start, 0xff)
+		}
+
+		options := badger.DefaultIteratorOptions
+		options.Reverse = true
+		options.Prefix = prefix
+
+		it := tx.NewIterator(options)
+		defer it.Close()
+
+		for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+
+			item := it.Item()
+			key := item.Key()
+			if len(key) != len(prefix)+8+len(flow.ZeroID) {
+				return fmt.Errorf("invalid register key length: %d", len(key))
+			}
+
+			updateHeight := binary.BigEndian.Uint64(key[len(prefix):])
+			var blockID flow.Identifier
+			copy(blockID[:], key[len(prefix)+8:])
+
+			// skip updates by blocks which are not finalized
+			var finalizedID flow.Identifier
+			err := LookupBlockHeight(updateHeight, &finalizedID)(tx)
+			if errors.Is(err, storage.ErrNotFound) {
+				// only pruned heights may be missing from the index
+				if !hasRoot || updateHeight > rootHeight {
+					continue
+				}
+				finalizedID = blockID
+			} else if err != nil {
+				return fmt.Errorf("could not look up finalized block at height %d: %w", updateHeight, err)
+			}
+			if finalizedID != blockID {
+				continue
+			}
+
+			err = item.Value(func(val []byte) error {
+				return msgpack.Unmarshal(val, value)
+			})
+			if err != nil {
+				return fmt.Errorf("could not decode register value: %w", err)
+			}
+
+			return nil
+		}
+
+		return storage.ErrNotFound
+	}
+}
@@ -25,3 +25,8 @@ func IndexExecutionResult(blockID flow.Identifier, resultID flow.Identifier) fun
 func LookupExecutionResult(blockID flow.Identifier, resultID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeIndexExecutionResultByBlock, blockID), resultID)
 }
+
+// RemoveExecutionResultIndex removes the index of an execution result by block
+func RemoveExecutionResultIndex(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeIndexExecutionResultByBlock, blockID))
+}
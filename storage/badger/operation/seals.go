@@ -14,6 +14,10 @@ func RetrieveSeal(sealID flow.Identifier, seal *flow.Seal) func(*badger.Txn) err
 	return retrieve(makePrefix(codeSeal, sealID), seal)
 }
 
+func RemoveSeal(sealID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeSeal, sealID))
+}
+
 func IndexPayloadSeals(blockID flow.Identifier, sealIDs []flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codePayloadSeals, blockID), sealIDs)
 }
@@ -22,6 +26,10 @@ func LookupPayloadSeals(blockID flow.Identifier, sealIDs *[]flow.Identifier) fun
 	return retrieve(makePrefix(codePayloadSeals, blockID), sealIDs)
 }
 
+func RemovePayloadSeals(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadSeals, blockID))
+}
+
 func IndexPayloadReceipts(blockID flow.Identifier, receiptIDs []flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codePayloadReceipts, blockID), receiptIDs)
 }
@@ -30,6 +38,10 @@ func LookupPayloadReceipts(blockID flow.Identifier, receiptIDs *[]flow.Identifie
 	return retrieve(makePrefix(codePayloadReceipts, blockID), receiptIDs)
 }
 
+func RemovePayloadReceipts(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadReceipts, blockID))
+}
+
 func IndexBlockSeal(blockID flow.Identifier, sealID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeBlockToSeal, blockID), sealID)
 }
@@ -38,6 +50,22 @@ func LookupBlockSeal(blockID flow.Identifier, sealID *flow.Identifier) func(*bad
 	return retrieve(makePrefix(codeBlockToSeal, blockID), &sealID)
 }
 
+func RemoveBlockSeal(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeBlockToSeal, blockID))
+}
+
+func IndexFinalizedSealByBlockID(sealedID flow.Identifier, sealID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeBlockToFinalSeal, sealedID), sealID)
+}
+
+func LookupBySealedBlockID(sealedID flow.Identifier, sealID *flow.Identifier) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeBlockToFinalSeal, sealedID), sealID)
+}
+
+func RemoveFinalizedSealIndex(sealedID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeBlockToFinalSeal, sealedID))
+}
+
 func InsertExecutionForkEvidence(conflictingSeals []*flow.IncorporatedResultSeal) func(*badger.Txn) error {
 	return insert(makePrefix(codeExecutionFork), conflictingSeals)
 }
@@ -53,3 +53,21 @@ func RetrieveIndex(blockID flow.Identifier, index *flow.Index) func(tx *badger.T
 		return nil
 	}
 }
+
+func RemoveIndex(blockID flow.Identifier) func(tx *badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := operation.RemovePayloadGuarantees(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove guarantee index: %w", err)
+		}
+		err = operation.RemovePayloadSeals(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove seal index: %w", err)
+		}
+		err = operation.RemovePayloadReceipts(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove receipts index: %w", err)
+		}
+		return nil
+	}
+}
@@ -1,12 +1,14 @@
 package procedure
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -25,3 +27,20 @@ func TestInsertRetrieveIndex(t *testing.T) {
 		require.Equal(t, index, &retrieved)
 	})
 }
+
+func TestInsertRemoveIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blockID := unittest.IdentifierFixture()
+		index := unittest.IndexFixture()
+
+		err := db.Update(InsertIndex(blockID, index))
+		require.NoError(t, err)
+
+		err = db.Update(RemoveIndex(blockID))
+		require.NoError(t, err)
+
+		var retrieved flow.Index
+		err = db.View(RetrieveIndex(blockID, &retrieved))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
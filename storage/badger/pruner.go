@@ -0,0 +1,426 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/badger/procedure"
+)
+
+// Pruner removes historical blocks from the protocol state. It keeps the
+// blocks within a retention window below the latest sealed block, and removes
+// all finalized blocks below the window, together with their payloads and
+// derived indices, as well as all blocks on forks orphaned by them. Every
+// pruned height moves the root of the protocol state forward within the same
+// transaction, so the database always has a consistent root.
+//
+// Execution results and epoch service events are not pruned, as they may be
+// referenced by blocks within the retention window. The index of finalized
+// blocks by height is not pruned either, as execution and access nodes look up
+// the blocks below the root by height, nor are the cluster blocks of
+// collection nodes.
+//
+// The pruner evicts removed entities from the caches of the given storage
+// components, so they have to be the instances used by the node.
+type Pruner struct {
+	log        zerolog.Logger
+	db         *badger.DB
+	metrics    module.PrunerMetrics
+	headers    *Headers
+	index      *Index
+	guarantees *Guarantees
+	seals      *Seals
+	receipts   *ExecutionReceipts
+	statuses   *EpochStatuses
+	retention  uint64
+	running    int32
+}
+
+// NewPruner returns a pruner that keeps `retention` blocks below the latest
+// sealed block. The retention should be larger than the transaction expiry,
+// so that reference blocks of guarantees can still be checked.
+func NewPruner(
+	log zerolog.Logger,
+	db *badger.DB,
+	metrics module.PrunerMetrics,
+	headers *Headers,
+	index *Index,
+	guarantees *Guarantees,
+	seals *Seals,
+	receipts *ExecutionReceipts,
+	statuses *EpochStatuses,
+	retention uint64,
+) *Pruner {
+	p := &Pruner{
+		log:        log.With().Str("component", "pruner").Logger(),
+		db:         db,
+		metrics:    metrics,
+		headers:    headers,
+		index:      index,
+		guarantees: guarantees,
+		seals:      seals,
+		receipts:   receipts,
+		statuses:   statuses,
+		retention:  retention,
+	}
+	return p
+}
+
+// evictions collects the cache entries of removed entities. They are evicted
+// only once the transaction removing the entities has been committed.
+type evictions []func()
+
+func (e *evictions) add(cache *Cache, key interface{}) {
+	*e = append(*e, func() { cache.Remove(key) })
+}
+
+func (e evictions) run() {
+	for _, evict := range e {
+		evict()
+	}
+}
+
+// RunPruning runs a pruning run in its own goroutine, unless one is
+// already in progress.
+func (p *Pruner) RunPruning() {
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&p.running, 0)
+		started := time.Now()
+		pruned, err := p.Prune()
+		if err != nil {
+			p.log.Error().Err(err).Msg("pruning of protocol state failed")
+			return
+		}
+		if pruned == 0 {
+			return
+		}
+		p.log.Debug().
+			Uint("pruned_blocks", pruned).
+			Dur("pruning_duration", time.Since(started)).
+			Msg("pruning of protocol state executed")
+	}()
+}
+
+// Prune removes all blocks below the retention window and returns the number
+// of removed blocks. Each height is pruned in its own transaction, so an error
+// leaves the database at a consistent, partially pruned state.
+func (p *Pruner) Prune() (uint, error) {
+
+	var rootHeight uint64
+	err := p.db.View(operation.RetrieveRootHeight(&rootHeight))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve root height: %w", err)
+	}
+	var sealedHeight uint64
+	err = p.db.View(operation.RetrieveSealedHeight(&sealedHeight))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve sealed height: %w", err)
+	}
+
+	// the new root is the lowest block we retain
+	if sealedHeight <= rootHeight+p.retention {
+		return 0, nil
+	}
+	newRootHeight := sealedHeight - p.retention
+
+	total := uint(0)
+	for height := rootHeight; height < newRootHeight; height++ {
+		var pruned uint
+		var evicted evictions
+		err = p.db.Update(func(tx *badger.Txn) error {
+			var err error
+			evicted = nil
+			pruned, err = p.pruneHeight(height, &evicted)(tx)
+			return err
+		})
+		if err != nil {
+			p.metrics.BlocksPruned(total, height)
+			return total, fmt.Errorf("could not prune height %d: %w", height, err)
+		}
+		evicted.run()
+		total += pruned
+	}
+
+	p.metrics.BlocksPruned(total, newRootHeight)
+
+	return total, nil
+}
+
+// pruneHeight removes the finalized block at the given height and all forks
+// orphaned by its finalized child, then moves the root to the next height.
+func (p *Pruner) pruneHeight(height uint64, evicted *evictions) func(*badger.Txn) (uint, error) {
+	return func(tx *badger.Txn) (uint, error) {
+
+		var blockID flow.Identifier
+		err := operation.LookupBlockHeight(height, &blockID)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not look up block: %w", err)
+		}
+		var nextID flow.Identifier
+		err = operation.LookupBlockHeight(height+1, &nextID)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not look up next root block: %w", err)
+		}
+
+		// the latest seal as of the new root block is the new root seal, so
+		// we have to keep it, even if it was included in a pruned block
+		var rootSealID flow.Identifier
+		err = operation.LookupBlockSeal(blockID, &rootSealID)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not look up root seal: %w", err)
+		}
+		var nextSealID flow.Identifier
+		err = operation.LookupBlockSeal(nextID, &nextSealID)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not look up next root seal: %w", err)
+		}
+
+		// any child other than the next finalized block is on an orphaned fork
+		var childrenIDs []flow.Identifier
+		err = operation.RetrieveBlockChildren(blockID, &childrenIDs)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return 0, fmt.Errorf("could not retrieve children: %w", err)
+		}
+		pruned := uint(0)
+		for _, childID := range childrenIDs {
+			if childID == nextID {
+				continue
+			}
+			count, err := p.pruneFork(childID, evicted)(tx)
+			if err != nil {
+				return 0, fmt.Errorf("could not prune orphaned fork (%x): %w", childID, err)
+			}
+			pruned += count
+		}
+
+		err = p.prunePayload(blockID, nextSealID, evicted)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not prune payload: %w", err)
+		}
+		err = p.pruneBlock(blockID, evicted)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not prune block: %w", err)
+		}
+		pruned++
+
+		// the old root seal is only referenced by pruned blocks now
+		if rootSealID != nextSealID {
+			err = removeIfExists(operation.RemoveSeal(rootSealID))(tx)
+			if err != nil {
+				return 0, fmt.Errorf("could not remove root seal: %w", err)
+			}
+			evicted.add(p.seals.cache, rootSealID)
+		}
+
+		// the first pruned block is the bootstrap root block, which is recorded
+		// so that the bootstrap data can still be checked against the database
+		err = operation.SkipDuplicates(operation.InsertPrunedRoot(blockID))(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not record pruned root: %w", err)
+		}
+		err = operation.UpdateRootHeight(height + 1)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not update root height: %w", err)
+		}
+
+		return pruned, nil
+	}
+}
+
+// pruneFork removes the block with the given ID and all of its descendants.
+// Payload entities of orphaned blocks may also be included in finalized
+// blocks, so only the indices of the orphaned blocks are removed.
+func (p *Pruner) pruneFork(blockID flow.Identifier, evicted *evictions) func(*badger.Txn) (uint, error) {
+	return func(tx *badger.Txn) (uint, error) {
+
+		var childrenIDs []flow.Identifier
+		err := operation.RetrieveBlockChildren(blockID, &childrenIDs)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return 0, fmt.Errorf("could not retrieve children: %w", err)
+		}
+		pruned := uint(0)
+		for _, childID := range childrenIDs {
+			count, err := p.pruneFork(childID, evicted)(tx)
+			if err != nil {
+				return 0, err
+			}
+			pruned += count
+		}
+
+		err = procedure.RemoveIndex(blockID)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not remove payload index (%x): %w", blockID, err)
+		}
+		evicted.add(p.index.cache, blockID)
+		err = p.pruneBlock(blockID, evicted)(tx)
+		if err != nil {
+			return 0, fmt.Errorf("could not prune block (%x): %w", blockID, err)
+		}
+
+		return pruned + 1, nil
+	}
+}
+
+// prunePayload removes the payload of a finalized block, except for the seal
+// with the given ID, as well as the chunk locators of the included results.
+func (p *Pruner) prunePayload(blockID flow.Identifier, keepSealID flow.Identifier, evicted *evictions) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+
+		var index flow.Index
+		err := procedure.RetrieveIndex(blockID, &index)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve payload index: %w", err)
+		}
+
+		for _, collID := range index.CollectionIDs {
+			err = removeIfExists(operation.RemoveGuarantee(collID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove guarantee (%x): %w", collID, err)
+			}
+			evicted.add(p.guarantees.cache, collID)
+			err = removeIfExists(operation.RemoveCollectionBlock(collID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove collection block index (%x): %w", collID, err)
+			}
+		}
+
+		for _, sealID := range index.SealIDs {
+			if sealID == keepSealID {
+				continue
+			}
+			err = removeIfExists(operation.RemoveSeal(sealID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove seal (%x): %w", sealID, err)
+			}
+			evicted.add(p.seals.cache, sealID)
+		}
+
+		for _, receiptID := range index.ReceiptIDs {
+			var meta flow.ExecutionReceiptMeta
+			err = operation.RetrieveExecutionReceiptMeta(receiptID, &meta)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve receipt (%x): %w", receiptID, err)
+			}
+			var result flow.ExecutionResult
+			err = operation.RetrieveExecutionResult(meta.ResultID, &result)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve result (%x): %w", meta.ResultID, err)
+			}
+			for i := range result.Chunks {
+				locator := chunks.Locator{ResultID: meta.ResultID, Index: uint64(i)}
+				err = removeIfExists(operation.RemoveChunkLocator(locator.ID()))(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove chunk locator (%x): %w", locator.ID(), err)
+				}
+			}
+			err = operation.RemoveExecutionReceiptMeta(receiptID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove receipt (%x): %w", receiptID, err)
+			}
+			evicted.add(p.receipts.cache, receiptID)
+		}
+
+		err = procedure.RemoveIndex(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove payload index: %w", err)
+		}
+		evicted.add(p.index.cache, blockID)
+
+		return nil
+	}
+}
+
+// pruneBlock removes the header of a block and the indices keyed by its ID,
+// including the indices of execution results and receipts for the block.
+func (p *Pruner) pruneBlock(blockID flow.Identifier, evicted *evictions) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := operation.RemoveHeader(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove header: %w", err)
+		}
+		evicted.add(p.headers.cache, blockID)
+		err = removeIfExists(operation.RemoveBlockChildren(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove children index: %w", err)
+		}
+		err = removeIfExists(operation.RemoveBlockValidity(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove validity: %w", err)
+		}
+		err = removeIfExists(operation.RemoveBlockSeal(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove seal index: %w", err)
+		}
+		err = removeIfExists(operation.RemoveFinalizedSealIndex(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove finalized seal index: %w", err)
+		}
+		err = removeIfExists(operation.RemoveEpochStatus(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove epoch status: %w", err)
+		}
+		evicted.add(p.statuses.cache, blockID)
+
+		// execution nodes index their own result for the block, as well as the
+		// block by the chunks of that result
+		var resultID flow.Identifier
+		err = operation.LookupExecutionResult(blockID, &resultID)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not look up execution result: %w", err)
+		}
+		if err == nil {
+			var result flow.ExecutionResult
+			err = operation.RetrieveExecutionResult(resultID, &result)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve execution result (%x): %w", resultID, err)
+			}
+			for _, chunk := range result.Chunks {
+				err = removeIfExists(operation.RemoveBlockIDByChunkID(chunk.ID()))(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove chunk index (%x): %w", chunk.ID(), err)
+				}
+				evicted.add(p.headers.chunkIDCache, chunk.ID())
+			}
+			err = operation.RemoveExecutionResultIndex(blockID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove execution result index: %w", err)
+			}
+		}
+
+		err = removeIfExists(operation.RemoveExecutionReceiptIndex(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove execution receipt index: %w", err)
+		}
+		err = operation.RemoveExecutionReceiptsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove execution receipts index: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// removeIfExists wraps a remove operation so that removing a missing key is
+// not an error. This is used for indices that only some node roles maintain.
+func removeIfExists(op func(*badger.Txn) error) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := op(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+}
@@ -0,0 +1,185 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestPrune builds a finalized chain with an orphaned fork, prunes it and
+// checks that only the blocks within the retention window are left.
+func TestPrune(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		collector := metrics.NewNoopCollector()
+		headers := badgerstorage.NewHeaders(collector, db)
+		guarantees := badgerstorage.NewGuarantees(collector, db)
+		seals := badgerstorage.NewSeals(collector, db)
+		index := badgerstorage.NewIndex(collector, db)
+		results := badgerstorage.NewExecutionResults(collector, db)
+		receipts := badgerstorage.NewExecutionReceipts(collector, db, results)
+		statuses := badgerstorage.NewEpochStatuses(collector, db)
+		blocks := badgerstorage.NewBlocks(db, headers, badgerstorage.NewPayloads(db, index, guarantees, seals, receipts))
+
+		// root block with its root seal
+		root := unittest.BlockFixture()
+		root.Header.Height = 0
+		rootSeal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(root.ID()))
+		require.NoError(t, db.Update(operation.InsertSeal(rootSeal.ID(), rootSeal)))
+		require.NoError(t, db.Update(operation.IndexBlockSeal(root.ID(), rootSeal.ID())))
+		require.NoError(t, blocks.Store(&root))
+		require.NoError(t, db.Update(operation.IndexBlockHeight(0, root.ID())))
+		require.NoError(t, db.Update(operation.InsertRootHeight(0)))
+
+		// finalized chain of 10 blocks, each sealing its parent
+		finalized := []*flow.Block{&root}
+		latestSeal := rootSeal
+		var locators []flow.Identifier
+		var chunkIDs []flow.Identifier
+		for height := uint64(1); height <= 10; height++ {
+			parent := finalized[len(finalized)-1]
+			block := unittest.BlockWithParentFixture(parent.Header)
+			seal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(parent.ID()))
+			receipt := unittest.ExecutionReceiptFixture()
+			block.Payload.Seals = []*flow.Seal{seal}
+			block.Payload.Receipts = []*flow.ExecutionReceipt{receipt}
+			block.Header.PayloadHash = block.Payload.Hash()
+			latestSeal = seal
+
+			locator := &chunks.Locator{ResultID: receipt.ExecutionResult.ID(), Index: 0}
+			locators = append(locators, locator.ID())
+			require.NoError(t, db.Update(operation.InsertChunkLocator(locator)))
+
+			require.NoError(t, blocks.Store(&block))
+			require.NoError(t, db.Update(operation.IndexBlockHeight(height, block.ID())))
+			require.NoError(t, db.Update(operation.IndexBlockSeal(block.ID(), latestSeal.ID())))
+			require.NoError(t, db.Update(operation.InsertBlockChildren(parent.ID(), []flow.Identifier{block.ID()})))
+			finalized = append(finalized, &block)
+
+			// the parent is executed, as on an execution node
+			result := unittest.ExecutionResultFixture(unittest.WithBlock(parent))
+			require.NoError(t, results.Store(result))
+			require.NoError(t, results.Index(parent.ID(), result.ID()))
+			require.NoError(t, headers.IndexByChunkID(parent.ID(), result.Chunks[0].ID()))
+			require.NoError(t, receipts.Index(parent.ID(), receipt.ID()))
+			require.NoError(t, db.Update(operation.IndexExecutionReceiptByBlockIDExecutionID(parent.ID(), receipt.ExecutorID, receipt.ID())))
+			chunkIDs = append(chunkIDs, result.Chunks[0].ID())
+		}
+		require.NoError(t, db.Update(operation.InsertSealedHeight(10)))
+
+		// orphaned fork of two blocks conflicting with the finalized block at height 3
+		orphan := unittest.BlockWithParentFixture(finalized[2].Header)
+		orphanChild := unittest.BlockWithParentFixture(orphan.Header)
+		require.NoError(t, blocks.Store(&orphan))
+		require.NoError(t, blocks.Store(&orphanChild))
+		require.NoError(t, db.Update(operation.UpdateBlockChildren(finalized[2].ID(), []flow.Identifier{finalized[3].ID(), orphan.ID()})))
+		require.NoError(t, db.Update(operation.InsertBlockChildren(orphan.ID(), []flow.Identifier{orphanChild.ID()})))
+
+		// load the blocks to be pruned into the caches
+		for height := uint64(0); height < 5; height++ {
+			_, err := blocks.ByHeight(height)
+			require.NoError(t, err)
+		}
+		_, err := seals.ByID(rootSeal.ID())
+		require.NoError(t, err)
+
+		prunerMetrics := &module.PrunerMetrics{}
+		prunerMetrics.On("BlocksPruned", uint(7), uint64(5)).Once()
+		pruner := badgerstorage.NewPruner(zerolog.Nop(), db, prunerMetrics, headers, index, guarantees, seals, receipts, statuses, 5)
+
+		pruned, err := pruner.Prune()
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), pruned) // heights 0 to 4 and the orphaned fork
+		prunerMetrics.AssertExpectations(t)
+
+		// the bootstrap root block is recorded as pruned root
+		var prunedRootID flow.Identifier
+		require.NoError(t, db.View(operation.RetrievePrunedRoot(&prunedRootID)))
+		assert.Equal(t, root.ID(), prunedRootID)
+
+		var rootHeight uint64
+		require.NoError(t, db.View(operation.RetrieveRootHeight(&rootHeight)))
+		assert.Equal(t, uint64(5), rootHeight)
+
+		// blocks below the new root are removed, together with their payloads,
+		// while their height index is kept
+		for height := uint64(0); height < 5; height++ {
+			var blockID flow.Identifier
+			err = db.View(operation.LookupBlockHeight(height, &blockID))
+			require.NoError(t, err)
+			assert.Equal(t, finalized[height].ID(), blockID)
+
+			var header flow.Header
+			err = db.View(operation.RetrieveHeader(finalized[height].ID(), &header))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+			// pruned entities are evicted from the caches
+			_, err = blocks.ByHeight(height)
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+			_, err = blocks.ByID(finalized[height].ID())
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+			// the execution result and receipt indices of the block are removed
+			var resultID flow.Identifier
+			err = db.View(operation.LookupExecutionResult(finalized[height].ID(), &resultID))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+			var receiptID flow.Identifier
+			err = db.View(operation.LookupExecutionReceipt(finalized[height].ID(), &receiptID))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+			var receiptIDs []flow.Identifier
+			require.NoError(t, db.View(operation.LookupExecutionReceiptByBlockIDAllExecutionIDs(finalized[height].ID(), &receiptIDs)))
+			assert.Empty(t, receiptIDs)
+
+			for _, guarantee := range finalized[height].Payload.Guarantees {
+				var retrieved flow.CollectionGuarantee
+				err = db.View(operation.RetrieveGuarantee(guarantee.ID(), &retrieved))
+				assert.True(t, errors.Is(err, storage.ErrNotFound))
+			}
+		}
+		for _, chunkID := range chunkIDs[:5] {
+			_, err = headers.IDByChunkID(chunkID)
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+		}
+		for _, locatorID := range locators[:4] {
+			var locator chunks.Locator
+			err = db.View(operation.RetrieveChunkLocator(locatorID, &locator))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+		}
+		for _, orphaned := range []flow.Block{orphan, orphanChild} {
+			var header flow.Header
+			err = db.View(operation.RetrieveHeader(orphaned.ID(), &header))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+		}
+
+		// the seal of the new root block remains available, the old root seal is removed
+		_, err = seals.ByID(rootSeal.ID())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+		seal, err := badgerstorage.NewSeals(metrics.NewNoopCollector(), db).ByBlockID(finalized[5].ID())
+		require.NoError(t, err)
+		assert.Equal(t, finalized[5].Payload.Seals[0].ID(), seal.ID())
+
+		// blocks within the retention window are untouched
+		for height := uint64(5); height <= 10; height++ {
+			block, err := blocks.ByHeight(height)
+			require.NoError(t, err)
+			assert.Equal(t, finalized[height].ID(), block.ID())
+		}
+
+		// pruning again without new sealed blocks is a no-op
+		pruned, err = pruner.Prune()
+		require.NoError(t, err)
+		assert.Equal(t, uint(0), pruned)
+	})
+}
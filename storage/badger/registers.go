@@ -0,0 +1,156 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// registerBatchSize is the number of register values indexed in a single
+// transaction, so that bootstrapping the complete execution state doesn't
+// exceed the transaction size limit.
+const registerBatchSize = 1000
+
+// RegisterIndex implements the register index on top of badger.
+type RegisterIndex struct {
+	db *badger.DB
+}
+
+func NewRegisterIndex(db *badger.DB) *RegisterIndex {
+	return &RegisterIndex{db: db}
+}
+
+// Store indexes the register values updated by the block with the given ID at
+// the given height, and advances the latest indexed height.
+func (r *RegisterIndex) Store(height uint64, blockID flow.Identifier, entries flow.RegisterEntries) error {
+	err := r.index(height, blockID, entries)
+	if err != nil {
+		return err
+	}
+
+	return operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		var latest uint64
+		err := operation.RetrieveRegisterLatestHeight(&latest)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return operation.InsertRegisterLatestHeight(height)(tx)
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		}
+		if height <= latest {
+			return nil
+		}
+		return operation.UpdateRegisterLatestHeight(height)(tx)
+	})
+}
+
+// Bootstrap indexes the complete execution state after the block with the
+// given ID at the given height, and sets it as the first indexed height.
+func (r *RegisterIndex) Bootstrap(height uint64, blockID flow.Identifier, entries flow.RegisterEntries) error {
+	err := r.index(height, blockID, entries)
+	if err != nil {
+		return err
+	}
+
+	return operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		err := operation.UpdateRegisterFirstHeight(height)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = operation.InsertRegisterFirstHeight(height)(tx)
+		}
+		if err != nil {
+			return fmt.Errorf("could not index first height: %w", err)
+		}
+
+		var latest uint64
+		err = operation.RetrieveRegisterLatestHeight(&latest)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return operation.InsertRegisterLatestHeight(height)(tx)
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		}
+		if height <= latest {
+			return nil
+		}
+		return operation.UpdateRegisterLatestHeight(height)(tx)
+	})
+}
+
+func (r *RegisterIndex) index(height uint64, blockID flow.Identifier, entries flow.RegisterEntries) error {
+	for start := 0; start < len(entries); start += registerBatchSize {
+		end := start + registerBatchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		err := operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+			for _, entry := range entries[start:end] {
+				err := operation.SkipDuplicates(operation.IndexRegister(entry.Key, height, blockID, entry.Value))(tx)
+				if err != nil {
+					return fmt.Errorf("could not index register %s: %w", entry.Key.String(), err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not index registers at height %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the value of the register at the given finalized height. Empty
+// values are returned for registers which were not set at the height.
+func (r *RegisterIndex) Get(id flow.RegisterID, height uint64) (flow.RegisterValue, error) {
+	var value flow.RegisterValue
+	err := r.db.View(func(tx *badger.Txn) error {
+
+		var first, latest uint64
+		err := operation.RetrieveRegisterFirstHeight(&first)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve first height: %w", err)
+		}
+		err = operation.RetrieveRegisterLatestHeight(&latest)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		}
+		if height < first || height > latest {
+			return fmt.Errorf("height %d is outside of the indexed heights [%d, %d]: %w", height, first, latest, storage.ErrNotFound)
+		}
+
+		err = operation.LookupRegisterAtHeight(id, height, &value)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			// the register was not set at the height
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Heights returns the first and the latest indexed heights.
+func (r *RegisterIndex) Heights() (uint64, uint64, error) {
+	var first, latest uint64
+	err := r.db.View(func(tx *badger.Txn) error {
+		err := operation.RetrieveRegisterFirstHeight(&first)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve first height: %w", err)
+		}
+		err = operation.RetrieveRegisterLatestHeight(&latest)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		}
+		return nil
+	})
+	return first, latest, err
+}
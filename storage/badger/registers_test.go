@@ -0,0 +1,130 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+func TestRegisterIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewRegisterIndex(db)
+
+		// finalize a block at each height
+		blockIDs := make(map[uint64]flow.Identifier)
+		for height := uint64(10); height <= 13; height++ {
+			blockIDs[height] = unittest.IdentifierFixture()
+			require.NoError(t, db.Update(operation.IndexBlockHeight(height, blockIDs[height])))
+		}
+
+		regA := flow.NewRegisterID("owner", "", "a")
+		regB := flow.NewRegisterID("owner", "", "b")
+
+		// the index can't be read before it's bootstrapped
+		_, _, err := index.Heights()
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		err = index.Bootstrap(10, blockIDs[10], flow.RegisterEntries{
+			{Key: regA, Value: []byte("a10")},
+		})
+		require.NoError(t, err)
+
+		err = index.Store(11, blockIDs[11], flow.RegisterEntries{
+			{Key: regB, Value: []byte("b11")},
+		})
+		require.NoError(t, err)
+
+		// a conflicting block at height 12, which is not finalized
+		err = index.Store(12, unittest.IdentifierFixture(), flow.RegisterEntries{
+			{Key: regA, Value: []byte("conflicting")},
+		})
+		require.NoError(t, err)
+
+		err = index.Store(13, blockIDs[13], flow.RegisterEntries{
+			{Key: regA, Value: []byte("a13")},
+		})
+		require.NoError(t, err)
+
+		first, latest, err := index.Heights()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), first)
+		assert.Equal(t, uint64(13), latest)
+
+		value, err := index.Get(regA, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("a10"), value)
+
+		// registers not set at a height are empty
+		value, err = index.Get(regB, 10)
+		require.NoError(t, err)
+		assert.Empty(t, value)
+
+		value, err = index.Get(regB, 13)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("b11"), value)
+
+		// updates by conflicting blocks are skipped
+		value, err = index.Get(regA, 12)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("a10"), value)
+
+		value, err = index.Get(regA, 13)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("a13"), value)
+
+		// heights outside of the index can't be read
+		_, err = index.Get(regA, 9)
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+		_, err = index.Get(regA, 14)
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
+
+func TestRegisterIndex_PrunedHeights(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewRegisterIndex(db)
+
+		blockIDs := make(map[uint64]flow.Identifier)
+		for height := uint64(10); height <= 12; height++ {
+			blockIDs[height] = unittest.IdentifierFixture()
+		}
+
+		// the blocks below the root at height 12 were pruned, without keeping
+		// their heights indexed
+		require.NoError(t, db.Update(operation.InsertRootHeight(12)))
+		require.NoError(t, db.Update(operation.IndexBlockHeight(12, blockIDs[12])))
+
+		regA := flow.NewRegisterID("owner", "", "a")
+		regB := flow.NewRegisterID("owner", "", "b")
+
+		err := index.Bootstrap(10, blockIDs[10], flow.RegisterEntries{
+			{Key: regA, Value: []byte("a10")},
+		})
+		require.NoError(t, err)
+		err = index.Store(11, blockIDs[11], flow.RegisterEntries{
+			{Key: regB, Value: []byte("b11")},
+		})
+		require.NoError(t, err)
+		err = index.Store(12, blockIDs[12], nil)
+		require.NoError(t, err)
+
+		// registers last updated below the root are still found
+		value, err := index.Get(regA, 12)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("a10"), value)
+
+		value, err = index.Get(regB, 12)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("b11"), value)
+	})
+}
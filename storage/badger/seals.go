@@ -79,3 +79,12 @@ func (s *Seals) ByBlockID(blockID flow.Identifier) (*flow.Seal, error) {
 	}
 	return s.ByID(sealID)
 }
+
+func (s *Seals) FinalizedSealForBlock(sealedID flow.Identifier) (*flow.Seal, error) {
+	var sealID flow.Identifier
+	err := s.db.View(operation.LookupBySealedBlockID(sealedID, &sealID))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up finalized seal for block: %w", err)
+	}
+	return s.ByID(sealID)
+}
@@ -25,6 +25,9 @@ func TestRetrieveWithoutStore(t *testing.T) {
 
 		_, err = store.ByBlockID(unittest.IdentifierFixture())
 		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		_, err = store.FinalizedSealForBlock(unittest.IdentifierFixture())
+		require.True(t, errors.Is(err, storage.ErrNotFound))
 	})
 }
 
@@ -72,3 +75,27 @@ func TestSealIndexAndRetrieve(t *testing.T) {
 		require.Equal(t, expectedSeal, seal)
 	})
 }
+
+// TestFinalizedSealIndexAndRetrieve verifies that a seal indexed by the block
+// it seals can be retrieved as the finalized seal for that block.
+func TestFinalizedSealIndexAndRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		store := badgerstorage.NewSeals(metrics, db)
+
+		expectedSeal := unittest.Seal.Fixture()
+
+		// store the seal first
+		err := store.Store(expectedSeal)
+		require.NoError(t, err)
+
+		// index the seal ID by the block it seals
+		err = operation.RetryOnConflict(db.Update, operation.IndexFinalizedSealByBlockID(expectedSeal.BlockID, expectedSeal.ID()))
+		require.NoError(t, err)
+
+		// retrieve the finalized seal
+		seal, err := store.FinalizedSealForBlock(expectedSeal.BlockID)
+		require.NoError(t, err)
+		require.Equal(t, expectedSeal, seal)
+	})
+}
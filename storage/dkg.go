@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/dkg"
+)
+
+// DKGKeys is the storage interface for the private information a node
+// obtains from running the distributed key generation.
+type DKGKeys interface {
+
+	// InsertMyDKGPrivateInfo stores the random beacon private key share and
+	// associated information for the epoch with the given counter.
+	InsertMyDKGPrivateInfo(epochCounter uint64, info *dkg.DKGParticipantPriv) error
+
+	// RetrieveMyDKGPrivateInfo returns the random beacon private key share and
+	// associated information for the epoch with the given counter.
+	RetrieveMyDKGPrivateInfo(epochCounter uint64) (*dkg.DKGParticipantPriv, error)
+}
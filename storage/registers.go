@@ -0,0 +1,25 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// RegisterIndex represents persistent storage indexing register values by the
+// height of the block which updated them, so that the execution state can be
+// read at past finalized heights without keeping the execution state trie.
+type RegisterIndex interface {
+
+	// Store indexes the register values updated by the block with the given ID
+	// at the given height.
+	Store(height uint64, blockID flow.Identifier, entries flow.RegisterEntries) error
+
+	// Bootstrap indexes the complete execution state after the block with the
+	// given ID at the given height, from which on registers can be read.
+	Bootstrap(height uint64, blockID flow.Identifier, entries flow.RegisterEntries) error
+
+	// Get returns the value of the register at the given finalized height. It
+	// returns ErrNotFound if the height is not indexed.
+	Get(id flow.RegisterID, height uint64) (flow.RegisterValue, error)
+
+	// Heights returns the first and the latest indexed heights. It returns
+	// ErrNotFound if the index has not been bootstrapped.
+	Heights() (first uint64, latest uint64, err error)
+}
@@ -18,4 +18,8 @@ type Seals interface {
 
 	// ByBlockID retrieves the last seal in the chain of seals for the block.
 	ByBlockID(sealedID flow.Identifier) (*flow.Seal, error)
+
+	// FinalizedSealForBlock retrieves the seal of the given block which was
+	// included in a finalized block.
+	FinalizedSealForBlock(sealedID flow.Identifier) (*flow.Seal, error)
 }